@@ -63,6 +63,24 @@ func BenchmarkGo_SDP_AudioUnit_Decode(b *testing.B) {
 	}
 }
 
+// BenchmarkGo_SDP_AudioUnit_Decode_Arena decodes into a fresh value like
+// BenchmarkGo_SDP_AudioUnit_Decode, but carves all strings and slices out
+// of a few arena allocations.
+func BenchmarkGo_SDP_AudioUnit_Decode_Arena(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var decoded audiounit.PluginRegistry
+		err := audiounit.DecodePluginRegistryArena(&decoded, testDataSDP)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if decoded.TotalPluginCount != testData.TotalPluginCount {
+			b.Fatal("decode mismatch")
+		}
+	}
+}
+
 func BenchmarkGo_SDP_AudioUnit_Roundtrip(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,29 @@
+package benchmarks
+
+import (
+	"testing"
+
+	audiounit "github.com/shaban/serial-data-protocol/testdata/generated/go/audiounit"
+)
+
+// ============================================================================
+// Decode Reuse Benchmarks - AudioUnit Schema
+// Compare with BenchmarkGo_SDP_AudioUnit_Decode, which decodes every frame
+// into a fresh value. DecodePluginRegistryReuse keeps the slices and
+// unchanged strings of the previous frame, so steady-state decoding of
+// similar frames allocates nothing.
+// ============================================================================
+
+func BenchmarkGo_SDP_AudioUnit_Decode_Reuse(b *testing.B) {
+	var decoded audiounit.PluginRegistry
+	if err := audiounit.DecodePluginRegistryReuse(&decoded, testDataSDP); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := audiounit.DecodePluginRegistryReuse(&decoded, testDataSDP); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
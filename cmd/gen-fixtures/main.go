@@ -1,45 +1,285 @@
-package genfixtures
-// Generate canonical binary fixtures for benchmarks
+// Command gen-fixtures regenerates the golden .sdpb files listed in a
+// fixture manifest, or with -verify checks that they are up to date.
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
-	"github.com/shaban/serial-data-protocol/testdata/generated/go/arrays"
+	"github.com/shaban/serial-data-protocol/internal/conformance"
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
 )
 
+// manifest lists the fixtures to build. Relative paths are relative to the
+// directory of the manifest file.
+type manifest struct {
+	Fixtures []fixture `json:"fixtures"`
+}
+
+// fixture describes one golden file: the values of Input, encoded as Type
+// of Schema, written back to back to Output.
+type fixture struct {
+	Output string `json:"output"`
+	Schema string `json:"schema"`
+	Type   string `json:"type"`
+
+	// Input holds the values in the canonical JSON mapping or, for
+	// .sdptxt files, in the SDP text format
+	Input string `json:"input"`
+
+	// Select is the top-level JSON key holding the values, for inputs that
+	// bundle several types
+	Select string `json:"select,omitempty"`
+
+	// Index picks the value at this position of the input, for fixtures
+	// that hold a single value; without it every value is encoded
+	Index *int `json:"index,omitempty"`
+
+	// Mode is "bytes" (default) or "message"
+	Mode string `json:"mode,omitempty"`
+}
+
 func main() {
-	// Load the JSON fixture
-	jsonData, err := os.ReadFile("../testdata/data/arrays.json")
+	var (
+		manifestPath = flag.String("manifest", "testdata/fixtures.json", "Path to the fixture manifest")
+		verify       = flag.Bool("verify", false, "Check the fixtures instead of writing them")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "gen-fixtures - build golden .sdpb files from a manifest\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: gen-fixtures [-manifest testdata/fixtures.json] [-verify]\n\n")
+		fmt.Fprintf(os.Stderr, "Each manifest entry names an output file, a schema, a struct type, an input\n")
+		fmt.Fprintf(os.Stderr, "file (canonical JSON, or the SDP text format for .sdptxt) and optionally a\n")
+		fmt.Fprintf(os.Stderr, "top-level JSON key to select, the index of a single value and the mode (bytes\n")
+		fmt.Fprintf(os.Stderr, "or message). Without an index all values of the input are encoded back to\n")
+		fmt.Fprintf(os.Stderr, "back with sdp/dynamic. With -verify nothing is written: every value is also\n")
+		fmt.Fprintf(os.Stderr, "encoded by generated Go code (which needs the go command), a disagreement is\n")
+		fmt.Fprintf(os.Stderr, "an error, and the exit status is 1 if any output differs from what the\n")
+		fmt.Fprintf(os.Stderr, "encoders produce now.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	stale, err := run(*manifestPath, *verify)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to read arrays.json: %v", err))
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	if stale > 0 {
+		fmt.Fprintf(os.Stderr, "%d fixture(s) out of date; regenerate with gen-fixtures -manifest %s\n", stale, *manifestPath)
+		os.Exit(1)
 	}
+}
 
-	// Unmarshal into struct
-	var data arrays.ArraysOfPrimitives
-	err = json.Unmarshal(jsonData, &data)
+// run builds every fixture of the manifest. With verify it compares instead
+// of writing and returns the number of outdated fixtures.
+func run(manifestPath string, verify bool) (int, error) {
+	data, err := os.ReadFile(manifestPath)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to unmarshal JSON: %v", err))
+		return 0, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	var m manifest
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return 0, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	// With verify every value is also encoded by the generated Go code of
+	// its schema, built once per schema in a temporary directory
+	var tmp string
+	generated := make(map[string]conformance.Backend)
+	if verify {
+		if tmp, err = os.MkdirTemp("", "gen-fixtures-"); err != nil {
+			return 0, err
+		}
+		defer os.RemoveAll(tmp)
 	}
 
-	// Encode to SDP format
-	encoded, err := arrays.EncodeArraysOfPrimitives(&data)
+	dir := filepath.Dir(manifestPath)
+	stale := 0
+	for _, f := range m.Fixtures {
+		s, parts, err := build(dir, f)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", f.Output, err)
+		}
+		encoded := bytes.Join(parts, nil)
+		out := resolve(dir, f.Output)
+
+		if verify {
+			backend, ok := generated[f.Schema]
+			if !ok {
+				backend, err = conformance.BuildGo(s, filepath.Join(tmp, strconv.Itoa(len(generated))))
+				if err != nil {
+					return 0, fmt.Errorf("%s: %w", f.Schema, err)
+				}
+				generated[f.Schema] = backend
+			}
+			if err := checkGenerated(backend, s, f, parts); err != nil {
+				return 0, fmt.Errorf("%s: %w", f.Output, err)
+			}
+		}
+
+		if !verify {
+			if err := os.WriteFile(out, encoded, 0644); err != nil {
+				return 0, fmt.Errorf("failed to write output: %w", err)
+			}
+			fmt.Printf("✓ %s (%d bytes)\n", out, len(encoded))
+			continue
+		}
+
+		current, err := os.ReadFile(out)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, err
+		}
+		switch {
+		case err != nil:
+			fmt.Printf("✗ %s: missing\n", out)
+		case !bytes.Equal(current, encoded):
+			fmt.Printf("✗ %s: %s\n", out, describeDiff(current, encoded))
+			fmt.Printf("  inspect with: sdp-inspect -schema %s -type %s %s\n", resolve(dir, f.Schema), f.Type, out)
+		default:
+			fmt.Printf("✓ %s\n", out)
+			continue
+		}
+		stale++
+	}
+	return stale, nil
+}
+
+// build encodes the values of a fixture's input with sdp/dynamic and returns
+// the schema and the encoding of each value.
+func build(dir string, f fixture) (*schema.Schema, [][]byte, error) {
+	if f.Output == "" || f.Schema == "" || f.Input == "" {
+		return nil, nil, fmt.Errorf("output, schema and input are required")
+	}
+	if f.Type == "" && (f.Mode != "message" || strings.HasSuffix(f.Input, ".sdptxt")) {
+		return nil, nil, fmt.Errorf("type is required unless message mode JSON input names it")
+	}
+	message := false
+	switch f.Mode {
+	case "", "bytes":
+	case "message":
+		message = true
+	default:
+		return nil, nil, fmt.Errorf("unknown mode %q (want bytes or message)", f.Mode)
+	}
+
+	s, err := schema.Load(resolve(dir, f.Schema))
+	if err != nil {
+		return nil, nil, err
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return nil, nil, err
+	}
+	input, err := os.ReadFile(resolve(dir, f.Input))
 	if err != nil {
-		panic(fmt.Sprintf("Failed to encode: %v", err))
+		return nil, nil, fmt.Errorf("failed to read input: %w", err)
 	}
 
-	// Write to binary file
-	err = os.WriteFile("../testdata/binaries/arrays_primitives.sdpb", encoded, 0644)
+	var values []any
+	if strings.HasSuffix(f.Input, ".sdptxt") {
+		if f.Select != "" {
+			return nil, nil, fmt.Errorf("select only applies to JSON input")
+		}
+		for _, text := range dynamic.SplitText(input) {
+			values = append(values, text)
+		}
+	} else if values, err = dynamic.SplitJSON(input, f.Select); err != nil {
+		return nil, nil, err
+	}
+
+	if f.Index != nil {
+		if *f.Index < 0 || *f.Index >= len(values) {
+			return nil, nil, fmt.Errorf("index %d out of range (input has %d values)", *f.Index, len(values))
+		}
+		values = values[*f.Index : *f.Index+1]
+	}
+
+	parts, err := codec.EncodeValues(values, f.Type, message)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to write binary: %v", err))
+		return nil, nil, err
 	}
+	return s, parts, nil
+}
 
-	fmt.Printf("✓ Generated arrays_primitives.sdpb (%d bytes)\n", len(encoded))
-	fmt.Printf("  u8_array: %d elements\n", len(data.U8Array))
-	fmt.Printf("  u32_array: %d elements\n", len(data.U32Array))
-	fmt.Printf("  f64_array: %d elements\n", len(data.F64Array))
-	fmt.Printf("  str_array: %d elements\n", len(data.StrArray))
-	fmt.Printf("  bool_array: %d elements\n", len(data.BoolArray))
+// checkGenerated encodes every value of a fixture again with generated Go
+// code, from the canonical JSON of the value, and fails if the bytes differ
+// from the sdp/dynamic encoding. Values that JSON cannot express (NaN and
+// infinite floats) are decoded and re-encoded by the generated code instead.
+// Messages are compared by payload.
+func checkGenerated(backend conformance.Backend, s *schema.Schema, f fixture, parts [][]byte) error {
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return err
+	}
+	for i, part := range parts {
+		var (
+			name    = f.Type
+			payload = part
+			v       map[string]any
+		)
+		if f.Mode == "message" {
+			name, v, err = codec.DecodeMessage(part)
+			payload = part[sdp.MessageHeaderSize:]
+		} else {
+			v, err = codec.DecodeStrict(name, part)
+		}
+		if err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+		var results []conformance.Result
+		if js, jsonErr := codec.AppendJSON(nil, name, v); jsonErr == nil {
+			results, err = backend.Encode(name, [][]byte{js})
+		} else {
+			results, err = backend.Decode(name, [][]byte{payload})
+		}
+		if err != nil {
+			return err
+		}
+		if results[0].Err != "" {
+			return fmt.Errorf("value %d: generated Go: %s", i, results[0].Err)
+		}
+		if !bytes.Equal(results[0].Data, payload) {
+			return fmt.Errorf("value %d: generated Go encodes %d bytes that differ from sdp/dynamic (%d bytes)", i, len(results[0].Data), len(payload))
+		}
+	}
+	return nil
+}
+
+// resolve returns path relative to the manifest directory.
+func resolve(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(dir, path)
+}
+
+// describeDiff summarizes how the checked-in bytes differ from the current
+// encoding.
+func describeDiff(current, encoded []byte) string {
+	n := min(len(current), len(encoded))
+	for i := range n {
+		if current[i] != encoded[i] {
+			return fmt.Sprintf("differs at offset %06x (%d bytes checked in, %d encoded)", i, len(current), len(encoded))
+		}
+	}
+	return fmt.Sprintf("length differs (%d bytes checked in, %d encoded)", len(current), len(encoded))
 }
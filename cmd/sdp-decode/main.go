@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+func main() {
+	var (
+		schemaPath = flag.String("schema", "", "Path to .sdp schema file (required)")
+		typeName   = flag.String("type", "", "Struct to decode (required for byte mode)")
+		mode       = flag.String("mode", "auto", "Input format: auto, bytes or message (auto detects the SDP magic)")
+		strict     = flag.Bool("strict", false, "Only accept canonical encodings")
+		pretty     = flag.Bool("pretty", false, "Indent the JSON output")
+		text       = flag.Bool("text", false, "Print values in the SDP text format instead of JSON")
+		outFile    = flag.String("out", "", "Path to output .json file (default: stdout)")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "sdp-decode - decode SDP data to JSON with any SDP schema\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: sdp-decode -schema <file.sdp> [-type <Struct>] [options] [in.sdpb]\n\n")
+		fmt.Fprintf(os.Stderr, "Reads concatenated values from the file (default: stdin) and prints one\n")
+		fmt.Fprintf(os.Stderr, "JSON value per line. Messages are printed as {\"type\": \"Struct\", \"value\": {...}};\n")
+		fmt.Fprintf(os.Stderr, "sdp-encode accepts both forms back.\n")
+		fmt.Fprintf(os.Stderr, "With -text, values are printed in the SDP text format, separated by --- lines;\n")
+		fmt.Fprintf(os.Stderr, "sdp-encode -text reads them back.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if *schemaPath == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	if err := run(*schemaPath, *typeName, *mode, flag.Arg(0), *outFile, *strict, *pretty, *text); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, typeName, mode, inFile, outFile string, strict, pretty, text bool) error {
+	s, err := schema.Load(schemaPath)
+	if err != nil {
+		return err
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if inFile == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(inFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if mode == "auto" {
+		mode = "bytes"
+		if len(data) >= 4 && string(data[0:3]) == sdp.MessageMagic && data[3] == sdp.MessageVersion {
+			mode = "message"
+		}
+	}
+
+	out := &output{pretty: pretty, text: text}
+	switch mode {
+	case "bytes":
+		if typeName == "" {
+			return errors.New("-type is required for byte mode input")
+		}
+		err = decodeValues(codec, typeName, data, strict, out)
+	case "message":
+		err = decodeMessages(codec, data, strict, out)
+	default:
+		return fmt.Errorf("-mode must be 'auto', 'bytes' or 'message', got '%s'", mode)
+	}
+	if err != nil {
+		return err
+	}
+
+	if outFile == "" {
+		_, err = os.Stdout.Write(out.buf.Bytes())
+		return err
+	}
+	return os.WriteFile(outFile, out.buf.Bytes(), 0644)
+}
+
+// decodeValues decodes concatenated byte mode values of one struct.
+func decodeValues(codec *dynamic.Codec, typeName string, data []byte, strict bool, out *output) error {
+	for offset, i := 0, 0; offset < len(data); i++ {
+		v, n, err := codec.DecodePrefix(typeName, data[offset:])
+		if err == nil && strict {
+			v, err = codec.DecodeStrict(typeName, data[offset:offset+n])
+		}
+		if err != nil {
+			return fmt.Errorf("value %d at byte %d: %w", i, offset, err)
+		}
+
+		if err := out.write(codec, "", typeName, v); err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
+		}
+		offset += n
+	}
+	return nil
+}
+
+// decodeMessages decodes concatenated messages of any struct in the schema.
+func decodeMessages(codec *dynamic.Codec, data []byte, strict bool, out *output) error {
+	for offset, i := 0, 0; offset < len(data); i++ {
+		_, payload, err := sdp.ParseMessageHeader(data[offset:])
+		if err != nil {
+			return fmt.Errorf("message %d at byte %d: %w", i, offset, err)
+		}
+		msg := data[offset : offset+sdp.MessageHeaderSize+len(payload)]
+
+		name, v, err := codec.DecodeMessage(msg)
+		if err == nil && strict {
+			v, err = codec.DecodeStrict(name, payload)
+		}
+		if err != nil {
+			return fmt.Errorf("message %d at byte %d: %w", i, offset, err)
+		}
+
+		if err := out.write(codec, name, name, v); err != nil {
+			return fmt.Errorf("message %d: %w", i, err)
+		}
+		offset += len(msg)
+	}
+	return nil
+}
+
+// output collects the printed values in JSON or the text format.
+type output struct {
+	buf    bytes.Buffer
+	pretty bool
+	text   bool
+	count  int
+}
+
+// write prints one value. For messages, message is the struct name, which
+// wraps JSON values as {"type": ..., "value": ...} and becomes a comment in
+// the text format.
+func (o *output) write(codec *dynamic.Codec, message, typeName string, v map[string]any) error {
+	defer func() { o.count++ }()
+
+	if o.text {
+		if o.count > 0 {
+			o.buf.WriteString("---\n")
+		}
+		if message != "" {
+			fmt.Fprintf(&o.buf, "# %s\n", message)
+		}
+		text, err := codec.AppendText(nil, typeName, v)
+		if err != nil {
+			return err
+		}
+		o.buf.Write(text)
+		return nil
+	}
+
+	line, err := codec.AppendJSON(nil, typeName, v)
+	if err != nil {
+		return err
+	}
+	if message != "" {
+		line = fmt.Appendf(nil, `{"type":%q,"value":%s}`, message, line)
+	}
+	if o.pretty {
+		// line is valid JSON produced by AppendJSON, so Indent cannot fail
+		json.Indent(&o.buf, line, "", "  ")
+	} else {
+		o.buf.Write(line)
+	}
+	o.buf.WriteByte('\n')
+	return nil
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// changeType reports values of different structs (message mode only)
+const changeType dynamic.ChangeKind = "type"
+
+// value is one decoded value of an input file.
+type value struct {
+	name string
+	data map[string]any
+}
+
+func main() {
+	var (
+		schemaPath = flag.String("schema", "", "Path to .sdp schema file (required)")
+		typeName   = flag.String("type", "", "Struct of the values (required for byte mode)")
+		mode       = flag.String("mode", "auto", "Input format: auto, bytes or message (auto detects the SDP magic)")
+		absTol     = flag.Float64("abs", 0, "Absolute tolerance for float comparisons")
+		relTol     = flag.Float64("rel", 0, "Relative tolerance for float comparisons")
+		jsonOut    = flag.Bool("json", false, "Print the differences as JSON")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "sdp-diff - field-level diff of two SDP payloads\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: sdp-diff -schema <file.sdp> [-type <Struct>] [options] <a.sdpb> <b.sdpb>\n\n")
+		fmt.Fprintf(os.Stderr, "Files may hold several values back to back; their paths are then prefixed\n")
+		fmt.Fprintf(os.Stderr, "with #<index>. Exit status is 0 if the payloads are equal, 1 if they\n")
+		fmt.Fprintf(os.Stderr, "differ and 2 on errors.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if *schemaPath == "" || flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := dynamic.DiffOptions{AbsTolerance: *absTol, RelTolerance: *relTol}
+	changes, err := run(*schemaPath, *typeName, *mode, flag.Arg(0), flag.Arg(1), opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	if *jsonOut {
+		err = printJSON(changes)
+	} else {
+		printText(changes)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+
+	if len(changes) > 0 {
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, typeName, mode, fileA, fileB string, opts dynamic.DiffOptions) ([]dynamic.Change, error) {
+	s, err := schema.Load(schemaPath)
+	if err != nil {
+		return nil, err
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := load(codec, fileA, typeName, mode)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileA, err)
+	}
+	b, err := load(codec, fileB, typeName, mode)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fileB, err)
+	}
+
+	var changes []dynamic.Change
+	if len(a) != len(b) {
+		changes = append(changes, dynamic.Change{Path: "#", Kind: dynamic.ChangeLength, Old: len(a), New: len(b)})
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		prefix := ""
+		if len(a) > 1 || len(b) > 1 {
+			prefix = "#" + strconv.Itoa(i)
+		}
+
+		if a[i].name != b[i].name {
+			changes = append(changes, dynamic.Change{Path: prefix, Kind: changeType, Old: a[i].name, New: b[i].name})
+			continue
+		}
+
+		diff, err := codec.Diff(a[i].name, a[i].data, b[i].data, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range diff {
+			if prefix != "" {
+				c.Path = prefix + "." + c.Path
+			}
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+// load decodes all values of a byte mode or message mode file.
+func load(codec *dynamic.Codec, path, typeName, mode string) ([]value, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == "auto" {
+		mode = "bytes"
+		if len(data) >= 4 && string(data[0:3]) == sdp.MessageMagic && data[3] == sdp.MessageVersion {
+			mode = "message"
+		}
+	}
+
+	var values []value
+	for offset := 0; offset < len(data); {
+		var v value
+		switch mode {
+		case "bytes":
+			if typeName == "" {
+				return nil, errors.New("-type is required for byte mode input")
+			}
+			var n int
+			v.name = typeName
+			v.data, n, err = codec.DecodePrefix(typeName, data[offset:])
+			offset += n
+		case "message":
+			_, payload, perr := sdp.ParseMessageHeader(data[offset:])
+			if perr != nil {
+				return nil, fmt.Errorf("message at byte %d: %w", offset, perr)
+			}
+			end := offset + sdp.MessageHeaderSize + len(payload)
+			v.name, v.data, err = codec.DecodeMessage(data[offset:end])
+			offset = end
+		default:
+			return nil, fmt.Errorf("-mode must be 'auto', 'bytes' or 'message', got '%s'", mode)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", len(values), err)
+		}
+		values = append(values, v)
+	}
+	return values, nil
+}
+
+func printText(changes []dynamic.Change) {
+	for _, c := range changes {
+		if c.Path == "" {
+			c.Path = "(value)"
+		}
+		switch c.Kind {
+		case dynamic.ChangePresence:
+			fmt.Printf("%s: %s -> %s\n", c.Path, presence(c.Old), presence(c.New))
+		case dynamic.ChangeLength:
+			fmt.Printf("%s: length %v -> %v\n", c.Path, c.Old, c.New)
+		case changeType:
+			fmt.Printf("%s: type %v -> %v\n", c.Path, c.Old, c.New)
+		default:
+			fmt.Printf("%s: %s -> %s\n", c.Path, format(c.Old), format(c.New))
+		}
+	}
+	if len(changes) == 0 {
+		fmt.Println("payloads are equal")
+	}
+}
+
+func printJSON(changes []dynamic.Change) error {
+	for i := range changes {
+		changes[i].Old = jsonSafe(changes[i].Old)
+		changes[i].New = jsonSafe(changes[i].New)
+	}
+	if changes == nil {
+		changes = []dynamic.Change{}
+	}
+
+	out, err := json.MarshalIndent(struct {
+		Equal   bool             `json:"equal"`
+		Changes []dynamic.Change `json:"changes"`
+	}{len(changes) == 0, changes}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(out))
+	return nil
+}
+
+func presence(v any) string {
+	if v == true {
+		return "present"
+	}
+	return "absent"
+}
+
+func format(v any) string {
+	if s, ok := v.(string); ok {
+		return strconv.Quote(s)
+	}
+	return fmt.Sprint(v)
+}
+
+// jsonSafe replaces NaN and infinities, which JSON cannot represent, by strings.
+func jsonSafe(v any) any {
+	var f float64
+	switch x := v.(type) {
+	case float32:
+		f = float64(x)
+	case float64:
+		f = x
+	default:
+		return v
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return fmt.Sprint(f)
+	}
+	return v
+}
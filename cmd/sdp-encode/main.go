@@ -1,216 +1,182 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
 	"flag"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"os"
+	"path/filepath"
+	"strconv"
 
-	arrays "github.com/shaban/serial-data-protocol/testdata/generated/go/arrays"
-	audiounit "github.com/shaban/serial-data-protocol/testdata/generated/go/audiounit"
-	nested "github.com/shaban/serial-data-protocol/testdata/generated/go/nested"
-	optional "github.com/shaban/serial-data-protocol/testdata/generated/go/optional"
-	primitives "github.com/shaban/serial-data-protocol/testdata/generated/go/primitives"
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
 )
 
 func main() {
-	schema := flag.String("schema", "", "Schema name: primitives, arrays, nested, optional, audiounit")
-	jsonFile := flag.String("json", "", "Path to input .json file")
-	outFile := flag.String("out", "", "Path to output .sdpb file")
-	typeName := flag.String("type", "", "Type name to encode")
-	flag.Parse()
-
-	if *schema == "" || *jsonFile == "" || *outFile == "" || *typeName == "" {
-		fmt.Fprintf(os.Stderr, "Usage: sdp-encode -schema <primitives|arrays|nested|optional|audiounit> -json <file.json> -out <file.sdpb> -type <TypeName>\n")
-		os.Exit(1)
+	var (
+		schemaPath = flag.String("schema", "", "Path to .sdp schema file (required)")
+		typeName   = flag.String("type", "", "Struct to encode (required unless -message input names the type)")
+		jsonFile   = flag.String("json", "", "Path to input .json file (default: stdin)")
+		textFile   = flag.String("text", "", "Path to input file in the SDP text format instead of JSON ('-' for stdin)")
+		outFile    = flag.String("out", "", "Path to output .sdpb file (default: stdout)")
+		selectKey  = flag.String("select", "", "Top-level JSON key holding the values, for files that bundle several types")
+		message    = flag.Bool("message", false, "Encode in message mode (10-byte header with type ID) instead of byte mode")
+		random     = flag.Int("random", 0, "Write this many random values of -type as .json/.sdpb pairs to the -out directory")
+		seed       = flag.Uint64("seed", 1, "Seed for -random")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "sdp-encode - encode JSON values with any SDP schema\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: sdp-encode -schema <file.sdp> -type <Struct> [-json in.json] [-out out.sdpb] [options]\n\n")
+		fmt.Fprintf(os.Stderr, "The input is one JSON object, an array of objects, or a stream of objects;\n")
+		fmt.Fprintf(os.Stderr, "several values are written back to back. Values use the canonical JSON\n")
+		fmt.Fprintf(os.Stderr, "mapping: keys are schema field names (or @json names), u64/i64 are strings\n")
+		fmt.Fprintf(os.Stderr, "and []u8 is base64.\n")
+		fmt.Fprintf(os.Stderr, "With -message and no -type, each value is {\"type\": \"Struct\", \"value\": {...}},\n")
+		fmt.Fprintf(os.Stderr, "as printed by sdp-decode for message files.\n")
+		fmt.Fprintf(os.Stderr, "With -text, the input is in the SDP text format (as printed by sdp-decode -text),\n")
+		fmt.Fprintf(os.Stderr, "with several values separated by --- lines; -type is required.\n")
+		fmt.Fprintf(os.Stderr, "With -random N, no input is read: N random values of -type are written to the\n")
+		fmt.Fprintf(os.Stderr, "-out directory as <Struct>-<i>.json and <Struct>-<i>.sdpb pairs.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
 	}
 
-	// Read JSON
-	jsonData, err := os.ReadFile(*jsonFile)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to read JSON: %v\n", err)
+	flag.Parse()
+
+	if *schemaPath == "" || (*typeName == "" && (!*message || *textFile != "")) || (*jsonFile != "" && *textFile != "") {
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	// Encode based on schema and type
-	var encoded []byte
-	switch *schema {
-	case "primitives":
-		if *typeName != "AllPrimitives" {
-			fmt.Fprintf(os.Stderr, "Type '%s' not found in schema 'primitives'\n", *typeName)
+	if *random > 0 {
+		if *typeName == "" || *outFile == "" || *jsonFile != "" || *textFile != "" {
+			flag.Usage()
 			os.Exit(1)
 		}
-		var data []primitives.AllPrimitives
-		if err := json.Unmarshal(jsonData, &data); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to parse JSON: %v\n", err)
+		if err := runRandom(*schemaPath, *typeName, *outFile, *random, *seed, *message); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		// Encode all instances concatenated
-		for _, item := range data {
-			buf, err := primitives.EncodeAllPrimitives(&item)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to encode: %v\n", err)
-				os.Exit(1)
-			}
-			encoded = append(encoded, buf...)
-		}
+		return
+	}
 
-	case "arrays":
-		// arrays.json has "primitives" and "structs" keys
-		var wrapper struct {
-			Primitives []arrays.ArraysOfPrimitives `json:"primitives"`
-			Structs    []arrays.ArraysOfStructs    `json:"structs"`
-		}
-		if err := json.Unmarshal(jsonData, &wrapper); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to parse JSON: %v\n", err)
-			os.Exit(1)
-		}
+	if err := run(*schemaPath, *typeName, *jsonFile, *textFile, *outFile, *selectKey, *message); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
 
-		switch *typeName {
-		case "ArraysOfPrimitives":
-			for _, item := range wrapper.Primitives {
-				buf, err := arrays.EncodeArraysOfPrimitives(&item)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Failed to encode: %v\n", err)
-					os.Exit(1)
-				}
-				encoded = append(encoded, buf...)
-			}
-		case "ArraysOfStructs":
-			for _, item := range wrapper.Structs {
-				buf, err := arrays.EncodeArraysOfStructs(&item)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Failed to encode: %v\n", err)
-					os.Exit(1)
-				}
-				encoded = append(encoded, buf...)
-			}
-		default:
-			fmt.Fprintf(os.Stderr, "Type '%s' not found in schema 'arrays'\n", *typeName)
-			os.Exit(1)
-		}
+func run(schemaPath, typeName, jsonFile, textFile, outFile, selectKey string, message bool) error {
+	s, err := schema.Load(schemaPath)
+	if err != nil {
+		return err
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return err
+	}
 
-	case "nested":
-		if *typeName != "Scene" {
-			fmt.Fprintf(os.Stderr, "Type '%s' not found in schema 'nested'\n", *typeName)
-			os.Exit(1)
+	var values []any
+	if textFile != "" {
+		if textFile == "-" {
+			textFile = ""
 		}
-		var data []nested.Scene
-		if err := json.Unmarshal(jsonData, &data); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to parse JSON: %v\n", err)
-			os.Exit(1)
+		input, err := readInput(textFile)
+		if err != nil {
+			return err
 		}
-		for _, item := range data {
-			buf, err := nested.EncodeScene(&item)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to encode: %v\n", err)
-				os.Exit(1)
-			}
-			encoded = append(encoded, buf...)
+		for _, text := range dynamic.SplitText(input) {
+			values = append(values, text)
 		}
-
-	case "optional":
-		if *typeName != "Config" {
-			fmt.Fprintf(os.Stderr, "Type '%s' not found in schema 'optional'\n", *typeName)
-			os.Exit(1)
+	} else {
+		input, err := readInput(jsonFile)
+		if err != nil {
+			return err
 		}
-		var data []optional.Config
-		if err := json.Unmarshal(jsonData, &data); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to parse JSON: %v\n", err)
-			os.Exit(1)
+		if values, err = dynamic.SplitJSON(input, selectKey); err != nil {
+			return err
 		}
-		for _, item := range data {
-			buf, err := optional.EncodeConfig(&item)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to encode: %v\n", err)
-				os.Exit(1)
-			}
-			encoded = append(encoded, buf...)
+	}
+
+	parts, err := codec.EncodeValues(values, typeName, message)
+	if err != nil {
+		return err
+	}
+	encoded := bytes.Join(parts, nil)
+
+	if outFile == "" {
+		_, err = os.Stdout.Write(encoded)
+		return err
+	}
+	if err := os.WriteFile(outFile, encoded, 0644); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Encoded %d value(s), %d bytes to %s\n", len(values), len(encoded), outFile)
+	return nil
+}
+
+// runRandom writes count random values of typeName to outDir, each as a
+// canonical JSON file and its encoding. The values are those of the
+// generated RandomX(rand.New(rand.NewPCG(seed, 0)), nil) called count times.
+func runRandom(schemaPath, typeName, outDir string, count int, seed uint64, message bool) error {
+	s, err := schema.Load(schemaPath)
+	if err != nil {
+		return err
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	r := rand.New(rand.NewPCG(seed, 0))
+	width := len(strconv.Itoa(count - 1))
+	for i := range count {
+		v, err := codec.Random(r, typeName, nil)
+		if err != nil {
+			return err
 		}
 
-	case "audiounit":
-		if *typeName != "PluginRegistry" {
-			fmt.Fprintf(os.Stderr, "Type '%s' not found in schema 'audiounit'\n", *typeName)
-			os.Exit(1)
+		js, err := codec.AppendJSON(nil, typeName, v)
+		if err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
 		}
-		// plugins.json is array of plugins, need to wrap in PluginRegistry
-		var pluginsJSON []struct {
-			Name           string `json:"name"`
-			ManufacturerID string `json:"manufacturerID"`
-			Type           string `json:"type"`
-			Subtype        string `json:"subtype"`
-			Parameters     []struct {
-				Address      uint64  `json:"address"`
-				DisplayName  string  `json:"displayName"`
-				Identifier   string  `json:"identifier"`
-				Unit         string  `json:"unit"`
-				MinValue     float32 `json:"minValue"`
-				MaxValue     float32 `json:"maxValue"`
-				DefaultValue float32 `json:"defaultValue"`
-				CurrentValue float32 `json:"currentValue"`
-				RawFlags     uint32  `json:"rawFlags"`
-				IsWritable   bool    `json:"isWritable"`
-				CanRamp      bool    `json:"canRamp"`
-			} `json:"parameters"`
+		var data []byte
+		if message {
+			data, err = codec.EncodeMessage(typeName, v)
+		} else {
+			data, err = codec.Encode(typeName, v)
 		}
-		if err := json.Unmarshal(jsonData, &pluginsJSON); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to parse JSON: %v\n", err)
-			os.Exit(1)
+		if err != nil {
+			return fmt.Errorf("value %d: %w", i, err)
 		}
 
-		// Convert to PluginRegistry
-		registry := audiounit.PluginRegistry{
-			Plugins:             make([]audiounit.Plugin, 0, len(pluginsJSON)),
-			TotalPluginCount:    uint32(len(pluginsJSON)),
-			TotalParameterCount: 0,
+		base := filepath.Join(outDir, fmt.Sprintf("%s-%0*d", typeName, width, i))
+		if err := os.WriteFile(base+".json", append(js, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
 		}
-
-		for _, pJSON := range pluginsJSON {
-			plugin := audiounit.Plugin{
-				Name:             pJSON.Name,
-				ManufacturerId:   pJSON.ManufacturerID,
-				ComponentType:    pJSON.Type,
-				ComponentSubtype: pJSON.Subtype,
-				Parameters:       make([]audiounit.Parameter, 0, len(pJSON.Parameters)),
-			}
-			for _, paramJSON := range pJSON.Parameters {
-				param := audiounit.Parameter{
-					Address:      paramJSON.Address,
-					DisplayName:  paramJSON.DisplayName,
-					Identifier:   paramJSON.Identifier,
-					Unit:         paramJSON.Unit,
-					MinValue:     paramJSON.MinValue,
-					MaxValue:     paramJSON.MaxValue,
-					DefaultValue: paramJSON.DefaultValue,
-					CurrentValue: paramJSON.CurrentValue,
-					RawFlags:     paramJSON.RawFlags,
-					IsWritable:   paramJSON.IsWritable,
-					CanRamp:      paramJSON.CanRamp,
-				}
-				plugin.Parameters = append(plugin.Parameters, param)
-				registry.TotalParameterCount++
-			}
-			registry.Plugins = append(registry.Plugins, plugin)
+		if err := os.WriteFile(base+".sdpb", data, 0644); err != nil {
+			return fmt.Errorf("failed to write output: %w", err)
 		}
+	}
 
-		// Encode
-		buf, err := audiounit.EncodePluginRegistry(&registry)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to encode: %v\n", err)
-			os.Exit(1)
-		}
-		encoded = buf
+	fmt.Fprintf(os.Stderr, "Wrote %d random %s value(s) to %s\n", count, typeName, outDir)
+	return nil
+}
 
-	default:
-		fmt.Fprintf(os.Stderr, "Unknown schema: %s\n", *schema)
-		os.Exit(1)
+func readInput(path string) ([]byte, error) {
+	if path == "" {
+		return io.ReadAll(os.Stdin)
 	}
-
-	// Write output
-	if err := os.WriteFile(*outFile, encoded, 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to write output: %v\n", err)
-		os.Exit(1)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
 	}
-
-	fmt.Printf("Successfully encoded %d bytes to %s\n", len(encoded), *outFile)
+	return data, nil
 }
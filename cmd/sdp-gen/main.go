@@ -4,16 +4,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 
-	"github.com/shaban/serial-data-protocol/internal/generator/cpp"
-	"github.com/shaban/serial-data-protocol/internal/generator/golang"
-	"github.com/shaban/serial-data-protocol/internal/generator/rust"
-	"github.com/shaban/serial-data-protocol/internal/generator/rustexp"
-	"github.com/shaban/serial-data-protocol/internal/generator/swift"
-	"github.com/shaban/serial-data-protocol/internal/parser"
-	"github.com/shaban/serial-data-protocol/internal/validator"
+	"github.com/shaban/serial-data-protocol/sdp/generate"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
 )
 
 const version = "1.0.0"
@@ -25,6 +18,11 @@ func main() {
 		outputDir    = flag.String("output", "", "Output directory for generated code (required)")
 		lang         = flag.String("lang", "go", "Target language: go, cpp, rust, swift")
 		packageName  = flag.String("package", "", "Package name for generated code (Go only, defaults to output dir basename)")
+		useRuntime   = flag.Bool("runtime", false, "Import errors, limits and message constants from the shared sdp runtime package instead of generating them (Go only)")
+		fuzz         = flag.Bool("fuzz", false, "Also generate fuzz_test.go with a FuzzDecodeX target for every struct (Go only)")
+		bench        = flag.Bool("bench", false, "Also generate bench_test.go with encode, decode and round-trip benchmarks for every struct (Go only)")
+		benchJSON    = flag.Bool("bench-json", false, "Like -bench, plus the same benchmarks with encoding/json for comparison (Go only)")
+		arena        = flag.Bool("arena", false, "Also generate arena.go with DecodeXArena, which decodes with a few up-front allocations (Go only)")
 		validateOnly = flag.Bool("validate-only", false, "Only validate schema without generating code")
 		verbose      = flag.Bool("verbose", false, "Enable verbose output")
 		showVersion  = flag.Bool("version", false, "Show version and exit")
@@ -38,6 +36,14 @@ func main() {
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  # Generate Go code\n")
 		fmt.Fprintf(os.Stderr, "  sdp-gen -schema device.sdp -output ./generated -lang go\n\n")
+		fmt.Fprintf(os.Stderr, "  # Generate Go code sharing errors with other packages via the sdp runtime\n")
+		fmt.Fprintf(os.Stderr, "  sdp-gen -schema device.sdp -output ./generated -lang go -runtime\n\n")
+		fmt.Fprintf(os.Stderr, "  # Generate Go code with fuzz targets for every decoder\n")
+		fmt.Fprintf(os.Stderr, "  sdp-gen -schema device.sdp -output ./generated -lang go -fuzz\n\n")
+		fmt.Fprintf(os.Stderr, "  # Generate Go code with benchmarks compared against encoding/json\n")
+		fmt.Fprintf(os.Stderr, "  sdp-gen -schema device.sdp -output ./generated -lang go -bench-json\n\n")
+		fmt.Fprintf(os.Stderr, "  # Generate Go code with arena decoding\n")
+		fmt.Fprintf(os.Stderr, "  sdp-gen -schema device.sdp -output ./generated -lang go -arena\n\n")
 		fmt.Fprintf(os.Stderr, "  # Generate C++ code\n")
 		fmt.Fprintf(os.Stderr, "  sdp-gen -schema device.sdp -output ./generated -lang cpp\n\n")
 		fmt.Fprintf(os.Stderr, "  # Generate Rust code\n")
@@ -82,7 +88,7 @@ func main() {
 	}
 
 	// Run the generator
-	if err := run(*schemaPath, *outputDir, *lang, *packageName, *validateOnly, *verbose); err != nil {
+	if err := run(*schemaPath, *outputDir, *lang, *packageName, *useRuntime, *fuzz, *bench || *benchJSON, *benchJSON, *arena, *validateOnly, *verbose); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -90,19 +96,19 @@ func main() {
 	os.Exit(0)
 }
 
-func run(schemaPath, outputDir, lang, packageName string, validateOnly, verbose bool) error {
+func run(schemaPath, outputDir, lang, packageName string, useRuntime, fuzz, bench, benchJSON, arena, validateOnly, verbose bool) error {
 	// Step 1: Load schema
 	if verbose {
 		fmt.Printf("Loading schema from: %s\n", schemaPath)
 	}
 
-	schema, err := parser.LoadSchemaFile(schemaPath)
+	s, err := schema.Load(schemaPath)
 	if err != nil {
 		return fmt.Errorf("failed to load schema: %w", err)
 	}
 
 	if verbose {
-		fmt.Printf("Loaded %d struct(s)\n", len(schema.Structs))
+		fmt.Printf("Loaded %d struct(s)\n", len(s.Structs))
 	}
 
 	// Step 2: Validate schema
@@ -110,7 +116,7 @@ func run(schemaPath, outputDir, lang, packageName string, validateOnly, verbose
 		fmt.Println("Validating schema...")
 	}
 
-	if err := validator.Validate(schema); err != nil {
+	if err := schema.Validate(s); err != nil {
 		return fmt.Errorf("schema validation failed: %w", err)
 	}
 
@@ -126,9 +132,8 @@ func run(schemaPath, outputDir, lang, packageName string, validateOnly, verbose
 
 	// Step 3: Determine package name for Go
 	if packageName == "" && lang == "go" {
-		packageName = filepath.Base(outputDir)
 		// Sanitize package name: replace hyphens and invalid characters with underscores
-		packageName = sanitizePackageName(packageName)
+		packageName = generate.PackageName(outputDir)
 		if verbose {
 			fmt.Printf("Using package name: %s\n", packageName)
 		}
@@ -141,57 +146,47 @@ func run(schemaPath, outputDir, lang, packageName string, validateOnly, verbose
 
 	switch lang {
 	case "go":
-		var files map[string]string
-		files, err = generateGo(schema, packageName)
+		files, err := generate.Go(s, generate.GoOptions{Package: packageName, Runtime: useRuntime, Fuzz: fuzz, Bench: bench, BenchJSON: benchJSON, Arena: arena})
 		if err != nil {
 			return fmt.Errorf("failed to generate Go code: %w", err)
 		}
 
-		// Create output directory
-		if err := os.MkdirAll(outputDir, 0755); err != nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
-		}
-
 		// Write files
 		if verbose {
 			fmt.Printf("Writing files to: %s\n", outputDir)
-		}
-
-		for filename, content := range files {
-			filePath := filepath.Join(outputDir, filename)
-			if verbose {
+			for _, filename := range files.Names() {
 				fmt.Printf("  Writing %s\n", filename)
 			}
+		}
 
-			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
-				return fmt.Errorf("failed to write %s: %w", filename, err)
-			}
+		if err := files.Write(outputDir); err != nil {
+			return err
 		}
 
 	case "rust":
 		// Rust generator writes files directly
-		err = rust.Generate(schema, outputDir, verbose)
+		err = generate.Rust(s, generate.Options{OutputDir: outputDir, Verbose: verbose})
 		if err != nil {
 			return fmt.Errorf("failed to generate Rust code: %w", err)
 		}
 
 	case "rustexp":
 		// Experimental Rust generator with fast-path decoding
-		err = rustexp.Generate(schema, outputDir, verbose)
+		err = generate.RustExp(s, generate.Options{OutputDir: outputDir, Verbose: verbose})
 		if err != nil {
 			return fmt.Errorf("failed to generate experimental Rust code: %w", err)
 		}
 
 	case "swift":
 		// Swift generator writes files directly
-		err = swift.Generate(schema, outputDir, verbose)
+		err = generate.Swift(s, generate.Options{OutputDir: outputDir, Verbose: verbose})
 		if err != nil {
 			return fmt.Errorf("failed to generate Swift code: %w", err)
 		}
 
 	case "cpp":
 		// C++ generator writes files directly
-		err = cpp.Generate(schema, outputDir, verbose)
+		err = generate.Cpp(s, generate.Options{OutputDir: outputDir, Verbose: verbose})
 		if err != nil {
 			return fmt.Errorf("failed to generate C++ code: %w", err)
 		}
@@ -203,146 +198,3 @@ func run(schemaPath, outputDir, lang, packageName string, validateOnly, verbose
 	fmt.Printf("Successfully generated %s code in %s\n", lang, outputDir)
 	return nil
 }
-
-// generateGo generates Go code files
-func generateGo(schema *parser.Schema, packageName string) (map[string]string, error) {
-	files := make(map[string]string)
-
-	// Generate structs
-	structs, err := golang.GenerateStructs(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate structs: %w", err)
-	}
-
-	// Generate encoder
-	encoder, err := golang.GenerateEncoder(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate encoder: %w", err)
-	}
-
-	encodeHelpers, err := golang.GenerateEncodeHelpers(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate encode helpers: %w", err)
-	}
-
-	// Generate decoder
-	decoder, err := golang.GenerateDecoder(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate decoder: %w", err)
-	}
-
-	decodeHelpers, err := golang.GenerateDecodeHelpers(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate decode helpers: %w", err)
-	}
-
-	// Generate message mode encoders
-	messageEncoders, err := golang.GenerateMessageEncoders(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate message encoders: %w", err)
-	}
-
-	// Generate message mode decoders
-	messageDecoders, err := golang.GenerateMessageDecoders(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate message decoders: %w", err)
-	}
-
-	// Generate message dispatcher
-	messageDispatcher, err := golang.GenerateMessageDispatcher(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate message dispatcher: %w", err)
-	}
-
-	// Generate writer-based encoders (streaming I/O)
-	writerEncoders, err := golang.GenerateWriterEncoder(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate writer encoders: %w", err)
-	}
-
-	// Generate reader-based decoders (streaming I/O)
-	readerDecoders, err := golang.GenerateReaderDecoder(schema)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate reader decoders: %w", err)
-	}
-
-	// Generate errors and context
-	errors := golang.GenerateErrors()
-	context := golang.GenerateDecodeContext()
-
-	// Combine encoder code (regular + helpers + message mode + writer mode)
-	encodeCode := encoder + "\n\n" + encodeHelpers + "\n\n" + messageEncoders + "\n\n" + writerEncoders
-
-	// Combine decoder code (context + regular + helpers + message mode + dispatcher + reader mode)
-	decodeCode := context + "\n\n" + decoder + "\n\n" + decodeHelpers + "\n\n" + messageDecoders + "\n\n" + messageDispatcher + "\n\n" + readerDecoders
-
-	// Determine imports based on content
-	files["types.go"] = formatGoFileWithAutoImports(packageName, structs)
-	files["encode.go"] = formatGoFileWithAutoImports(packageName, encodeCode)
-	files["decode.go"] = formatGoFileWithAutoImports(packageName, decodeCode)
-	files["errors.go"] = formatGoFileWithAutoImports(packageName, errors)
-
-	return files, nil
-}
-
-// formatGoFile creates a complete Go source file with package and imports
-func formatGoFile(packageName string, imports []string, body string) string {
-	result := fmt.Sprintf("package %s\n\n", packageName)
-
-	if len(imports) > 0 {
-		result += "import (\n"
-		for _, imp := range imports {
-			result += fmt.Sprintf("\t%q\n", imp)
-		}
-		result += ")\n\n"
-	}
-
-	result += body
-
-	return result
-}
-
-// formatGoFileWithAutoImports creates a Go file and automatically detects needed imports
-func formatGoFileWithAutoImports(packageName string, body string) string {
-	var neededImports []string
-
-	// Check for common imports based on what's in the code
-	importChecks := map[string][]string{
-		"encoding/binary": {"binary.LittleEndian"},
-		"errors":          {"errors.New"},
-		"math":            {"math.Float"},
-		"io":              {"io.ReadAll", "w io.Writer", "r io.Reader"}, // For streaming I/O functions
-		"unsafe":          {"unsafe.Slice", "unsafe.Pointer"},           // For bulk array copy optimization
-	}
-
-	for importPath, markers := range importChecks {
-		for _, marker := range markers {
-			if strings.Contains(body, marker) {
-				neededImports = append(neededImports, importPath)
-				break // Only add the import once
-			}
-		}
-	}
-
-	return formatGoFile(packageName, neededImports, body)
-}
-
-// sanitizePackageName converts a directory name to a valid Go package name
-func sanitizePackageName(name string) string {
-	// Replace hyphens and other invalid characters with underscores
-	result := ""
-	for _, r := range name {
-		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
-			result += string(r)
-		} else {
-			result += "_"
-		}
-	}
-
-	// Ensure it doesn't start with a digit
-	if len(result) > 0 && result[0] >= '0' && result[0] <= '9' {
-		result = "_" + result
-	}
-
-	return result
-}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// errDecodeFailed is returned after the failure has already been printed
+var errDecodeFailed = errors.New("decoding failed")
+
+func main() {
+	var (
+		schemaPath = flag.String("schema", "", "Path to .sdp schema file (required)")
+		typeName   = flag.String("type", "", "Struct to inspect (required for byte mode)")
+		mode       = flag.String("mode", "auto", "Input format: auto, bytes or message (auto detects the SDP magic)")
+		strict     = flag.Bool("strict", false, "Stop at the first non-canonical encoding")
+		all        = flag.Bool("all", false, "Inspect every value of a file holding several values back to back")
+		maxBytes   = flag.Int("bytes", 8, "Maximum raw bytes shown per line")
+	)
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "sdp-inspect - annotated hex dump of SDP data\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: sdp-inspect -schema <file.sdp> [-type <Struct>] [options] [in.sdpb]\n\n")
+		fmt.Fprintf(os.Stderr, "Prints every field with its offset, length, raw bytes and decoded value,\n")
+		fmt.Fprintf(os.Stderr, "marks the field where decoding fails (!!) and reports trailing bytes.\n\n")
+		fmt.Fprintf(os.Stderr, "Options:\n")
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	if *schemaPath == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	err := run(*schemaPath, *typeName, *mode, flag.Arg(0), *strict, *all, *maxBytes)
+	if errors.Is(err, errDecodeFailed) {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(schemaPath, typeName, mode, inFile string, strict, all bool, maxBytes int) error {
+	s, err := schema.Load(schemaPath)
+	if err != nil {
+		return err
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	if inFile == "" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(inFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if mode == "auto" {
+		mode = "bytes"
+		if len(data) >= 4 && string(data[0:3]) == sdp.MessageMagic && data[3] == sdp.MessageVersion {
+			mode = "message"
+		}
+	}
+	if mode != "bytes" && mode != "message" {
+		return fmt.Errorf("-mode must be 'auto', 'bytes' or 'message', got '%s'", mode)
+	}
+	if mode == "bytes" && typeName == "" {
+		return errors.New("-type is required for byte mode input")
+	}
+
+	d := &dumper{
+		codec:    codec,
+		data:     data,
+		strict:   strict,
+		maxBytes: maxBytes,
+		w:        tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0),
+	}
+	defer d.w.Flush()
+
+	fmt.Fprintf(d.w, "OFFSET\tLEN\tBYTES\tFIELD\tTYPE\tVALUE\n")
+	for i := 0; ; i++ {
+		var n int
+		if mode == "message" {
+			n, err = d.message(d.offset)
+		} else {
+			n, err = d.value(typeName, d.offset, 0)
+		}
+		d.offset += n
+		if err != nil {
+			return err
+		}
+
+		if !all || d.offset >= len(data) {
+			break
+		}
+		fmt.Fprintf(d.w, "\t\t\t\t\t\n")
+	}
+
+	d.w.Flush()
+	if trailing := len(data) - d.offset; trailing > 0 {
+		fmt.Printf("\n%d trailing byte(s) at offset %06x: %s\n", trailing, d.offset, d.hex(d.offset, trailing))
+	} else {
+		fmt.Printf("\nno trailing bytes (%d bytes total)\n", len(data))
+	}
+	return nil
+}
+
+// dumper prints annotated lines for the values in data.
+type dumper struct {
+	codec    *dynamic.Codec
+	data     []byte
+	offset   int
+	strict   bool
+	maxBytes int
+	w        *tabwriter.Writer
+}
+
+// value inspects one byte mode value at base (relative to d.data) that
+// must end before limit bytes from base if limit > 0, and returns its length.
+func (d *dumper) value(typeName string, base, limit int) (int, error) {
+	data := d.data[base:]
+	if limit > 0 {
+		data = data[:limit]
+	}
+
+	inspect := d.codec.Inspect
+	if d.strict {
+		inspect = d.codec.InspectStrict
+	}
+	spans, n, err := inspect(typeName, data)
+
+	for _, sp := range spans {
+		length, marker := sp.Length, "   "
+		if length < 0 {
+			length, marker = n-sp.Offset, "!! "
+		}
+		name := sp.Path[strings.LastIndexAny(sp.Path, ".]")+1:]
+		if strings.HasSuffix(sp.Path, "]") {
+			name = sp.Path[strings.LastIndex(sp.Path, "["):]
+		}
+		d.line(base+sp.Offset, length, marker+strings.Repeat("  ", sp.Depth)+name, sp.Type, formatValue(sp))
+	}
+
+	if err != nil {
+		d.w.Flush()
+		fmt.Printf("\n!! %s decode failed at offset %06x: %v\n", typeName, base+n, err)
+		if rest := len(d.data) - base - n; rest > 0 {
+			fmt.Printf("   next bytes: %s\n", d.hex(base+n, rest))
+		} else {
+			fmt.Printf("   (end of data)\n")
+		}
+		return n, errDecodeFailed
+	}
+	return n, nil
+}
+
+// message inspects the message header at base and its payload.
+func (d *dumper) message(base int) (int, error) {
+	data := d.data[base:]
+	typeID, payload, err := sdp.ParseMessageHeader(data)
+	if len(data) >= sdp.MessageHeaderSize {
+		id := binary.LittleEndian.Uint16(data[4:6])
+		d.line(base, 3, "   magic", "header", fmt.Sprintf("%q", data[0:3]))
+		d.line(base+3, 1, "   version", "header", fmt.Sprintf("%q", data[3]))
+		d.line(base+4, 2, "   type_id", "header", fmt.Sprintf("%d (%s)", id, d.typeLabel(id)))
+		d.line(base+6, 4, "   length", "header", fmt.Sprint(binary.LittleEndian.Uint32(data[6:10])))
+	}
+	if err == nil && d.codec.TypeName(typeID) == "" {
+		err = sdp.ErrUnknownMessageType
+	}
+	if err != nil {
+		d.w.Flush()
+		fmt.Printf("\n!! message header at offset %06x is invalid: %v\n", base, err)
+		return 0, errDecodeFailed
+	}
+
+	name := d.codec.TypeName(typeID)
+	n, err := d.value(name, base+sdp.MessageHeaderSize, len(payload))
+	if err != nil {
+		return sdp.MessageHeaderSize + n, err
+	}
+	if n < len(payload) {
+		d.w.Flush()
+		fmt.Printf("\n!! %s payload has %d unused byte(s) at offset %06x\n", name, len(payload)-n, base+sdp.MessageHeaderSize+n)
+	}
+	return sdp.MessageHeaderSize + len(payload), nil
+}
+
+func (d *dumper) typeLabel(id uint16) string {
+	if name := d.codec.TypeName(id); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+func (d *dumper) line(offset, length int, field, typ, value string) {
+	fmt.Fprintf(d.w, "%06x\t%d\t%s\t%s\t%s\t%s\n", offset, length, d.hex(offset, length), field, typ, value)
+}
+
+// hex formats up to maxBytes of the length bytes at offset.
+func (d *dumper) hex(offset, length int) string {
+	if offset+length > len(d.data) {
+		length = len(d.data) - offset
+	}
+	var b strings.Builder
+	for i := 0; i < length && i < d.maxBytes; i++ {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%02x", d.data[offset+i])
+	}
+	if length > d.maxBytes {
+		b.WriteString(" …")
+	}
+	return b.String()
+}
+
+// formatValue renders a span's value for the VALUE column.
+func formatValue(sp dynamic.Span) string {
+	switch {
+	case sp.Length < 0 || sp.Value == nil:
+		return ""
+	case strings.HasPrefix(sp.Type, "[]"):
+		return fmt.Sprintf("%d elements", sp.Value)
+	case strings.HasPrefix(sp.Type, "Option<"):
+		if sp.Value == true {
+			return "present"
+		}
+		return "absent"
+	case sp.Type == "str":
+		return fmt.Sprintf("%q", sp.Value)
+	}
+	return fmt.Sprint(sp.Value)
+}
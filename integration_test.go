@@ -3,25 +3,37 @@ package integration_test
 import (
 	"bytes"
 	"compress/gzip"
+	"encoding"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"log/slog"
 	"math"
+	"math/rand/v2"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+	"unsafe"
 
+	"github.com/shaban/serial-data-protocol/internal/conformance"
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
 	"github.com/shaban/serial-data-protocol/testdata/generated/go/arrays"
 	"github.com/shaban/serial-data-protocol/testdata/generated/go/audiounit"
 	"github.com/shaban/serial-data-protocol/testdata/generated/go/complex"
 	"github.com/shaban/serial-data-protocol/testdata/generated/go/nested"
 	"github.com/shaban/serial-data-protocol/testdata/generated/go/optional"
 	"github.com/shaban/serial-data-protocol/testdata/generated/go/primitives"
+	rtmessage "github.com/shaban/serial-data-protocol/testdata/generated/go/runtime/message_test"
+	rtnested "github.com/shaban/serial-data-protocol/testdata/generated/go/runtime/nested"
 )
 
 var (
@@ -130,9 +142,11 @@ func buildGenerator() error {
 			}
 		}
 
-		// Check internal/generator/**/*.go files (generator logic)
+		// Check internal/generator/**/*.go and sdp/generate/*.go files (generator logic)
 		if !needsRebuild {
 			genFiles, _ := filepath.Glob("internal/generator/*/*.go")
+			apiFiles, _ := filepath.Glob("sdp/generate/*.go")
+			genFiles = append(genFiles, apiFiles...)
 			for _, f := range genFiles {
 				info, err := os.Stat(f)
 				if err == nil && info.ModTime().After(binInfo.ModTime()) {
@@ -252,9 +266,10 @@ func generatePackage(lang, schemaFile, outputDir, pkgName string) error {
 		"-output", outputDir,
 	}
 
-	// Go needs package name
+	// Go needs package name; the fuzz targets run their seeds as plain tests
+	// and the benchmarks are compiled by every test run
 	if lang == "go" {
-		args = append(args, "-package", pkgName)
+		args = append(args, "-package", pkgName, "-fuzz", "-bench-json", "-arena")
 	}
 
 	cmd := exec.Command(genPath, args...)
@@ -451,7 +466,7 @@ func TestWireFormatTruncatedData(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for truncated data, got nil")
 	}
-	if err != primitives.ErrUnexpectedEOF {
+	if !errors.Is(err, primitives.ErrUnexpectedEOF) {
 		t.Errorf("Expected ErrUnexpectedEOF, got %v", err)
 	}
 }
@@ -485,7 +500,7 @@ func TestWireFormatInvalidStringLength(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for invalid string length, got nil")
 	}
-	if err != primitives.ErrUnexpectedEOF {
+	if !errors.Is(err, primitives.ErrUnexpectedEOF) {
 		t.Errorf("Expected ErrUnexpectedEOF, got %v", err)
 	}
 }
@@ -698,11 +713,1586 @@ func TestWireFormatOversizedArray(t *testing.T) {
 	if err == nil {
 		t.Fatal("Expected error for oversized array, got nil")
 	}
-	if err != arrays.ErrArrayTooLarge {
+	if !errors.Is(err, arrays.ErrArrayTooLarge) {
 		t.Errorf("Expected ErrArrayTooLarge, got %v", err)
 	}
 }
 
+// TestDecodeErrorContext verifies that decode errors report the field path
+// and byte offset where decoding failed, and still match the sentinel errors.
+func TestDecodeErrorContext(t *testing.T) {
+	// Truncate a Scene inside main_rect.bottom_right.y
+	scene := nested.Scene{
+		Name: "ab",
+		MainRect: nested.Rectangle{
+			TopLeft:     nested.Point{X: 1, Y: 2},
+			BottomRight: nested.Point{X: 3, Y: 4},
+			Color:       0xFF0000,
+		},
+		Count: 7,
+	}
+	data, err := nested.EncodeScene(&scene)
+	if err != nil {
+		t.Fatalf("EncodeScene failed: %v", err)
+	}
+
+	// name: 4+2 bytes, top_left: 8 bytes, bottom_right.x: 4 bytes => y at 18
+	var decoded nested.Scene
+	err = nested.DecodeScene(&decoded, data[:20])
+	if !errors.Is(err, nested.ErrUnexpectedEOF) {
+		t.Fatalf("expected ErrUnexpectedEOF, got %v", err)
+	}
+	var de *nested.DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("expected *DecodeError, got %T", err)
+	}
+	if de.Path != "main_rect.bottom_right.y" {
+		t.Errorf("Path: got %q, want %q", de.Path, "main_rect.bottom_right.y")
+	}
+	if de.Offset != 18 {
+		t.Errorf("Offset: got %d, want 18", de.Offset)
+	}
+	if want := "decode main_rect.bottom_right.y at offset 18: unexpected end of data"; err.Error() != want {
+		t.Errorf("Error(): got %q, want %q", err.Error(), want)
+	}
+
+	// Array elements are reported by index
+	plugin := complex.Plugin{
+		Id:   1,
+		Name: "p",
+		Parameters: []complex.Parameter{
+			{Id: 1, Name: "a"},
+			{Id: 2, Name: "b"},
+		},
+	}
+	data, err = complex.EncodePlugin(&plugin)
+	if err != nil {
+		t.Fatalf("EncodePlugin failed: %v", err)
+	}
+
+	var decodedPlugin complex.Plugin
+	err = complex.DecodePlugin(&decodedPlugin, data[:len(data)-1])
+	var cde *complex.DecodeError
+	if !errors.As(err, &cde) {
+		t.Fatalf("expected *DecodeError, got %v", err)
+	}
+	if cde.Path != "parameters[1].max" {
+		t.Errorf("Path: got %q, want %q", cde.Path, "parameters[1].max")
+	}
+	if cde.Offset != len(data)-4 {
+		t.Errorf("Offset: got %d, want %d", cde.Offset, len(data)-4)
+	}
+
+	// Primitive and string array elements are reported by index too
+	prims := arrays.ArraysOfPrimitives{StrArray: []string{"a", "b", "c", "d"}}
+	data, err = arrays.EncodeArraysOfPrimitives(&prims)
+	if err != nil {
+		t.Fatalf("EncodeArraysOfPrimitives failed: %v", err)
+	}
+	var decodedPrims arrays.ArraysOfPrimitives
+	err = arrays.DecodeArraysOfPrimitives(&decodedPrims, data[:len(data)-6])
+	var ade *arrays.DecodeError
+	if !errors.As(err, &ade) {
+		t.Fatalf("expected *DecodeError, got %v", err)
+	}
+	if ade.Path != "str_array[3]" {
+		t.Errorf("Path: got %q, want %q", ade.Path, "str_array[3]")
+	}
+
+	// Invalid UTF-8 is rejected by strict decoding and validation alike, and
+	// left unchecked by DecodeX
+	data, err = nested.EncodeScene(&scene)
+	if err != nil {
+		t.Fatalf("EncodeScene failed: %v", err)
+	}
+	data[4] = 0xFF
+	if err := nested.DecodeScene(&decoded, data); err != nil {
+		t.Fatalf("DecodeScene should not check UTF-8: %v", err)
+	}
+	err = nested.DecodeSceneStrict(&decoded, data)
+	if !errors.Is(err, nested.ErrInvalidUTF8) {
+		t.Fatalf("expected ErrInvalidUTF8, got %v", err)
+	}
+	if want := "decode name at offset 4: invalid UTF-8 string"; err.Error() != want {
+		t.Errorf("Error(): got %q, want %q", err.Error(), want)
+	}
+	if _, verr := nested.ValidateScene(data); verr == nil || verr.Error() != err.Error() {
+		t.Errorf("ValidateScene: got %v, want %v", verr, err)
+	}
+}
+
+// TestDecodeStrict verifies that strict decoding accepts only the canonical
+// encoding, while the default decoder stays lenient.
+func TestDecodeStrict(t *testing.T) {
+	value := primitives.AllPrimitives{
+		F64Field:  math.Float64frombits(primitives.CanonicalNaN64),
+		BoolField: true,
+		StrField:  "x",
+	}
+	canonical, err := primitives.EncodeAllPrimitives(&value)
+	if err != nil {
+		t.Fatalf("EncodeAllPrimitives failed: %v", err)
+	}
+
+	var decoded primitives.AllPrimitives
+	if err := primitives.DecodeAllPrimitivesStrict(&decoded, canonical); err != nil {
+		t.Fatalf("strict decode of canonical data failed: %v", err)
+	}
+
+	// Offsets: f64_field at 34, bool_field at 42
+	mutate := func(f func(b []byte)) []byte {
+		b := append([]byte(nil), canonical...)
+		f(b)
+		return b
+	}
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+		path    string
+	}{
+		{"trailing bytes", append(append([]byte(nil), canonical...), 0), primitives.ErrTrailingBytes, ""},
+		{"bool byte 2", mutate(func(b []byte) { b[42] = 2 }), primitives.ErrNonCanonical, "bool_field"},
+		{"NaN with payload", mutate(func(b []byte) {
+			binary.LittleEndian.PutUint64(b[34:], math.Float64bits(math.NaN()))
+		}), primitives.ErrNonCanonical, "f64_field"},
+		{"negative NaN", mutate(func(b []byte) {
+			binary.LittleEndian.PutUint64(b[34:], 0xFFF8000000000000)
+		}), primitives.ErrNonCanonical, "f64_field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result primitives.AllPrimitives
+			err := primitives.DecodeAllPrimitivesStrict(&result, tt.data)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("strict: got %v, want %v", err, tt.wantErr)
+			}
+			var de *primitives.DecodeError
+			if tt.path != "" && (!errors.As(err, &de) || de.Path != tt.path) {
+				t.Errorf("strict: got error %v, want path %q", err, tt.path)
+			}
+
+			// The default decoder accepts every variant
+			if err := primitives.DecodeAllPrimitives(&result, tt.data); err != nil {
+				t.Errorf("lenient: unexpected error %v", err)
+			}
+		})
+	}
+}
+
+// TestValidate verifies that ValidateX accepts exactly what DecodeX accepts
+// (plus the UTF-8 checks of DecodeXStrict), reports the consumed length and does not allocate.
+func TestValidate(t *testing.T) {
+	plugin := complex.Plugin{
+		Id:   1,
+		Name: "reverb",
+		Parameters: []complex.Parameter{
+			{Id: 1, Name: "mix"},
+			{Id: 2, Name: "size"},
+		},
+	}
+	data, err := complex.EncodePlugin(&plugin)
+	if err != nil {
+		t.Fatalf("EncodePlugin failed: %v", err)
+	}
+
+	// Consumed length excludes anything after the value
+	n, err := complex.ValidatePlugin(append(append([]byte(nil), data...), 0xFF, 0xFF))
+	if err != nil {
+		t.Fatalf("ValidatePlugin failed: %v", err)
+	}
+	if n != len(data) {
+		t.Errorf("consumed %d bytes, want %d", n, len(data))
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		complex.ValidatePlugin(data)
+	})
+	if allocs != 0 {
+		t.Errorf("ValidatePlugin allocated %.0f times, want 0", allocs)
+	}
+
+	// Truncation fails with the same error as decoding
+	var decoded complex.Plugin
+	decodeErr := complex.DecodePlugin(&decoded, data[:len(data)-1])
+	_, validateErr := complex.ValidatePlugin(data[:len(data)-1])
+	if validateErr == nil || validateErr.Error() != decodeErr.Error() {
+		t.Errorf("validate error %v, want %v", validateErr, decodeErr)
+	}
+
+	// Invalid UTF-8 in parameters[1].name (the last 4 bytes of "size" precede 3 f32s)
+	bad := append([]byte(nil), data...)
+	bad[len(bad)-12-1] = 0xFF
+	_, err = complex.ValidatePlugin(bad)
+	var de *complex.DecodeError
+	if !errors.Is(err, complex.ErrInvalidUTF8) || !errors.As(err, &de) || de.Path != "parameters[1].name" {
+		t.Errorf("expected ErrInvalidUTF8 at parameters[1].name, got %v", err)
+	}
+	if decodeErr := complex.DecodePluginStrict(&decoded, bad); decodeErr == nil || decodeErr.Error() != err.Error() {
+		t.Errorf("strict decode error %v, want %v", decodeErr, err)
+	}
+
+	// Message mode
+	msg, err := complex.EncodePluginMessage(&plugin)
+	if err != nil {
+		t.Fatalf("EncodePluginMessage failed: %v", err)
+	}
+	n, err = complex.ValidateMessage(msg)
+	if err != nil || n != len(msg) {
+		t.Errorf("ValidateMessage = %d, %v; want %d, nil", n, err, len(msg))
+	}
+	bad = append([]byte(nil), msg...)
+	bad[4] = 0xFF
+	if _, err := complex.ValidateMessage(bad); !errors.Is(err, complex.ErrUnknownMessageType) {
+		t.Errorf("expected ErrUnknownMessageType, got %v", err)
+	}
+	if _, err := complex.ValidateMessage(msg[:len(msg)-1]); !errors.Is(err, complex.ErrUnexpectedEOF) {
+		t.Errorf("expected ErrUnexpectedEOF for truncated message, got %v", err)
+	}
+
+	// A payload longer than its value is rejected, by the decoders as well
+	padded := append(append([]byte(nil), msg...), 0x00)
+	binary.LittleEndian.PutUint32(padded[6:10], uint32(len(padded)-complex.MessageHeaderSize))
+	if _, err := complex.ValidateMessage(padded); !errors.Is(err, complex.ErrTrailingBytes) {
+		t.Errorf("expected ErrTrailingBytes for padded payload, got %v", err)
+	}
+	if _, err := complex.DecodePluginMessage(padded); !errors.Is(err, complex.ErrTrailingBytes) {
+		t.Errorf("DecodePluginMessage: expected ErrTrailingBytes for padded payload, got %v", err)
+	}
+	if _, err := complex.DecodeMessage(padded); !errors.Is(err, complex.ErrTrailingBytes) {
+		t.Errorf("DecodeMessage: expected ErrTrailingBytes for padded payload, got %v", err)
+	}
+}
+
+// TestViews verifies that XView accessors read the same values as DecodeX
+// without copying strings out of the buffer.
+func TestViews(t *testing.T) {
+	device := complex.AudioDevice{
+		DeviceId:   7,
+		DeviceName: "interface",
+		SampleRate: 48000,
+		IsDefault:  true,
+		ActivePlugins: []complex.Plugin{
+			{Id: 1, Name: "eq", Enabled: true},
+			{Id: 2, Name: "reverb", Manufacturer: "acme", Version: 3, Parameters: []complex.Parameter{
+				{Id: 1, Name: "mix", Value: 0.5},
+				{Id: 2, Name: "size", Value: 0.25, Max: 1},
+			}},
+		},
+	}
+	data, err := complex.EncodeAudioDevice(&device)
+	if err != nil {
+		t.Fatalf("EncodeAudioDevice failed: %v", err)
+	}
+
+	view, err := complex.NewAudioDeviceView(data)
+	if err != nil {
+		t.Fatalf("NewAudioDeviceView failed: %v", err)
+	}
+	if view.DeviceId() != 7 || view.DeviceName() != "interface" || view.SampleRate() != 48000 || !view.IsDefault() {
+		t.Errorf("scalar fields mismatch")
+	}
+
+	plugins := view.ActivePlugins()
+	if plugins.Len() != 2 {
+		t.Fatalf("ActivePlugins().Len() = %d, want 2", plugins.Len())
+	}
+	reverb := plugins.At(1)
+	if reverb.Name() != "reverb" || reverb.Manufacturer() != "acme" || reverb.Version() != 3 {
+		t.Errorf("plugin fields mismatch: %q %q %d", reverb.Name(), reverb.Manufacturer(), reverb.Version())
+	}
+	size := reverb.Parameters().At(1)
+	if size.Name() != "size" || size.Value() != 0.25 || size.Max() != 1 {
+		t.Errorf("parameter fields mismatch")
+	}
+
+	// Iteration visits every element in order
+	var names []string
+	for i, p := range plugins.All() {
+		if p.Id() != uint32(i+1) {
+			t.Errorf("plugin %d: id %d", i, p.Id())
+		}
+		names = append(names, p.Name())
+	}
+	if strings.Join(names, ",") != "eq,reverb" {
+		t.Errorf("All() visited %v", names)
+	}
+
+	// Strings alias the input buffer
+	if got := unsafe.StringData(view.DeviceName()); got != &data[8] {
+		t.Errorf("DeviceName was copied")
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = view.ActivePlugins().At(1).Parameters().At(1).Name()
+	})
+	if allocs != 0 {
+		t.Errorf("accessor chain allocated %.0f times, want 0", allocs)
+	}
+
+	// Nested views decode like the top-level value
+	var decoded complex.Plugin
+	if err := reverb.Decode(&decoded); err != nil {
+		t.Fatalf("PluginView.Decode failed: %v", err)
+	}
+	if decoded.Name != "reverb" || len(decoded.Parameters) != 2 {
+		t.Errorf("decoded plugin mismatch: %+v", decoded)
+	}
+
+	// Views share validation with the decoder
+	if _, err := complex.NewAudioDeviceView(data[:len(data)-1]); !errors.Is(err, complex.ErrUnexpectedEOF) {
+		t.Errorf("expected ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+// TestViewsArraysAndOptionals covers primitive arrays, []u8 and optional fields.
+func TestViewsArraysAndOptionals(t *testing.T) {
+	arr := arrays.ArraysOfPrimitives{
+		U8Array:   []uint8{1, 2, 3},
+		U32Array:  []uint32{10, 20},
+		F64Array:  []float64{1.5},
+		StrArray:  []string{"a", "bc"},
+		BoolArray: []bool{true, false},
+	}
+	data, err := arrays.EncodeArraysOfPrimitives(&arr)
+	if err != nil {
+		t.Fatalf("EncodeArraysOfPrimitives failed: %v", err)
+	}
+	av, err := arrays.NewArraysOfPrimitivesView(data)
+	if err != nil {
+		t.Fatalf("NewArraysOfPrimitivesView failed: %v", err)
+	}
+	u8s := av.U8Array()
+	if !bytes.Equal(u8s, arr.U8Array) || cap(u8s) != len(u8s) {
+		t.Errorf("U8Array = %v (cap %d)", u8s, cap(u8s))
+	}
+	if av.U32Array().At(1) != 20 || av.F64Array().At(0) != 1.5 {
+		t.Errorf("fixed-size array elements mismatch")
+	}
+	if av.StrArray().At(1) != "bc" || !av.BoolArray().At(0) || av.BoolArray().At(1) {
+		t.Errorf("variable-size array elements mismatch")
+	}
+
+	for _, tt := range []struct {
+		name string
+		cfg  optional.Config
+	}{
+		{"absent", optional.Config{Name: "app"}},
+		{"present", optional.Config{
+			Name:     "app",
+			Database: &optional.DatabaseConfig{Host: "db", Port: 5432},
+			Cache:    &optional.CacheConfig{SizeMb: 64, TtlSeconds: 30},
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := optional.EncodeConfig(&tt.cfg)
+			if err != nil {
+				t.Fatalf("EncodeConfig failed: %v", err)
+			}
+			cv, err := optional.NewConfigView(data)
+			if err != nil {
+				t.Fatalf("NewConfigView failed: %v", err)
+			}
+			db, ok := cv.Database()
+			if ok != (tt.cfg.Database != nil) {
+				t.Fatalf("Database() ok = %v", ok)
+			}
+			if ok && (db.Host() != "db" || db.Port() != 5432) {
+				t.Errorf("Database fields mismatch")
+			}
+			cache, ok := cv.Cache()
+			if ok != (tt.cfg.Cache != nil) {
+				t.Fatalf("Cache() ok = %v", ok)
+			}
+			if ok && (cache.SizeMb() != 64 || cache.TtlSeconds() != 30) {
+				t.Errorf("Cache fields mismatch")
+			}
+		})
+	}
+}
+
+// TestDecodeReuse verifies that DecodeXReuse produces the same value as
+// DecodeX while recycling the slices, structs and strings already in dest.
+func TestDecodeReuse(t *testing.T) {
+	big := complex.AudioDevice{
+		DeviceName: "interface",
+		ActivePlugins: []complex.Plugin{
+			{Id: 1, Name: "eq", Parameters: []complex.Parameter{{Id: 1, Name: "gain"}, {Id: 2, Name: "freq"}}},
+			{Id: 2, Name: "reverb", Parameters: []complex.Parameter{{Id: 1, Name: "mix"}}},
+		},
+	}
+	small := complex.AudioDevice{
+		DeviceName: "interface",
+		ActivePlugins: []complex.Plugin{
+			{Id: 3, Name: "delay", Parameters: []complex.Parameter{{Id: 1, Name: "time", Value: 0.5}}},
+		},
+	}
+	bigData, err := complex.EncodeAudioDevice(&big)
+	if err != nil {
+		t.Fatalf("EncodeAudioDevice failed: %v", err)
+	}
+	smallData, err := complex.EncodeAudioDevice(&small)
+	if err != nil {
+		t.Fatalf("EncodeAudioDevice failed: %v", err)
+	}
+
+	var dest complex.AudioDevice
+	if err := complex.DecodeAudioDeviceReuse(&dest, bigData); err != nil {
+		t.Fatalf("DecodeAudioDeviceReuse failed: %v", err)
+	}
+	plugins := &dest.ActivePlugins[0]
+	params := &dest.ActivePlugins[0].Parameters[0]
+
+	// Shrinking reuses the existing backing arrays, including nested slices
+	if err := complex.DecodeAudioDeviceReuse(&dest, smallData); err != nil {
+		t.Fatalf("DecodeAudioDeviceReuse failed: %v", err)
+	}
+	var fresh complex.AudioDevice
+	if err := complex.DecodeAudioDevice(&fresh, smallData); err != nil {
+		t.Fatalf("DecodeAudioDevice failed: %v", err)
+	}
+	if !reflect.DeepEqual(dest, fresh) {
+		t.Errorf("reuse decode = %+v, want %+v", dest, fresh)
+	}
+	if &dest.ActivePlugins[0] != plugins || &dest.ActivePlugins[0].Parameters[0] != params {
+		t.Errorf("slices were reallocated instead of reused")
+	}
+
+	// Growing back fits in the retained capacity of the outer slice
+	if err := complex.DecodeAudioDeviceReuse(&dest, bigData); err != nil {
+		t.Fatalf("DecodeAudioDeviceReuse failed: %v", err)
+	}
+	if !reflect.DeepEqual(dest, big) {
+		t.Errorf("reuse decode = %+v, want %+v", dest, big)
+	}
+
+	// A warmed-up value decodes the same frame without allocating
+	allocs := testing.AllocsPerRun(100, func() {
+		complex.DecodeAudioDeviceReuse(&dest, bigData)
+	})
+	if allocs != 0 {
+		t.Errorf("DecodeAudioDeviceReuse allocated %.0f times, want 0", allocs)
+	}
+
+	// Optional structs are decoded in place when present and cleared when absent
+	withDB := optional.Config{Name: "app", Database: &optional.DatabaseConfig{Host: "a", Port: 1}}
+	data, err := optional.EncodeConfig(&withDB)
+	if err != nil {
+		t.Fatalf("EncodeConfig failed: %v", err)
+	}
+	var cfg optional.Config
+	if err := optional.DecodeConfigReuse(&cfg, data); err != nil {
+		t.Fatalf("DecodeConfigReuse failed: %v", err)
+	}
+	db := cfg.Database
+	withDB.Database.Host = "b"
+	data, _ = optional.EncodeConfig(&withDB)
+	if err := optional.DecodeConfigReuse(&cfg, data); err != nil {
+		t.Fatalf("DecodeConfigReuse failed: %v", err)
+	}
+	if cfg.Database != db || db.Host != "b" {
+		t.Errorf("optional struct not reused: %p vs %p, host %q", cfg.Database, db, db.Host)
+	}
+	data, _ = optional.EncodeConfig(&optional.Config{Name: "app"})
+	if err := optional.DecodeConfigReuse(&cfg, data); err != nil {
+		t.Fatalf("DecodeConfigReuse failed: %v", err)
+	}
+	if cfg.Database != nil {
+		t.Errorf("absent optional should be nil")
+	}
+}
+
+// TestDecodeArena verifies that DecodeXArena produces the same values and
+// errors as DecodeX with a handful of allocations.
+func TestDecodeArena(t *testing.T) {
+	data, err := os.ReadFile("testdata/binaries/audiounit.sdpb")
+	if err != nil {
+		t.Fatalf("failed to read audiounit.sdpb: %v", err)
+	}
+
+	var want, got audiounit.PluginRegistry
+	if err := audiounit.DecodePluginRegistry(&want, data); err != nil {
+		t.Fatalf("DecodePluginRegistry failed: %v", err)
+	}
+	if err := audiounit.DecodePluginRegistryArena(&got, data); err != nil {
+		t.Fatalf("DecodePluginRegistryArena failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("arena decode differs from DecodePluginRegistry")
+	}
+
+	// One block for strings and primitive slices, plus one slice per struct type
+	allocs := testing.AllocsPerRun(10, func() {
+		var r audiounit.PluginRegistry
+		audiounit.DecodePluginRegistryArena(&r, data)
+	})
+	if allocs > 3 {
+		t.Errorf("DecodePluginRegistryArena allocated %.0f times, want at most 3", allocs)
+	}
+
+	// Errors match the regular decoder, including field paths
+	for _, n := range []int{len(data) - 1, len(data) / 2, 3} {
+		wantErr := audiounit.DecodePluginRegistry(&want, data[:n])
+		gotErr := audiounit.DecodePluginRegistryArena(&got, data[:n])
+		if gotErr == nil || gotErr.Error() != wantErr.Error() {
+			t.Errorf("truncated at %d: got %v, want %v", n, gotErr, wantErr)
+		}
+	}
+
+	// Primitive arrays of every width and []str share the arena
+	arr := arrays.ArraysOfPrimitives{
+		U8Array:   []uint8{1, 2, 3},
+		U32Array:  []uint32{10, 20, 30},
+		F64Array:  []float64{1.5, -2.5},
+		StrArray:  []string{"a", "", "bc"},
+		BoolArray: []bool{true, false, true},
+	}
+	encoded, err := arrays.EncodeArraysOfPrimitives(&arr)
+	if err != nil {
+		t.Fatalf("EncodeArraysOfPrimitives failed: %v", err)
+	}
+	var decodedArr arrays.ArraysOfPrimitives
+	if err := arrays.DecodeArraysOfPrimitivesArena(&decodedArr, encoded); err != nil {
+		t.Fatalf("DecodeArraysOfPrimitivesArena failed: %v", err)
+	}
+	if !reflect.DeepEqual(decodedArr, arr) {
+		t.Errorf("got %+v, want %+v", decodedArr, arr)
+	}
+
+	// Optional structs come from the arena too
+	cfg := optional.Config{Name: "app", Cache: &optional.CacheConfig{SizeMb: 64, TtlSeconds: 30}}
+	encoded, err = optional.EncodeConfig(&cfg)
+	if err != nil {
+		t.Fatalf("EncodeConfig failed: %v", err)
+	}
+	var decodedCfg optional.Config
+	if err := optional.DecodeConfigArena(&decodedCfg, encoded); err != nil {
+		t.Fatalf("DecodeConfigArena failed: %v", err)
+	}
+	if !reflect.DeepEqual(decodedCfg, cfg) {
+		t.Errorf("got %+v, want %+v", decodedCfg, cfg)
+	}
+}
+
+// TestBinaryMarshaler verifies the encoding.Binary* implementations agree with
+// the byte-mode encoder and decoder.
+func TestBinaryMarshaler(t *testing.T) {
+	plugin := &complex.Plugin{
+		Id:         1,
+		Name:       "reverb",
+		Parameters: []complex.Parameter{{Id: 1, Name: "mix", Value: 0.5}},
+	}
+	var (
+		_ encoding.BinaryMarshaler   = plugin
+		_ encoding.BinaryAppender    = plugin
+		_ encoding.BinaryUnmarshaler = plugin
+	)
+
+	want, err := complex.EncodePlugin(plugin)
+	if err != nil {
+		t.Fatalf("EncodePlugin failed: %v", err)
+	}
+	got, err := plugin.MarshalBinary()
+	if err != nil || !bytes.Equal(got, want) {
+		t.Fatalf("MarshalBinary = %x, %v; want %x", got, err, want)
+	}
+
+	// AppendBinary keeps the prefix and reuses spare capacity
+	prefix := []byte("key:")
+	appended, err := plugin.AppendBinary(prefix)
+	if err != nil {
+		t.Fatalf("AppendBinary failed: %v", err)
+	}
+	if !bytes.Equal(appended, append([]byte("key:"), want...)) {
+		t.Errorf("AppendBinary = %x", appended)
+	}
+	buf := make([]byte, 0, 256)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = plugin.AppendBinary(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendBinary with spare capacity allocated %.0f times, want 0", allocs)
+	}
+
+	var decoded complex.Plugin
+	if err := decoded.UnmarshalBinary(want); err != nil {
+		t.Fatalf("UnmarshalBinary failed: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, plugin) {
+		t.Errorf("UnmarshalBinary = %+v, want %+v", decoded, *plugin)
+	}
+	if err := decoded.UnmarshalBinary(want[:len(want)-1]); !errors.Is(err, complex.ErrUnexpectedEOF) {
+		t.Errorf("expected ErrUnexpectedEOF, got %v", err)
+	}
+}
+
+// typedCache is generic middleware that stores any SDP type as bytes.
+type typedCache[T any, PT interface {
+	*T
+	sdp.Codec
+}] struct {
+	entries map[string][]byte
+}
+
+func (c *typedCache[T, PT]) Put(key string, v PT) error {
+	data, err := sdp.Marshal(v)
+	if err != nil {
+		return err
+	}
+	c.entries[key] = data
+	return nil
+}
+
+func (c *typedCache[T, PT]) Get(key string) (T, error) {
+	return sdp.Unmarshal[T, PT](c.entries[key])
+}
+
+// TestRuntimeCodec verifies that generated types implement sdp.Codec and
+// work with generic infrastructure code.
+func TestRuntimeCodec(t *testing.T) {
+	var (
+		_ sdp.Codec = (*complex.Plugin)(nil)
+		_ sdp.Codec = (*audiounit.PluginRegistry)(nil)
+		_ sdp.Codec = (*optional.Config)(nil)
+	)
+
+	plugin := &complex.Plugin{Id: 3, Name: "delay", Parameters: []complex.Parameter{{Id: 1, Name: "time"}}}
+	data, err := sdp.Marshal(plugin)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if len(data) != plugin.EncodedSize() {
+		t.Errorf("EncodedSize = %d, encoded %d bytes", plugin.EncodedSize(), len(data))
+	}
+
+	decoded, err := sdp.Unmarshal[complex.Plugin](data)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, plugin) {
+		t.Errorf("Unmarshal = %+v, want %+v", decoded, *plugin)
+	}
+
+	cache := &typedCache[optional.Config, *optional.Config]{entries: map[string][]byte{}}
+	cfg := &optional.Config{Name: "app", Cache: &optional.CacheConfig{SizeMb: 8}}
+	if err := cache.Put("app", cfg); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	got, err := cache.Get("app")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if !reflect.DeepEqual(&got, cfg) {
+		t.Errorf("Get = %+v, want %+v", got, *cfg)
+	}
+	if _, err := cache.Get("missing"); !errors.Is(err, optional.ErrUnexpectedEOF) {
+		t.Errorf("expected ErrUnexpectedEOF for missing entry, got %v", err)
+	}
+}
+
+// TestRuntimeSharedErrors verifies that packages generated with -runtime share
+// their sentinel errors and message framing with package sdp
+func TestRuntimeSharedErrors(t *testing.T) {
+	scene := &rtnested.Scene{Name: "stage", Count: 3}
+	data, err := rtnested.EncodeScene(scene)
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+
+	var got rtnested.Scene
+	err = rtnested.DecodeScene(&got, data[:len(data)-2])
+	if !errors.Is(err, sdp.ErrUnexpectedEOF) {
+		t.Fatalf("expected sdp.ErrUnexpectedEOF, got %v", err)
+	}
+	var decErr *sdp.DecodeError
+	if !errors.As(err, &decErr) {
+		t.Fatalf("expected *sdp.DecodeError, got %T", err)
+	}
+
+	var rect rtmessage.Rectangle
+	if err := rtmessage.DecodeRectangle(&rect, nil); !errors.Is(err, sdp.ErrUnexpectedEOF) {
+		t.Errorf("expected sdp.ErrUnexpectedEOF from second package, got %v", err)
+	}
+
+	point := &rtmessage.Point{X: 1.5, Y: -2}
+	msg, err := rtmessage.EncodePointMessage(point)
+	if err != nil {
+		t.Fatalf("message encode failed: %v", err)
+	}
+	typeID, payload, err := sdp.ParseMessageHeader(msg)
+	if err != nil {
+		t.Fatalf("ParseMessageHeader failed: %v", err)
+	}
+	if typeID != 1 {
+		t.Errorf("typeID = %d, want 1", typeID)
+	}
+	var decoded rtmessage.Point
+	if err := rtmessage.DecodePoint(&decoded, payload); err != nil {
+		t.Fatalf("decode payload failed: %v", err)
+	}
+	if decoded != *point {
+		t.Errorf("decoded = %+v, want %+v", decoded, *point)
+	}
+
+	msg[0] = 'X'
+	if _, err := rtmessage.DecodeMessage(msg); !errors.Is(err, sdp.ErrInvalidMagic) {
+		t.Errorf("expected sdp.ErrInvalidMagic, got %v", err)
+	}
+}
+
+// TestDynamicCodecMatchesGenerated cross-checks the schema-driven dynamic
+// codec against generated code: it must accept the same bytes, re-encode them
+// identically, and fail on every truncation with the same error, path and offset
+func TestDynamicCodecMatchesGenerated(t *testing.T) {
+	tests := []struct {
+		schema string
+		name   string
+		value  interface{ MarshalBinary() ([]byte, error) }
+		decode func([]byte) error
+	}{
+		{
+			schema: "primitives", name: "AllPrimitives",
+			value: &primitives.AllPrimitives{
+				U8Field: 255, U16Field: 65535, U32Field: 1 << 31, U64Field: 1 << 63,
+				I8Field: -128, I16Field: -32768, I32Field: -1, I64Field: math.MinInt64,
+				F32Field: 3.5, F64Field: -1e300, BoolField: true, StrField: "héllo",
+			},
+			decode: func(b []byte) error { var v primitives.AllPrimitives; return primitives.DecodeAllPrimitives(&v, b) },
+		},
+		{
+			schema: "arrays", name: "ArraysOfPrimitives",
+			value: &arrays.ArraysOfPrimitives{
+				U8Array: []uint8{1, 2, 3}, U32Array: []uint32{7, 8}, F64Array: []float64{0.5, -2},
+				StrArray: []string{"a", "", "xyz"}, BoolArray: []bool{true, false},
+			},
+			decode: func(b []byte) error { var v arrays.ArraysOfPrimitives; return arrays.DecodeArraysOfPrimitives(&v, b) },
+		},
+		{
+			schema: "complex", name: "AudioDevice",
+			value: &complex.AudioDevice{
+				DeviceId: 1, DeviceName: "Interface", SampleRate: 48000, BufferSize: 256,
+				InputChannels: 2, OutputChannels: 8, IsDefault: true,
+				ActivePlugins: []complex.Plugin{
+					{Id: 10, Name: "EQ", Manufacturer: "Acme", Version: 2, Enabled: true,
+						Parameters: []complex.Parameter{{Id: 1, Name: "gain", Value: 0.5, Min: -1, Max: 1}}},
+					{Id: 11, Name: "Comp"},
+				},
+			},
+			decode: func(b []byte) error { var v complex.AudioDevice; return complex.DecodeAudioDevice(&v, b) },
+		},
+		{
+			schema: "optional", name: "Config",
+			value: &optional.Config{
+				Name:  "prod",
+				Cache: &optional.CacheConfig{SizeMb: 64, TtlSeconds: 30},
+			},
+			decode: func(b []byte) error { var v optional.Config; return optional.DecodeConfig(&v, b) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := schema.Load(filepath.Join("testdata", "schemas", tt.schema+".sdp"))
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			codec, err := dynamic.New(s)
+			if err != nil {
+				t.Fatalf("dynamic.New failed: %v", err)
+			}
+
+			data, err := tt.value.MarshalBinary()
+			if err != nil {
+				t.Fatalf("MarshalBinary failed: %v", err)
+			}
+
+			value, err := codec.DecodeStrict(tt.name, data)
+			if err != nil {
+				t.Fatalf("dynamic decode failed: %v", err)
+			}
+			again, err := codec.Encode(tt.name, value)
+			if err != nil {
+				t.Fatalf("dynamic encode failed: %v", err)
+			}
+			if !bytes.Equal(again, data) {
+				t.Fatalf("dynamic re-encoding differs:\n got %x\nwant %x", again, data)
+			}
+
+			// Generated JSON tags and the dynamic codec share the canonical mapping
+			wantJSON, err := json.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("json.Marshal failed: %v", err)
+			}
+			gotJSON, err := codec.AppendJSON(nil, tt.name, value)
+			if err != nil {
+				t.Fatalf("AppendJSON failed: %v", err)
+			}
+			if !bytes.Equal(gotJSON, wantJSON) {
+				t.Errorf("dynamic JSON differs from generated:\n got %s\nwant %s", gotJSON, wantJSON)
+			}
+
+			for n := 0; n < len(data); n++ {
+				wantErr := tt.decode(data[:n])
+				_, gotErr := codec.Decode(tt.name, data[:n])
+				if wantErr == nil || gotErr == nil || wantErr.Error() != gotErr.Error() {
+					t.Errorf("truncated to %d bytes: dynamic error %v, generated error %v", n, gotErr, wantErr)
+				}
+			}
+		})
+	}
+}
+
+// TestJSONFixturesMatchGenerated checks that the JSON fixtures unmarshal
+// into generated structs through their JSON tags and encode to the
+// reference binaries
+func TestJSONFixturesMatchGenerated(t *testing.T) {
+	tests := []struct {
+		json, selectKey, binary string
+		encode                  func(data []byte) ([]byte, error)
+	}{
+		{"primitives.json", "", "primitives.sdpb", encodeJSONFixture(primitives.EncodeAllPrimitives)},
+		{"nested.json", "", "nested.sdpb", encodeJSONFixture(nested.EncodeScene)},
+		{"optional.json", "", "optional.sdpb", encodeJSONFixture(optional.EncodeConfig)},
+		{"arrays.json", "primitives", "arrays_primitives_all.sdpb", encodeJSONFixture(arrays.EncodeArraysOfPrimitives)},
+		{"arrays.json", "structs", "arrays_structs.sdpb", encodeJSONFixture(arrays.EncodeArraysOfStructs)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.binary, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", "data", tt.json))
+			if err != nil {
+				t.Fatalf("read fixture: %v", err)
+			}
+			if tt.selectKey != "" {
+				var bundle map[string]json.RawMessage
+				if err := json.Unmarshal(data, &bundle); err != nil {
+					t.Fatalf("unmarshal bundle: %v", err)
+				}
+				data = bundle[tt.selectKey]
+			}
+			want, err := os.ReadFile(filepath.Join("testdata", "binaries", tt.binary))
+			if err != nil {
+				t.Fatalf("read reference: %v", err)
+			}
+
+			got, err := tt.encode(data)
+			if err != nil {
+				t.Fatalf("encode fixture: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("fixture encodes to %d bytes that differ from %s (%d bytes)", len(got), tt.binary, len(want))
+			}
+		})
+	}
+}
+
+// TestBenchmarkFixtureSingleValue checks that arrays_primitives.sdpb, which
+// the benchmarks decode as one value, holds exactly the first value of
+// arrays_primitives_all.sdpb
+func TestBenchmarkFixtureSingleValue(t *testing.T) {
+	single, err := os.ReadFile(filepath.Join("testdata", "binaries", "arrays_primitives.sdpb"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	all, err := os.ReadFile(filepath.Join("testdata", "binaries", "arrays_primitives_all.sdpb"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	var v arrays.ArraysOfPrimitives
+	if err := arrays.DecodeArraysOfPrimitivesStrict(&v, single); err != nil {
+		t.Fatalf("arrays_primitives.sdpb is not a single value: %v", err)
+	}
+	if !bytes.HasPrefix(all, single) {
+		t.Errorf("arrays_primitives.sdpb differs from the first value of arrays_primitives_all.sdpb")
+	}
+}
+
+// TestAudioUnitExportMatchesFixture checks that audiounit.json, the source of
+// audiounit.sdpb, holds the same data as the raw AudioUnit export in
+// plugins.json
+func TestAudioUnitExportMatchesFixture(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "data", "plugins.json"))
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	var export []struct {
+		Name           string `json:"name"`
+		ManufacturerID string `json:"manufacturerID"`
+		Type           string `json:"type"`
+		Subtype        string `json:"subtype"`
+		Parameters     []struct {
+			Address      uint64  `json:"address"`
+			DisplayName  string  `json:"displayName"`
+			Identifier   string  `json:"identifier"`
+			Unit         string  `json:"unit"`
+			MinValue     float32 `json:"minValue"`
+			MaxValue     float32 `json:"maxValue"`
+			DefaultValue float32 `json:"defaultValue"`
+			CurrentValue float32 `json:"currentValue"`
+			RawFlags     uint32  `json:"rawFlags"`
+			IsWritable   bool    `json:"isWritable"`
+			CanRamp      bool    `json:"canRamp"`
+		} `json:"parameters"`
+	}
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("parse export: %v", err)
+	}
+
+	want := audiounit.PluginRegistry{TotalPluginCount: uint32(len(export))}
+	for _, p := range export {
+		plugin := audiounit.Plugin{
+			Name:             p.Name,
+			ManufacturerId:   p.ManufacturerID,
+			ComponentType:    p.Type,
+			ComponentSubtype: p.Subtype,
+			Parameters:       []audiounit.Parameter{},
+		}
+		for _, param := range p.Parameters {
+			plugin.Parameters = append(plugin.Parameters, audiounit.Parameter(param))
+		}
+		want.TotalParameterCount += uint32(len(p.Parameters))
+		want.Plugins = append(want.Plugins, plugin)
+	}
+
+	data, err = os.ReadFile(filepath.Join("testdata", "data", "audiounit.json"))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	var got audiounit.PluginRegistry
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("parse fixture: %v", err)
+	}
+	if !got.Equal(&want) {
+		t.Errorf("audiounit.json differs from plugins.json: %v", got.Diff(&want))
+	}
+}
+
+// encodeJSONFixture returns a function that unmarshals a JSON array into
+// generated values and encodes them back to back
+func encodeJSONFixture[T any](encode func(*T) ([]byte, error)) func([]byte) ([]byte, error) {
+	return func(data []byte) ([]byte, error) {
+		var values []T
+		if err := json.Unmarshal(data, &values); err != nil {
+			return nil, err
+		}
+		var out []byte
+		for i := range values {
+			b, err := encode(&values[i])
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, b...)
+		}
+		return out, nil
+	}
+}
+
+// TestEncodeDecodeTools verifies that sdp-encode reproduces the reference
+// binaries from their JSON sources and that sdp-decode output encodes back
+// to the same bytes, in byte mode and message mode
+func TestEncodeDecodeTools(t *testing.T) {
+	encodeBin := buildTool(t, "sdp-encode")
+	decodeBin := buildTool(t, "sdp-decode")
+
+	tests := []struct {
+		schema, typeName, json, binary string
+		args                           []string
+	}{
+		{"primitives", "AllPrimitives", "primitives.json", "primitives.sdpb", nil},
+		{"nested", "Scene", "nested.json", "nested.sdpb", nil},
+		{"optional", "Config", "optional.json", "optional.sdpb", nil},
+		{"arrays", "ArraysOfPrimitives", "arrays.json", "arrays_primitives_all.sdpb", []string{"-select", "primitives"}},
+		{"arrays", "ArraysOfStructs", "arrays.json", "arrays_structs.sdpb", []string{"-select", "structs"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.binary, func(t *testing.T) {
+			schemaFile := filepath.Join("testdata", "schemas", tt.schema+".sdp")
+			want, err := os.ReadFile(filepath.Join("testdata", "binaries", tt.binary))
+			if err != nil {
+				t.Fatalf("read reference: %v", err)
+			}
+
+			args := append([]string{"-schema", schemaFile, "-type", tt.typeName, "-json", filepath.Join("testdata", "data", tt.json)}, tt.args...)
+			got, err := exec.Command(encodeBin, args...).Output()
+			if err != nil {
+				t.Fatalf("sdp-encode failed: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Fatalf("sdp-encode output (%d bytes) differs from %s (%d bytes)", len(got), tt.binary, len(want))
+			}
+
+			decode := exec.Command(decodeBin, "-schema", schemaFile, "-type", tt.typeName, "-strict")
+			decode.Stdin = bytes.NewReader(want)
+			jsonLines, err := decode.Output()
+			if err != nil {
+				t.Fatalf("sdp-decode failed: %v", err)
+			}
+
+			encode := exec.Command(encodeBin, "-schema", schemaFile, "-type", tt.typeName)
+			encode.Stdin = bytes.NewReader(jsonLines)
+			again, err := encode.Output()
+			if err != nil {
+				t.Fatalf("sdp-encode of decoded JSON failed: %v", err)
+			}
+			if !bytes.Equal(again, want) {
+				t.Errorf("decode/encode round trip differs from %s", tt.binary)
+			}
+
+			// The text format must reproduce the bytes as well
+			decode = exec.Command(decodeBin, "-schema", schemaFile, "-type", tt.typeName, "-text")
+			decode.Stdin = bytes.NewReader(want)
+			text, err := decode.Output()
+			if err != nil {
+				t.Fatalf("sdp-decode -text failed: %v", err)
+			}
+			textFile := filepath.Join(t.TempDir(), tt.schema+".sdptxt")
+			if err := os.WriteFile(textFile, text, 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err = exec.Command(encodeBin, "-schema", schemaFile, "-type", tt.typeName, "-text", textFile).Output()
+			if err != nil {
+				t.Fatalf("sdp-encode -text failed: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("sdp-decode -text | sdp-encode -text differs from %s", tt.binary)
+			}
+		})
+	}
+
+	t.Run("messages", func(t *testing.T) {
+		var want []byte
+		for _, name := range []string{"message_point.sdpb", "message_rectangle.sdpb"} {
+			data, err := os.ReadFile(filepath.Join("testdata", "binaries", name))
+			if err != nil {
+				t.Fatalf("read reference: %v", err)
+			}
+			want = append(want, data...)
+		}
+		schemaFile := filepath.Join("testdata", "schemas", "message_test.sdp")
+
+		decode := exec.Command(decodeBin, "-schema", schemaFile)
+		decode.Stdin = bytes.NewReader(want)
+		jsonLines, err := decode.Output()
+		if err != nil {
+			t.Fatalf("sdp-decode failed: %v", err)
+		}
+		if !strings.HasPrefix(string(jsonLines), `{"type":"Point","value":{"x":3.14,`) {
+			t.Errorf("unexpected message JSON: %s", jsonLines)
+		}
+
+		encode := exec.Command(encodeBin, "-schema", schemaFile, "-message")
+		encode.Stdin = bytes.NewReader(jsonLines)
+		again, err := encode.Output()
+		if err != nil {
+			t.Fatalf("sdp-encode failed: %v", err)
+		}
+		if !bytes.Equal(again, want) {
+			t.Errorf("message round trip differs")
+		}
+	})
+
+	// primitives_edge.sdptxt is the golden text form of primitives_edge.sdpb
+	t.Run("primitives_edge.sdpb", func(t *testing.T) {
+		schemaFile := filepath.Join("testdata", "schemas", "primitives.sdp")
+		textFile := filepath.Join("testdata", "data", "primitives_edge.sdptxt")
+		want, err := os.ReadFile(filepath.Join("testdata", "binaries", "primitives_edge.sdpb"))
+		if err != nil {
+			t.Fatalf("read reference: %v", err)
+		}
+		wantText, err := os.ReadFile(textFile)
+		if err != nil {
+			t.Fatalf("read reference: %v", err)
+		}
+
+		got, err := exec.Command(encodeBin, "-schema", schemaFile, "-type", "AllPrimitives", "-text", textFile).Output()
+		if err != nil {
+			t.Fatalf("sdp-encode -text failed: %v", err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("sdp-encode -text output (%d bytes) differs from primitives_edge.sdpb (%d bytes)", len(got), len(want))
+		}
+
+		decode := exec.Command(decodeBin, "-schema", schemaFile, "-type", "AllPrimitives", "-strict", "-text")
+		decode.Stdin = bytes.NewReader(want)
+		text, err := decode.Output()
+		if err != nil {
+			t.Fatalf("sdp-decode -text failed: %v", err)
+		}
+		if !bytes.Equal(text, wantText) {
+			t.Errorf("sdp-decode -text output differs from primitives_edge.sdptxt:\n%s", text)
+		}
+	})
+}
+
+// buildTool builds cmd/<name> into a temporary directory and returns its path
+func buildTool(t *testing.T, name string) string {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), name)
+	if out, err := exec.Command("go", "build", "-o", bin, "./cmd/"+name).CombinedOutput(); err != nil {
+		t.Fatalf("build %s failed: %v\n%s", name, err, out)
+	}
+	return bin
+}
+
+// TestInspectTool verifies that sdp-inspect annotates every field, reports
+// trailing bytes, and pinpoints where decoding fails
+func TestInspectTool(t *testing.T) {
+	inspectBin := buildTool(t, "sdp-inspect")
+	schemaFile := filepath.Join("testdata", "schemas", "arrays.sdp")
+	data, err := os.ReadFile(filepath.Join("testdata", "binaries", "arrays_structs.sdpb"))
+	if err != nil {
+		t.Fatalf("read reference: %v", err)
+	}
+
+	cmd := exec.Command(inspectBin, "-schema", schemaFile, "-type", "ArraysOfStructs")
+	cmd.Stdin = bytes.NewReader(data)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("sdp-inspect failed: %v", err)
+	}
+	for _, want := range []string{"[]Item  3 elements", `"Item One"`, "trailing byte(s) at offset 00003a"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	cmd = exec.Command(inspectBin, "-schema", schemaFile, "-type", "ArraysOfStructs")
+	cmd.Stdin = bytes.NewReader(data[:40])
+	out, err = cmd.Output()
+	if err == nil {
+		t.Fatal("expected non-zero exit for truncated data")
+	}
+	if !strings.Contains(string(out), "decode items[2].name at offset 40: unexpected end of data") {
+		t.Errorf("output does not report the failing field:\n%s", out)
+	}
+}
+
+func TestDiffTool(t *testing.T) {
+	diffBin := buildTool(t, "sdp-diff")
+	schemaFile := filepath.Join("testdata", "schemas", "optional.sdp")
+	dir := t.TempDir()
+
+	write := func(name string, cfg *optional.Config) string {
+		data, err := optional.EncodeConfig(cfg)
+		if err != nil {
+			t.Fatalf("encode %s: %v", name, err)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	a := write("a.sdpb", &optional.Config{Name: "prod", Database: &optional.DatabaseConfig{Host: "db", Port: 5432}})
+	b := write("b.sdpb", &optional.Config{Name: "prod", Database: &optional.DatabaseConfig{Host: "db", Port: 5433}, Cache: &optional.CacheConfig{SizeMb: 64}})
+
+	out, err := exec.Command(diffBin, "-schema", schemaFile, "-type", "Config", a, a).Output()
+	if err != nil || !strings.Contains(string(out), "payloads are equal") {
+		t.Fatalf("identical payloads: err = %v, output:\n%s", err, out)
+	}
+
+	out, err = exec.Command(diffBin, "-schema", schemaFile, "-type", "Config", a, b).Output()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("differing payloads: err = %v, want exit status 1", err)
+	}
+	for _, want := range []string{"database.port: 5432 -> 5433", "cache: absent -> present"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, _ = exec.Command(diffBin, "-schema", schemaFile, "-type", "Config", "-json", a, b).Output()
+	var report struct {
+		Equal   bool             `json:"equal"`
+		Changes []dynamic.Change `json:"changes"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if report.Equal || len(report.Changes) != 2 || report.Changes[0].Path != "database.port" {
+		t.Errorf("JSON report = %+v", report)
+	}
+}
+
+// TestGenFixtures checks that the checked-in golden files match the current
+// encoders, and that gen-fixtures writes and verifies a manifest
+func TestGenFixtures(t *testing.T) {
+	genBin := buildTool(t, "gen-fixtures")
+
+	if out, err := exec.Command(genBin, "-verify", "-manifest", filepath.Join("testdata", "fixtures.json")).CombinedOutput(); err != nil {
+		t.Fatalf("checked-in fixtures are out of date: %v\n%s", err, out)
+	}
+
+	dir := t.TempDir()
+	schemaFile, err := filepath.Abs(filepath.Join("testdata", "schemas", "message_test.sdp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := `{"type": "Point", "value": {"x": 1, "y": 2}}
+{"type": "Rectangle", "value": {"top_left": {"x": 0, "y": 0}, "width": 3, "height": 4}}`
+	manifest := fmt.Sprintf(`{"fixtures": [
+		{"output": "points.sdpb", "schema": %q, "type": "Point", "input": "points.sdptxt"},
+		{"output": "shapes.sdpb", "schema": %q, "input": "shapes.json", "mode": "message"}
+	]}`, schemaFile, schemaFile)
+	for name, content := range map[string]string{
+		"fixtures.json": manifest,
+		"points.sdptxt": "x: 1\ny: 2\n---\nx: 3\ny: 4\n",
+		"shapes.json":   input,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifestFile := filepath.Join(dir, "fixtures.json")
+
+	out, err := exec.Command(genBin, "-verify", "-manifest", manifestFile).CombinedOutput()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || !strings.Contains(string(out), "points.sdpb: missing") {
+		t.Fatalf("verify before generating: err = %v, output:\n%s", err, out)
+	}
+
+	if out, err := exec.Command(genBin, "-manifest", manifestFile).CombinedOutput(); err != nil {
+		t.Fatalf("gen-fixtures failed: %v\n%s", err, out)
+	}
+	points, err := os.ReadFile(filepath.Join(dir, "points.sdpb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 32 {
+		t.Errorf("points.sdpb has %d bytes, want two 16-byte points", len(points))
+	}
+	shapes, err := os.ReadFile(filepath.Join(dir, "shapes.sdpb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shapes) != 26+42 || !bytes.HasPrefix(shapes, []byte("SDP")) {
+		t.Errorf("shapes.sdpb = %x, want a Point and a Rectangle message", shapes)
+	}
+
+	if out, err := exec.Command(genBin, "-verify", "-manifest", manifestFile).CombinedOutput(); err != nil {
+		t.Fatalf("verify after generating: %v\n%s", err, out)
+	}
+
+	points[20] ^= 0xff
+	if err := os.WriteFile(filepath.Join(dir, "points.sdpb"), points, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = exec.Command(genBin, "-verify", "-manifest", manifestFile).CombinedOutput()
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || !strings.Contains(string(out), "differs at offset 000014") {
+		t.Errorf("verify after corruption: err = %v, output:\n%s", err, out)
+	}
+}
+
+// TestGeneratedEqualCloneDiff checks that Equal agrees with the encoded bytes
+// and that Clone and Diff follow the same semantics.
+func TestGeneratedEqualCloneDiff(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	pairs := []struct {
+		name string
+		a, b *arrays.ArraysOfPrimitives
+	}{
+		{"nil vs empty", &arrays.ArraysOfPrimitives{}, &arrays.ArraysOfPrimitives{U8Array: []uint8{}, StrArray: []string{}}},
+		{"NaN", &arrays.ArraysOfPrimitives{F64Array: []float64{math.NaN()}}, &arrays.ArraysOfPrimitives{F64Array: []float64{math.NaN()}}},
+		{"signed zero", &arrays.ArraysOfPrimitives{F64Array: []float64{0}}, &arrays.ArraysOfPrimitives{F64Array: []float64{negZero}}},
+		{"string", &arrays.ArraysOfPrimitives{StrArray: []string{"a"}}, &arrays.ArraysOfPrimitives{StrArray: []string{"b"}}},
+	}
+	for _, p := range pairs {
+		encA, _ := arrays.EncodeArraysOfPrimitives(p.a)
+		encB, _ := arrays.EncodeArraysOfPrimitives(p.b)
+		if got, want := p.a.Equal(p.b), bytes.Equal(encA, encB); got != want {
+			t.Errorf("%s: Equal() = %v, encodings equal = %v", p.name, got, want)
+		}
+		if p.a.Equal(p.b) != (p.a.Diff(p.b) == nil) {
+			t.Errorf("%s: Diff() = %+v disagrees with Equal()", p.name, p.a.Diff(p.b))
+		}
+	}
+
+	orig := &arrays.ArraysOfStructs{Items: []arrays.Item{{Id: 1, Name: "one"}, {Id: 2, Name: "two"}}, Count: 2}
+	clone := orig.Clone()
+	if !clone.Equal(orig) {
+		t.Fatal("Clone() is not Equal to the original")
+	}
+	clone.Items[0].Name = "uno"
+	clone.Items = append(clone.Items, arrays.Item{Id: 3})
+	if orig.Items[0].Name != "one" {
+		t.Error("Clone() shares array storage with the original")
+	}
+	if (&arrays.ArraysOfPrimitives{}).Clone().U8Array != nil {
+		t.Error("Clone() should keep nil arrays nil")
+	}
+
+	want := []arrays.FieldChange{
+		{Path: "items", Kind: "length", Old: 2, New: 3},
+		{Path: "items[0].name", Kind: "value", Old: "one", New: "uno"},
+	}
+	if got := orig.Diff(clone); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+
+	a := &optional.Config{Name: "prod", Database: &optional.DatabaseConfig{Host: "db", Port: 5432}}
+	b := a.Clone()
+	b.Database.Port = 5433
+	b.Cache = &optional.CacheConfig{SizeMb: 64}
+	wantCfg := []optional.FieldChange{
+		{Path: "database.port", Kind: "value", Old: uint16(5432), New: uint16(5433)},
+		{Path: "cache", Kind: "presence", Old: false, New: true},
+	}
+	if got := a.Diff(b); !reflect.DeepEqual(got, wantCfg) {
+		t.Errorf("Diff() = %+v, want %+v", got, wantCfg)
+	}
+	if a.Database.Port != 5432 {
+		t.Error("Clone() shares optional structs with the original")
+	}
+}
+
+// TestRandomValues checks that generated RandomX constructors draw the same
+// values as the dynamic codec and sdp-encode -random from the same seed.
+func TestRandomValues(t *testing.T) {
+	s, err := schema.Load(filepath.Join("testdata", "schemas", "complex.sdp"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		t.Fatalf("dynamic.New failed: %v", err)
+	}
+
+	options := []struct {
+		generated *complex.RandomOptions
+		dynamic   *dynamic.RandomOptions
+	}{
+		{nil, nil},
+		{
+			&complex.RandomOptions{MinArrayLen: 1, MaxArrayLen: 3, MaxStringLen: 5, Charset: "äö€"},
+			&dynamic.RandomOptions{MinArrayLen: 1, MaxArrayLen: 3, MaxStringLen: 5, Charset: "äö€"},
+		},
+		{&complex.RandomOptions{Charset: "x"}, &dynamic.RandomOptions{Charset: "x"}},
+		{
+			&complex.RandomOptions{OptionalProbability: -1, MaxArrayLen: -1, MaxStringLen: -1},
+			&dynamic.RandomOptions{OptionalProbability: -1, MaxArrayLen: -1, MaxStringLen: -1},
+		},
+	}
+	for _, opts := range options {
+		for seed := uint64(0); seed < 10; seed++ {
+			value := complex.RandomAudioDevice(rand.New(rand.NewPCG(seed, 0)), opts.generated)
+			want, err := complex.EncodeAudioDevice(value)
+			if err != nil {
+				t.Fatalf("EncodeAudioDevice failed: %v", err)
+			}
+			if opts.generated != nil && opts.generated.MinArrayLen > 0 && len(value.ActivePlugins) == 0 {
+				t.Errorf("seed %d: MinArrayLen 1 produced an empty array", seed)
+			}
+
+			dyn, err := codec.Random(rand.New(rand.NewPCG(seed, 0)), "AudioDevice", opts.dynamic)
+			if err != nil {
+				t.Fatalf("Random failed: %v", err)
+			}
+			got, err := codec.Encode("AudioDevice", dyn)
+			if err != nil {
+				t.Fatalf("dynamic encode failed: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("seed %d: dynamic random value differs from generated:\n got %x\nwant %x", seed, got, want)
+			}
+		}
+	}
+
+	// sdp-encode -random writes JSON/.sdpb pairs of the same sequence
+	encodeBin := buildTool(t, "sdp-encode")
+	dir := t.TempDir()
+	schemaFile := filepath.Join("testdata", "schemas", "optional.sdp")
+	cmd := exec.Command(encodeBin, "-schema", schemaFile, "-type", "Config", "-random", "12", "-seed", "5", "-out", dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("sdp-encode -random failed: %v\n%s", err, out)
+	}
+	r := rand.New(rand.NewPCG(5, 0))
+	for i := 0; i < 12; i++ {
+		base := filepath.Join(dir, fmt.Sprintf("Config-%02d", i))
+		js, err := os.ReadFile(base + ".json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(base + ".sdpb")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var fromJSON optional.Config
+		if err := json.Unmarshal(js, &fromJSON); err != nil {
+			t.Fatalf("%s.json: %v", base, err)
+		}
+		if encoded, _ := optional.EncodeConfig(&fromJSON); !bytes.Equal(encoded, data) {
+			t.Errorf("%s: JSON and .sdpb disagree", base)
+		}
+		if want, _ := optional.EncodeConfig(optional.RandomConfig(r, nil)); !bytes.Equal(data, want) {
+			t.Errorf("%s: differs from RandomConfig with the same seed", base)
+		}
+	}
+}
+
+// TestConformance checks that the generated Go, C++ and Rust code and the
+// dynamic codec agree on random values, with generated Go as the reference.
+// Backends whose toolchain is not installed are skipped.
+func TestConformance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles Go, C++ and Rust drivers")
+	}
+
+	builds := []struct {
+		name  string
+		build func(*schema.Schema, string) (conformance.Backend, error)
+	}{
+		{"go", conformance.BuildGo},
+		{"cpp", conformance.BuildCpp},
+		{"rust", conformance.BuildRust},
+		{"rustexp", conformance.BuildRustExp},
+	}
+	for _, name := range []string{"primitives", "arrays", "nested", "optional", "complex"} {
+		t.Run(name, func(t *testing.T) {
+			s, err := schema.Load(filepath.Join("testdata", "schemas", name+".sdp"))
+			if err != nil {
+				t.Fatalf("Load failed: %v", err)
+			}
+			dyn, err := conformance.NewDynamic(s)
+			if err != nil {
+				t.Fatalf("NewDynamic failed: %v", err)
+			}
+
+			var backends []conformance.Backend
+			for _, b := range builds {
+				backend, err := b.build(s, t.TempDir())
+				if errors.Is(err, conformance.ErrUnavailable) {
+					t.Logf("skipping %s: %v", b.name, err)
+					continue
+				}
+				if err != nil {
+					t.Fatalf("building %s failed: %v", b.name, err)
+				}
+				backends = append(backends, backend)
+			}
+			if len(backends) == 0 {
+				t.Skip("no compiled backend available")
+			}
+			backends = append(backends, dyn)
+
+			mismatches, err := conformance.Run(s, backends, conformance.Options{Count: 100, Seed: 1})
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			for _, m := range mismatches {
+				t.Error(m.Error())
+			}
+		})
+	}
+}
+
+// TestOptionalAccessors checks that getters read through absent optionals
+// and nil receivers, and that the helpers round-trip presence.
+func TestOptionalAccessors(t *testing.T) {
+	var nilCfg *optional.Config
+	if nilCfg.GetDatabase().GetHost() != "" || nilCfg.GetDatabase().GetPort() != 0 || nilCfg.HasDatabase() {
+		t.Error("nil Config should read as zero")
+	}
+
+	cfg := &optional.Config{Name: "prod"}
+	if cfg.HasDatabase() || cfg.GetDatabase() != nil || cfg.GetDatabase().GetPort() != 0 {
+		t.Error("absent database should read as zero")
+	}
+
+	db := optional.DatabaseConfig{Host: "db", Port: 5432}
+	cfg.SetDatabase(db)
+	db.Port = 1
+	if !cfg.HasDatabase() || cfg.GetDatabase().GetHost() != "db" || cfg.GetDatabase().GetPort() != 5432 {
+		t.Errorf("after SetDatabase: %v", cfg)
+	}
+
+	data, err := optional.EncodeConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded optional.Config
+	if err := optional.DecodeConfig(&decoded, data); err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.HasDatabase() || decoded.HasCache() {
+		t.Errorf("decoded presence: database=%v cache=%v", decoded.HasDatabase(), decoded.HasCache())
+	}
+
+	cfg.ClearDatabase()
+	if cfg.HasDatabase() || cfg.Database != nil {
+		t.Error("ClearDatabase should leave database absent")
+	}
+
+	// Required nested structs are returned by pointer to the field
+	scene := &nested.Scene{}
+	scene.GetMainRect().GetTopLeft().X = 3
+	if scene.MainRect.TopLeft.X != 3 {
+		t.Error("GetMainRect should point at the field")
+	}
+	var nilScene *nested.Scene
+	if nilScene.GetMainRect().GetTopLeft().GetX() != 0 || nilScene.GetName() != "" {
+		t.Error("nil Scene should read as zero")
+	}
+}
+
+// TestStringAndLogValue checks the String and LogValue methods: nested and
+// absent optionals, []u8 in hex, truncated arrays and redacted fields.
+func TestStringAndLogValue(t *testing.T) {
+	req := &optional.Request{Id: 7, Metadata: &optional.Metadata{UserId: 42, Username: "alice"}}
+	if got, want := req.String(), `Request{id: 7, metadata: Metadata{user_id: 42, username: [REDACTED]}}`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	cfg := &optional.Config{Name: "prod", Cache: &optional.CacheConfig{SizeMb: 64, TtlSeconds: 30}}
+	if got, want := cfg.String(), `Config{name: "prod", database: nil, cache: CacheConfig{size_mb: 64, ttl_seconds: 30}}`; got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	var nilReq *optional.Request
+	if got := nilReq.String(); got != "<nil>" {
+		t.Errorf("nil String() = %s, want <nil>", got)
+	}
+
+	prims := &arrays.ArraysOfPrimitives{
+		U8Array:   bytes.Repeat([]byte{0xab}, 40),
+		U32Array:  make([]uint32, 20),
+		StrArray:  []string{"a", "b"},
+		BoolArray: []bool{true},
+	}
+	if got, want := prims.String(), `ArraysOfPrimitives{u8_array: 0x`+strings.Repeat("ab", 32)+`... (+8 more), `+
+		`u32_array: [0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, ... (+4 more)], `+
+		`f64_array: [], str_array: ["a", "b"], bool_array: [true]}`; got != want {
+		t.Errorf("String() = %s\nwant %s", got, want)
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	structs := &arrays.ArraysOfStructs{Items: []arrays.Item{{Id: 1, Name: "x"}, {Id: 2, Name: "y"}}, Count: 2}
+	logger.Info("decoded", "req", req, "structs", structs, "prims", &arrays.ArraysOfPrimitives{U8Array: []byte{1, 2}, U32Array: []uint32{3}})
+	want := `{"msg":"decoded",` +
+		`"req":{"id":7,"metadata":{"user_id":42,"username":"[REDACTED]"}},` +
+		`"structs":{"items":{"0":{"id":1,"name":"x"},"1":{"id":2,"name":"y"}},"count":2},` +
+		`"prims":{"u8_array":"0x0102","u32_array":"[3]","f64_array":"[]","str_array":"[]","bool_array":"[]"}}` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("JSON log = %s\nwant %s", got, want)
+	}
+
+	// Groups of long arrays end with the number of elements left out
+	items := make([]arrays.Item, 20)
+	v := (&arrays.ArraysOfStructs{Items: items}).LogValue().Group()[0].Value.Group()
+	if len(v) != 17 || v[16].Key != "more" || v[16].Value.Int64() != 4 {
+		t.Errorf("items group = %v, want 16 elements and more=4", v)
+	}
+}
+
 // TestWireFormatComplex tests a realistic complex structure
 func TestWireFormatComplex(t *testing.T) {
 	// Plugin: {id: u32, name: str, manufacturer: str, version: u32, enabled: bool, parameters: []Parameter}
@@ -1970,76 +3560,7 @@ func BenchmarkAllAtOnceConstruction(b *testing.B) {
 //   - FlatBuffers: 1,000 µs (1.0 ms) roundtrip
 func BenchmarkRealWorldAudioUnit(b *testing.B) {
 	// Load real AudioUnit plugin data from JSON
-	jsonData, err := os.ReadFile("testdata/data/plugins.json")
-	if err != nil {
-		b.Skipf("plugins.json not found, skipping real-world benchmark: %v", err)
-		return
-	}
-
-	// Parse JSON into intermediate structure
-	var pluginsJSON []struct {
-		Name           string `json:"name"`
-		ManufacturerID string `json:"manufacturerID"`
-		Type           string `json:"type"`
-		Subtype        string `json:"subtype"`
-		Parameters     []struct {
-			Address      uint64  `json:"address"`
-			DisplayName  string  `json:"displayName"`
-			Identifier   string  `json:"identifier"`
-			Unit         string  `json:"unit"`
-			MinValue     float32 `json:"minValue"`
-			MaxValue     float32 `json:"maxValue"`
-			DefaultValue float32 `json:"defaultValue"`
-			CurrentValue float32 `json:"currentValue"`
-			RawFlags     uint32  `json:"rawFlags"`
-			IsWritable   bool    `json:"isWritable"`
-			CanRamp      bool    `json:"canRamp"`
-		} `json:"parameters"`
-	}
-
-	if err := json.Unmarshal(jsonData, &pluginsJSON); err != nil {
-		b.Fatalf("Failed to parse plugins.json: %v", err)
-	}
-
-	// Convert to SDP structs (simulating real usage pattern)
-	registry := audiounit.PluginRegistry{
-		Plugins:             make([]audiounit.Plugin, 0, len(pluginsJSON)),
-		TotalPluginCount:    uint32(len(pluginsJSON)),
-		TotalParameterCount: 0,
-	}
-
-	totalParams := uint32(0)
-	for _, pJSON := range pluginsJSON {
-		plugin := audiounit.Plugin{
-			Name:             pJSON.Name,
-			ManufacturerId:   pJSON.ManufacturerID,
-			ComponentType:    pJSON.Type,
-			ComponentSubtype: pJSON.Subtype,
-			Parameters:       make([]audiounit.Parameter, 0, len(pJSON.Parameters)),
-		}
-
-		for _, paramJSON := range pJSON.Parameters {
-			param := audiounit.Parameter{
-				Address:      paramJSON.Address,
-				DisplayName:  paramJSON.DisplayName,
-				Identifier:   paramJSON.Identifier,
-				Unit:         paramJSON.Unit,
-				MinValue:     paramJSON.MinValue,
-				MaxValue:     paramJSON.MaxValue,
-				DefaultValue: paramJSON.DefaultValue,
-				CurrentValue: paramJSON.CurrentValue,
-				RawFlags:     paramJSON.RawFlags,
-				IsWritable:   paramJSON.IsWritable,
-				CanRamp:      paramJSON.CanRamp,
-			}
-			plugin.Parameters = append(plugin.Parameters, param)
-			totalParams++
-		}
-
-		registry.Plugins = append(registry.Plugins, plugin)
-	}
-
-	registry.TotalParameterCount = totalParams
+	registry := loadPluginRegistry(b)
 
 	b.Logf("Loaded real-world data: %d plugins, %d parameters",
 		len(registry.Plugins), registry.TotalParameterCount)
@@ -2067,74 +3588,23 @@ func BenchmarkRealWorldAudioUnit(b *testing.B) {
 	}
 }
 
-// BenchmarkRealWorldAudioUnitEncodeOnly measures just encoding performance
-func BenchmarkRealWorldAudioUnitEncodeOnly(b *testing.B) {
-	jsonData, err := os.ReadFile("testdata/data/plugins.json")
+// loadPluginRegistry reads the real-world AudioUnit data in testdata/data/audiounit.json.
+func loadPluginRegistry(b *testing.B) audiounit.PluginRegistry {
+	b.Helper()
+	jsonData, err := os.ReadFile("testdata/data/audiounit.json")
 	if err != nil {
-		b.Skipf("plugins.json not found: %v", err)
-		return
-	}
-
-	var pluginsJSON []struct {
-		Name           string `json:"name"`
-		ManufacturerID string `json:"manufacturerID"`
-		Type           string `json:"type"`
-		Subtype        string `json:"subtype"`
-		Parameters     []struct {
-			Address      uint64  `json:"address"`
-			DisplayName  string  `json:"displayName"`
-			Identifier   string  `json:"identifier"`
-			Unit         string  `json:"unit"`
-			MinValue     float32 `json:"minValue"`
-			MaxValue     float32 `json:"maxValue"`
-			DefaultValue float32 `json:"defaultValue"`
-			CurrentValue float32 `json:"currentValue"`
-			RawFlags     uint32  `json:"rawFlags"`
-			IsWritable   bool    `json:"isWritable"`
-			CanRamp      bool    `json:"canRamp"`
-		} `json:"parameters"`
+		b.Skipf("audiounit.json not found: %v", err)
 	}
-
-	json.Unmarshal(jsonData, &pluginsJSON)
-
-	registry := audiounit.PluginRegistry{
-		Plugins:             make([]audiounit.Plugin, 0, len(pluginsJSON)),
-		TotalPluginCount:    uint32(len(pluginsJSON)),
-		TotalParameterCount: 0,
-	}
-
-	totalParams := uint32(0)
-	for _, pJSON := range pluginsJSON {
-		plugin := audiounit.Plugin{
-			Name:             pJSON.Name,
-			ManufacturerId:   pJSON.ManufacturerID,
-			ComponentType:    pJSON.Type,
-			ComponentSubtype: pJSON.Subtype,
-			Parameters:       make([]audiounit.Parameter, 0, len(pJSON.Parameters)),
-		}
-
-		for _, paramJSON := range pJSON.Parameters {
-			param := audiounit.Parameter{
-				Address:      paramJSON.Address,
-				DisplayName:  paramJSON.DisplayName,
-				Identifier:   paramJSON.Identifier,
-				Unit:         paramJSON.Unit,
-				MinValue:     paramJSON.MinValue,
-				MaxValue:     paramJSON.MaxValue,
-				DefaultValue: paramJSON.DefaultValue,
-				CurrentValue: paramJSON.CurrentValue,
-				RawFlags:     paramJSON.RawFlags,
-				IsWritable:   paramJSON.IsWritable,
-				CanRamp:      paramJSON.CanRamp,
-			}
-			plugin.Parameters = append(plugin.Parameters, param)
-			totalParams++
-		}
-
-		registry.Plugins = append(registry.Plugins, plugin)
+	var registry audiounit.PluginRegistry
+	if err := json.Unmarshal(jsonData, &registry); err != nil {
+		b.Fatalf("Failed to parse audiounit.json: %v", err)
 	}
+	return registry
+}
 
-	registry.TotalParameterCount = totalParams
+// BenchmarkRealWorldAudioUnitEncodeOnly measures just encoding performance
+func BenchmarkRealWorldAudioUnitEncodeOnly(b *testing.B) {
+	registry := loadPluginRegistry(b)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -2147,72 +3617,7 @@ func BenchmarkRealWorldAudioUnitEncodeOnly(b *testing.B) {
 
 // BenchmarkRealWorldAudioUnitDecodeOnly measures just decoding performance
 func BenchmarkRealWorldAudioUnitDecodeOnly(b *testing.B) {
-	jsonData, err := os.ReadFile("testdata/data/plugins.json")
-	if err != nil {
-		b.Skipf("plugins.json not found: %v", err)
-		return
-	}
-
-	var pluginsJSON []struct {
-		Name           string `json:"name"`
-		ManufacturerID string `json:"manufacturerID"`
-		Type           string `json:"type"`
-		Subtype        string `json:"subtype"`
-		Parameters     []struct {
-			Address      uint64  `json:"address"`
-			DisplayName  string  `json:"displayName"`
-			Identifier   string  `json:"identifier"`
-			Unit         string  `json:"unit"`
-			MinValue     float32 `json:"minValue"`
-			MaxValue     float32 `json:"maxValue"`
-			DefaultValue float32 `json:"defaultValue"`
-			CurrentValue float32 `json:"currentValue"`
-			RawFlags     uint32  `json:"rawFlags"`
-			IsWritable   bool    `json:"isWritable"`
-			CanRamp      bool    `json:"canRamp"`
-		} `json:"parameters"`
-	}
-
-	json.Unmarshal(jsonData, &pluginsJSON)
-
-	registry := audiounit.PluginRegistry{
-		Plugins:             make([]audiounit.Plugin, 0, len(pluginsJSON)),
-		TotalPluginCount:    uint32(len(pluginsJSON)),
-		TotalParameterCount: 0,
-	}
-
-	totalParams := uint32(0)
-	for _, pJSON := range pluginsJSON {
-		plugin := audiounit.Plugin{
-			Name:             pJSON.Name,
-			ManufacturerId:   pJSON.ManufacturerID,
-			ComponentType:    pJSON.Type,
-			ComponentSubtype: pJSON.Subtype,
-			Parameters:       make([]audiounit.Parameter, 0, len(pJSON.Parameters)),
-		}
-
-		for _, paramJSON := range pJSON.Parameters {
-			param := audiounit.Parameter{
-				Address:      paramJSON.Address,
-				DisplayName:  paramJSON.DisplayName,
-				Identifier:   paramJSON.Identifier,
-				Unit:         paramJSON.Unit,
-				MinValue:     paramJSON.MinValue,
-				MaxValue:     paramJSON.MaxValue,
-				DefaultValue: paramJSON.DefaultValue,
-				CurrentValue: paramJSON.CurrentValue,
-				RawFlags:     paramJSON.RawFlags,
-				IsWritable:   paramJSON.IsWritable,
-				CanRamp:      paramJSON.CanRamp,
-			}
-			plugin.Parameters = append(plugin.Parameters, param)
-			totalParams++
-		}
-
-		registry.Plugins = append(registry.Plugins, plugin)
-	}
-
-	registry.TotalParameterCount = totalParams
+	registry := loadPluginRegistry(b)
 
 	// Pre-encode once
 	encoded, _ := audiounit.EncodePluginRegistry(&registry)
@@ -2491,8 +3896,13 @@ func TestOptionalDecodeErrors(t *testing.T) {
 
 	var decoded optional.Request
 	err := optional.DecodeRequest(&decoded, badData)
-	if err == nil {
-		t.Error("decode should fail with invalid presence flag")
+	if !errors.Is(err, optional.ErrInvalidData) {
+		t.Errorf("decode should fail with invalid presence flag, got %v", err)
+	}
+	// The offset is that of the bad flag itself
+	var de *optional.DecodeError
+	if !errors.As(err, &de) || de.Path != "metadata" || de.Offset != 4 {
+		t.Errorf("expected metadata at offset 4, got %v", err)
 	}
 
 	// Test 2: Truncated data (presence flag present but no data after)
@@ -2993,6 +4403,87 @@ func TestMessageModeMultipleTypes(t *testing.T) {
 	}
 }
 
+// TestMessageModeRouter tests typed handler dispatch over a message stream
+func TestMessageModeRouter(t *testing.T) {
+	point := nested.Point{X: 1.5, Y: 2.5}
+	rect := nested.Rectangle{
+		TopLeft:     nested.Point{X: 0, Y: 0},
+		BottomRight: nested.Point{X: 10, Y: 10},
+		Color:       0x00FF00,
+	}
+	scene := nested.Scene{Name: "unhandled", MainRect: rect, Count: 1}
+
+	pointMsg, err := nested.EncodePointMessage(&point)
+	if err != nil {
+		t.Fatalf("EncodePointMessage failed: %v", err)
+	}
+	rectMsg, err := nested.EncodeRectangleMessage(&rect)
+	if err != nil {
+		t.Fatalf("EncodeRectangleMessage failed: %v", err)
+	}
+	sceneMsg, err := nested.EncodeSceneMessage(&scene)
+	if err != nil {
+		t.Fatalf("EncodeSceneMessage failed: %v", err)
+	}
+
+	var stream bytes.Buffer
+	stream.Write(pointMsg)
+	stream.Write(rectMsg)
+	stream.Write(sceneMsg)
+
+	var gotPoint *nested.Point
+	var gotRect *nested.Rectangle
+	var unknownIDs []uint16
+
+	router := nested.NewRouter().
+		OnPoint(func(p *nested.Point) error {
+			gotPoint = p
+			return nil
+		}).
+		OnRectangle(func(r *nested.Rectangle) error {
+			gotRect = r
+			return nil
+		}).
+		OnUnknown(func(typeID uint16, data []byte) error {
+			unknownIDs = append(unknownIDs, typeID)
+			return nil
+		})
+
+	if err := router.Serve(nested.NewMessageReader(&stream)); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	if gotPoint == nil || *gotPoint != point {
+		t.Errorf("Point handler got %+v, want %+v", gotPoint, point)
+	}
+	if gotRect == nil || *gotRect != rect {
+		t.Errorf("Rectangle handler got %+v, want %+v", gotRect, rect)
+	}
+	// Scene has no handler, so it must reach the fallback
+	if len(unknownIDs) != 1 || unknownIDs[0] != 3 {
+		t.Errorf("fallback got type IDs %v, want [3]", unknownIDs)
+	}
+
+	// Without a fallback, unhandled messages are rejected
+	bare := nested.NewRouter()
+	if err := bare.Dispatch(sceneMsg); err != nested.ErrUnknownMessageType {
+		t.Errorf("Dispatch without handler: got %v, want ErrUnknownMessageType", err)
+	}
+
+	// Handler errors propagate out of Serve
+	handlerErr := fmt.Errorf("handler failed")
+	failing := nested.NewRouter().OnPoint(func(p *nested.Point) error { return handlerErr })
+	if err := failing.Serve(nested.NewMessageReader(bytes.NewReader(pointMsg))); err != handlerErr {
+		t.Errorf("Serve: got %v, want handler error", err)
+	}
+
+	// A stream cut inside a message is reported as truncated
+	truncated := bytes.NewReader(rectMsg[:len(rectMsg)-1])
+	if _, err := nested.NewMessageReader(truncated).ReadMessage(); err != nested.ErrUnexpectedEOF {
+		t.Errorf("ReadMessage on truncated stream: got %v, want ErrUnexpectedEOF", err)
+	}
+}
+
 // TestMessageModeHeaderSize verifies all messages have 10-byte headers
 func TestMessageModeHeaderSize(t *testing.T) {
 	schemas := []struct {
@@ -0,0 +1,265 @@
+package conformance
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/shaban/serial-data-protocol/internal/generator/cpp"
+	"github.com/shaban/serial-data-protocol/internal/generator/golang"
+	"github.com/shaban/serial-data-protocol/internal/generator/rust"
+	"github.com/shaban/serial-data-protocol/internal/generator/rustexp"
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/generate"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// ErrUnavailable is returned by the Build functions when the toolchain or
+// the dependencies a backend needs are not installed.
+var ErrUnavailable = errors.New("backend unavailable")
+
+// Backend encodes and decodes values of one schema with one implementation.
+// Results are returned in input order, one per input.
+type Backend interface {
+	// Name identifies the backend in mismatch reports.
+	Name() string
+
+	// Encode encodes values of the named struct given in the canonical JSON
+	// mapping.
+	Encode(structName string, values [][]byte) ([]Result, error)
+
+	// Decode decodes payloads of the named struct and encodes the decoded
+	// values again, so the results can be compared byte for byte.
+	Decode(structName string, payloads [][]byte) ([]Result, error)
+}
+
+// Result is the outcome of encoding or decoding one input.
+type Result struct {
+	Data []byte // Encoded bytes
+	Err  string // Error message if the backend rejected the input
+}
+
+// dynamicBackend runs the dynamic codec in-process.
+type dynamicBackend struct {
+	codec *dynamic.Codec
+}
+
+// NewDynamic returns the in-process backend of the dynamic codec, which
+// needs no toolchain. Generated Go code is exercised by BuildGo.
+func NewDynamic(s *schema.Schema) (Backend, error) {
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return nil, err
+	}
+	return &dynamicBackend{codec: codec}, nil
+}
+
+func (g *dynamicBackend) Name() string { return "dynamic" }
+
+func (g *dynamicBackend) Encode(structName string, values [][]byte) ([]Result, error) {
+	results := make([]Result, len(values))
+	for i, js := range values {
+		dec := json.NewDecoder(bytes.NewReader(js))
+		dec.UseNumber()
+		var obj map[string]any
+		if err := dec.Decode(&obj); err != nil {
+			results[i].Err = err.Error()
+			continue
+		}
+		v, err := g.codec.FromJSON(structName, obj)
+		if err == nil {
+			results[i].Data, err = g.codec.Encode(structName, v)
+		}
+		if err != nil {
+			results[i].Err = err.Error()
+		}
+	}
+	return results, nil
+}
+
+func (g *dynamicBackend) Decode(structName string, payloads [][]byte) ([]Result, error) {
+	results := make([]Result, len(payloads))
+	for i, data := range payloads {
+		v, err := g.codec.DecodeStrict(structName, data)
+		if err == nil {
+			results[i].Data, err = g.codec.Encode(structName, v)
+		}
+		if err != nil {
+			results[i].Err = err.Error()
+		}
+	}
+	return results, nil
+}
+
+// driverBackend runs a compiled driver program once per batch. Drivers read
+// frames (u32 little-endian length, payload) from stdin and write one frame
+// per input to stdout: a status byte (0 ok, 1 error), a u32 length and the
+// encoded bytes or the error message.
+type driverBackend struct {
+	name string
+	path string
+}
+
+func (d *driverBackend) Name() string { return d.name }
+
+func (d *driverBackend) Encode(structName string, values [][]byte) ([]Result, error) {
+	return d.run("encode", structName, values)
+}
+
+func (d *driverBackend) Decode(structName string, payloads [][]byte) ([]Result, error) {
+	return d.run("decode", structName, payloads)
+}
+
+func (d *driverBackend) run(mode, structName string, inputs [][]byte) ([]Result, error) {
+	var stdin bytes.Buffer
+	for _, in := range inputs {
+		stdin.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(in))))
+		stdin.Write(in)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(d.path, mode, structName)
+	cmd.Stdin = &stdin
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s driver: %w\n%s", d.name, err, stderr.Bytes())
+	}
+
+	results := make([]Result, len(inputs))
+	for i := range results {
+		if len(out) < 5 {
+			return nil, fmt.Errorf("%s driver: missing result %d of %d", d.name, i, len(inputs))
+		}
+		n := int(binary.LittleEndian.Uint32(out[1:]))
+		if len(out) < 5+n {
+			return nil, fmt.Errorf("%s driver: truncated result %d", d.name, i)
+		}
+		if out[0] == 0 {
+			results[i].Data = out[5 : 5+n]
+		} else {
+			results[i].Err = string(out[5 : 5+n])
+		}
+		out = out[5+n:]
+	}
+	return results, nil
+}
+
+// BuildGo generates a Go package and its driver for s in dir and builds them
+// with the go command.
+func BuildGo(s *schema.Schema, dir string) (Backend, error) {
+	if _, err := exec.LookPath("go"); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	const module = "sdpdriver"
+	src := filepath.Join(dir, "go")
+	files, err := generate.Go(s, generate.GoOptions{Package: "gen"})
+	if err != nil {
+		return nil, err
+	}
+	if err := files.Write(filepath.Join(src, "gen")); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(src, "go.mod"), []byte("module "+module+"\n\ngo 1.23\n"), 0644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(src, "main.go"), []byte(golang.GenerateDriver(s, module+"/gen")), 0644); err != nil {
+		return nil, err
+	}
+
+	// The driver is its own module; keep an enclosing go.work out of it.
+	bin := filepath.Join(dir, "go-driver")
+	build := exec.Command("go", "build", "-o", bin, ".")
+	build.Dir = src
+	build.Env = append(os.Environ(), "GOWORK=off")
+	if out, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("building Go driver: %w\n%s", err, out)
+	}
+	return &driverBackend{name: "go", path: bin}, nil
+}
+
+// BuildCpp generates C++ code and its driver for s in dir and compiles them
+// with $CXX (default g++).
+func BuildCpp(s *schema.Schema, dir string) (Backend, error) {
+	compiler := os.Getenv("CXX")
+	if compiler == "" {
+		compiler = "g++"
+	}
+	if _, err := exec.LookPath(compiler); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	src := filepath.Join(dir, "cpp")
+	if err := cpp.Generate(s, src, false); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(src, "driver.cpp"), []byte(cpp.GenerateDriver(s)), 0644); err != nil {
+		return nil, err
+	}
+
+	sources, err := filepath.Glob(filepath.Join(src, "*.cpp"))
+	if err != nil {
+		return nil, err
+	}
+	bin := filepath.Join(dir, "cpp-driver")
+	args := append([]string{"-std=c++17", "-O1", "-o", bin}, sources...)
+	if out, err := exec.Command(compiler, args...).CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("compiling C++ driver: %w\n%s", err, out)
+	}
+	return &driverBackend{name: "cpp", path: bin}, nil
+}
+
+// BuildRust generates a Rust crate and its driver for s in dir and builds
+// them with cargo.
+func BuildRust(s *schema.Schema, dir string) (Backend, error) {
+	return buildCargo("rust", s, dir, rust.Generate, rust.GenerateDriver)
+}
+
+// BuildRustExp is BuildRust for the experimental Rust generator.
+func BuildRustExp(s *schema.Schema, dir string) (Backend, error) {
+	return buildCargo("rustexp", s, dir, rustexp.Generate, rustexp.GenerateDriver)
+}
+
+func buildCargo(name string, s *schema.Schema, dir string,
+	generate func(*schema.Schema, string, bool) error,
+	generateDriver func(*schema.Schema, string) (string, string)) (Backend, error) {
+	if _, err := exec.LookPath("cargo"); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	crate := filepath.Join(dir, name)
+	if err := generate(s, crate, false); err != nil {
+		return nil, err
+	}
+	driver := filepath.Join(dir, name+"-driver")
+	if err := os.MkdirAll(filepath.Join(driver, "src"), 0755); err != nil {
+		return nil, err
+	}
+	manifest, main := generateDriver(s, crate)
+	if err := os.WriteFile(filepath.Join(driver, "Cargo.toml"), []byte(manifest), 0644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(driver, "src", "main.rs"), []byte(main), 0644); err != nil {
+		return nil, err
+	}
+
+	// Missing crates are an environment problem, compile errors are not.
+	// Cargo reads its configuration relative to the working directory.
+	fetch := exec.Command("cargo", "fetch")
+	fetch.Dir = driver
+	if out, err := fetch.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%w: cargo fetch: %v\n%s", ErrUnavailable, err, out)
+	}
+	build := exec.Command("cargo", "build", "--quiet")
+	build.Dir = driver
+	if out, err := build.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("building %s driver: %w\n%s", name, err, out)
+	}
+	return &driverBackend{name: name, path: filepath.Join(driver, "target", "debug", "sdp-conformance-driver")}, nil
+}
@@ -0,0 +1,240 @@
+// Package conformance checks that the SDP backends agree on the wire format.
+//
+// Run generates random values for every struct of a schema, encodes them
+// with every backend and checks that all backends produce the bytes of the
+// reference backend (the first one) and decode the reference bytes back to
+// the same value. When a backend disagrees, the failing value is shrunk to
+// a minimal value that still fails before it is reported.
+//
+// Backends other than the dynamic codec are driver programs generated next
+// to the code they exercise and compiled with the local toolchain; see
+// BuildGo, BuildCpp and BuildRust.
+package conformance
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/sdp/dynamic"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// Options configures Run.
+type Options struct {
+	// Count is the number of random values generated per struct.
+	Count int
+
+	// Seed seeds the random values, so failures are reproducible.
+	Seed uint64
+
+	// Random controls the shape of the values; nil selects the defaults of
+	// dynamic.RandomOptions.
+	Random *dynamic.RandomOptions
+}
+
+// Check names the operation a Mismatch was found in.
+type Check string
+
+// Checks performed by Run
+const (
+	CheckEncode Check = "encode" // Encoding a JSON value
+	CheckDecode Check = "decode" // Decoding the reference bytes and encoding again
+)
+
+// Mismatch is a minimized value on which a backend disagrees with the
+// reference backend.
+type Mismatch struct {
+	Struct    string
+	Check     Check
+	Backend   string
+	Reference string // Name of the reference backend
+
+	// Value is the minimized value in the canonical JSON mapping
+	Value []byte
+
+	// Input is the input given to the backends: Value for CheckEncode, the
+	// reference encoding of Value for CheckDecode
+	Input []byte
+
+	Want, Got Result
+}
+
+func (m *Mismatch) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %s differs from %s\n", m.Struct, m.Check, m.Backend, m.Reference)
+	fmt.Fprintf(&b, "  value: %s\n", m.Value)
+	if m.Check == CheckDecode {
+		fmt.Fprintf(&b, "  input: %s\n", hex.EncodeToString(m.Input))
+	}
+	fmt.Fprintf(&b, "  %-*s %s\n", len(m.Reference)+1, m.Reference+":", describe(m.Want))
+	fmt.Fprintf(&b, "  %-*s %s", len(m.Reference)+1, m.Backend+":", describe(m.Got))
+	return b.String()
+}
+
+func describe(r Result) string {
+	if r.Err != "" {
+		return "error: " + r.Err
+	}
+	return hex.EncodeToString(r.Data)
+}
+
+func sameResult(a, b Result) bool {
+	return (a.Err != "") == (b.Err != "") && bytes.Equal(a.Data, b.Data)
+}
+
+// Run checks every struct of s with opts.Count random values. The first
+// backend is the reference the others are compared with. It returns at most
+// one mismatch per struct, check and backend; the error is reserved for
+// backends that fail to run.
+func Run(s *schema.Schema, backends []Backend, opts Options) ([]Mismatch, error) {
+	if len(backends) < 2 {
+		return nil, fmt.Errorf("need a reference and at least one other backend, got %d", len(backends))
+	}
+	codec, err := dynamic.New(s)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &runner{codec: codec, backends: backends, structs: make(map[string]*schema.Struct)}
+	for i := range s.Structs {
+		r.structs[s.Structs[i].Name] = &s.Structs[i]
+	}
+	rng := rand.New(rand.NewPCG(opts.Seed, 0))
+
+	var mismatches []Mismatch
+	for _, st := range s.Structs {
+		values := make([]map[string]any, opts.Count)
+		for i := range values {
+			if values[i], err = codec.Random(rng, st.Name, opts.Random); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, check := range []Check{CheckEncode, CheckDecode} {
+			failing, err := r.failing(st.Name, check, values)
+			if err != nil {
+				return nil, err
+			}
+			for _, f := range failing {
+				m, err := r.minimize(st.Name, check, f.backend, values[f.index])
+				if err != nil {
+					return nil, err
+				}
+				mismatches = append(mismatches, *m)
+			}
+		}
+	}
+	return mismatches, nil
+}
+
+type runner struct {
+	codec    *dynamic.Codec
+	backends []Backend
+	structs  map[string]*schema.Struct
+}
+
+// failure is the first value a backend disagrees on.
+type failure struct {
+	backend Backend
+	index   int
+}
+
+// results runs check on values with backend b and with the reference. It
+// also returns the inputs given to the backends.
+func (r *runner) results(structName string, check Check, b Backend, values []map[string]any) (inputs [][]byte, want, got []Result, err error) {
+	inputs = make([][]byte, len(values))
+	for i, v := range values {
+		if check == CheckEncode {
+			inputs[i], err = r.codec.AppendJSON(nil, structName, v)
+		} else {
+			inputs[i], err = r.codec.Encode(structName, v)
+		}
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	run := func(b Backend) ([]Result, error) {
+		if check == CheckEncode {
+			return b.Encode(structName, inputs)
+		}
+		return b.Decode(structName, inputs)
+	}
+	if want, err = run(r.backends[0]); err != nil {
+		return nil, nil, nil, err
+	}
+	if got, err = run(b); err != nil {
+		return nil, nil, nil, err
+	}
+	if len(want) != len(inputs) || len(got) != len(inputs) {
+		return nil, nil, nil, fmt.Errorf("%s: got %d results for %d inputs", b.Name(), len(got), len(inputs))
+	}
+	return inputs, want, got, nil
+}
+
+// failing returns, for each backend that disagrees with the reference on
+// some value, the index of the first such value.
+func (r *runner) failing(structName string, check Check, values []map[string]any) ([]failure, error) {
+	var failing []failure
+	for _, b := range r.backends[1:] {
+		_, want, got, err := r.results(structName, check, b, values)
+		if err != nil {
+			return nil, err
+		}
+		for i := range values {
+			if !sameResult(want[i], got[i]) {
+				failing = append(failing, failure{b, i})
+				break
+			}
+		}
+	}
+	return failing, nil
+}
+
+// minimize shrinks a value on which backend b fails until no smaller
+// candidate fails any more. Each round checks all candidates in one batch.
+func (r *runner) minimize(structName string, check Check, b Backend, value map[string]any) (*Mismatch, error) {
+	for {
+		candidates := shrinkStruct(r.structs, r.structs[structName], value)
+		if len(candidates) == 0 {
+			break
+		}
+		_, want, got, err := r.results(structName, check, b, candidates)
+		if err != nil {
+			return nil, err
+		}
+		smaller := -1
+		for i := range candidates {
+			if !sameResult(want[i], got[i]) {
+				smaller = i
+				break
+			}
+		}
+		if smaller < 0 {
+			break
+		}
+		value = candidates[smaller]
+	}
+
+	inputs, want, got, err := r.results(structName, check, b, []map[string]any{value})
+	if err != nil {
+		return nil, err
+	}
+	js, err := r.codec.AppendJSON(nil, structName, value)
+	if err != nil {
+		return nil, err
+	}
+	return &Mismatch{
+		Struct:    structName,
+		Check:     check,
+		Backend:   b.Name(),
+		Reference: r.backends[0].Name(),
+		Value:     js,
+		Input:     inputs[0],
+		Want:      want[0],
+		Got:       got[0],
+	}, nil
+}
@@ -0,0 +1,183 @@
+package conformance
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+const testSchema = `
+struct Point { x: f32, y: f32 }
+
+struct Shape {
+    id: u32,
+    name: str,
+    points: []Point,
+    tags: []str,
+    origin: Option<Point>,
+}
+`
+
+// brokenBackend wraps the dynamic backend and corrupts the last byte of every
+// encoding longer than limit.
+type brokenBackend struct {
+	Backend
+	limit int
+}
+
+func (b *brokenBackend) Name() string { return "broken" }
+
+func (b *brokenBackend) Encode(structName string, values [][]byte) ([]Result, error) {
+	return b.corrupt(b.Backend.Encode(structName, values))
+}
+
+func (b *brokenBackend) Decode(structName string, payloads [][]byte) ([]Result, error) {
+	return b.corrupt(b.Backend.Decode(structName, payloads))
+}
+
+func (b *brokenBackend) corrupt(results []Result, err error) ([]Result, error) {
+	for i := range results {
+		if n := len(results[i].Data); n > b.limit {
+			data := append([]byte(nil), results[i].Data...)
+			data[n-1] ^= 0xff
+			results[i].Data = data
+		}
+	}
+	return results, err
+}
+
+func parse(t *testing.T) *schema.Schema {
+	t.Helper()
+	s, err := schema.Parse(testSchema)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return s
+}
+
+func TestRunAgreeingBackends(t *testing.T) {
+	s := parse(t)
+	a, err := NewDynamic(s)
+	if err != nil {
+		t.Fatalf("NewDynamic() error = %v", err)
+	}
+	mismatches, err := Run(s, []Backend{a, a}, Options{Count: 50, Seed: 1})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for _, m := range mismatches {
+		t.Errorf("unexpected mismatch: %v", &m)
+	}
+}
+
+func TestRunMinimizes(t *testing.T) {
+	s := parse(t)
+	ref, err := NewDynamic(s)
+	if err != nil {
+		t.Fatalf("NewDynamic() error = %v", err)
+	}
+	// Point always encodes to 8 bytes, so only Shape can fail
+	broken := &brokenBackend{Backend: ref, limit: 40}
+	mismatches, err := Run(s, []Backend{ref, broken}, Options{Count: 50, Seed: 1})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(mismatches) != 2 {
+		t.Fatalf("got %d mismatches, want 2 (Shape encode and decode)", len(mismatches))
+	}
+	for i, check := range []Check{CheckEncode, CheckDecode} {
+		m := mismatches[i]
+		if m.Struct != "Shape" || m.Check != check || m.Backend != "broken" || m.Reference != "dynamic" {
+			t.Errorf("mismatch %d = %s %s %s/%s, want Shape %s broken/dynamic", i, m.Struct, m.Check, m.Backend, m.Reference, check)
+		}
+		if len(m.Want.Data) != 41 {
+			t.Errorf("%s: minimized encoding has %d bytes, want 41", check, len(m.Want.Data))
+		}
+
+		var v map[string]any
+		if err := json.Unmarshal(m.Value, &v); err != nil {
+			t.Fatalf("%s: Value %s: %v", check, m.Value, err)
+		}
+		if v["id"] != 0.0 {
+			t.Errorf("%s: id = %v, want it shrunk to 0", check, v["id"])
+		}
+		if !strings.Contains(m.Error(), "Shape "+string(check)+": broken differs from dynamic") {
+			t.Errorf("Error() = %q", m.Error())
+		}
+	}
+}
+
+func TestBuildGo(t *testing.T) {
+	if testing.Short() {
+		t.Skip("compiles a Go driver")
+	}
+	s := parse(t)
+	driver, err := BuildGo(s, t.TempDir())
+	if errors.Is(err, ErrUnavailable) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("BuildGo() error = %v", err)
+	}
+	dyn, err := NewDynamic(s)
+	if err != nil {
+		t.Fatalf("NewDynamic() error = %v", err)
+	}
+
+	mismatches, err := Run(s, []Backend{driver, dyn}, Options{Count: 50, Seed: 1})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	for _, m := range mismatches {
+		t.Errorf("unexpected mismatch: %v", &m)
+	}
+
+	// Rejected input is reported per value, not as a failure of the driver
+	results, err := driver.Decode("Point", [][]byte{{1, 2, 3}, make([]byte, 9)})
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(results) != 2 || results[0].Err == "" || results[1].Err == "" {
+		t.Errorf("Decode() = %+v, want errors for truncated and trailing bytes", results)
+	}
+}
+
+func TestRunNeedsTwoBackends(t *testing.T) {
+	s := parse(t)
+	ref, err := NewDynamic(s)
+	if err != nil {
+		t.Fatalf("NewDynamic() error = %v", err)
+	}
+	if _, err := Run(s, []Backend{ref}, Options{Count: 1}); err == nil {
+		t.Error("Run() with one backend succeeded")
+	}
+}
+
+func TestShrinkValue(t *testing.T) {
+	s := parse(t)
+	structs := map[string]*schema.Struct{}
+	for i := range s.Structs {
+		structs[s.Structs[i].Name] = &s.Structs[i]
+	}
+	point := map[string]any{"x": float32(1), "y": float32(0)}
+	value := map[string]any{
+		"id":     uint32(7),
+		"name":   "ab",
+		"points": []any{point},
+		"tags":   []any{},
+		"origin": point,
+	}
+
+	candidates := shrinkStruct(structs, structs["Shape"], value)
+	// id → 0; name → "", "a", "b"; points → [], [shrunk point]; origin → nil, shrunk point
+	if len(candidates) != 8 {
+		t.Fatalf("got %d candidates, want 8: %v", len(candidates), candidates)
+	}
+	if value["id"] != uint32(7) || point["x"] != float32(1) || len(value["points"].([]any)) != 1 {
+		t.Errorf("shrinkStruct modified its input: %v", value)
+	}
+}
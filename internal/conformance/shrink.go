@@ -0,0 +1,84 @@
+package conformance
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// shrinkStruct returns the values one simplification away from v, in field
+// order and most aggressive first: optionals become absent, arrays empty or
+// shorter, strings shorter and other primitives zero. Every candidate is
+// strictly simpler than v, so repeated shrinking terminates.
+func shrinkStruct(structs map[string]*schema.Struct, st *schema.Struct, v map[string]any) []map[string]any {
+	var candidates []map[string]any
+	for _, field := range st.Fields {
+		for _, fv := range shrinkValue(structs, &field.Type, v[field.Name]) {
+			c := maps.Clone(v)
+			c[field.Name] = fv
+			candidates = append(candidates, c)
+		}
+	}
+	return candidates
+}
+
+func shrinkValue(structs map[string]*schema.Struct, t *schema.TypeExpr, v any) []any {
+	switch t.Kind {
+	case schema.TypeKindNamed:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		var candidates []any
+		if t.Optional {
+			candidates = append(candidates, nil)
+		}
+		for _, c := range shrinkStruct(structs, structs[t.Name], obj) {
+			candidates = append(candidates, c)
+		}
+		return candidates
+
+	case schema.TypeKindArray:
+		arr, _ := v.([]any)
+		if len(arr) == 0 {
+			return nil
+		}
+		candidates := []any{[]any{}}
+		if len(arr) > 1 {
+			candidates = append(candidates, arr[:len(arr)/2], arr[len(arr)/2:])
+			for i := range arr {
+				candidates = append(candidates, slices.Delete(slices.Clone(arr), i, i+1))
+			}
+		}
+		for i, elem := range arr {
+			for _, c := range shrinkValue(structs, t.Elem, elem) {
+				smaller := slices.Clone(arr)
+				smaller[i] = c
+				candidates = append(candidates, smaller)
+			}
+		}
+		return candidates
+
+	default:
+		if s, ok := v.(string); ok {
+			runes := []rune(s)
+			if len(runes) == 0 {
+				return nil
+			}
+			if len(runes) == 1 {
+				return []any{""}
+			}
+			return []any{"", string(runes[:len(runes)/2]), string(runes[len(runes)/2:])}
+		}
+		if v == nil {
+			return nil
+		}
+		zero := reflect.Zero(reflect.TypeOf(v)).Interface()
+		if v == zero {
+			return nil
+		}
+		return []any{zero}
+	}
+}
@@ -31,6 +31,7 @@ set(CMAKE_CXX_FLAGS "${CMAKE_CXX_FLAGS} -Wall -Wextra -O3")
 set(SOURCES
     encode.cpp
     decode.cpp
+    json.cpp
 )
 
 # Headers
@@ -38,6 +39,7 @@ set(HEADERS
     types.hpp
     encode.hpp
     decode.hpp
+    json.hpp
     endian.hpp
 )
 
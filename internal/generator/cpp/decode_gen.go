@@ -24,13 +24,47 @@ func GenerateDecodeHeader(schema *parser.Schema, packageName string) string {
 #include <cstdint>
 #include <cstddef>
 #include <stdexcept>
+#include <string>
 
 namespace sdp {
 
-/* Decode error exception */
+/* Decode error exception
+ * Carries the field path (e.g. "plugins[12].parameters[3].unit") and the
+ * byte offset where decoding failed, in addition to the failure reason.
+ */
 class DecodeError : public std::runtime_error {
 public:
-    explicit DecodeError(const char* msg) : std::runtime_error(msg) {}
+    explicit DecodeError(const char* msg) : std::runtime_error(msg), reason_(msg), offset_(0) {}
+
+    DecodeError(const char* reason, const std::string& path, size_t offset)
+        : std::runtime_error(describe(reason, path, offset)),
+          reason_(reason), path_(path), offset_(offset) {}
+
+    /* Failure reason without context, e.g. "Buffer too small" */
+    const char* reason() const noexcept { return reason_; }
+
+    /* Field path where decoding failed */
+    const std::string& path() const noexcept { return path_; }
+
+    /* Byte offset of the failing field */
+    size_t offset() const noexcept { return offset_; }
+
+    /* Returns a copy of this error with field prepended to the path */
+    DecodeError within(const std::string& field) const {
+        if (path_.empty() || path_[0] == '[') {
+            return DecodeError(reason_, field + path_, offset_);
+        }
+        return DecodeError(reason_, field + "." + path_, offset_);
+    }
+
+private:
+    static std::string describe(const char* reason, const std::string& path, size_t offset) {
+        return std::string(reason) + " at " + path + " (offset " + std::to_string(offset) + ")";
+    }
+
+    const char* reason_;
+    std::string path_;
+    size_t offset_;
 };
 
 `, packageName, guard, guard))
@@ -150,6 +184,19 @@ func generateDecodeFunction(structDef parser.Struct) string {
 	return b.String()
 }
 
+// decodeThrow returns the statement that throws a DecodeError for reason,
+// recording the schema field name and the offset where the failing read
+// started.
+func decodeThrow(reason string, field parser.Field, offset string) string {
+	return "throw DecodeError(\"" + reason + "\", \"" + field.Name + "\", " + offset + ");"
+}
+
+// elementThrow is decodeThrow for element i of an array field, whose path
+// carries the index, e.g. "names[3]".
+func elementThrow(reason string, field parser.Field, offset string) string {
+	return "throw DecodeError(\"" + reason + "\", \"" + field.Name + "[\" + std::to_string(i) + \"]\", " + offset + ");"
+}
+
 func generateFieldDecode(field parser.Field) string {
 	var b strings.Builder
 
@@ -173,13 +220,21 @@ func generateFieldDecode(field parser.Field) string {
 		nestedHelper := toSnakeCase(field.Type.Name) + "_decode_impl"
 		if field.Type.Optional {
 			presentVar := toSnakeCase(field.Name) + "_present"
-			b.WriteString("    if (offset >= buf_len) throw DecodeError(\"Buffer too small\");\n")
+			b.WriteString("    if (offset >= buf_len) " + decodeThrow("Buffer too small", field, "offset") + "\n")
 			b.WriteString(fmt.Sprintf("    uint8_t %s = buf[offset++];\n", presentVar))
 			b.WriteString(fmt.Sprintf("    if (%s) {\n", presentVar))
-			b.WriteString(fmt.Sprintf("        %s = %s(buf, buf_len, offset);\n", fieldName, nestedHelper))
+			b.WriteString("        try {\n")
+			b.WriteString(fmt.Sprintf("            %s = %s(buf, buf_len, offset);\n", fieldName, nestedHelper))
+			b.WriteString("        } catch (const DecodeError& e) {\n")
+			b.WriteString(fmt.Sprintf("            throw e.within(\"%s\");\n", field.Name))
+			b.WriteString("        }\n")
 			b.WriteString("    }\n")
 		} else {
-			b.WriteString(fmt.Sprintf("    %s = %s(buf, buf_len, offset);\n", fieldName, nestedHelper))
+			b.WriteString("    try {\n")
+			b.WriteString(fmt.Sprintf("        %s = %s(buf, buf_len, offset);\n", fieldName, nestedHelper))
+			b.WriteString("    } catch (const DecodeError& e) {\n")
+			b.WriteString(fmt.Sprintf("        throw e.within(\"%s\");\n", field.Name))
+			b.WriteString("    }\n")
 		}
 	}
 
@@ -193,21 +248,21 @@ func generateStringDecode(field parser.Field, fieldName string) string {
 
 	if field.Type.Optional {
 		presentVar := toSnakeCase(field.Name) + "_present"
-		b.WriteString("    if (offset >= buf_len) throw DecodeError(\"Buffer too small\");\n")
+		b.WriteString("    if (offset >= buf_len) " + decodeThrow("Buffer too small", field, "offset") + "\n")
 		b.WriteString(fmt.Sprintf("    uint8_t %s = buf[offset++];\n", presentVar))
 		b.WriteString(fmt.Sprintf("    if (%s) {\n", presentVar))
-		b.WriteString("        if (offset + 4 > buf_len) throw DecodeError(\"Buffer too small\");\n")
+		b.WriteString("        if (offset + 4 > buf_len) " + decodeThrow("Buffer too small", field, "offset") + "\n")
 		b.WriteString("        uint32_t len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));\n")
 		b.WriteString("        offset += 4;\n")
-		b.WriteString("        if (offset + len > buf_len) throw DecodeError(\"Buffer too small\");\n")
+		b.WriteString("        if (offset + len > buf_len) " + decodeThrow("Buffer too small", field, "offset") + "\n")
 		b.WriteString(fmt.Sprintf("        %s = std::string(reinterpret_cast<const char*>(buf + offset), len);\n", fieldName))
 		b.WriteString("        offset += len;\n")
 		b.WriteString("    }\n")
 	} else {
-		b.WriteString("    if (offset + 4 > buf_len) throw DecodeError(\"Buffer too small\");\n")
+		b.WriteString("    if (offset + 4 > buf_len) " + decodeThrow("Buffer too small", field, "offset") + "\n")
 		b.WriteString(fmt.Sprintf("    uint32_t %s_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));\n", toSnakeCase(field.Name)))
 		b.WriteString("    offset += 4;\n")
-		b.WriteString(fmt.Sprintf("    if (offset + %s_len > buf_len) throw DecodeError(\"Buffer too small\");\n", toSnakeCase(field.Name)))
+		b.WriteString(fmt.Sprintf("    if (offset + %s_len > buf_len) "+decodeThrow("Buffer too small", field, "offset")+"\n", toSnakeCase(field.Name)))
 		b.WriteString(fmt.Sprintf("    %s = std::string(reinterpret_cast<const char*>(buf + offset), %s_len);\n", fieldName, toSnakeCase(field.Name)))
 		b.WriteString(fmt.Sprintf("    offset += %s_len;\n", toSnakeCase(field.Name)))
 	}
@@ -222,14 +277,14 @@ func generatePrimitiveDecode(field parser.Field, fieldName string) string {
 
 	if field.Type.Optional {
 		presentVar := toSnakeCase(field.Name) + "_present"
-		b.WriteString("    if (offset >= buf_len) throw DecodeError(\"Buffer too small\");\n")
+		b.WriteString("    if (offset >= buf_len) " + decodeThrow("Buffer too small", field, "offset") + "\n")
 		b.WriteString(fmt.Sprintf("    uint8_t %s = buf[offset++];\n", presentVar))
 		b.WriteString(fmt.Sprintf("    if (%s) {\n", presentVar))
-		b.WriteString(fmt.Sprintf("        if (offset + %d > buf_len) throw DecodeError(\"Buffer too small\");\n", primitiveSize))
+		b.WriteString(fmt.Sprintf("        if (offset + %d > buf_len) "+decodeThrow("Buffer too small", field, "offset")+"\n", primitiveSize))
 		b.WriteString(generatePrimitiveDecodeInline(field.Type.Name, fieldName, "        "))
 		b.WriteString("    }\n")
 	} else {
-		b.WriteString(fmt.Sprintf("    if (offset + %d > buf_len) throw DecodeError(\"Buffer too small\");\n", primitiveSize))
+		b.WriteString(fmt.Sprintf("    if (offset + %d > buf_len) "+decodeThrow("Buffer too small", field, "offset")+"\n", primitiveSize))
 		b.WriteString(generatePrimitiveDecodeInline(field.Type.Name, fieldName, "    "))
 	}
 
@@ -270,21 +325,21 @@ func generatePrimitiveDecodeInline(typeName string, fieldName string, indent str
 func generateArrayDecode(field parser.Field, fieldName string) string {
 	var b strings.Builder
 
-	b.WriteString("    if (offset + 4 > buf_len) throw DecodeError(\"Buffer too small\");\n")
+	b.WriteString("    if (offset + 4 > buf_len) " + decodeThrow("Buffer too small", field, "offset") + "\n")
 	b.WriteString(fmt.Sprintf("    uint32_t %s_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));\n", toSnakeCase(field.Name)))
 	b.WriteString("    offset += 4;\n")
-	b.WriteString(fmt.Sprintf("    if (%s_count > MAX_ARRAY_ELEMENTS) throw DecodeError(\"Array too large\");\n", toSnakeCase(field.Name)))
+	b.WriteString(fmt.Sprintf("    if (%s_count > MAX_ARRAY_ELEMENTS) "+decodeThrow("Array too large", field, "offset - 4")+"\n", toSnakeCase(field.Name)))
 	b.WriteString(fmt.Sprintf("    total_elements += %s_count;\n", toSnakeCase(field.Name)))
-	b.WriteString("    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError(\"Total elements too large\");\n")
+	b.WriteString("    if (total_elements > MAX_TOTAL_ELEMENTS) " + decodeThrow("Total elements too large", field, "offset - 4") + "\n")
 	b.WriteString(fmt.Sprintf("    %s.reserve(%s_count);\n", fieldName, toSnakeCase(field.Name)))
 
 	if field.Type.Elem.Name == "str" {
 		// String array
 		b.WriteString(fmt.Sprintf("    for (uint32_t i = 0; i < %s_count; i++) {\n", toSnakeCase(field.Name)))
-		b.WriteString("        if (offset + 4 > buf_len) throw DecodeError(\"Buffer too small\");\n")
+		b.WriteString("        if (offset + 4 > buf_len) " + elementThrow("Buffer too small", field, "offset") + "\n")
 		b.WriteString("        uint32_t len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));\n")
 		b.WriteString("        offset += 4;\n")
-		b.WriteString("        if (offset + len > buf_len) throw DecodeError(\"Buffer too small\");\n")
+		b.WriteString("        if (offset + len > buf_len) " + elementThrow("Buffer too small", field, "offset") + "\n")
 		b.WriteString(fmt.Sprintf("        %s.emplace_back(reinterpret_cast<const char*>(buf + offset), len);\n", fieldName))
 		b.WriteString("        offset += len;\n")
 		b.WriteString("    }\n")
@@ -292,19 +347,23 @@ func generateArrayDecode(field parser.Field, fieldName string) string {
 		// Struct array - use helper that tracks offset
 		nestedHelper := toSnakeCase(field.Type.Elem.Name) + "_decode_impl"
 		b.WriteString(fmt.Sprintf("    for (uint32_t i = 0; i < %s_count; i++) {\n", toSnakeCase(field.Name)))
-		b.WriteString(fmt.Sprintf("        %s.push_back(%s(buf, buf_len, offset));\n", fieldName, nestedHelper))
+		b.WriteString("        try {\n")
+		b.WriteString(fmt.Sprintf("            %s.push_back(%s(buf, buf_len, offset));\n", fieldName, nestedHelper))
+		b.WriteString("        } catch (const DecodeError& e) {\n")
+		b.WriteString(fmt.Sprintf("            throw e.within(\"%s[\" + std::to_string(i) + \"]\");\n", field.Name))
+		b.WriteString("        }\n")
 		b.WriteString("    }\n")
 	} else {
 		// Primitive array
 		elemSize := getPrimitiveSize(field.Type.Elem.Name)
 		if elemSize == 1 && field.Type.Elem.Name != "bool" {
 			// Fast path: single-byte types (but not bool)
-			b.WriteString(fmt.Sprintf("    if (offset + %s_count > buf_len) throw DecodeError(\"Buffer too small\");\n", toSnakeCase(field.Name)))
+			b.WriteString(fmt.Sprintf("    if (offset + %s_count > buf_len) "+decodeThrow("Buffer too small", field, "offset")+"\n", toSnakeCase(field.Name)))
 			b.WriteString(fmt.Sprintf("    %s.assign(buf + offset, buf + offset + %s_count);\n", fieldName, toSnakeCase(field.Name)))
 			b.WriteString(fmt.Sprintf("    offset += %s_count;\n", toSnakeCase(field.Name)))
 		} else {
 			// Element-by-element (for endianness or bool)
-			b.WriteString(fmt.Sprintf("    if (offset + %s_count * %d > buf_len) throw DecodeError(\"Buffer too small\");\n", toSnakeCase(field.Name), elemSize))
+			b.WriteString(fmt.Sprintf("    if (offset + %s_count * %d > buf_len) "+decodeThrow("Buffer too small", field, "offset")+"\n", toSnakeCase(field.Name), elemSize))
 			b.WriteString(fmt.Sprintf("    for (uint32_t i = 0; i < %s_count; i++) {\n", toSnakeCase(field.Name)))
 			elemType := getCppType(field.Type.Elem.Name)
 			b.WriteString(fmt.Sprintf("        %s elem;\n", elemType))
@@ -0,0 +1,96 @@
+package cpp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateDriver generates driver.cpp, a command line program used by the
+// conformance harness to exercise the generated code:
+//
+//	driver encode <Struct>   JSON values in, encoded bytes out
+//	driver decode <Struct>   encoded bytes in, decoded and re-encoded bytes out
+//
+// Inputs are read from stdin as frames (u32 little-endian length, payload).
+// For each input one output frame is written to stdout: a status byte (0 ok,
+// 1 error), a u32 length and the bytes or the error message.
+func GenerateDriver(schema *parser.Schema) string {
+	var b strings.Builder
+
+	b.WriteString(`/* driver.cpp - Conformance test driver
+ * Generated by the SDP conformance harness - DO NOT EDIT
+ */
+
+#include "decode.hpp"
+#include "encode.hpp"
+#include "json.hpp"
+
+#include <cstdint>
+#include <cstdio>
+#include <cstring>
+#include <stdexcept>
+#include <string>
+
+namespace {
+
+bool read_frame(std::string& out) {
+    uint8_t header[4];
+    if (std::fread(header, 1, 4, stdin) != 4) {
+        return false;
+    }
+    uint32_t len = uint32_t(header[0]) | uint32_t(header[1]) << 8 |
+                   uint32_t(header[2]) << 16 | uint32_t(header[3]) << 24;
+    out.resize(len);
+    return len == 0 || std::fread(&out[0], 1, len, stdin) == len;
+}
+
+void write_frame(uint8_t status, const std::string& data) {
+    uint32_t len = uint32_t(data.size());
+    uint8_t header[5] = {status, uint8_t(len), uint8_t(len >> 8), uint8_t(len >> 16), uint8_t(len >> 24)};
+    std::fwrite(header, 1, 5, stdout);
+    std::fwrite(data.data(), 1, data.size(), stdout);
+}
+
+std::string run(bool encode, const std::string& type, const std::string& input) {
+    const uint8_t* data = reinterpret_cast<const uint8_t*>(input.data());
+`)
+
+	for _, s := range schema.Structs {
+		name := toSnakeCase(s.Name)
+		fmt.Fprintf(&b, `    if (type == "%s") {
+        sdp::%s value = encode ? sdp::%s_from_json(input) : sdp::%s_decode(data, input.size());
+        std::string out(sdp::%s_size(value), '\0');
+        out.resize(sdp::%s_encode(value, reinterpret_cast<uint8_t*>(&out[0])));
+        return out;
+    }
+`, s.Name, s.Name, name, name, name, name)
+	}
+
+	b.WriteString(`    throw std::runtime_error("unknown struct " + type);
+}
+
+}  // namespace
+
+int main(int argc, char** argv) {
+    if (argc != 3 || (std::strcmp(argv[1], "encode") != 0 && std::strcmp(argv[1], "decode") != 0)) {
+        std::fprintf(stderr, "usage: %s encode|decode <Struct>\n", argv[0]);
+        return 2;
+    }
+    bool encode = std::strcmp(argv[1], "encode") == 0;
+
+    std::string input;
+    while (read_frame(input)) {
+        try {
+            write_frame(0, run(encode, argv[2], input));
+        } catch (const std::exception& e) {
+            write_frame(1, e.what());
+        }
+    }
+    return 0;
+}
+`)
+
+	return b.String()
+}
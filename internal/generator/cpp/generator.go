@@ -75,6 +75,16 @@ func Generate(schema *parser.Schema, outputDir string, verbose bool) error {
 		return err
 	}
 
+	// Generate json.hpp
+	if err := generateFile(outputDir, "json.hpp", GenerateJSONHeader(schema, packageName), verbose); err != nil {
+		return err
+	}
+
+	// Generate json.cpp
+	if err := generateFile(outputDir, "json.cpp", GenerateJSONImpl(schema, packageName), verbose); err != nil {
+		return err
+	}
+
 	// Generate endian.hpp
 	if err := generateFile(outputDir, "endian.hpp", GenerateEndianHeader(), verbose); err != nil {
 		return err
@@ -0,0 +1,661 @@
+package cpp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateJSONHeader generates json.hpp
+func GenerateJSONHeader(schema *parser.Schema, packageName string) string {
+	var b strings.Builder
+
+	guard := strings.ToUpper(toSnakeCase(packageName)) + "_JSON_HPP"
+
+	b.WriteString(fmt.Sprintf(`/* json.hpp - Canonical JSON mapping for %s
+ * Generated by sdp-gen - DO NOT EDIT
+ *
+ * Keys are schema field names (or their @json overrides) in schema order,
+ * 64-bit integers are decimal strings, byte arrays are base64, empty
+ * arrays are omitted and absent optionals are null.
+ */
+
+#ifndef %s
+#define %s
+
+#include "types.hpp"
+#include <cstddef>
+#include <stdexcept>
+#include <string>
+
+namespace sdp {
+
+/* JSON error exception
+ * Carries the byte offset in the input where reading failed
+ * (0 for write errors).
+ */
+class JsonError : public std::runtime_error {
+public:
+    explicit JsonError(const std::string& msg) : std::runtime_error(msg), offset_(0) {}
+
+    JsonError(const std::string& msg, size_t offset)
+        : std::runtime_error(msg + " (offset " + std::to_string(offset) + ")"), offset_(offset) {}
+
+    /* Byte offset in the input */
+    size_t offset() const noexcept { return offset_; }
+
+private:
+    size_t offset_;
+};
+
+`, packageName, guard, guard))
+
+	for _, structDef := range schema.Structs {
+		snake := toSnakeCase(structDef.Name)
+		structName := toPascalCase(structDef.Name)
+
+		b.WriteString(fmt.Sprintf("/* Write %s as canonical JSON\n", structDef.Name))
+		b.WriteString(" * Throws JsonError for NaN or infinite floats\n")
+		b.WriteString(" */\n")
+		b.WriteString(fmt.Sprintf("std::string %s_to_json(const %s& value);\n\n", snake, structName))
+
+		b.WriteString(fmt.Sprintf("/* Read %s from JSON\n", structDef.Name))
+		b.WriteString(" * Missing fields and nulls keep the default value, unknown keys are ignored.\n")
+		b.WriteString(" * Throws JsonError on malformed input or out-of-range numbers\n")
+		b.WriteString(" */\n")
+		b.WriteString(fmt.Sprintf("%s %s_from_json(const std::string& json);\n\n", structName, snake))
+	}
+
+	b.WriteString(fmt.Sprintf("}  // namespace sdp\n\n#endif  // %s\n", guard))
+
+	return b.String()
+}
+
+// GenerateJSONImpl generates json.cpp
+func GenerateJSONImpl(schema *parser.Schema, packageName string) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf(`/* json.cpp - Canonical JSON mapping for %s
+ * Generated by sdp-gen - DO NOT EDIT
+ */
+
+#include "json.hpp"
+#include <charconv>
+#include <cmath>
+#include <cstdint>
+#include <cstdlib>
+#include <cstring>
+#include <vector>
+
+namespace sdp {
+
+`, packageName))
+
+	b.WriteString(jsonRuntime)
+
+	// Forward declarations for helper functions
+	b.WriteString("/* Forward declarations for internal JSON helpers */\n")
+	for _, structDef := range schema.Structs {
+		snake := toSnakeCase(structDef.Name)
+		structName := toPascalCase(structDef.Name)
+		b.WriteString(fmt.Sprintf("static void %s_write_json(std::string& out, const %s& value);\n", snake, structName))
+		b.WriteString(fmt.Sprintf("static void %s_read_json(JsonReader& in, %s& value);\n", snake, structName))
+	}
+	b.WriteString("\n")
+
+	for _, structDef := range schema.Structs {
+		b.WriteString(generateJSONWrite(structDef))
+		b.WriteString("\n")
+		b.WriteString(generateJSONRead(structDef))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("}  // namespace sdp\n")
+
+	return b.String()
+}
+
+func generateJSONWrite(structDef parser.Struct) string {
+	var b strings.Builder
+
+	snake := toSnakeCase(structDef.Name)
+	structName := toPascalCase(structDef.Name)
+
+	b.WriteString(fmt.Sprintf("static void %s_write_json(std::string& out, const %s& value) {\n", snake, structName))
+	if len(structDef.Fields) == 0 {
+		b.WriteString("    (void)value;\n")
+		b.WriteString("    out += \"{}\";\n")
+	} else {
+		b.WriteString("    bool first = true;\n")
+		b.WriteString("    out += '{';\n")
+		for _, field := range structDef.Fields {
+			fieldName := "value." + toSnakeCase(field.Name)
+			key := field.JSONKey()
+
+			switch {
+			case field.Type.Kind == parser.TypeKindArray:
+				// Empty arrays are omitted
+				b.WriteString(fmt.Sprintf("    if (!%s.empty()) {\n", fieldName))
+				b.WriteString(fmt.Sprintf("        json_write_key(out, first, \"%s\");\n", key))
+				if field.Type.Elem.Kind == parser.TypeKindPrimitive && field.Type.Elem.Name == "u8" {
+					b.WriteString(fmt.Sprintf("        json_write_base64(out, %s);\n", fieldName))
+				} else {
+					b.WriteString("        out += '[';\n")
+					b.WriteString(fmt.Sprintf("        for (size_t i = 0; i < %s.size(); i++) {\n", fieldName))
+					b.WriteString("            if (i > 0) out += ',';\n")
+					b.WriteString("            " + jsonWriteValue(field.Type.Elem, fieldName+"[i]") + "\n")
+					b.WriteString("        }\n")
+					b.WriteString("        out += ']';\n")
+				}
+				b.WriteString("    }\n")
+
+			case field.Type.Optional:
+				b.WriteString(fmt.Sprintf("    json_write_key(out, first, \"%s\");\n", key))
+				b.WriteString(fmt.Sprintf("    if (%s) {\n", fieldName))
+				b.WriteString("        " + jsonWriteValue(&field.Type, "*"+fieldName) + "\n")
+				b.WriteString("    } else {\n")
+				b.WriteString("        out += \"null\";\n")
+				b.WriteString("    }\n")
+
+			default:
+				b.WriteString(fmt.Sprintf("    json_write_key(out, first, \"%s\");\n", key))
+				b.WriteString("    " + jsonWriteValue(&field.Type, fieldName) + "\n")
+			}
+		}
+		b.WriteString("    out += '}';\n")
+	}
+	b.WriteString("}\n\n")
+
+	b.WriteString(fmt.Sprintf("std::string %s_to_json(const %s& value) {\n", snake, structName))
+	b.WriteString("    std::string out;\n")
+	b.WriteString(fmt.Sprintf("    %s_write_json(out, value);\n", snake))
+	b.WriteString("    return out;\n")
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// jsonWriteValue returns the statement that appends a single (non-array)
+// value to out.
+func jsonWriteValue(t *parser.TypeExpr, expr string) string {
+	if t.Kind == parser.TypeKindNamed {
+		return fmt.Sprintf("%s_write_json(out, %s);", toSnakeCase(t.Name), expr)
+	}
+
+	switch t.Name {
+	case "u64", "i64":
+		return fmt.Sprintf("json_write_quoted(out, %s);", expr)
+	case "f32", "f64":
+		return fmt.Sprintf("json_write_float(out, %s);", expr)
+	case "bool":
+		return fmt.Sprintf("out += %s ? \"true\" : \"false\";", expr)
+	case "str":
+		return fmt.Sprintf("json_write_string(out, %s);", expr)
+	default:
+		return fmt.Sprintf("out += std::to_string(%s);", expr)
+	}
+}
+
+func generateJSONRead(structDef parser.Struct) string {
+	var b strings.Builder
+
+	snake := toSnakeCase(structDef.Name)
+	structName := toPascalCase(structDef.Name)
+
+	b.WriteString(fmt.Sprintf("static void %s_read_json(JsonReader& in, %s& value) {\n", snake, structName))
+	if len(structDef.Fields) == 0 {
+		b.WriteString("    (void)value;\n")
+	}
+	b.WriteString("    bool first = true;\n")
+	b.WriteString("    std::string key;\n")
+	b.WriteString("    in.begin_object();\n")
+	b.WriteString("    while (in.next_member(first, key)) {\n")
+	b.WriteString("        if (in.consume_null()) {\n")
+	b.WriteString("            continue;\n")
+	b.WriteString("        }\n")
+	for i, field := range structDef.Fields {
+		fieldName := "value." + toSnakeCase(field.Name)
+
+		if i == 0 {
+			b.WriteString(fmt.Sprintf("        if (key == \"%s\") {\n", field.JSONKey()))
+		} else {
+			b.WriteString(fmt.Sprintf("        } else if (key == \"%s\") {\n", field.JSONKey()))
+		}
+
+		switch {
+		case field.Type.Kind == parser.TypeKindArray:
+			elem := field.Type.Elem
+			if elem.Kind == parser.TypeKindPrimitive && elem.Name == "u8" {
+				b.WriteString(fmt.Sprintf("            %s = in.read_bytes();\n", fieldName))
+				break
+			}
+			b.WriteString(fmt.Sprintf("            %s.clear();\n", fieldName))
+			b.WriteString("            bool first_element = true;\n")
+			b.WriteString("            in.begin_array();\n")
+			b.WriteString("            while (in.next_element(first_element)) {\n")
+			if elem.Kind == parser.TypeKindNamed {
+				b.WriteString(fmt.Sprintf("                %s.emplace_back();\n", fieldName))
+				b.WriteString(fmt.Sprintf("                %s_read_json(in, %s.back());\n", toSnakeCase(elem.Name), fieldName))
+			} else {
+				b.WriteString(fmt.Sprintf("                %s.push_back(%s);\n", fieldName, jsonReadValue(elem)))
+			}
+			b.WriteString("            }\n")
+
+		case field.Type.Kind == parser.TypeKindNamed && field.Type.Optional:
+			b.WriteString(fmt.Sprintf("            %s.emplace();\n", fieldName))
+			b.WriteString(fmt.Sprintf("            %s_read_json(in, *%s);\n", toSnakeCase(field.Type.Name), fieldName))
+
+		case field.Type.Kind == parser.TypeKindNamed:
+			b.WriteString(fmt.Sprintf("            %s_read_json(in, %s);\n", toSnakeCase(field.Type.Name), fieldName))
+
+		default:
+			b.WriteString(fmt.Sprintf("            %s = %s;\n", fieldName, jsonReadValue(&field.Type)))
+		}
+	}
+	if len(structDef.Fields) == 0 {
+		b.WriteString("        in.skip_value();\n")
+	} else {
+		b.WriteString("        } else {\n")
+		b.WriteString("            in.skip_value();\n")
+		b.WriteString("        }\n")
+	}
+	b.WriteString("    }\n")
+	b.WriteString("}\n\n")
+
+	b.WriteString(fmt.Sprintf("%s %s_from_json(const std::string& json) {\n", structName, snake))
+	b.WriteString("    JsonReader in(json);\n")
+	b.WriteString(fmt.Sprintf("    %s value{};\n", structName))
+	b.WriteString(fmt.Sprintf("    %s_read_json(in, value);\n", snake))
+	b.WriteString("    in.finish();\n")
+	b.WriteString("    return value;\n")
+	b.WriteString("}\n")
+
+	return b.String()
+}
+
+// jsonReadValue returns the expression that reads a single primitive value.
+func jsonReadValue(t *parser.TypeExpr) string {
+	switch t.Name {
+	case "f32", "f64":
+		return fmt.Sprintf("in.read_float<%s>()", getCppType(t.Name))
+	case "bool":
+		return "in.read_bool()"
+	case "str":
+		return "in.read_string()"
+	default:
+		return fmt.Sprintf("in.read_int<%s>()", getCppType(t.Name))
+	}
+}
+
+// jsonRuntime holds the writer helpers and the reader shared by all structs.
+// Floats are formatted like encoding/json so every implementation produces
+// the same bytes for the same value.
+const jsonRuntime = `namespace {
+
+/* Writer helpers */
+
+inline void json_write_key(std::string& out, bool& first, const char* key) {
+    if (!first) out += ',';
+    first = false;
+    out += '"';
+    out += key;
+    out += "\":";
+}
+
+inline void json_write_string(std::string& out, const std::string& s) {
+    static const char hex[] = "0123456789abcdef";
+    out += '"';
+    for (size_t i = 0; i < s.size(); i++) {
+        unsigned char c = static_cast<unsigned char>(s[i]);
+        switch (c) {
+        case '"': out += "\\\""; break;
+        case '\\': out += "\\\\"; break;
+        case '\b': out += "\\b"; break;
+        case '\f': out += "\\f"; break;
+        case '\n': out += "\\n"; break;
+        case '\r': out += "\\r"; break;
+        case '\t': out += "\\t"; break;
+        default:
+            if (c < 0x20 || c == '<' || c == '>' || c == '&') {
+                out += "\\u00";
+                out += hex[c >> 4];
+                out += hex[c & 0xF];
+            } else if (c == 0xE2 && i + 2 < s.size() &&
+                       static_cast<unsigned char>(s[i + 1]) == 0x80 &&
+                       (static_cast<unsigned char>(s[i + 2]) & 0xFE) == 0xA8) {
+                /* U+2028 and U+2029 */
+                out += static_cast<unsigned char>(s[i + 2]) == 0xA8 ? "\\u2028" : "\\u2029";
+                i += 2;
+            } else {
+                out += static_cast<char>(c);
+            }
+        }
+    }
+    out += '"';
+}
+
+template <typename T>
+void json_write_quoted(std::string& out, T value) {
+    out += '"';
+    out += std::to_string(value);
+    out += '"';
+}
+
+template <typename T>
+void json_write_float(std::string& out, T value) {
+    if (!std::isfinite(value)) {
+        throw JsonError("NaN and infinite floats have no JSON representation");
+    }
+    /* Shortest round-trip digits, e.g. -1.2345e+08 */
+    char buf[64];
+    auto r = std::to_chars(buf, buf + sizeof(buf), value, std::chars_format::scientific);
+    std::string sci(buf, r.ptr);
+    T abs = std::fabs(value);
+    if (abs != 0 && (abs < static_cast<T>(1e-6) || abs >= static_cast<T>(1e21))) {
+        /* Shorten e-07 to e-7 */
+        size_t n = sci.size();
+        if (n >= 4 && sci[n - 4] == 'e' && sci[n - 3] == '-' && sci[n - 2] == '0') sci.erase(n - 2, 1);
+        out += sci;
+        return;
+    }
+    size_t e = sci.find('e');
+    int exp = std::atoi(sci.c_str() + e + 1);
+    size_t start = 0;
+    if (sci[0] == '-') {
+        out += '-';
+        start = 1;
+    }
+    std::string digits;
+    for (size_t i = start; i < e; i++) {
+        if (sci[i] != '.') digits += sci[i];
+    }
+    if (exp < 0) {
+        out += "0.";
+        out.append(static_cast<size_t>(-exp - 1), '0');
+        out += digits;
+    } else if (static_cast<size_t>(exp) + 1 >= digits.size()) {
+        out += digits;
+        out.append(static_cast<size_t>(exp) + 1 - digits.size(), '0');
+    } else {
+        out.append(digits, 0, static_cast<size_t>(exp) + 1);
+        out += '.';
+        out.append(digits, static_cast<size_t>(exp) + 1, std::string::npos);
+    }
+}
+
+inline void json_write_base64(std::string& out, const std::vector<uint8_t>& data) {
+    static const char alphabet[] = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/";
+    out += '"';
+    for (size_t i = 0; i < data.size(); i += 3) {
+        size_t left = data.size() - i;
+        uint32_t n = static_cast<uint32_t>(data[i]) << 16;
+        if (left > 1) n |= static_cast<uint32_t>(data[i + 1]) << 8;
+        if (left > 2) n |= data[i + 2];
+        out += alphabet[(n >> 18) & 63];
+        out += alphabet[(n >> 12) & 63];
+        out += left > 1 ? alphabet[(n >> 6) & 63] : '=';
+        out += left > 2 ? alphabet[n & 63] : '=';
+    }
+    out += '"';
+}
+
+/* Reader over a complete JSON document */
+class JsonReader {
+public:
+    explicit JsonReader(const std::string& s) : begin_(s.data()), p_(s.data()), end_(s.data() + s.size()) {}
+
+    [[noreturn]] void fail(const std::string& msg) const {
+        throw JsonError(msg, static_cast<size_t>(p_ - begin_));
+    }
+
+    void skip_ws() {
+        while (p_ < end_ && (*p_ == ' ' || *p_ == '\t' || *p_ == '\n' || *p_ == '\r')) p_++;
+    }
+
+    void expect(char c) {
+        skip_ws();
+        if (p_ >= end_ || *p_ != c) fail(std::string("expected '") + c + "'");
+        p_++;
+    }
+
+    void finish() {
+        skip_ws();
+        if (p_ != end_) fail("unexpected data after value");
+    }
+
+    bool consume_null() {
+        skip_ws();
+        if (end_ - p_ >= 4 && std::memcmp(p_, "null", 4) == 0) {
+            p_ += 4;
+            return true;
+        }
+        return false;
+    }
+
+    void begin_object() { expect('{'); }
+
+    /* Reads the next key of an object, or returns false at its end */
+    bool next_member(bool& first, std::string& key) {
+        skip_ws();
+        if (p_ < end_ && *p_ == '}') {
+            p_++;
+            return false;
+        }
+        if (!first) expect(',');
+        first = false;
+        key = read_string();
+        expect(':');
+        return true;
+    }
+
+    void begin_array() { expect('['); }
+
+    /* Moves to the next array element, or returns false at the end */
+    bool next_element(bool& first) {
+        skip_ws();
+        if (p_ < end_ && *p_ == ']') {
+            p_++;
+            return false;
+        }
+        if (!first) expect(',');
+        first = false;
+        return true;
+    }
+
+    bool read_bool() {
+        skip_ws();
+        if (end_ - p_ >= 4 && std::memcmp(p_, "true", 4) == 0) {
+            p_ += 4;
+            return true;
+        }
+        if (end_ - p_ >= 5 && std::memcmp(p_, "false", 5) == 0) {
+            p_ += 5;
+            return false;
+        }
+        fail("expected boolean");
+    }
+
+    /* Integers are accepted as numbers or decimal strings */
+    template <typename T>
+    T read_int() {
+        skip_ws();
+        bool quoted = p_ < end_ && *p_ == '"';
+        if (quoted) p_++;
+        T value{};
+        auto r = std::from_chars(p_, end_, value);
+        if (r.ec == std::errc::result_out_of_range) fail("integer out of range");
+        if (r.ec != std::errc()) fail("expected integer");
+        p_ = r.ptr;
+        if (quoted) {
+            if (p_ >= end_ || *p_ != '"') fail("expected integer");
+            p_++;
+        } else if (p_ < end_ && (*p_ == '.' || *p_ == 'e' || *p_ == 'E')) {
+            fail("expected integer");
+        }
+        return value;
+    }
+
+    template <typename T>
+    T read_float() {
+        skip_ws();
+        if (p_ >= end_ || !(*p_ == '-' || (*p_ >= '0' && *p_ <= '9'))) fail("expected number");
+        T value{};
+        auto r = std::from_chars(p_, end_, value);
+        if (r.ec == std::errc::result_out_of_range) fail("number out of range");
+        if (r.ec != std::errc()) fail("expected number");
+        p_ = r.ptr;
+        return value;
+    }
+
+    std::string read_string() {
+        skip_ws();
+        if (p_ >= end_ || *p_ != '"') fail("expected string");
+        p_++;
+        std::string s;
+        while (true) {
+            if (p_ >= end_) fail("unterminated string");
+            unsigned char c = static_cast<unsigned char>(*p_++);
+            if (c == '"') return s;
+            if (c < 0x20) fail("control character in string");
+            if (c != '\\') {
+                s += static_cast<char>(c);
+                continue;
+            }
+            if (p_ >= end_) fail("unterminated string");
+            switch (*p_++) {
+            case '"': s += '"'; break;
+            case '\\': s += '\\'; break;
+            case '/': s += '/'; break;
+            case 'b': s += '\b'; break;
+            case 'f': s += '\f'; break;
+            case 'n': s += '\n'; break;
+            case 'r': s += '\r'; break;
+            case 't': s += '\t'; break;
+            case 'u': append_utf8(s, read_code_point()); break;
+            default: fail("invalid escape");
+            }
+        }
+    }
+
+    /* Byte arrays are accepted as base64 strings or arrays of numbers */
+    std::vector<uint8_t> read_bytes() {
+        skip_ws();
+        std::vector<uint8_t> out;
+        if (p_ < end_ && *p_ == '[') {
+            bool first = true;
+            begin_array();
+            while (next_element(first)) out.push_back(read_int<uint8_t>());
+            return out;
+        }
+        std::string s = read_string();
+        if (s.size() % 4 != 0) fail("invalid base64");
+        for (size_t i = 0; i < s.size(); i += 4) {
+            uint32_t n = 0;
+            int pad = 0;
+            for (size_t j = 0; j < 4; j++) {
+                char c = s[i + j];
+                int v;
+                if (c >= 'A' && c <= 'Z') v = c - 'A';
+                else if (c >= 'a' && c <= 'z') v = c - 'a' + 26;
+                else if (c >= '0' && c <= '9') v = c - '0' + 52;
+                else if (c == '+') v = 62;
+                else if (c == '/') v = 63;
+                else if (c == '=' && j >= 2 && i + 4 == s.size()) { v = 0; pad++; }
+                else fail("invalid base64");
+                if (pad > 0 && c != '=') fail("invalid base64");
+                n = (n << 6) | static_cast<uint32_t>(v);
+            }
+            out.push_back(static_cast<uint8_t>(n >> 16));
+            if (pad < 2) out.push_back(static_cast<uint8_t>(n >> 8));
+            if (pad < 1) out.push_back(static_cast<uint8_t>(n));
+        }
+        return out;
+    }
+
+    void skip_value() {
+        skip_ws();
+        if (p_ >= end_) fail("unexpected end of input");
+        switch (*p_) {
+        case '"':
+            read_string();
+            break;
+        case '{': {
+            bool first = true;
+            std::string key;
+            begin_object();
+            while (next_member(first, key)) skip_value();
+            break;
+        }
+        case '[': {
+            bool first = true;
+            begin_array();
+            while (next_element(first)) skip_value();
+            break;
+        }
+        case 't':
+        case 'f':
+            read_bool();
+            break;
+        case 'n':
+            if (!consume_null()) fail("invalid literal");
+            break;
+        default:
+            read_float<double>();
+        }
+    }
+
+private:
+    uint32_t read_hex4() {
+        if (end_ - p_ < 4) fail("invalid unicode escape");
+        uint32_t v = 0;
+        for (int i = 0; i < 4; i++) {
+            char c = *p_++;
+            v <<= 4;
+            if (c >= '0' && c <= '9') v |= static_cast<uint32_t>(c - '0');
+            else if (c >= 'a' && c <= 'f') v |= static_cast<uint32_t>(c - 'a' + 10);
+            else if (c >= 'A' && c <= 'F') v |= static_cast<uint32_t>(c - 'A' + 10);
+            else fail("invalid unicode escape");
+        }
+        return v;
+    }
+
+    uint32_t read_code_point() {
+        uint32_t cp = read_hex4();
+        if (cp >= 0xD800 && cp < 0xDC00 && end_ - p_ >= 6 && p_[0] == '\\' && p_[1] == 'u') {
+            const char* save = p_;
+            p_ += 2;
+            uint32_t lo = read_hex4();
+            if (lo >= 0xDC00 && lo < 0xE000) return 0x10000 + ((cp - 0xD800) << 10) + (lo - 0xDC00);
+            p_ = save;
+        }
+        if (cp >= 0xD800 && cp < 0xE000) return 0xFFFD;
+        return cp;
+    }
+
+    static void append_utf8(std::string& s, uint32_t cp) {
+        if (cp < 0x80) {
+            s += static_cast<char>(cp);
+        } else if (cp < 0x800) {
+            s += static_cast<char>(0xC0 | (cp >> 6));
+            s += static_cast<char>(0x80 | (cp & 0x3F));
+        } else if (cp < 0x10000) {
+            s += static_cast<char>(0xE0 | (cp >> 12));
+            s += static_cast<char>(0x80 | ((cp >> 6) & 0x3F));
+            s += static_cast<char>(0x80 | (cp & 0x3F));
+        } else {
+            s += static_cast<char>(0xF0 | (cp >> 18));
+            s += static_cast<char>(0x80 | ((cp >> 12) & 0x3F));
+            s += static_cast<char>(0x80 | ((cp >> 6) & 0x3F));
+            s += static_cast<char>(0x80 | (cp & 0x3F));
+        }
+    }
+
+    const char* begin_;
+    const char* p_;
+    const char* end_;
+};
+
+}  // namespace
+
+`
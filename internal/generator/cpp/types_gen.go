@@ -32,9 +32,24 @@ func GenerateTypes(schema *parser.Schema, packageName string) string {
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 `, packageName, guard, guard))
 
 	// Generate struct definitions in dependency order
@@ -63,6 +78,9 @@ func generateStruct(structDef parser.Struct) string {
 		b.WriteString(generateField(field))
 	}
 
+	b.WriteString(generateOptionalAccessors(structDef))
+	b.WriteString(generateEquality(structDef))
+
 	b.WriteString("};\n")
 
 	return b.String()
@@ -122,6 +140,73 @@ func generateField(field parser.Field) string {
 	return b.String()
 }
 
+// generateOptionalAccessors generates get_/has_/set_/clear_ member functions
+// for the optional struct fields. get_ returns a default-constructed value
+// when the field is absent, so nested reads need no checks.
+func generateOptionalAccessors(structDef parser.Struct) string {
+	var b strings.Builder
+
+	for _, field := range structDef.Fields {
+		if !field.Type.Optional || field.Type.Kind != parser.TypeKindNamed {
+			continue
+		}
+		fieldName := toSnakeCase(field.Name)
+		typeName := toPascalCase(field.Type.Name)
+
+		b.WriteString(fmt.Sprintf("\n    const %s& get_%s() const {\n", typeName, fieldName))
+		b.WriteString(fmt.Sprintf("        static const %s empty{};\n", typeName))
+		b.WriteString(fmt.Sprintf("        return %s ? *%s : empty;\n", fieldName, fieldName))
+		b.WriteString("    }\n")
+		b.WriteString(fmt.Sprintf("    bool has_%s() const { return %s.has_value(); }\n", fieldName, fieldName))
+		b.WriteString(fmt.Sprintf("    void set_%s(%s value) { %s = std::move(value); }\n", fieldName, typeName, fieldName))
+		b.WriteString(fmt.Sprintf("    void clear_%s() { %s.reset(); }\n", fieldName, fieldName))
+	}
+
+	return b.String()
+}
+
+// generateEquality generates member-wise operator== and operator!=.
+// Floats compare with IEEE semantics, so a NaN field makes values unequal.
+func generateEquality(structDef parser.Struct) string {
+	var b strings.Builder
+
+	structName := toPascalCase(structDef.Name)
+
+	b.WriteString(fmt.Sprintf("\n    bool operator==(const %s& other) const {\n", structName))
+	if len(structDef.Fields) == 0 {
+		b.WriteString("        (void)other;\n")
+		b.WriteString("        return true;\n")
+	} else {
+		for i, field := range structDef.Fields {
+			fieldName := toSnakeCase(field.Name)
+			if i == 0 {
+				b.WriteString("        return ")
+			} else {
+				b.WriteString(" &&\n               ")
+			}
+			if isFloatField(field.Type) {
+				b.WriteString(fmt.Sprintf("bits_equal(%s, other.%s)", fieldName, fieldName))
+			} else {
+				b.WriteString(fmt.Sprintf("%s == other.%s", fieldName, fieldName))
+			}
+		}
+		b.WriteString(";\n")
+	}
+	b.WriteString("    }\n")
+	b.WriteString(fmt.Sprintf("    bool operator!=(const %s& other) const { return !(*this == other); }\n", structName))
+
+	return b.String()
+}
+
+// isFloatField reports whether a field holds f32/f64 values, which
+// operator== compares by bit pattern.
+func isFloatField(t parser.TypeExpr) bool {
+	if t.Kind == parser.TypeKindArray && t.Elem != nil {
+		t = *t.Elem
+	}
+	return t.Kind == parser.TypeKindPrimitive && (t.Name == "f32" || t.Name == "f64")
+}
+
 func getArrayElementType(elemType *parser.TypeExpr) string {
 	switch elemType.Kind {
 	case parser.TypeKindPrimitive:
@@ -0,0 +1,110 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateAccessors generates nil-safe field accessors.
+//
+// For each field, it generates:
+//   - (x *StructName) GetFieldName() T
+//
+// And for each optional field:
+//   - (x *StructName) HasFieldName() bool
+//   - (x *StructName) SetFieldName(v T)
+//   - (x *StructName) ClearFieldName()
+//
+// Getters return the zero value on a nil receiver, so chains such as
+// cfg.GetDatabase().GetHost() need no nil checks. Struct-valued fields are
+// returned by pointer: the field itself for required structs, nil for absent
+// optionals. SetFieldName stores a copy of v.
+func GenerateAccessors(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+
+	for i, s := range schema.Structs {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if err := generateAccessors(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// generateAccessors generates the accessors of a struct's fields.
+func generateAccessors(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+
+	for i, field := range s.Fields {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		fieldName := ToGoName(field.Name)
+
+		goType, err := mapFieldType(&field.Type)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		value, zero := "x."+fieldName, "nil"
+		switch {
+		case field.Type.Kind == parser.TypeKindNamed && !field.Type.Optional:
+			goType, value = "*"+goType, "&x."+fieldName
+		case field.Type.Kind == parser.TypeKindPrimitive:
+			zero = primitiveZero(field.Type.Name)
+		}
+
+		buf.WriteString("// Get" + fieldName + " returns " + field.Name + ", or the zero value if x is nil.\n")
+		buf.WriteString("func (x *" + structName + ") Get" + fieldName + "() " + goType + " {\n")
+		buf.WriteString("\tif x != nil {\n")
+		buf.WriteString("\t\treturn " + value + "\n")
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn " + zero + "\n")
+		buf.WriteString("}\n")
+
+		if !field.Type.Optional {
+			continue
+		}
+		elemType := strings.TrimPrefix(goType, "*")
+
+		buf.WriteString("\n// Has" + fieldName + " reports whether " + field.Name + " is present.\n")
+		buf.WriteString("func (x *" + structName + ") Has" + fieldName + "() bool {\n")
+		buf.WriteString("\treturn x != nil && x." + fieldName + " != nil\n")
+		buf.WriteString("}\n\n")
+
+		buf.WriteString("// Set" + fieldName + " sets " + field.Name + " to a copy of v.\n")
+		buf.WriteString("func (x *" + structName + ") Set" + fieldName + "(v " + elemType + ") {\n")
+		buf.WriteString("\tx." + fieldName + " = &v\n")
+		buf.WriteString("}\n\n")
+
+		buf.WriteString("// Clear" + fieldName + " marks " + field.Name + " absent.\n")
+		buf.WriteString("func (x *" + structName + ") Clear" + fieldName + "() {\n")
+		buf.WriteString("\tx." + fieldName + " = nil\n")
+		buf.WriteString("}\n")
+	}
+	return nil
+}
+
+// primitiveZero returns the zero value literal of a primitive type.
+func primitiveZero(name string) string {
+	switch name {
+	case "str":
+		return `""`
+	case "bool":
+		return "false"
+	default:
+		return "0"
+	}
+}
@@ -0,0 +1,105 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateAccessors(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Config",
+				Fields: []parser.Field{
+					{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+					{Name: "port", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u16"}},
+					{Name: "enabled", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "bool"}},
+					{Name: "tags", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}}},
+					{Name: "database", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Database", Optional: true}},
+					{Name: "main_db", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Database"}},
+				},
+			},
+			{
+				Name: "Database",
+				Fields: []parser.Field{
+					{Name: "host", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name:   "getters",
+			schema: schema,
+			checkFunc: func(t *testing.T, code string) {
+				for _, want := range []string{
+					"func (x *Config) GetName() string {\n\tif x != nil {\n\t\treturn x.Name\n\t}\n\treturn \"\"\n}",
+					"func (x *Config) GetPort() uint16 {\n\tif x != nil {\n\t\treturn x.Port\n\t}\n\treturn 0\n}",
+					"func (x *Config) GetEnabled() bool {\n\tif x != nil {\n\t\treturn x.Enabled\n\t}\n\treturn false\n}",
+					"func (x *Config) GetTags() []string {\n\tif x != nil {\n\t\treturn x.Tags\n\t}\n\treturn nil\n}",
+					"func (x *Config) GetDatabase() *Database {\n\tif x != nil {\n\t\treturn x.Database\n\t}\n\treturn nil\n}",
+					"func (x *Database) GetHost() string {",
+				} {
+					if !strings.Contains(code, want) {
+						t.Errorf("missing %q", want)
+					}
+				}
+
+				// Required structs are returned by pointer so chains work
+				if !strings.Contains(code, "func (x *Config) GetMainDb() *Database {\n\tif x != nil {\n\t\treturn &x.MainDb\n\t}\n\treturn nil\n}") {
+					t.Errorf("required struct getter should return a pointer to the field")
+				}
+			},
+		},
+		{
+			name:   "optional helpers",
+			schema: schema,
+			checkFunc: func(t *testing.T, code string) {
+				for _, want := range []string{
+					"func (x *Config) HasDatabase() bool {\n\treturn x != nil && x.Database != nil\n}",
+					"func (x *Config) SetDatabase(v Database) {\n\tx.Database = &v\n}",
+					"func (x *Config) ClearDatabase() {\n\tx.Database = nil\n}",
+				} {
+					if !strings.Contains(code, want) {
+						t.Errorf("missing %q", want)
+					}
+				}
+				for _, unwanted := range []string{"HasName", "SetMainDb", "ClearTags"} {
+					if strings.Contains(code, unwanted) {
+						t.Errorf("%s generated for a required field", unwanted)
+					}
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateAccessors(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateAccessors() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateArena generates the arena decode mode.
+//
+// For each struct type, it generates:
+//   - DecodeStructNameArena(dest *StructName, data []byte) error
+//   - measureStructName(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error
+//
+// And once per schema, the decodeArena type referenced by DecodeContext and
+// its allocation helpers.
+//
+// Arena decoding makes two passes. The first walks the data with the same
+// bounds and limit checks as the decoder and adds up string bytes, primitive
+// slice bytes and element counts per struct type. The arena then allocates
+// one block for all strings and primitive slices, plus one slice per struct
+// type (and one for []str elements), and the regular decoder carves every
+// value out of them.
+func GenerateArena(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	// Struct types that need a pool: array elements and optional values
+	pooled := make(map[string]bool)
+	for _, s := range schema.Structs {
+		for _, field := range s.Fields {
+			t := field.Type
+			if t.Kind == parser.TypeKindArray && t.Elem != nil && t.Elem.Kind == parser.TypeKindNamed {
+				pooled[t.Elem.Name] = true
+			} else if t.Kind == parser.TypeKindNamed && t.Optional {
+				pooled[t.Name] = true
+			}
+		}
+	}
+	var pools []string
+	for _, s := range schema.Structs {
+		if pooled[s.Name] {
+			pools = append(pools, ToGoName(s.Name))
+		}
+	}
+
+	var buf strings.Builder
+
+	for _, s := range schema.Structs {
+		generateArenaEntry(&buf, ToGoName(s.Name))
+		buf.WriteString("\n")
+	}
+
+	generateArenaRuntime(&buf, pools)
+
+	for _, s := range schema.Structs {
+		buf.WriteString("\n")
+		if err := generateSkipHelper(&buf, &s, true); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// arenaSliceExpr returns the expression that carves a slice of count
+// elements from ctx.arena, used by the decoder in arena mode.
+func arenaSliceExpr(elemType *parser.TypeExpr, goType, count string) string {
+	switch {
+	case elemType.Kind == parser.TypeKindNamed:
+		return "arenaTake(&ctx.arena." + goType + ", " + count + ")"
+	case elemType.Kind == parser.TypeKindPrimitive && elemType.Name == "str":
+		return "arenaTake(&ctx.arena.strs, " + count + ")"
+	default:
+		return "arenaPrimitives[" + goType + "](ctx.arena, " + count + ")"
+	}
+}
+
+// generateArenaEntry generates the exported DecodeXArena entry point.
+func generateArenaEntry(buf *strings.Builder, structName string) {
+	funcName := "Decode" + structName + "Arena"
+
+	buf.WriteString("// ")
+	buf.WriteString(funcName)
+	buf.WriteString(" decodes a ")
+	buf.WriteString(structName)
+	buf.WriteString(" like Decode")
+	buf.WriteString(structName)
+	buf.WriteString(", but carves all strings and\n")
+	buf.WriteString("// slices out of a few up-front allocations sized by a first pass over data.\n")
+	buf.WriteString("//\n")
+	buf.WriteString("// The decoded value never aliases data. Strings and primitive slices share\n")
+	buf.WriteString("// one block of memory, so retaining any one of them keeps the whole block\n")
+	buf.WriteString("// alive; copy long-lived pieces out of large values. Slices have no spare\n")
+	buf.WriteString("// capacity, so appending to them reallocates.\n")
+	buf.WriteString("func ")
+	buf.WriteString(funcName)
+	buf.WriteString("(dest *")
+	buf.WriteString(structName)
+	buf.WriteString(", data []byte) error {\n")
+	buf.WriteString("\tif len(data) > MaxSerializedSize {\n")
+	buf.WriteString("\t\treturn ErrDataTooLarge\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tvar size decodeArenaSize\n")
+	buf.WriteString("\toffset := 0\n")
+	buf.WriteString("\tif err := measure")
+	buf.WriteString(structName)
+	buf.WriteString("(data, &offset, &DecodeContext{}, &size); err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tvar arena decodeArena\n")
+	buf.WriteString("\tarena.init(&size)\n")
+	buf.WriteString("\tctx := &DecodeContext{arena: &arena}\n")
+	buf.WriteString("\toffset = 0\n")
+	buf.WriteString("\treturn decode")
+	buf.WriteString(structName)
+	buf.WriteString("(dest, data, &offset, ctx)\n")
+	buf.WriteString("}\n")
+}
+
+// generateArenaRuntime generates decodeArena, decodeArenaSize and the
+// helpers the decoder uses to allocate from them.
+func generateArenaRuntime(buf *strings.Builder, pools []string) {
+	buf.WriteString("// decodeArenaSize accumulates the memory a value needs, measured by the\n")
+	buf.WriteString("// first pass of arena decoding.\n")
+	buf.WriteString("type decodeArenaSize struct {\n")
+	buf.WriteString("\tbytes   int  // Primitive slices, each rounded up to 8 bytes\n")
+	buf.WriteString("\tstrings int  // String contents\n")
+	buf.WriteString("\tstrs    int  // Elements of []str arrays\n")
+	for _, name := range pools {
+		buf.WriteString("\t")
+		buf.WriteString(name)
+		buf.WriteString(" int\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// decodeArena holds the preallocated memory that arena decoding carves\n")
+	buf.WriteString("// values from. buf holds primitive slices followed by string contents.\n")
+	buf.WriteString("type decodeArena struct {\n")
+	buf.WriteString("\tbuf    []byte\n")
+	buf.WriteString("\tpos    int  // Next free byte for primitive slices\n")
+	buf.WriteString("\tstrPos int  // Next free byte for string contents\n")
+	buf.WriteString("\tstrs   []string\n")
+	for _, name := range pools {
+		buf.WriteString("\t")
+		buf.WriteString(name)
+		buf.WriteString(" []")
+		buf.WriteString(name)
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// init allocates the arena memory for the measured size. The byte block is\n")
+	buf.WriteString("// backed by []uint64 so that every primitive slice is 8-byte aligned.\n")
+	buf.WriteString("func (a *decodeArena) init(size *decodeArenaSize) {\n")
+	buf.WriteString("\tif n := size.bytes + size.strings; n > 0 {\n")
+	buf.WriteString("\t\twords := make([]uint64, (n+7)/8)\n")
+	buf.WriteString("\t\ta.buf = unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), n)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\ta.strPos = size.bytes\n")
+	buf.WriteString("\tif size.strs > 0 {\n")
+	buf.WriteString("\t\ta.strs = make([]string, size.strs)\n")
+	buf.WriteString("\t}\n")
+	for _, name := range pools {
+		buf.WriteString("\tif size.")
+		buf.WriteString(name)
+		buf.WriteString(" > 0 {\n")
+		buf.WriteString("\t\ta.")
+		buf.WriteString(name)
+		buf.WriteString(" = make([]")
+		buf.WriteString(name)
+		buf.WriteString(", size.")
+		buf.WriteString(name)
+		buf.WriteString(")\n")
+		buf.WriteString("\t}\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// string copies b into the arena and returns it as a string.\n")
+	buf.WriteString("func (a *decodeArena) string(b []byte) string {\n")
+	buf.WriteString("\tif len(b) == 0 {\n")
+	buf.WriteString("\t\treturn \"\"\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tp := a.strPos\n")
+	buf.WriteString("\ta.strPos += copy(a.buf[p:], b)\n")
+	buf.WriteString("\treturn unsafe.String(&a.buf[p], len(b))\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// arenaPrimitives carves a zeroed slice of n elements from the arena's byte\n")
+	buf.WriteString("// block. T must be a primitive type without pointers.\n")
+	buf.WriteString("func arenaPrimitives[T any](a *decodeArena, n uint32) []T {\n")
+	buf.WriteString("\tif n == 0 {\n")
+	buf.WriteString("\t\treturn []T{}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tvar zero T\n")
+	buf.WriteString("\ts := unsafe.Slice((*T)(unsafe.Pointer(&a.buf[a.pos])), n)\n")
+	buf.WriteString("\ta.pos += (int(n)*int(unsafe.Sizeof(zero)) + 7) &^ 7\n")
+	buf.WriteString("\treturn s\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// arenaTake carves a zeroed slice of n elements from the front of pool.\n")
+	buf.WriteString("func arenaTake[T any](pool *[]T, n uint32) []T {\n")
+	buf.WriteString("\tif n == 0 {\n")
+	buf.WriteString("\t\treturn []T{}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\ts := (*pool)[:n:n]\n")
+	buf.WriteString("\t*pool = (*pool)[n:]\n")
+	buf.WriteString("\treturn s\n")
+	buf.WriteString("}\n")
+}
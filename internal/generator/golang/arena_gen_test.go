@@ -0,0 +1,113 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateArena(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "all field kinds",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Param",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+						},
+					},
+					{
+						Name: "Meta",
+						Fields: []parser.Field{
+							{Name: "owner", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+						},
+					},
+					{
+						Name: "Device",
+						Fields: []parser.Field{
+							{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+							{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}}},
+							{Name: "tags", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}}},
+							{Name: "params", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}}},
+							{Name: "meta", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Meta", Optional: true}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				// Entry point measures, then decodes with the arena
+				if !strings.Contains(code, "func DecodeDeviceArena(dest *Device, data []byte) error {") {
+					t.Errorf("missing DecodeDeviceArena")
+				}
+				if !strings.Contains(code, "if err := measureDevice(data, &offset, &DecodeContext{}, &size); err != nil {") {
+					t.Errorf("entry point should run the measuring pass")
+				}
+				if !strings.Contains(code, "ctx := &DecodeContext{arena: &arena}") {
+					t.Errorf("entry point should decode with the arena")
+				}
+				// One pool per struct type used in arrays or optionals
+				if !strings.Contains(code, "\tParam []Param\n") || !strings.Contains(code, "\tMeta []Meta\n") {
+					t.Errorf("missing struct pools")
+				}
+				if strings.Contains(code, "\tDevice []Device\n") {
+					t.Errorf("Device is never pooled")
+				}
+				// Measuring pass accumulates sizes
+				if !strings.Contains(code, "size.strings += int(strLen)") {
+					t.Errorf("missing string measurement")
+				}
+				if !strings.Contains(code, "size.bytes += (int(arrCount)*4 + 7) &^ 7") {
+					t.Errorf("primitive slices should be rounded up to 8 bytes")
+				}
+				if !strings.Contains(code, "size.strs += int(arrCount)") {
+					t.Errorf("missing []str element count")
+				}
+				if !strings.Contains(code, "size.Param += int(arrCount)") {
+					t.Errorf("missing struct element count")
+				}
+				if !strings.Contains(code, "\t\tsize.Meta += 1\n") {
+					t.Errorf("missing optional struct count")
+				}
+				// Measuring errors match decode errors
+				if !strings.Contains(code, `return decodeError(ErrUnexpectedEOF, "samples", *offset)`) {
+					t.Errorf("measure errors should carry the field path")
+				}
+				if !strings.Contains(code, "decodeElementError(err, i, *offset)") {
+					t.Errorf("missing element index annotation")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateArena(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateArena() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
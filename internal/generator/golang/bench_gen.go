@@ -0,0 +1,163 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateBenchmark generates Go benchmarks for the struct encoders and
+// decoders. The output is meant for a _test.go file in the generated package.
+//
+// For each struct, it generates:
+//   - BenchmarkEncodeStructName(b *testing.B)
+//   - BenchmarkDecodeStructName(b *testing.B)
+//   - BenchmarkRoundtripStructName(b *testing.B)
+//
+// With compareJSON, BenchmarkEncodeStructNameJSON, BenchmarkDecodeStructNameJSON
+// and BenchmarkRoundtripStructNameJSON measure encoding/json on the same value.
+//
+// The benchmarks run on <Struct>.sdpb from the directory given with the
+// -benchdata test flag, or on a random value from a fixed seed for structs
+// without a fixture. Fixture files hold one value in byte mode, as written
+// by sdp-encode.
+func GenerateBenchmark(schema *parser.Schema, compareJSON bool) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+
+	buf.WriteString("var benchData = flag.String(\"benchdata\", \"\", \"directory of <Struct>.sdpb files to benchmark instead of random values\")\n\n")
+	buf.WriteString("// benchRandomOptions shapes the random values of structs without a fixture.\n")
+	buf.WriteString("var benchRandomOptions = &RandomOptions{OptionalProbability: 1, MinArrayLen: 8, MaxArrayLen: 8, MaxStringLen: 32}\n\n")
+
+	for _, s := range schema.Structs {
+		structName := ToGoName(s.Name)
+		generateBenchValue(&buf, structName)
+		buf.WriteString("\n")
+		generateBenchmarks(&buf, structName)
+		if compareJSON {
+			buf.WriteString("\n")
+			generateJSONBenchmarks(&buf, structName)
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.String(), nil
+}
+
+// generateBenchValue generates benchValueX, which loads the fixture of a
+// struct or falls back to a random value.
+func generateBenchValue(buf *strings.Builder, structName string) {
+	buf.WriteString("// benchValue" + structName + " returns the " + structName + " the benchmarks run on.\n")
+	buf.WriteString("func benchValue" + structName + "(b *testing.B) *" + structName + " {\n")
+	buf.WriteString("\tif *benchData != \"\" {\n")
+	buf.WriteString("\t\tdata, err := os.ReadFile(filepath.Join(*benchData, \"" + structName + ".sdpb\"))\n")
+	buf.WriteString("\t\tif err == nil {\n")
+	buf.WriteString("\t\t\tvar x " + structName + "\n")
+	buf.WriteString("\t\t\tif err := Decode" + structName + "(&x, data); err != nil {\n")
+	buf.WriteString("\t\t\t\tb.Fatalf(\"" + structName + ".sdpb: %v\", err)\n")
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\t\treturn &x\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tif !os.IsNotExist(err) {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn Random" + structName + "(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)\n")
+	buf.WriteString("}\n")
+}
+
+// generateBenchmarks generates the encode, decode and round-trip benchmarks
+// of a struct.
+func generateBenchmarks(buf *strings.Builder, structName string) {
+	buf.WriteString("func BenchmarkEncode" + structName + "(b *testing.B) {\n")
+	buf.WriteString("\tx := benchValue" + structName + "(b)\n")
+	generateBenchEncodeOnce(buf, "Encode"+structName+"(x)")
+	buf.WriteString("\t\tif _, err := Encode" + structName + "(x); err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("func BenchmarkDecode" + structName + "(b *testing.B) {\n")
+	buf.WriteString("\tx := benchValue" + structName + "(b)\n")
+	generateBenchEncodeOnce(buf, "Encode"+structName+"(x)")
+	buf.WriteString("\t\tvar result " + structName + "\n")
+	buf.WriteString("\t\tif err := Decode" + structName + "(&result, data); err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("func BenchmarkRoundtrip" + structName + "(b *testing.B) {\n")
+	buf.WriteString("\tx := benchValue" + structName + "(b)\n")
+	generateBenchEncodeOnce(buf, "Encode"+structName+"(x)")
+	buf.WriteString("\t\tencoded, err := Encode" + structName + "(x)\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tvar result " + structName + "\n")
+	buf.WriteString("\t\tif err := Decode" + structName + "(&result, encoded); err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n")
+}
+
+// generateJSONBenchmarks generates the encoding/json counterparts of the
+// benchmarks of a struct.
+func generateJSONBenchmarks(buf *strings.Builder, structName string) {
+	buf.WriteString("func BenchmarkEncode" + structName + "JSON(b *testing.B) {\n")
+	buf.WriteString("\tx := benchValue" + structName + "(b)\n")
+	generateBenchEncodeOnce(buf, "json.Marshal(x)")
+	buf.WriteString("\t\tif _, err := json.Marshal(x); err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("func BenchmarkDecode" + structName + "JSON(b *testing.B) {\n")
+	buf.WriteString("\tx := benchValue" + structName + "(b)\n")
+	generateBenchEncodeOnce(buf, "json.Marshal(x)")
+	buf.WriteString("\t\tvar result " + structName + "\n")
+	buf.WriteString("\t\tif err := json.Unmarshal(data, &result); err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("func BenchmarkRoundtrip" + structName + "JSON(b *testing.B) {\n")
+	buf.WriteString("\tx := benchValue" + structName + "(b)\n")
+	generateBenchEncodeOnce(buf, "json.Marshal(x)")
+	buf.WriteString("\t\tencoded, err := json.Marshal(x)\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tvar result " + structName + "\n")
+	buf.WriteString("\t\tif err := json.Unmarshal(encoded, &result); err != nil {\n")
+	buf.WriteString("\t\t\tb.Fatal(err)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n")
+}
+
+// generateBenchEncodeOnce generates the setup shared by all benchmarks: it
+// encodes x once with encodeCall so throughput is reported in encoded bytes,
+// then opens the timed loop.
+func generateBenchEncodeOnce(buf *strings.Builder, encodeCall string) {
+	buf.WriteString("\tdata, err := " + encodeCall + "\n")
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString("\t\tb.Fatal(err)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tb.SetBytes(int64(len(data)))\n")
+	buf.WriteString("\tb.ReportAllocs()\n\n")
+	buf.WriteString("\tb.ResetTimer()\n")
+	buf.WriteString("\tfor i := 0; i < b.N; i++ {\n")
+}
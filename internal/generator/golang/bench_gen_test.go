@@ -0,0 +1,107 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateBenchmark(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Device",
+				Fields: []parser.Field{
+					{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+					{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f64"}}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		schema      *parser.Schema
+		compareJSON bool
+		wantErr     bool
+		checkFunc   func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name:   "sdp only",
+			schema: schema,
+			checkFunc: func(t *testing.T, code string) {
+				for _, want := range []string{
+					"func BenchmarkEncodeDevice(b *testing.B) {",
+					"func BenchmarkDecodeDevice(b *testing.B) {",
+					"func BenchmarkRoundtripDevice(b *testing.B) {",
+				} {
+					if !strings.Contains(code, want) {
+						t.Errorf("missing %q", want)
+					}
+				}
+				if strings.Contains(code, "json.") {
+					t.Errorf("encoding/json benchmarks generated without compareJSON")
+				}
+
+				// Values come from a fixture or a fixed seed
+				if !strings.Contains(code, `flag.String("benchdata", "",`) {
+					t.Errorf("missing -benchdata flag")
+				}
+				if !strings.Contains(code, `os.ReadFile(filepath.Join(*benchData, "Device.sdpb"))`) {
+					t.Errorf("fixture should be read from <Struct>.sdpb")
+				}
+				if !strings.Contains(code, "return RandomDevice(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)") {
+					t.Errorf("missing random fallback")
+				}
+
+				if strings.Count(code, "b.ReportAllocs()") != 3 || strings.Count(code, "b.SetBytes(int64(len(data)))") != 3 {
+					t.Errorf("every benchmark should report allocations and throughput")
+				}
+			},
+		},
+		{
+			name:        "compare with encoding/json",
+			schema:      schema,
+			compareJSON: true,
+			checkFunc: func(t *testing.T, code string) {
+				for _, want := range []string{
+					"func BenchmarkEncodeDeviceJSON(b *testing.B) {",
+					"func BenchmarkDecodeDeviceJSON(b *testing.B) {",
+					"func BenchmarkRoundtripDeviceJSON(b *testing.B) {",
+					"if err := json.Unmarshal(data, &result); err != nil {",
+				} {
+					if !strings.Contains(code, want) {
+						t.Errorf("missing %q", want)
+					}
+				}
+				if strings.Count(code, "b.ReportAllocs()") != 6 {
+					t.Errorf("every benchmark should report allocations")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateBenchmark(tt.schema, tt.compareJSON)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateBenchmark() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
@@ -6,8 +6,9 @@ import (
 
 // GenerateDecodeContext generates the DecodeContext type and related constants.
 // The DecodeContext is used during decoding to track array element counts and
-// enforce size limits as specified in DESIGN_SPEC.md Section 5.5.
-func GenerateDecodeContext() string {
+// enforce size limits as specified in DESIGN_SPEC.md Section 5.5. With arena
+// set it also carries the decodeArena of DecodeXArena (see GenerateArena).
+func GenerateDecodeContext(arena bool) string {
 	var buf strings.Builder
 
 	// Generate constants
@@ -18,13 +19,7 @@ func GenerateDecodeContext() string {
 	buf.WriteString("\tMaxTotalElements  = 10_000_000\n")
 	buf.WriteString(")\n\n")
 
-	// Generate DecodeContext type
-	buf.WriteString("// DecodeContext tracks state during decoding to enforce size limits.\n")
-	buf.WriteString("// It maintains a count of total elements across all arrays to prevent\n")
-	buf.WriteString("// excessive memory allocation from malicious or corrupted data.\n")
-	buf.WriteString("type DecodeContext struct {\n")
-	buf.WriteString("\ttotalElements int\n")
-	buf.WriteString("}\n\n")
+	generateDecodeContextType(&buf, arena)
 
 	// Generate checkArraySize method
 	buf.WriteString("// checkArraySize validates an array count against per-array and total limits.\n")
@@ -39,7 +34,94 @@ func GenerateDecodeContext() string {
 	buf.WriteString("\t\treturn ErrTooManyElements\n")
 	buf.WriteString("\t}\n\n")
 	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+
+	generateCanonicalFloatChecks(&buf)
+
+	return buf.String()
+}
+
+// generateDecodeContextType generates the DecodeContext struct, which is
+// the same whether or not the package targets the sdp runtime.
+func generateDecodeContextType(buf *strings.Builder, arena bool) {
+	buf.WriteString("// DecodeContext tracks state during decoding to enforce size limits.\n")
+	buf.WriteString("// It maintains a count of total elements across all arrays to prevent\n")
+	buf.WriteString("// excessive memory allocation from malicious or corrupted data.\n")
+	buf.WriteString("// In strict mode it also rejects non-canonical encodings; in reuse mode\n")
+	if arena {
+		buf.WriteString("// the decoder recycles the slices, structs and strings already in dest;\n")
+		buf.WriteString("// with an arena, strings and slices are carved from preallocated memory.\n")
+	} else {
+		buf.WriteString("// the decoder recycles the slices, structs and strings already in dest.\n")
+	}
+	buf.WriteString("type DecodeContext struct {\n")
+	buf.WriteString("\ttotalElements int\n")
+	buf.WriteString("\tstrict        bool\n")
+	buf.WriteString("\treuse         bool\n")
+	if arena {
+		buf.WriteString("\tarena         *decodeArena\n")
+	}
+	buf.WriteString("}\n\n")
+}
+
+// GenerateRuntimeDecodeContext generates the DecodeContext type for packages
+// that target the shared sdp runtime package. The limits, the array size
+// check and the canonical float checks delegate to package sdp instead of
+// being inlined.
+func GenerateRuntimeDecodeContext(arena bool) string {
+	var buf strings.Builder
+
+	buf.WriteString("// Size limit constants for decode validation, shared with package sdp\n")
+	buf.WriteString("const (\n")
+	buf.WriteString("\tMaxSerializedSize = sdp.MaxSerializedSize\n")
+	buf.WriteString("\tMaxArrayElements  = sdp.MaxArrayElements\n")
+	buf.WriteString("\tMaxTotalElements  = sdp.MaxTotalElements\n")
+	buf.WriteString(")\n\n")
+
+	generateDecodeContextType(&buf, arena)
+
+	buf.WriteString("// checkArraySize validates an array count against per-array and total limits.\n")
+	buf.WriteString("func (ctx *DecodeContext) checkArraySize(count uint32) error {\n")
+	buf.WriteString("\treturn sdp.CheckArraySize(&ctx.totalElements, count)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Canonical NaN bit patterns accepted by strict decoding\n")
+	buf.WriteString("const (\n")
+	buf.WriteString("\tCanonicalNaN32 = sdp.CanonicalNaN32\n")
+	buf.WriteString("\tCanonicalNaN64 = sdp.CanonicalNaN64\n")
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.\n")
+	buf.WriteString("func isCanonicalF32(bits uint32) bool {\n")
+	buf.WriteString("\treturn sdp.IsCanonicalF32(bits)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.\n")
+	buf.WriteString("func isCanonicalF64(bits uint64) bool {\n")
+	buf.WriteString("\treturn sdp.IsCanonicalF64(bits)\n")
 	buf.WriteString("}\n")
 
 	return buf.String()
 }
+
+// generateCanonicalFloatChecks generates the NaN canonicalization checks used
+// by strict decoding. Every NaN must be encoded as the positive quiet NaN with
+// an empty payload (0x7FC00000 / 0x7FF8000000000000), so that each value has
+// exactly one encoding. All other bit patterns are distinct values.
+func generateCanonicalFloatChecks(buf *strings.Builder) {
+	buf.WriteString("// Canonical NaN bit patterns accepted by strict decoding\n")
+	buf.WriteString("const (\n")
+	buf.WriteString("\tCanonicalNaN32 uint32 = 0x7FC00000\n")
+	buf.WriteString("\tCanonicalNaN64 uint64 = 0x7FF8000000000000\n")
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.\n")
+	buf.WriteString("func isCanonicalF32(bits uint32) bool {\n")
+	buf.WriteString("\treturn bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.\n")
+	buf.WriteString("func isCanonicalF64(bits uint64) bool {\n")
+	buf.WriteString("\treturn bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64\n")
+	buf.WriteString("}\n")
+}
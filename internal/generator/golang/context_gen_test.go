@@ -7,7 +7,7 @@ import (
 
 // TestGenerateDecodeContext verifies basic generation
 func TestGenerateDecodeContext(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	if result == "" {
 		t.Fatal("GenerateDecodeContext returned empty string")
@@ -25,7 +25,7 @@ func TestGenerateDecodeContext(t *testing.T) {
 
 // TestGenerateDecodeContextConstants verifies all three constants are present
 func TestGenerateDecodeContextConstants(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	expectedConstants := []string{
 		"MaxSerializedSize",
@@ -42,7 +42,7 @@ func TestGenerateDecodeContextConstants(t *testing.T) {
 
 // TestGenerateDecodeContextConstantValues verifies constant values match design spec
 func TestGenerateDecodeContextConstantValues(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	expectedValues := []struct {
 		name  string
@@ -68,7 +68,7 @@ func TestGenerateDecodeContextConstantValues(t *testing.T) {
 
 // TestGenerateDecodeContextTypeStructure verifies the struct definition
 func TestGenerateDecodeContextTypeStructure(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Check for type declaration
 	if !strings.Contains(result, "type DecodeContext struct {") {
@@ -104,7 +104,7 @@ func TestGenerateDecodeContextTypeStructure(t *testing.T) {
 
 // TestGenerateDecodeContextMethodSignature verifies checkArraySize signature
 func TestGenerateDecodeContextMethodSignature(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Check method signature
 	expectedSignature := "func (ctx *DecodeContext) checkArraySize(count uint32) error {"
@@ -115,7 +115,7 @@ func TestGenerateDecodeContextMethodSignature(t *testing.T) {
 
 // TestGenerateDecodeContextMethodLogic verifies checkArraySize implementation
 func TestGenerateDecodeContextMethodLogic(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Check for per-array limit check
 	if !strings.Contains(result, "if count > MaxArrayElements {") {
@@ -148,7 +148,7 @@ func TestGenerateDecodeContextMethodLogic(t *testing.T) {
 
 // TestGenerateDecodeContextComments verifies documentation comments
 func TestGenerateDecodeContextComments(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	expectedComments := []string{
 		"// Size limit constants",
@@ -165,7 +165,7 @@ func TestGenerateDecodeContextComments(t *testing.T) {
 
 // TestGenerateDecodeContextConstantBlock verifies const block format
 func TestGenerateDecodeContextConstantBlock(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Check for const block
 	if !strings.Contains(result, "const (") {
@@ -194,7 +194,7 @@ func TestGenerateDecodeContextConstantBlock(t *testing.T) {
 
 // TestGenerateDecodeContextMatchesDesignSpec verifies exact match with DESIGN_SPEC.md
 func TestGenerateDecodeContextMatchesDesignSpec(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// These exact patterns are from DESIGN_SPEC.md Section 5.5
 	designSpecPatterns := []string{
@@ -220,7 +220,7 @@ func TestGenerateDecodeContextMatchesDesignSpec(t *testing.T) {
 
 // TestGenerateDecodeContextOrder verifies logical ordering of components
 func TestGenerateDecodeContextOrder(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Constants should come first
 	constIndex := strings.Index(result, "const (")
@@ -242,7 +242,7 @@ func TestGenerateDecodeContextOrder(t *testing.T) {
 
 // TestGenerateDecodeContextNoExtraContent verifies clean output
 func TestGenerateDecodeContextNoExtraContent(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Should not contain package declaration
 	if strings.Contains(result, "package ") {
@@ -269,7 +269,7 @@ func TestGenerateDecodeContextNoExtraContent(t *testing.T) {
 
 // TestGenerateDecodeContextMethodReturnPaths verifies all return paths in checkArraySize
 func TestGenerateDecodeContextMethodReturnPaths(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Extract just the checkArraySize method
 	methodStart := strings.Index(result, "func (ctx *DecodeContext) checkArraySize")
@@ -300,7 +300,7 @@ func TestGenerateDecodeContextMethodReturnPaths(t *testing.T) {
 
 // TestGenerateDecodeContextAlignment verifies constant alignment
 func TestGenerateDecodeContextAlignment(t *testing.T) {
-	result := GenerateDecodeContext()
+	result := GenerateDecodeContext(false)
 
 	// Extract const block
 	constStart := strings.Index(result, "const (")
@@ -332,3 +332,53 @@ func TestGenerateDecodeContextAlignment(t *testing.T) {
 		}
 	}
 }
+
+// TestGenerateDecodeContextCanonicalFloats verifies the NaN checks used by strict mode
+func TestGenerateDecodeContextCanonicalFloats(t *testing.T) {
+	result := GenerateDecodeContext(false)
+
+	expected := []string{
+		"strict        bool",
+		"reuse         bool",
+		"CanonicalNaN32 uint32 = 0x7FC00000",
+		"CanonicalNaN64 uint64 = 0x7FF8000000000000",
+		"func isCanonicalF32(bits uint32) bool {",
+		"func isCanonicalF64(bits uint64) bool {",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
+
+// TestGenerateDecodeContextArena verifies the arena field is only generated
+// for arena decoding
+func TestGenerateDecodeContextArena(t *testing.T) {
+	if result := GenerateDecodeContext(false); strings.Contains(result, "arena") {
+		t.Errorf("arena field generated without arena decoding:\n%s", result)
+	}
+	if result := GenerateDecodeContext(true); !strings.Contains(result, "arena         *decodeArena") {
+		t.Errorf("missing arena field:\n%s", result)
+	}
+}
+
+// TestGenerateRuntimeDecodeContext verifies that runtime mode delegates limit checks to package sdp
+func TestGenerateRuntimeDecodeContext(t *testing.T) {
+	result := GenerateRuntimeDecodeContext(true)
+
+	expected := []string{
+		"MaxSerializedSize = sdp.MaxSerializedSize",
+		"type DecodeContext struct {",
+		"arena         *decodeArena",
+		"return sdp.CheckArraySize(&ctx.totalElements, count)",
+		"CanonicalNaN32 = sdp.CanonicalNaN32",
+		"return sdp.IsCanonicalF32(bits)",
+		"return sdp.IsCanonicalF64(bits)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
@@ -77,12 +77,90 @@ func GenerateDecoder(schema *parser.Schema) (string, error) {
 		buf.WriteString(helperName)
 		buf.WriteString("(dest, data, &offset, ctx)\n")
 
-		buf.WriteString("}\n")
+		buf.WriteString("}\n\n")
+
+		generateStrictDecoder(&buf, structName)
+		buf.WriteString("\n")
+		generateReuseDecoder(&buf, structName)
 	}
 
 	return buf.String(), nil
 }
 
+// generateStrictDecoder generates DecodeXStrict, which accepts exactly one
+// encoding per value: trailing bytes, bool bytes other than 0/1 and
+// non-canonical NaNs are rejected. Presence flags other than 0/1 are
+// rejected in both modes.
+func generateStrictDecoder(buf *strings.Builder, structName string) {
+	funcName := "Decode" + structName + "Strict"
+	helperName := "decode" + structName
+
+	buf.WriteString("// ")
+	buf.WriteString(funcName)
+	buf.WriteString(" decodes a ")
+	buf.WriteString(structName)
+	buf.WriteString(" like Decode")
+	buf.WriteString(structName)
+	buf.WriteString(", but only accepts the canonical encoding.\n")
+	buf.WriteString("// It returns ErrTrailingBytes if data continues after the value, and\n")
+	buf.WriteString("// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than\n")
+	buf.WriteString("// CanonicalNaN32/CanonicalNaN64.\n")
+	buf.WriteString("func ")
+	buf.WriteString(funcName)
+	buf.WriteString("(dest *")
+	buf.WriteString(structName)
+	buf.WriteString(", data []byte) error {\n")
+	buf.WriteString("\tif len(data) > MaxSerializedSize {\n")
+	buf.WriteString("\t\treturn ErrDataTooLarge\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tctx := &DecodeContext{strict: true}\n")
+	buf.WriteString("\toffset := 0\n")
+	buf.WriteString("\tif err := ")
+	buf.WriteString(helperName)
+	buf.WriteString("(dest, data, &offset, ctx); err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif offset != len(data) {\n")
+	buf.WriteString("\t\treturn ErrTrailingBytes\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n")
+}
+
+// generateReuseDecoder generates DecodeXReuse, which decodes into an existing
+// value and recycles its memory instead of allocating a fresh tree.
+func generateReuseDecoder(buf *strings.Builder, structName string) {
+	funcName := "Decode" + structName + "Reuse"
+	helperName := "decode" + structName
+
+	buf.WriteString("// ")
+	buf.WriteString(funcName)
+	buf.WriteString(" decodes a ")
+	buf.WriteString(structName)
+	buf.WriteString(" into dest, reusing the memory dest already holds:\n")
+	buf.WriteString("// slices are truncated and refilled when their capacity suffices, present\n")
+	buf.WriteString("// optional structs are decoded in place, and unchanged strings are kept.\n")
+	buf.WriteString("//\n")
+	buf.WriteString("// Everything reachable from dest is overwritten, so slices or pointers\n")
+	buf.WriteString("// obtained from a previous decode observe the new values; copy anything\n")
+	buf.WriteString("// that must outlive the next call. The result never aliases data, and on\n")
+	buf.WriteString("// error dest holds a partially decoded value.\n")
+	buf.WriteString("func ")
+	buf.WriteString(funcName)
+	buf.WriteString("(dest *")
+	buf.WriteString(structName)
+	buf.WriteString(", data []byte) error {\n")
+	buf.WriteString("\tif len(data) > MaxSerializedSize {\n")
+	buf.WriteString("\t\treturn ErrDataTooLarge\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tctx := &DecodeContext{reuse: true}\n")
+	buf.WriteString("\toffset := 0\n")
+	buf.WriteString("\treturn ")
+	buf.WriteString(helperName)
+	buf.WriteString("(dest, data, &offset, ctx)\n")
+	buf.WriteString("}\n")
+}
+
 // GenerateDecodeHelpers generates the helper decode functions for each struct.
 // These functions implement the actual decoding logic for struct fields.
 // For each struct, it generates a helper function like:
@@ -94,7 +172,11 @@ func GenerateDecoder(schema *parser.Schema) (string, error) {
 //
 // This function currently handles primitive types. String, array, and nested
 // struct decoding will be added in subsequent tasks.
-func GenerateDecodeHelpers(schema *parser.Schema) (string, error) {
+//
+// With arena set, strings, slices and optional structs are allocated from
+// ctx.arena when DecodeXArena provides one (see GenerateArena). Without it
+// the decoder contains no arena code.
+func GenerateDecodeHelpers(schema *parser.Schema, arena bool) (string, error) {
 	if schema == nil {
 		return "", fmt.Errorf("schema is nil")
 	}
@@ -156,7 +238,7 @@ func GenerateDecodeHelpers(schema *parser.Schema) (string, error) {
 
 		// Generate field decoding
 		for _, field := range s.Fields {
-			if err := generateFieldDecode(&buf, &field); err != nil {
+			if err := generateFieldDecode(&buf, &field, arena); err != nil {
 				return "", fmt.Errorf("struct %q, field %q: %w", s.Name, field.Name, err)
 			}
 		}
@@ -168,8 +250,34 @@ func GenerateDecodeHelpers(schema *parser.Schema) (string, error) {
 	return buf.String(), nil
 }
 
+// decodeCode accumulates the code that decodes one field. Error returns are
+// written with fail, which wraps them with the schema field name, so
+// failures surface as *DecodeError values carrying the full field path
+// (e.g. "plugins[12].parameters[3].unit").
+type decodeCode struct {
+	*strings.Builder
+	field   string // Schema field name
+	arena   bool   // Carve strings and slices from ctx.arena when it is set
+	element bool   // Inside a loop over array element i
+}
+
+// fail writes a return of errExpr wrapped with the field name and offset,
+// the expression for the offset where the failing read started. Inside an
+// element loop the error path also carries the element index.
+func (c decodeCode) fail(indent, errExpr, offset string) {
+	if c.element {
+		errExpr = "decodeElementError(" + errExpr + ", i, " + offset + ")"
+	}
+	c.WriteString(indent + "return decodeError(" + errExpr + ", \"" + c.field + "\", " + offset + ")\n")
+}
+
 // generateFieldDecode generates the decoding logic for a single field.
-func generateFieldDecode(buf *strings.Builder, field *parser.Field) error {
+func generateFieldDecode(buf *strings.Builder, field *parser.Field, arena bool) error {
+	return generateFieldDecodeBody(decodeCode{Builder: buf, field: field.Name, arena: arena}, field)
+}
+
+// generateFieldDecodeBody generates the decoding logic for a single field.
+func generateFieldDecodeBody(buf decodeCode, field *parser.Field) error {
 	fieldName := ToGoName(field.Name)
 
 	// Handle optional fields
@@ -180,9 +288,12 @@ func generateFieldDecode(buf *strings.Builder, field *parser.Field) error {
 
 		// Read presence flag
 		buf.WriteString("\tif *offset >= len(data) {\n")
-		buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t}\n")
 		buf.WriteString("\tpresence = data[*offset]\n")
+		buf.WriteString("\tif presence > 1 {\n")
+		buf.fail("\t\t", "ErrInvalidData", "*offset")
+		buf.WriteString("\t}\n")
 		buf.WriteString("\t*offset += 1\n\n")
 
 		// Check presence
@@ -190,10 +301,10 @@ func generateFieldDecode(buf *strings.Builder, field *parser.Field) error {
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
 		buf.WriteString(" = nil\n")
-		buf.WriteString("\t} else if presence == 1 {\n")
+		buf.WriteString("\t} else {\n")
 
 		// Allocate and decode the value
-		tempBuf := &strings.Builder{}
+		tempBuf := decodeCode{Builder: &strings.Builder{}, field: buf.field, arena: buf.arena}
 		var err error
 
 		switch field.Type.Kind {
@@ -221,9 +332,6 @@ func generateFieldDecode(buf *strings.Builder, field *parser.Field) error {
 			}
 		}
 
-		// Handle invalid presence flag
-		buf.WriteString("\t} else {\n")
-		buf.WriteString("\t\treturn ErrInvalidData\n")
 		buf.WriteString("\t}\n\n")
 
 		return nil
@@ -243,7 +351,7 @@ func generateFieldDecode(buf *strings.Builder, field *parser.Field) error {
 }
 
 // generatePrimitiveDecode generates decoding code for a primitive field.
-func generatePrimitiveDecode(buf *strings.Builder, primitiveType, fieldName string) error {
+func generatePrimitiveDecode(buf decodeCode, primitiveType, fieldName string) error {
 	// Add field comment
 	buf.WriteString("\t// Field: ")
 	buf.WriteString(fieldName)
@@ -285,9 +393,9 @@ func generatePrimitiveDecode(buf *strings.Builder, primitiveType, fieldName stri
 }
 
 // generateU8Decode generates decode code for u8 (uint8)
-func generateU8Decode(buf *strings.Builder, fieldName string) {
+func generateU8Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 1 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -297,9 +405,9 @@ func generateU8Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateU16Decode generates decode code for u16 (uint16)
-func generateU16Decode(buf *strings.Builder, fieldName string) {
+func generateU16Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 2 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -309,9 +417,9 @@ func generateU16Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateU32Decode generates decode code for u32 (uint32)
-func generateU32Decode(buf *strings.Builder, fieldName string) {
+func generateU32Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 4 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -321,9 +429,9 @@ func generateU32Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateU64Decode generates decode code for u64 (uint64)
-func generateU64Decode(buf *strings.Builder, fieldName string) {
+func generateU64Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 8 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -333,9 +441,9 @@ func generateU64Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateI8Decode generates decode code for i8 (int8)
-func generateI8Decode(buf *strings.Builder, fieldName string) {
+func generateI8Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 1 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -345,9 +453,9 @@ func generateI8Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateI16Decode generates decode code for i16 (int16)
-func generateI16Decode(buf *strings.Builder, fieldName string) {
+func generateI16Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 2 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -357,9 +465,9 @@ func generateI16Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateI32Decode generates decode code for i32 (int32)
-func generateI32Decode(buf *strings.Builder, fieldName string) {
+func generateI32Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 4 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -369,9 +477,9 @@ func generateI32Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateI64Decode generates decode code for i64 (int64)
-func generateI64Decode(buf *strings.Builder, fieldName string) {
+func generateI64Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 8 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
@@ -381,10 +489,11 @@ func generateI64Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateF32Decode generates decode code for f32 (float32)
-func generateF32Decode(buf *strings.Builder, fieldName string) {
+func generateF32Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 4 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
+	generateStrictFloatCheck(buf, "f32", "\t")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
 	buf.WriteString(" = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))\n")
@@ -393,10 +502,11 @@ func generateF32Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateF64Decode generates decode code for f64 (float64)
-func generateF64Decode(buf *strings.Builder, fieldName string) {
+func generateF64Decode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 8 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
+	generateStrictFloatCheck(buf, "f64", "\t")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
 	buf.WriteString(" = math.Float64frombits(binary.LittleEndian.Uint64(data[*offset:]))\n")
@@ -405,10 +515,11 @@ func generateF64Decode(buf *strings.Builder, fieldName string) {
 }
 
 // generateBoolDecode generates decode code for bool
-func generateBoolDecode(buf *strings.Builder, fieldName string) {
+func generateBoolDecode(buf decodeCode, fieldName string) {
 	buf.WriteString("\tif *offset + 1 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
+	generateStrictBoolCheck(buf, "\t")
 	buf.WriteString("\tdest.")
 	buf.WriteString(fieldName)
 	buf.WriteString(" = data[*offset] != 0\n")
@@ -416,11 +527,31 @@ func generateBoolDecode(buf *strings.Builder, fieldName string) {
 	buf.WriteString("\n")
 }
 
+// generateStrictBoolCheck generates the strict mode check that a bool byte
+// is exactly 0 or 1. It must be emitted after the bounds check.
+func generateStrictBoolCheck(buf decodeCode, indent string) {
+	buf.WriteString(indent + "if ctx.strict && data[*offset] > 1 {\n")
+	buf.fail(indent+"\t", "ErrNonCanonical", "*offset")
+	buf.WriteString(indent + "}\n")
+}
+
+// generateStrictFloatCheck generates the strict mode check that a NaN uses
+// the canonical bit pattern. It must be emitted after the bounds check.
+func generateStrictFloatCheck(buf decodeCode, primitiveType, indent string) {
+	if primitiveType == "f32" {
+		buf.WriteString(indent + "if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {\n")
+	} else {
+		buf.WriteString(indent + "if ctx.strict && !isCanonicalF64(binary.LittleEndian.Uint64(data[*offset:])) {\n")
+	}
+	buf.fail(indent+"\t", "ErrNonCanonical", "*offset")
+	buf.WriteString(indent + "}\n")
+}
+
 // generateStringDecode generates decode code for string (str)
-func generateStringDecode(buf *strings.Builder, fieldName string) {
+func generateStringDecode(buf decodeCode, fieldName string) {
 	// Read length prefix
 	buf.WriteString("\tif *offset + 4 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tstrLen = binary.LittleEndian.Uint32(data[*offset:])\n")
 	buf.WriteString("\t*offset += 4\n")
@@ -428,17 +559,59 @@ func generateStringDecode(buf *strings.Builder, fieldName string) {
 
 	// Read string bytes
 	buf.WriteString("\tif *offset + int(strLen) > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
-	buf.WriteString("\tdest.")
-	buf.WriteString(fieldName)
-	buf.WriteString(" = string(data[*offset:*offset+int(strLen)])\n")
+	generateUTF8Check(buf, "\t")
+	generateStringAssign(buf, "\t", "dest."+fieldName)
 	buf.WriteString("\t*offset += int(strLen)\n")
 	buf.WriteString("\n")
 }
 
+// generateStringAssign generates the assignment of the string at *offset to
+// target. In arena mode the bytes are copied into the arena; in reuse mode
+// an unchanged string is kept instead of reallocated (the comparison against
+// string(data[...]) does not allocate).
+func generateStringAssign(buf decodeCode, indent, target string) {
+	buf.WriteString(indent)
+	if buf.arena {
+		buf.WriteString("if ctx.arena != nil {\n")
+		buf.WriteString(indent + "\t" + target + " = ctx.arena.string(data[*offset:*offset+int(strLen)])\n")
+		buf.WriteString(indent + "} else ")
+	}
+	buf.WriteString("if !ctx.reuse || " + target + " != string(data[*offset:*offset+int(strLen)]) {\n")
+	buf.WriteString(indent + "\t" + target + " = string(data[*offset:*offset+int(strLen)])\n")
+	buf.WriteString(indent + "}\n")
+}
+
+// generateUTF8Check generates the strict mode check that the string at
+// *offset is valid UTF-8. DecodeX leaves strings unchecked to keep the hot
+// path free of the scan.
+func generateUTF8Check(buf decodeCode, indent string) {
+	buf.WriteString(indent + "if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {\n")
+	buf.fail(indent+"\t", "ErrInvalidUTF8", "*offset")
+	buf.WriteString(indent + "}\n")
+}
+
+// generateSliceAlloc generates the allocation of target as a slice of
+// arrCount elements. In arena mode the slice is carved from the arena; in
+// reuse mode existing capacity is resliced instead, and elements left over
+// from the previous value are overwritten by the decoder.
+func generateSliceAlloc(buf decodeCode, indent, target string, elemType *parser.TypeExpr, goType string) {
+	buf.WriteString(indent)
+	if buf.arena {
+		buf.WriteString("if ctx.arena != nil {\n")
+		buf.WriteString(indent + "\t" + target + " = " + arenaSliceExpr(elemType, goType, "arrCount") + "\n")
+		buf.WriteString(indent + "} else ")
+	}
+	buf.WriteString("if ctx.reuse && cap(" + target + ") >= int(arrCount) {\n")
+	buf.WriteString(indent + "\t" + target + " = " + target + "[:arrCount]\n")
+	buf.WriteString(indent + "} else {\n")
+	buf.WriteString(indent + "\t" + target + " = make([]" + goType + ", arrCount)\n")
+	buf.WriteString(indent + "}\n")
+}
+
 // generateNamedTypeDecode generates decode code for named type (nested struct) fields.
-func generateNamedTypeDecode(buf *strings.Builder, typeName, fieldName string) error {
+func generateNamedTypeDecode(buf decodeCode, typeName, fieldName string) error {
 	// Add field comment
 	buf.WriteString("\t// Field: ")
 	buf.WriteString(fieldName)
@@ -456,7 +629,7 @@ func generateNamedTypeDecode(buf *strings.Builder, typeName, fieldName string) e
 	buf.WriteString(fieldName)
 	buf.WriteString(", data, offset, ctx)\n")
 	buf.WriteString("\tif err != nil {\n")
-	buf.WriteString("\t\treturn err\n")
+	buf.fail("\t\t", "err", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\n")
 
@@ -464,7 +637,7 @@ func generateNamedTypeDecode(buf *strings.Builder, typeName, fieldName string) e
 }
 
 // generateArrayDecode generates decode code for array fields.
-func generateArrayDecode(buf *strings.Builder, arrayType *parser.TypeExpr, fieldName string) error {
+func generateArrayDecode(buf decodeCode, arrayType *parser.TypeExpr, fieldName string) error {
 	if arrayType.Elem == nil {
 		return fmt.Errorf("array type has no element type")
 	}
@@ -484,7 +657,7 @@ func generateArrayDecode(buf *strings.Builder, arrayType *parser.TypeExpr, field
 
 	// Read array count
 	buf.WriteString("\tif *offset + 4 > len(data) {\n")
-	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\tarrCount = binary.LittleEndian.Uint32(data[*offset:])\n")
 	buf.WriteString("\t*offset += 4\n")
@@ -493,22 +666,16 @@ func generateArrayDecode(buf *strings.Builder, arrayType *parser.TypeExpr, field
 	// Check array size limit
 	buf.WriteString("\terr = ctx.checkArraySize(arrCount)\n")
 	buf.WriteString("\tif err != nil {\n")
-	buf.WriteString("\t\treturn err\n")
+	buf.fail("\t\t", "err", "*offset-4")
 	buf.WriteString("\t}\n")
 	buf.WriteString("\n")
 
-	// Allocate array
-	buf.WriteString("\tdest.")
-	buf.WriteString(fieldName)
-	buf.WriteString(" = make([]")
-
-	// Get Go type for allocation
+	// Allocate array (or reuse its capacity)
 	goType, err := getGoTypeForArray(arrayType.Elem)
 	if err != nil {
 		return err
 	}
-	buf.WriteString(goType)
-	buf.WriteString(", arrCount)\n")
+	generateSliceAlloc(buf, "\t", "dest."+fieldName, arrayType.Elem, goType)
 
 	// Check if we can use bulk copy optimization for primitive integer arrays
 	if arrayType.Elem.Kind == parser.TypeKindPrimitive && canUseBulkCopy(arrayType.Elem.Name) {
@@ -573,9 +740,10 @@ func getGoTypeForArray(elemType *parser.TypeExpr) (string, error) {
 }
 
 // generateArrayElementDecode generates code to decode a single array element.
-func generateArrayElementDecode(buf *strings.Builder, elemType *parser.TypeExpr, fieldName string) error {
+func generateArrayElementDecode(buf decodeCode, elemType *parser.TypeExpr, fieldName string) error {
 	switch elemType.Kind {
 	case parser.TypeKindPrimitive:
+		buf.element = true
 		return generateArrayPrimitiveElementDecode(buf, elemType.Name, fieldName)
 	case parser.TypeKindNamed:
 		return generateArrayNamedTypeElementDecode(buf, elemType.Name, fieldName)
@@ -589,11 +757,11 @@ func generateArrayElementDecode(buf *strings.Builder, elemType *parser.TypeExpr,
 
 // generateArrayPrimitiveElementDecode generates decode code for primitive array elements.
 // This is only used when bulk copy optimization doesn't apply (floats, bools, strings).
-func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fieldName string) error {
+func generateArrayPrimitiveElementDecode(buf decodeCode, primitiveType, fieldName string) error {
 	switch primitiveType {
 	case "u8":
 		buf.WriteString("\t\tif *offset + 1 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -602,7 +770,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "u16":
 		buf.WriteString("\t\tif *offset + 2 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -611,7 +779,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "u32":
 		buf.WriteString("\t\tif *offset + 4 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -620,7 +788,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "u64":
 		buf.WriteString("\t\tif *offset + 8 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -629,7 +797,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "i8":
 		buf.WriteString("\t\tif *offset + 1 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -638,7 +806,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "i16":
 		buf.WriteString("\t\tif *offset + 2 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -647,7 +815,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "i32":
 		buf.WriteString("\t\tif *offset + 4 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -656,7 +824,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "i64":
 		buf.WriteString("\t\tif *offset + 8 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
@@ -665,8 +833,9 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "f32":
 		buf.WriteString("\t\tif *offset + 4 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
+		generateStrictFloatCheck(buf, "f32", "\t\t")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
 		buf.WriteString("[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))\n")
@@ -674,8 +843,9 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "f64":
 		buf.WriteString("\t\tif *offset + 8 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
+		generateStrictFloatCheck(buf, "f64", "\t\t")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
 		buf.WriteString("[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[*offset:]))\n")
@@ -683,8 +853,9 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 	case "bool":
 		buf.WriteString("\t\tif *offset + 1 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
+		generateStrictBoolCheck(buf, "\t\t")
 		buf.WriteString("\t\tdest.")
 		buf.WriteString(fieldName)
 		buf.WriteString("[i] = data[*offset] != 0\n")
@@ -693,17 +864,16 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 	case "str":
 		// String array element
 		buf.WriteString("\t\tif *offset + 4 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tstrLen := binary.LittleEndian.Uint32(data[*offset:])\n")
 		buf.WriteString("\t\t*offset += 4\n")
 		buf.WriteString("\t\t\n")
 		buf.WriteString("\t\tif *offset + int(strLen) > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
-		buf.WriteString("\t\tdest.")
-		buf.WriteString(fieldName)
-		buf.WriteString("[i] = string(data[*offset:*offset+int(strLen)])\n")
+		generateUTF8Check(buf, "\t\t")
+		generateStringAssign(buf, "\t\t", "dest."+fieldName+"[i]")
 		buf.WriteString("\t\t*offset += int(strLen)\n")
 
 	default:
@@ -715,7 +885,7 @@ func generateArrayPrimitiveElementDecode(buf *strings.Builder, primitiveType, fi
 
 // generateBulkArrayDecode generates optimized bulk copy code for decoding primitive integer arrays.
 // Uses unsafe.Slice to create a byte view of the destination array for a single copy operation.
-func generateBulkArrayDecode(buf *strings.Builder, primitiveType, fieldName string) {
+func generateBulkArrayDecode(buf decodeCode, primitiveType, fieldName string) {
 	elemSize := getPrimitiveSize(primitiveType)
 
 	buf.WriteString("\t// Bulk decode optimization for primitive arrays\n")
@@ -723,7 +893,7 @@ func generateBulkArrayDecode(buf *strings.Builder, primitiveType, fieldName stri
 
 	// Bounds check
 	buf.WriteString(fmt.Sprintf("\t\tif *offset + int(arrCount)*%d > len(data) {\n", elemSize))
-	buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t\t}\n")
 
 	if primitiveType == "u8" || primitiveType == "i8" {
@@ -746,7 +916,7 @@ func generateBulkArrayDecode(buf *strings.Builder, primitiveType, fieldName stri
 }
 
 // generateArrayNamedTypeElementDecode generates decode code for named type array elements.
-func generateArrayNamedTypeElementDecode(buf *strings.Builder, typeName, fieldName string) error {
+func generateArrayNamedTypeElementDecode(buf decodeCode, typeName, fieldName string) error {
 	// Call the helper decode function for the nested struct
 	goTypeName := ToGoName(typeName)
 	helperName := "decode" + goTypeName
@@ -757,7 +927,7 @@ func generateArrayNamedTypeElementDecode(buf *strings.Builder, typeName, fieldNa
 	buf.WriteString(fieldName)
 	buf.WriteString("[i], data, offset, ctx)\n")
 	buf.WriteString("\t\tif err != nil {\n")
-	buf.WriteString("\t\t\treturn err\n")
+	buf.fail("\t\t\t", "decodeElementError(err, i, *offset)", "*offset")
 	buf.WriteString("\t\t}\n")
 
 	return nil
@@ -765,7 +935,7 @@ func generateArrayNamedTypeElementDecode(buf *strings.Builder, typeName, fieldNa
 
 // generatePrimitiveDecodeForOptional generates decode code for optional primitive fields.
 // This allocates a new value and assigns it to the pointer.
-func generatePrimitiveDecodeForOptional(buf *strings.Builder, primitiveType, fieldName string) error {
+func generatePrimitiveDecodeForOptional(buf decodeCode, primitiveType, fieldName string) error {
 	goType, ok := primitiveTypeMap[primitiveType]
 	if !ok {
 		return fmt.Errorf("unknown primitive type: %s", primitiveType)
@@ -781,10 +951,11 @@ func generatePrimitiveDecodeForOptional(buf *strings.Builder, primitiveType, fie
 	case "u8", "i8", "bool":
 		size := 1
 		buf.WriteString(fmt.Sprintf("\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 
 		if primitiveType == "bool" {
+			generateStrictBoolCheck(buf, "\t\t")
 			buf.WriteString("\t\tval = data[*offset] != 0\n")
 		} else {
 			buf.WriteString(fmt.Sprintf("\t\tval = %s(data[*offset])\n", goType))
@@ -794,7 +965,7 @@ func generatePrimitiveDecodeForOptional(buf *strings.Builder, primitiveType, fie
 	case "u16", "i16":
 		size := 2
 		buf.WriteString(fmt.Sprintf("\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString(fmt.Sprintf("\t\tval = %s(binary.LittleEndian.Uint16(data[*offset:]))\n", goType))
 		buf.WriteString(fmt.Sprintf("\t\t*offset += %d\n", size))
@@ -802,9 +973,10 @@ func generatePrimitiveDecodeForOptional(buf *strings.Builder, primitiveType, fie
 	case "u32", "i32", "f32":
 		size := 4
 		buf.WriteString(fmt.Sprintf("\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		if primitiveType == "f32" {
+			generateStrictFloatCheck(buf, "f32", "\t\t")
 			buf.WriteString("\t\tval = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))\n")
 		} else {
 			buf.WriteString(fmt.Sprintf("\t\tval = %s(binary.LittleEndian.Uint32(data[*offset:]))\n", goType))
@@ -814,9 +986,10 @@ func generatePrimitiveDecodeForOptional(buf *strings.Builder, primitiveType, fie
 	case "u64", "i64", "f64":
 		size := 8
 		buf.WriteString(fmt.Sprintf("\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		if primitiveType == "f64" {
+			generateStrictFloatCheck(buf, "f64", "\t\t")
 			buf.WriteString("\t\tval = math.Float64frombits(binary.LittleEndian.Uint64(data[*offset:]))\n")
 		} else {
 			buf.WriteString(fmt.Sprintf("\t\tval = %s(binary.LittleEndian.Uint64(data[*offset:]))\n", goType))
@@ -825,14 +998,15 @@ func generatePrimitiveDecodeForOptional(buf *strings.Builder, primitiveType, fie
 
 	case "str":
 		buf.WriteString("\t\tif *offset + 4 > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
 		buf.WriteString("\t\tstrLen := binary.LittleEndian.Uint32(data[*offset:])\n")
 		buf.WriteString("\t\t*offset += 4\n\n")
 
 		buf.WriteString("\t\tif *offset + int(strLen) > len(data) {\n")
-		buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t}\n")
+		generateUTF8Check(buf, "\t\t")
 		buf.WriteString("\t\tval = string(data[*offset : *offset + int(strLen)])\n")
 		buf.WriteString("\t\t*offset += int(strLen)\n")
 
@@ -849,16 +1023,30 @@ func generatePrimitiveDecodeForOptional(buf *strings.Builder, primitiveType, fie
 }
 
 // generateNamedTypeDecodeForOptional generates decode code for optional named type fields.
-func generateNamedTypeDecodeForOptional(buf *strings.Builder, typeName, fieldName string) error {
+func generateNamedTypeDecodeForOptional(buf decodeCode, typeName, fieldName string) error {
 	goTypeName := ToGoName(typeName)
 	helperName := "decode" + goTypeName
 
-	// Allocate the struct
-	buf.WriteString("\t\tdest.")
+	// Allocate the struct, unless reusing an existing one
+	buf.WriteString("\t\t")
+	if buf.arena {
+		buf.WriteString("if ctx.arena != nil {\n")
+		buf.WriteString("\t\t\tdest.")
+		buf.WriteString(fieldName)
+		buf.WriteString(" = &arenaTake(&ctx.arena.")
+		buf.WriteString(goTypeName)
+		buf.WriteString(", 1)[0]\n")
+		buf.WriteString("\t\t} else ")
+	}
+	buf.WriteString("if !ctx.reuse || dest.")
+	buf.WriteString(fieldName)
+	buf.WriteString(" == nil {\n")
+	buf.WriteString("\t\t\tdest.")
 	buf.WriteString(fieldName)
 	buf.WriteString(" = &")
 	buf.WriteString(goTypeName)
 	buf.WriteString("{}\n")
+	buf.WriteString("\t\t}\n")
 
 	// Decode into the allocated struct
 	buf.WriteString("\t\terr = ")
@@ -867,21 +1055,21 @@ func generateNamedTypeDecodeForOptional(buf *strings.Builder, typeName, fieldNam
 	buf.WriteString(fieldName)
 	buf.WriteString(", data, offset, ctx)\n")
 	buf.WriteString("\t\tif err != nil {\n")
-	buf.WriteString("\t\t\treturn err\n")
+	buf.fail("\t\t\t", "err", "*offset")
 	buf.WriteString("\t\t}\n")
 
 	return nil
 }
 
 // generateArrayDecodeForOptional generates decode code for optional array fields.
-func generateArrayDecodeForOptional(buf *strings.Builder, typeExpr *parser.TypeExpr, fieldName string) error {
+func generateArrayDecodeForOptional(buf decodeCode, typeExpr *parser.TypeExpr, fieldName string) error {
 	if typeExpr.Elem == nil {
 		return fmt.Errorf("array type missing element")
 	}
 
 	// Read array count
 	buf.WriteString("\t\tif *offset + 4 > len(data) {\n")
-	buf.WriteString("\t\t\treturn ErrUnexpectedEOF\n")
+	buf.fail("\t\t\t", "ErrUnexpectedEOF", "*offset")
 	buf.WriteString("\t\t}\n")
 	buf.WriteString("\t\tcount := binary.LittleEndian.Uint32(data[*offset:])\n")
 	buf.WriteString("\t\t*offset += 4\n\n")
@@ -901,9 +1089,10 @@ func generateArrayDecodeForOptional(buf *strings.Builder, typeExpr *parser.TypeE
 	buf.WriteString("\t\t\tfor i := uint32(0); i < count; i++ {\n")
 
 	// Generate element decode code
-	tempBuf := &strings.Builder{}
+	tempBuf := decodeCode{Builder: &strings.Builder{}, field: buf.field, arena: buf.arena}
 	switch typeExpr.Elem.Kind {
 	case parser.TypeKindPrimitive:
+		tempBuf.element = true
 		err = generateArrayPrimitiveElementDecodeForOptional(tempBuf, typeExpr.Elem.Name)
 	case parser.TypeKindNamed:
 		err = generateArrayNamedTypeElementDecodeForOptional(tempBuf, typeExpr.Elem.Name)
@@ -935,15 +1124,16 @@ func generateArrayDecodeForOptional(buf *strings.Builder, typeExpr *parser.TypeE
 }
 
 // Helper functions for array element decode in optional context
-func generateArrayPrimitiveElementDecodeForOptional(buf *strings.Builder, primitiveType string) error {
+func generateArrayPrimitiveElementDecodeForOptional(buf decodeCode, primitiveType string) error {
 	// Similar to non-optional but uses "slice[i]" instead of "dest.FieldName[i]"
 	switch primitiveType {
 	case "u8", "i8", "bool":
 		size := 1
 		buf.WriteString(fmt.Sprintf("\t\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t\t}\n")
 		if primitiveType == "bool" {
+			generateStrictBoolCheck(buf, "\t\t\t")
 			buf.WriteString("\t\t\tslice[i] = data[*offset] != 0\n")
 		} else {
 			goType := primitiveTypeMap[primitiveType]
@@ -954,7 +1144,7 @@ func generateArrayPrimitiveElementDecodeForOptional(buf *strings.Builder, primit
 	case "u16", "i16":
 		size := 2
 		buf.WriteString(fmt.Sprintf("\t\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t\t}\n")
 		goType := primitiveTypeMap[primitiveType]
 		buf.WriteString(fmt.Sprintf("\t\t\tslice[i] = %s(binary.LittleEndian.Uint16(data[*offset:]))\n", goType))
@@ -963,9 +1153,10 @@ func generateArrayPrimitiveElementDecodeForOptional(buf *strings.Builder, primit
 	case "u32", "i32", "f32":
 		size := 4
 		buf.WriteString(fmt.Sprintf("\t\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t\t}\n")
 		if primitiveType == "f32" {
+			generateStrictFloatCheck(buf, "f32", "\t\t\t")
 			buf.WriteString("\t\t\tslice[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))\n")
 		} else {
 			goType := primitiveTypeMap[primitiveType]
@@ -976,9 +1167,10 @@ func generateArrayPrimitiveElementDecodeForOptional(buf *strings.Builder, primit
 	case "u64", "i64", "f64":
 		size := 8
 		buf.WriteString(fmt.Sprintf("\t\t\tif *offset + %d > len(data) {\n", size))
-		buf.WriteString("\t\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t\t}\n")
 		if primitiveType == "f64" {
+			generateStrictFloatCheck(buf, "f64", "\t\t\t")
 			buf.WriteString("\t\t\tslice[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[*offset:]))\n")
 		} else {
 			goType := primitiveTypeMap[primitiveType]
@@ -988,14 +1180,15 @@ func generateArrayPrimitiveElementDecodeForOptional(buf *strings.Builder, primit
 
 	case "str":
 		buf.WriteString("\t\t\tif *offset + 4 > len(data) {\n")
-		buf.WriteString("\t\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t\t}\n")
 		buf.WriteString("\t\t\tstrLen := binary.LittleEndian.Uint32(data[*offset:])\n")
 		buf.WriteString("\t\t\t*offset += 4\n\n")
 
 		buf.WriteString("\t\t\tif *offset + int(strLen) > len(data) {\n")
-		buf.WriteString("\t\t\t\treturn ErrUnexpectedEOF\n")
+		buf.fail("\t\t\t\t", "ErrUnexpectedEOF", "*offset")
 		buf.WriteString("\t\t\t}\n")
+		generateUTF8Check(buf, "\t\t\t")
 		buf.WriteString("\t\t\tslice[i] = string(data[*offset : *offset + int(strLen)])\n")
 		buf.WriteString("\t\t\t*offset += int(strLen)\n")
 
@@ -1006,7 +1199,7 @@ func generateArrayPrimitiveElementDecodeForOptional(buf *strings.Builder, primit
 	return nil
 }
 
-func generateArrayNamedTypeElementDecodeForOptional(buf *strings.Builder, typeName string) error {
+func generateArrayNamedTypeElementDecodeForOptional(buf decodeCode, typeName string) error {
 	goTypeName := ToGoName(typeName)
 	helperName := "decode" + goTypeName
 
@@ -1014,7 +1207,7 @@ func generateArrayNamedTypeElementDecodeForOptional(buf *strings.Builder, typeNa
 	buf.WriteString(helperName)
 	buf.WriteString("(&slice[i], data, offset, ctx)\n")
 	buf.WriteString("\t\t\tif err != nil {\n")
-	buf.WriteString("\t\t\t\treturn err\n")
+	buf.fail("\t\t\t\t", "decodeElementError(err, i, *offset)", "*offset")
 	buf.WriteString("\t\t\t}\n")
 
 	return nil
@@ -274,10 +274,164 @@ func TestGenerateDecoderComplexSchema(t *testing.T) {
 		}
 	}
 
-	// Count functions (should be 3)
+	// Count functions (should be 9: DecodeX, DecodeXStrict and DecodeXReuse per struct)
 	funcCount := strings.Count(result, "func Decode")
-	if funcCount != 3 {
-		t.Errorf("expected 3 decoder functions, found %d", funcCount)
+	if funcCount != 9 {
+		t.Errorf("expected 9 decoder functions, found %d", funcCount)
+	}
+}
+
+// TestGenerateDecoderStrict verifies the strict entry point
+func TestGenerateDecoderStrict(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Flag",
+				Fields: []parser.Field{
+					{Name: "on", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "bool"}},
+				},
+			},
+		},
+	}
+
+	result, err := GenerateDecoder(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"func DecodeFlagStrict(dest *Flag, data []byte) error {",
+		"ctx := &DecodeContext{strict: true}",
+		"if err := decodeFlag(dest, data, &offset, ctx); err != nil {",
+		"if offset != len(data) {",
+		"return ErrTrailingBytes",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q\ngot:\n%s", want, result)
+		}
+	}
+}
+
+// TestGenerateDecoderReuse verifies the reuse entry point and the reuse
+// branches in the helpers for slices, strings and optional structs
+func TestGenerateDecoderReuse(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Item",
+				Fields: []parser.Field{
+					{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+				},
+			},
+			{
+				Name: "Frame",
+				Fields: []parser.Field{
+					{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+					{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}}},
+					{Name: "tags", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}}},
+					{Name: "items", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Item"}}},
+					{Name: "main", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Item", Optional: true}},
+				},
+			},
+		},
+	}
+
+	entry, err := GenerateDecoder(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"func DecodeFrameReuse(dest *Frame, data []byte) error {",
+		"ctx := &DecodeContext{reuse: true}",
+		"return decodeFrame(dest, data, &offset, ctx)",
+	} {
+		if !strings.Contains(entry, want) {
+			t.Errorf("missing %q\ngot:\n%s", want, entry)
+		}
+	}
+
+	helpers, err := GenerateDecodeHelpers(schema, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"\tif !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {",
+		"\tif ctx.reuse && cap(dest.Samples) >= int(arrCount) {\n\t\tdest.Samples = dest.Samples[:arrCount]\n\t} else {\n\t\tdest.Samples = make([]float32, arrCount)\n\t}",
+		"\tif !ctx.reuse || dest.Main == nil {\n\t\t\t\tdest.Main = &Item{}\n\t\t\t}",
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("missing %q\ngot:\n%s", want, helpers)
+		}
+	}
+	// The decoder has no arena code unless arena decoding is generated
+	if strings.Contains(helpers, "arena") {
+		t.Errorf("arena code generated without arena decoding:\n%s", helpers)
+	}
+
+	helpers, err = GenerateDecodeHelpers(schema, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"} else if !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {",
+		"} else if !ctx.reuse || dest.Tags[i] != string(data[*offset:*offset+int(strLen)]) {",
+		"} else if ctx.reuse && cap(dest.Samples) >= int(arrCount) {\n\t\tdest.Samples = dest.Samples[:arrCount]\n\t} else {\n\t\tdest.Samples = make([]float32, arrCount)\n\t}",
+		"} else if ctx.reuse && cap(dest.Items) >= int(arrCount) {",
+		// Arena mode takes precedence over reuse
+		"dest.Name = ctx.arena.string(data[*offset:*offset+int(strLen)])",
+		"dest.Samples = arenaPrimitives[float32](ctx.arena, arrCount)",
+		"dest.Tags = arenaTake(&ctx.arena.strs, arrCount)",
+		"dest.Items = arenaTake(&ctx.arena.Item, arrCount)",
+		"dest.Main = &arenaTake(&ctx.arena.Item, 1)[0]",
+		"} else if !ctx.reuse || dest.Main == nil {\n\t\t\t\tdest.Main = &Item{}\n\t\t\t}",
+	} {
+		if !strings.Contains(helpers, want) {
+			t.Errorf("missing %q\ngot:\n%s", want, helpers)
+		}
+	}
+}
+
+// TestGenerateDecodeHelpersStrictChecks verifies non-canonical values are
+// rejected in strict mode for scalars, array elements and optionals
+func TestGenerateDecodeHelpersStrictChecks(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Values",
+				Fields: []parser.Field{
+					{Name: "flag", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "bool"}},
+					{Name: "ratio", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}},
+					{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f64"}}},
+					{Name: "maybe", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "bool", Optional: true}},
+				},
+			},
+		},
+	}
+
+	result, err := GenerateDecodeHelpers(schema, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"if ctx.strict && data[*offset] > 1 {",
+		"if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {",
+		"if ctx.strict && !isCanonicalF64(binary.LittleEndian.Uint64(data[*offset:])) {",
+		`return decodeError(ErrNonCanonical, "flag", *offset)`,
+		`return decodeError(ErrNonCanonical, "ratio", *offset)`,
+		`return decodeError(decodeElementError(ErrNonCanonical, i, *offset), "samples", *offset)`,
+		`return decodeError(ErrNonCanonical, "maybe", *offset)`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+
+	// One bool check for flag and one for maybe
+	if got := strings.Count(result, "if ctx.strict && data[*offset] > 1 {"); got != 2 {
+		t.Errorf("expected 2 strict bool checks, got %d", got)
 	}
 }
 
@@ -365,7 +519,7 @@ func TestGenerateDecodeHelpersSimple(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -390,8 +544,8 @@ func TestGenerateDecodeHelpersSimple(t *testing.T) {
 		t.Errorf("missing bounds check, got:\n%s", result)
 	}
 
-	// Check EOF error
-	if !strings.Contains(result, "return ErrUnexpectedEOF") {
+	// Check EOF error (annotated with field name and offset)
+	if !strings.Contains(result, `return decodeError(ErrUnexpectedEOF, "id", *offset)`) {
 		t.Errorf("missing EOF error return, got:\n%s", result)
 	}
 
@@ -434,7 +588,7 @@ func TestGenerateDecodeHelpersAllPrimitives(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -519,7 +673,7 @@ func TestGenerateDecodeHelpersMultipleFields(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -568,7 +722,7 @@ func TestGenerateDecodeHelpersMultipleStructs(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -611,7 +765,7 @@ func TestGenerateDecodeHelpersBoundsChecks(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -623,7 +777,7 @@ func TestGenerateDecodeHelpersBoundsChecks(t *testing.T) {
 	}
 
 	// Count EOF errors (should be 3, one per field)
-	eofErrorCount := strings.Count(result, "return ErrUnexpectedEOF")
+	eofErrorCount := strings.Count(result, "return decodeError(ErrUnexpectedEOF")
 	if eofErrorCount != 3 {
 		t.Errorf("expected 3 EOF errors, found %d", eofErrorCount)
 	}
@@ -631,7 +785,7 @@ func TestGenerateDecodeHelpersBoundsChecks(t *testing.T) {
 
 // TestGenerateDecodeHelpersNilSchema verifies error handling
 func TestGenerateDecodeHelpersNilSchema(t *testing.T) {
-	result, err := GenerateDecodeHelpers(nil)
+	result, err := GenerateDecodeHelpers(nil, false)
 
 	if err == nil {
 		t.Errorf("expected error for nil schema, got result: %s", result)
@@ -647,7 +801,7 @@ func TestGenerateDecodeHelpersEmptySchema(t *testing.T) {
 		Structs: []parser.Struct{},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 
 	if err == nil {
 		t.Errorf("expected error for empty schema, got result: %s", result)
@@ -671,7 +825,7 @@ func TestGenerateDecodeHelpersNameConversion(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -703,7 +857,7 @@ func TestGenerateDecodeHelpersWithString(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -758,7 +912,7 @@ func TestGenerateDecodeHelpersWithMultipleStrings(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -805,7 +959,7 @@ func TestGenerateDecodeHelpersMixedTypes(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -858,7 +1012,7 @@ func TestGenerateDecodeHelpersStringBoundsChecks(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -870,10 +1024,15 @@ func TestGenerateDecodeHelpersStringBoundsChecks(t *testing.T) {
 	}
 
 	// Should have 2 EOF errors
-	eofErrorCount := strings.Count(result, "return ErrUnexpectedEOF")
+	eofErrorCount := strings.Count(result, "return decodeError(ErrUnexpectedEOF")
 	if eofErrorCount != 2 {
 		t.Errorf("expected 2 EOF errors for string field, found %d", eofErrorCount)
 	}
+
+	// Strict decoding should reject invalid UTF-8 before advancing past the bytes
+	if !strings.Contains(result, "if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {\n\t\treturn decodeError(ErrInvalidUTF8, \"text\", *offset)") {
+		t.Errorf("missing UTF-8 check")
+	}
 }
 
 // TestGenerateDecodeHelpersAllTypesIncludingString verifies complete type coverage
@@ -900,7 +1059,7 @@ func TestGenerateDecodeHelpersAllTypesIncludingString(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -952,7 +1111,7 @@ func TestGenerateDecodeHelpersWithPrimitiveArray(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1015,7 +1174,7 @@ func TestGenerateDecodeHelpersWithStringArray(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1055,7 +1214,7 @@ func TestGenerateDecodeHelpersWithMultiplePrimitiveArrays(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1103,7 +1262,7 @@ func TestGenerateDecodeHelpersMixedFieldsWithArrays(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1141,7 +1300,7 @@ func TestGenerateDecodeHelpersArrayCheckCount(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1174,7 +1333,7 @@ func TestGenerateDecodeHelpersArrayLoopStructure(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1226,7 +1385,7 @@ func TestGenerateDecodeHelpersAllArrayPrimitiveTypes(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1289,7 +1448,7 @@ func TestGenerateDecodeHelpersWithNamedType(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1304,8 +1463,8 @@ func TestGenerateDecodeHelpersWithNamedType(t *testing.T) {
 		t.Errorf("missing helper function call, got:\n%s", result)
 	}
 
-	// Check error handling
-	if !strings.Contains(result, "if err != nil") || !strings.Contains(result, "return err") {
+	// Check error handling (nested errors get the field name prepended)
+	if !strings.Contains(result, "if err != nil") || !strings.Contains(result, `return decodeError(err, "item", *offset)`) {
 		t.Errorf("missing error handling")
 	}
 }
@@ -1324,7 +1483,7 @@ func TestGenerateDecodeHelpersWithMultipleNamedTypes(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1361,7 +1520,7 @@ func TestGenerateDecodeHelpersMixedWithNamedTypes(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1404,7 +1563,7 @@ func TestGenerateDecodeHelpersNamedTypeSnakeCase(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1439,7 +1598,7 @@ func TestGenerateDecodeHelpersWithNamedTypeArray(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1464,9 +1623,9 @@ func TestGenerateDecodeHelpersWithNamedTypeArray(t *testing.T) {
 		t.Errorf("missing element decode helper call, got:\n%s", result)
 	}
 
-	// Check error return in loop
-	if !strings.Contains(result, "return err") {
-		t.Errorf("missing error return")
+	// Check error return in loop (element index, then field name)
+	if !strings.Contains(result, `return decodeError(decodeElementError(err, i, *offset), "items", *offset)`) {
+		t.Errorf("missing element error return")
 	}
 }
 
@@ -1492,7 +1651,7 @@ func TestGenerateDecodeHelpersComplexNesting(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1538,7 +1697,7 @@ func TestGenerateDecodeHelpersNamedTypePassesContext(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -1568,7 +1727,7 @@ func TestGenerateDecodeHelpersArrayNamedTypePassesContext(t *testing.T) {
 		},
 	}
 
-	result, err := GenerateDecodeHelpers(schema)
+	result, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -0,0 +1,118 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateDriver generates main.go, a command line program used by the
+// conformance harness to exercise the generated package at importPath:
+//
+//	driver encode <Struct>   JSON values in, EncodeX bytes out
+//	driver decode <Struct>   encoded bytes in, decoded and re-encoded bytes out
+//
+// Decoding uses DecodeXStrict and checks that DecodeX accepts the same input
+// and produces an equal value.
+//
+// Inputs are read from stdin as frames (u32 little-endian length, payload).
+// For each input one output frame is written to stdout: a status byte (0 ok,
+// 1 error), a u32 length and the bytes or the error message.
+func GenerateDriver(schema *parser.Schema, importPath string) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, `// Conformance test driver
+// Generated by the SDP conformance harness - DO NOT EDIT
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	gen %q
+)
+
+// decode decodes input with strict and checks that loose agrees with it.
+func decode[T any](dest *T, input []byte, strict, loose func(*T, []byte) error, equal func(*T, *T) bool) error {
+	if err := strict(dest, input); err != nil {
+		return err
+	}
+	var other T
+	if err := loose(&other, input); err != nil {
+		return fmt.Errorf("non-strict decode: %%w", err)
+	}
+	if !equal(dest, &other) {
+		return errors.New("strict and non-strict decode disagree")
+	}
+	return nil
+}
+
+func run(encode bool, typeName string, input []byte) ([]byte, error) {
+	switch typeName {
+`, importPath)
+
+	for _, s := range schema.Structs {
+		name := ToGoName(s.Name)
+		fmt.Fprintf(&b, `	case %q:
+		var v gen.%s
+		var err error
+		if encode {
+			err = json.Unmarshal(input, &v)
+		} else {
+			err = decode(&v, input, gen.Decode%sStrict, gen.Decode%s, (*gen.%s).Equal)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return gen.Encode%s(&v)
+`, s.Name, name, name, name, name, name)
+	}
+
+	b.WriteString(`	}
+	return nil, fmt.Errorf("unknown struct %s", typeName)
+}
+
+func main() {
+	if len(os.Args) != 3 || (os.Args[1] != "encode" && os.Args[1] != "decode") {
+		fmt.Fprintf(os.Stderr, "usage: %s encode|decode <Struct>\n", os.Args[0])
+		os.Exit(2)
+	}
+	encode := os.Args[1] == "encode"
+
+	in := bufio.NewReader(os.Stdin)
+	out := bufio.NewWriter(os.Stdout)
+	for {
+		var header [4]byte
+		if _, err := io.ReadFull(in, header[:]); err != nil {
+			break
+		}
+		input := make([]byte, binary.LittleEndian.Uint32(header[:]))
+		if _, err := io.ReadFull(in, input); err != nil {
+			break
+		}
+
+		data, err := run(encode, os.Args[2], input)
+		status := byte(0)
+		if err != nil {
+			status, data = 1, []byte(err.Error())
+		}
+		out.WriteByte(status)
+		out.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(data))))
+		out.Write(data)
+	}
+	if err := out.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`)
+
+	return b.String()
+}
@@ -0,0 +1,35 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateDriver(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "AudioDevice",
+				Fields: []parser.Field{
+					{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+				},
+			},
+		},
+	}
+
+	code := GenerateDriver(schema, "example.com/driver/gen")
+	for _, want := range []string{
+		"package main\n",
+		"\tgen \"example.com/driver/gen\"\n",
+		"\tcase \"AudioDevice\":\n\t\tvar v gen.AudioDevice\n",
+		"\t\t\terr = json.Unmarshal(input, &v)\n",
+		"\t\t\terr = decode(&v, input, gen.DecodeAudioDeviceStrict, gen.DecodeAudioDevice, (*gen.AudioDevice).Equal)\n",
+		"\t\treturn gen.EncodeAudioDevice(&v)\n",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
@@ -13,6 +13,8 @@ import (
 // For each struct type, it generates:
 //   - calculateStructNameSize(src *StructName) int - Fast size calculation
 //   - EncodeStructName(src *StructName) ([]byte, error) - Public encoder
+//   - EncodedSize, MarshalBinary, AppendBinary and UnmarshalBinary methods,
+//     which implement sdp.Codec
 //
 // The encoder:
 //  1. Calculates exact buffer size needed (single pass, ~50ns overhead)
@@ -73,6 +75,11 @@ func GenerateEncoder(schema *parser.Schema) (string, error) {
 		if err := generateEncoderFunction(&buf, structName, encodeFunc, sizeFunc, helperFunc); err != nil {
 			return "", err
 		}
+
+		buf.WriteString("\n")
+
+		// Standard library interfaces
+		generateBinaryMethods(&buf, structName, sizeFunc, helperFunc)
 	}
 
 	return buf.String(), nil
@@ -344,6 +351,74 @@ func generateEncoderFunction(buf *strings.Builder, structName, funcName, sizeFun
 	return nil
 }
 
+// generateBinaryMethods generates the encoding.BinaryMarshaler,
+// encoding.BinaryAppender and encoding.BinaryUnmarshaler implementations,
+// which delegate to the byte-mode encoder and decoder. Together with
+// EncodedSize they implement the sdp.Codec runtime interface.
+func generateBinaryMethods(buf *strings.Builder, structName, sizeFunc, helperFunc string) {
+	// EncodedSize
+	buf.WriteString("// EncodedSize returns the number of bytes Encode")
+	buf.WriteString(structName)
+	buf.WriteString(" produces for src.\n")
+	buf.WriteString("func (src *")
+	buf.WriteString(structName)
+	buf.WriteString(") EncodedSize() int {\n")
+	buf.WriteString("\treturn ")
+	buf.WriteString(sizeFunc)
+	buf.WriteString("(src)\n")
+	buf.WriteString("}\n\n")
+
+	// MarshalBinary
+	buf.WriteString("// MarshalBinary implements encoding.BinaryMarshaler using Encode")
+	buf.WriteString(structName)
+	buf.WriteString(".\n")
+	buf.WriteString("func (src *")
+	buf.WriteString(structName)
+	buf.WriteString(") MarshalBinary() ([]byte, error) {\n")
+	buf.WriteString("\treturn Encode")
+	buf.WriteString(structName)
+	buf.WriteString("(src)\n")
+	buf.WriteString("}\n\n")
+
+	// AppendBinary
+	buf.WriteString("// AppendBinary implements encoding.BinaryAppender. It appends the encoding\n")
+	buf.WriteString("// to b, growing it at most once, and returns the extended slice.\n")
+	buf.WriteString("func (src *")
+	buf.WriteString(structName)
+	buf.WriteString(") AppendBinary(b []byte) ([]byte, error) {\n")
+	buf.WriteString("\tsize := ")
+	buf.WriteString(sizeFunc)
+	buf.WriteString("(src)\n")
+	buf.WriteString("\tstart := len(b)\n")
+	buf.WriteString("\tif cap(b)-start < size {\n")
+	buf.WriteString("\t\tgrown := make([]byte, start, start+size)\n")
+	buf.WriteString("\t\tcopy(grown, b)\n")
+	buf.WriteString("\t\tb = grown\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tb = b[:start+size]\n")
+	buf.WriteString("\toffset := start\n")
+	buf.WriteString("\tif err := ")
+	buf.WriteString(helperFunc)
+	buf.WriteString("(src, b, &offset); err != nil {\n")
+	buf.WriteString("\t\treturn b[:start], err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn b, nil\n")
+	buf.WriteString("}\n\n")
+
+	// UnmarshalBinary
+	buf.WriteString("// UnmarshalBinary implements encoding.BinaryUnmarshaler using Decode")
+	buf.WriteString(structName)
+	buf.WriteString(".\n")
+	buf.WriteString("// The decoded value does not retain data.\n")
+	buf.WriteString("func (dest *")
+	buf.WriteString(structName)
+	buf.WriteString(") UnmarshalBinary(data []byte) error {\n")
+	buf.WriteString("\treturn Decode")
+	buf.WriteString(structName)
+	buf.WriteString("(dest, data)\n")
+	buf.WriteString("}\n")
+}
+
 // GenerateEncodeHelpers generates helper encode functions for each struct in the schema.
 // These functions perform the actual buffer writes using direct memory operations.
 //
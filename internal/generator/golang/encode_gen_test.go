@@ -51,6 +51,39 @@ func TestGenerateEncoderSimple(t *testing.T) {
 	}
 }
 
+// TestGenerateEncoderBinaryMethods verifies the encoding.Binary* methods
+func TestGenerateEncoderBinaryMethods(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Device",
+				Fields: []parser.Field{
+					{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+				},
+			},
+		},
+	}
+
+	result, err := GenerateEncoder(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"func (src *Device) EncodedSize() int {\n\treturn calculateDeviceSize(src)\n}",
+		"func (src *Device) MarshalBinary() ([]byte, error) {\n\treturn EncodeDevice(src)\n}",
+		"func (src *Device) AppendBinary(b []byte) ([]byte, error) {",
+		"b = b[:start+size]",
+		"if err := encodeDevice(src, b, &offset); err != nil {",
+		"func (dest *Device) UnmarshalBinary(data []byte) error {\n\treturn DecodeDevice(dest, data)\n}",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q\ngot:\n%s", want, result)
+		}
+	}
+}
+
 // TestGenerateEncoderMultipleStructs verifies multiple struct encoding
 func TestGenerateEncoderMultipleStructs(t *testing.T) {
 	schema := &parser.Schema{
@@ -0,0 +1,340 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateEqual generates comparison and copy methods with wire semantics.
+//
+// For each struct type, it generates:
+//   - (x *StructName) Equal(other *StructName) bool
+//   - (x *StructName) Clone() *StructName
+//   - (x *StructName) Diff(other *StructName) []FieldChange
+//
+// And once per schema the FieldChange type returned by Diff.
+//
+// Equal reports whether two values encode to the same bytes: nil and empty
+// arrays are equal (both encode as count 0) and floats are compared by bit
+// pattern, so NaN equals an identical NaN and 0.0 differs from -0.0. Diff
+// uses the same comparison and reports differences by schema field path.
+// C++ operator== and Rust PartialEq compare floats the same way.
+func GenerateEqual(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+
+	generateFieldChange(&buf)
+	buf.WriteString("\n")
+
+	for _, s := range schema.Structs {
+		if err := generateEqualMethod(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+		if err := generateCloneMethods(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+		if err := generateDiffMethods(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.String(), nil
+}
+
+// generateFieldChange generates the FieldChange type and the path helper
+// shared by all Diff methods.
+func generateFieldChange(buf *strings.Builder) {
+	buf.WriteString("// FieldChange is one field-level difference reported by Diff.\n")
+	buf.WriteString("type FieldChange struct {\n")
+	buf.WriteString("\t// Path is the schema path of the field, e.g. \"plugins[2].name\".\n")
+	buf.WriteString("\tPath string\n")
+	buf.WriteString("\t// Kind is \"value\" if a primitive field differs, \"length\" if an array\n")
+	buf.WriteString("\t// has a different number of elements and \"presence\" if an optional\n")
+	buf.WriteString("\t// is set on one side only.\n")
+	buf.WriteString("\tKind string\n")
+	buf.WriteString("\t// Old and New are the field values for \"value\", the element counts\n")
+	buf.WriteString("\t// (int) for \"length\" and the presence flags (bool) for \"presence\".\n")
+	buf.WriteString("\tOld any\n")
+	buf.WriteString("\tNew any\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// diffPath joins a field name to the path of its struct.\n")
+	buf.WriteString("func diffPath(prefix, name string) string {\n")
+	buf.WriteString("\tif prefix == \"\" {\n")
+	buf.WriteString("\t\treturn name\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn prefix + \".\" + name\n")
+	buf.WriteString("}\n")
+}
+
+// generateEqualMethod generates the Equal method of a struct.
+func generateEqualMethod(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// Equal reports whether x and other encode to the same bytes. Nil and empty\n")
+	buf.WriteString("// arrays are equal and floats are compared by bit pattern.\n")
+	buf.WriteString("func (x *")
+	buf.WriteString(structName)
+	buf.WriteString(") Equal(other *")
+	buf.WriteString(structName)
+	buf.WriteString(") bool {\n")
+	buf.WriteString("\tif x == nil || other == nil {\n")
+	buf.WriteString("\t\treturn x == other\n")
+	buf.WriteString("\t}\n")
+
+	for _, field := range s.Fields {
+		fieldName := ToGoName(field.Name)
+		if err := generateEqualCheck(buf, &field.Type, "x."+fieldName, "other."+fieldName, "\t", 0); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+
+	buf.WriteString("\treturn true\n")
+	buf.WriteString("}\n")
+	return nil
+}
+
+// generateEqualCheck generates code that returns false if the values a and b
+// of the given type differ.
+func generateEqualCheck(buf *strings.Builder, typeExpr *parser.TypeExpr, a, b, indent string, depth int) error {
+	switch typeExpr.Kind {
+	case parser.TypeKindPrimitive:
+		buf.WriteString(indent + "if " + primitiveNotEqual(typeExpr.Name, a, b) + " {\n")
+		buf.WriteString(indent + "\treturn false\n")
+		buf.WriteString(indent + "}\n")
+
+	case parser.TypeKindNamed:
+		// Optionals are pointers already; Equal handles nil
+		if !typeExpr.Optional {
+			b = "&" + b
+		}
+		buf.WriteString(indent + "if !" + a + ".Equal(" + b + ") {\n")
+		buf.WriteString(indent + "\treturn false\n")
+		buf.WriteString(indent + "}\n")
+
+	case parser.TypeKindArray:
+		if typeExpr.Elem == nil {
+			return fmt.Errorf("array type has no element type")
+		}
+		i := loopVar(depth)
+		buf.WriteString(indent + "if len(" + a + ") != len(" + b + ") {\n")
+		buf.WriteString(indent + "\treturn false\n")
+		buf.WriteString(indent + "}\n")
+		buf.WriteString(indent + "for " + i + " := range " + a + " {\n")
+		if err := generateEqualCheck(buf, typeExpr.Elem, a+"["+i+"]", b+"["+i+"]", indent+"\t", depth+1); err != nil {
+			return err
+		}
+		buf.WriteString(indent + "}\n")
+
+	default:
+		return fmt.Errorf("unknown type kind: %v", typeExpr.Kind)
+	}
+	return nil
+}
+
+// primitiveNotEqual returns a condition that is true if two primitive values
+// encode differently.
+func primitiveNotEqual(typeName, a, b string) string {
+	switch typeName {
+	case "f32":
+		return "math.Float32bits(" + a + ") != math.Float32bits(" + b + ")"
+	case "f64":
+		return "math.Float64bits(" + a + ") != math.Float64bits(" + b + ")"
+	default:
+		return a + " != " + b
+	}
+}
+
+// loopVar returns the index variable for an array nested depth levels deep.
+func loopVar(depth int) string {
+	if depth == 0 {
+		return "i"
+	}
+	return fmt.Sprintf("i%d", depth)
+}
+
+// generateCloneMethods generates the Clone method and the cloneTo helper of
+// a struct.
+func generateCloneMethods(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// Clone returns a deep copy of x. Nil arrays stay nil.\n")
+	buf.WriteString("func (x *")
+	buf.WriteString(structName)
+	buf.WriteString(") Clone() *")
+	buf.WriteString(structName)
+	buf.WriteString(" {\n")
+	buf.WriteString("\tif x == nil {\n")
+	buf.WriteString("\t\treturn nil\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tc := new(")
+	buf.WriteString(structName)
+	buf.WriteString(")\n")
+	buf.WriteString("\tx.cloneTo(c)\n")
+	buf.WriteString("\treturn c\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// cloneTo deep-copies x into c.\n")
+	buf.WriteString("func (x *")
+	buf.WriteString(structName)
+	buf.WriteString(") cloneTo(c *")
+	buf.WriteString(structName)
+	buf.WriteString(") {\n")
+	buf.WriteString("\t*c = *x\n")
+
+	for _, field := range s.Fields {
+		fieldName := ToGoName(field.Name)
+		if err := generateCloneField(buf, &field.Type, "x."+fieldName, "c."+fieldName, "\t", 0); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+
+	buf.WriteString("}\n")
+	return nil
+}
+
+// generateCloneField generates code that replaces the shallow copy dst of
+// src by a deep copy. Primitives need no code.
+func generateCloneField(buf *strings.Builder, typeExpr *parser.TypeExpr, src, dst, indent string, depth int) error {
+	switch typeExpr.Kind {
+	case parser.TypeKindPrimitive:
+		// Copied by value (strings are immutable)
+
+	case parser.TypeKindNamed:
+		if typeExpr.Optional {
+			buf.WriteString(indent + dst + " = " + src + ".Clone()\n")
+		} else {
+			buf.WriteString(indent + src + ".cloneTo(&" + dst + ")\n")
+		}
+
+	case parser.TypeKindArray:
+		if typeExpr.Elem == nil {
+			return fmt.Errorf("array type has no element type")
+		}
+		goType, err := mapFieldType(typeExpr)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(indent + "if " + src + " != nil {\n")
+		buf.WriteString(indent + "\t" + dst + " = make(" + goType + ", len(" + src + "))\n")
+		if typeExpr.Elem.Kind == parser.TypeKindPrimitive {
+			buf.WriteString(indent + "\tcopy(" + dst + ", " + src + ")\n")
+		} else {
+			i := loopVar(depth)
+			buf.WriteString(indent + "\tfor " + i + " := range " + src + " {\n")
+			if err := generateCloneField(buf, typeExpr.Elem, src+"["+i+"]", dst+"["+i+"]", indent+"\t\t", depth+1); err != nil {
+				return err
+			}
+			buf.WriteString(indent + "\t}\n")
+		}
+		buf.WriteString(indent + "}\n")
+
+	default:
+		return fmt.Errorf("unknown type kind: %v", typeExpr.Kind)
+	}
+	return nil
+}
+
+// generateDiffMethods generates the Diff method and the diff helper of a
+// struct.
+func generateDiffMethods(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// Diff returns the fields in which other differs from x, in schema order,\n")
+	buf.WriteString("// using the same comparison as Equal. Arrays of different lengths are\n")
+	buf.WriteString("// reported once and compared up to the shorter length. Diff returns nil\n")
+	buf.WriteString("// if x.Equal(other).\n")
+	buf.WriteString("func (x *")
+	buf.WriteString(structName)
+	buf.WriteString(") Diff(other *")
+	buf.WriteString(structName)
+	buf.WriteString(") []FieldChange {\n")
+	buf.WriteString("\tif x == nil || other == nil {\n")
+	buf.WriteString("\t\tif x == other {\n")
+	buf.WriteString("\t\t\treturn nil\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\treturn []FieldChange{{Kind: \"presence\", Old: x != nil, New: other != nil}}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tvar changes []FieldChange\n")
+	buf.WriteString("\tx.diff(\"\", other, &changes)\n")
+	buf.WriteString("\treturn changes\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// diff appends the differences between x and other to changes.\n")
+	buf.WriteString("func (x *")
+	buf.WriteString(structName)
+	buf.WriteString(") diff(prefix string, other *")
+	buf.WriteString(structName)
+	buf.WriteString(", changes *[]FieldChange) {\n")
+
+	for _, field := range s.Fields {
+		fieldName := ToGoName(field.Name)
+		path := "diffPath(prefix, \"" + field.Name + "\")"
+		if err := generateDiffField(buf, &field.Type, "x."+fieldName, "other."+fieldName, path, "\t", 0); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+
+	buf.WriteString("}\n")
+	return nil
+}
+
+// generateDiffField generates code that appends the differences between the
+// values a and b of the given type, located at path, to changes.
+func generateDiffField(buf *strings.Builder, typeExpr *parser.TypeExpr, a, b, path, indent string, depth int) error {
+	switch typeExpr.Kind {
+	case parser.TypeKindPrimitive:
+		buf.WriteString(indent + "if " + primitiveNotEqual(typeExpr.Name, a, b) + " {\n")
+		buf.WriteString(indent + "\t*changes = append(*changes, FieldChange{Path: " + path + ", Kind: \"value\", Old: " + a + ", New: " + b + "})\n")
+		buf.WriteString(indent + "}\n")
+
+	case parser.TypeKindNamed:
+		if typeExpr.Optional {
+			buf.WriteString(indent + "if (" + a + " == nil) != (" + b + " == nil) {\n")
+			buf.WriteString(indent + "\t*changes = append(*changes, FieldChange{Path: " + path + ", Kind: \"presence\", Old: " + a + " != nil, New: " + b + " != nil})\n")
+			buf.WriteString(indent + "} else if " + a + " != nil {\n")
+			buf.WriteString(indent + "\t" + a + ".diff(" + path + ", " + b + ", changes)\n")
+			buf.WriteString(indent + "}\n")
+		} else {
+			buf.WriteString(indent + a + ".diff(" + path + ", &" + b + ", changes)\n")
+		}
+
+	case parser.TypeKindArray:
+		if typeExpr.Elem == nil {
+			return fmt.Errorf("array type has no element type")
+		}
+		i := loopVar(depth)
+		p := "path"
+		if depth > 0 {
+			p = fmt.Sprintf("path%d", depth)
+		}
+		buf.WriteString(indent + "{\n")
+		buf.WriteString(indent + "\t" + p + " := " + path + "\n")
+		buf.WriteString(indent + "\tif len(" + a + ") != len(" + b + ") {\n")
+		buf.WriteString(indent + "\t\t*changes = append(*changes, FieldChange{Path: " + p + ", Kind: \"length\", Old: len(" + a + "), New: len(" + b + ")})\n")
+		buf.WriteString(indent + "\t}\n")
+		buf.WriteString(indent + "\tfor " + i + " := 0; " + i + " < len(" + a + ") && " + i + " < len(" + b + "); " + i + "++ {\n")
+		elemPath := p + "+\"[\"+strconv.Itoa(" + i + ")+\"]\""
+		if err := generateDiffField(buf, typeExpr.Elem, a+"["+i+"]", b+"["+i+"]", elemPath, indent+"\t\t", depth+1); err != nil {
+			return err
+		}
+		buf.WriteString(indent + "\t}\n")
+		buf.WriteString(indent + "}\n")
+
+	default:
+		return fmt.Errorf("unknown type kind: %v", typeExpr.Kind)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateEqual(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "all field kinds",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Param",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+						},
+					},
+					{
+						Name: "Device",
+						Fields: []parser.Field{
+							{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+							{Name: "gain", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}},
+							{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f64"}}},
+							{Name: "params", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}}},
+							{Name: "first", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}},
+							{Name: "main", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param", Optional: true}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				if !strings.Contains(code, "type FieldChange struct {") {
+					t.Errorf("missing FieldChange type")
+				}
+				if strings.Count(code, "func diffPath(") != 1 {
+					t.Errorf("diffPath should be generated once per schema")
+				}
+
+				// Equal compares with wire semantics
+				if !strings.Contains(code, "func (x *Device) Equal(other *Device) bool {") {
+					t.Errorf("missing Equal method")
+				}
+				if !strings.Contains(code, "math.Float32bits(x.Gain) != math.Float32bits(other.Gain)") {
+					t.Errorf("floats should be compared by bit pattern")
+				}
+				if !strings.Contains(code, "math.Float64bits(x.Samples[i]) != math.Float64bits(other.Samples[i])") {
+					t.Errorf("float array elements should be compared by bit pattern")
+				}
+				if !strings.Contains(code, "if len(x.Samples) != len(other.Samples) {") {
+					t.Errorf("arrays should be compared by length, not nil-ness")
+				}
+				if !strings.Contains(code, "if !x.Params[i].Equal(&other.Params[i]) {") {
+					t.Errorf("struct elements should use Equal")
+				}
+				if !strings.Contains(code, "if !x.First.Equal(&other.First) {") {
+					t.Errorf("nested structs should use Equal")
+				}
+				if !strings.Contains(code, "if !x.Main.Equal(other.Main) {") {
+					t.Errorf("optionals should pass the pointer to Equal")
+				}
+
+				// Clone copies deeply and keeps nil arrays nil
+				if !strings.Contains(code, "func (x *Device) Clone() *Device {") {
+					t.Errorf("missing Clone method")
+				}
+				if !strings.Contains(code, "if x.Samples != nil {\n\t\tc.Samples = make([]float64, len(x.Samples))\n\t\tcopy(c.Samples, x.Samples)") {
+					t.Errorf("primitive arrays should be copied")
+				}
+				if !strings.Contains(code, "x.Params[i].cloneTo(&c.Params[i])") {
+					t.Errorf("struct elements should be cloned")
+				}
+				if !strings.Contains(code, "x.First.cloneTo(&c.First)") {
+					t.Errorf("nested structs should be cloned in place")
+				}
+				if !strings.Contains(code, "c.Main = x.Main.Clone()") {
+					t.Errorf("optionals should be cloned")
+				}
+
+				// Diff reports schema paths
+				if !strings.Contains(code, "func (x *Device) Diff(other *Device) []FieldChange {") {
+					t.Errorf("missing Diff method")
+				}
+				if !strings.Contains(code, `FieldChange{Path: diffPath(prefix, "gain"), Kind: "value", Old: x.Gain, New: other.Gain}`) {
+					t.Errorf("value changes should use the schema field name")
+				}
+				if !strings.Contains(code, `FieldChange{Path: path, Kind: "length", Old: len(x.Params), New: len(other.Params)}`) {
+					t.Errorf("missing array length change")
+				}
+				if !strings.Contains(code, `x.Params[i].diff(path+"["+strconv.Itoa(i)+"]", &other.Params[i], changes)`) {
+					t.Errorf("struct elements should be diffed with indexed paths")
+				}
+				if !strings.Contains(code, `Kind: "presence", Old: x.Main != nil, New: other.Main != nil`) {
+					t.Errorf("missing optional presence change")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateEqual(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateEqual() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package golang
 
 import (
+	"fmt"
 	"strings"
 )
 
@@ -30,7 +31,106 @@ func GenerateErrors() string {
 	buf.WriteString("\tErrInvalidMagic       = errors.New(\"invalid magic bytes (expected 'SDP')\")\n")
 	buf.WriteString("\tErrInvalidVersion     = errors.New(\"unsupported protocol version\")\n")
 	buf.WriteString("\tErrUnknownMessageType = errors.New(\"unknown message type ID\")\n")
-	buf.WriteString(")\n")
+	buf.WriteString("\tErrTrailingBytes      = errors.New(\"trailing bytes after value\")\n")
+	buf.WriteString("\tErrNonCanonical       = errors.New(\"non-canonical encoding\")\n")
+	buf.WriteString(")\n\n")
+
+	generateDecodeErrorType(&buf)
+
+	return buf.String()
+}
+
+// generateDecodeErrorType generates the DecodeError type that wraps the
+// sentinel errors with the failing field path and byte offset, plus the
+// helpers the decoders use to build it. The path is only assembled when
+// decoding fails, so the success path pays nothing for it.
+func generateDecodeErrorType(buf *strings.Builder) {
+	buf.WriteString("// DecodeError reports where in the payload decoding failed.\n")
+	buf.WriteString("// Path uses schema field names with array indices (e.g. \"plugins[12].parameters[3].unit\"),\n")
+	buf.WriteString("// Offset is the byte offset into the payload where the failing read started, and\n")
+	buf.WriteString("// Err is one of the sentinel errors above, so errors.Is(err, ErrUnexpectedEOF) still matches.\n")
+	buf.WriteString("type DecodeError struct {\n")
+	buf.WriteString("\tPath   string\n")
+	buf.WriteString("\tOffset int\n")
+	buf.WriteString("\tErr    error\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Error implements the error interface.\n")
+	buf.WriteString("func (e *DecodeError) Error() string {\n")
+	buf.WriteString("\treturn \"decode \" + e.Path + \" at offset \" + strconv.Itoa(e.Offset) + \": \" + e.Err.Error()\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Unwrap returns the underlying sentinel error for errors.Is.\n")
+	buf.WriteString("func (e *DecodeError) Unwrap() error {\n")
+	buf.WriteString("\treturn e.Err\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// decodeError annotates err with the field being decoded. Errors coming\n")
+	buf.WriteString("// from nested structs already carry a path and offset, so only the field\n")
+	buf.WriteString("// name is prepended to their path.\n")
+	buf.WriteString("func decodeError(err error, field string, offset int) error {\n")
+	buf.WriteString("\tif de, ok := err.(*DecodeError); ok {\n")
+	buf.WriteString("\t\tif de.Path == \"\" || de.Path[0] == '[' {\n")
+	buf.WriteString("\t\t\tde.Path = field + de.Path\n")
+	buf.WriteString("\t\t} else {\n")
+	buf.WriteString("\t\t\tde.Path = field + \".\" + de.Path\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\treturn de\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn &DecodeError{Path: field, Offset: offset, Err: err}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// decodeElementError annotates err with the index of the array element being decoded.\n")
+	buf.WriteString("func decodeElementError(err error, index uint32, offset int) error {\n")
+	buf.WriteString("\treturn decodeError(err, \"[\"+strconv.FormatUint(uint64(index), 10)+\"]\", offset)\n")
+	buf.WriteString("}\n")
+}
+
+// GenerateRuntimeErrors generates the error declarations for packages that
+// target the shared sdp runtime package. The message constants, sentinel
+// errors and DecodeError type are aliases of the ones in package sdp, so
+// errors.Is and errors.As work across all generated packages.
+func GenerateRuntimeErrors() string {
+	var buf strings.Builder
+
+	buf.WriteString("// Message mode constants for self-describing messages, shared with package sdp\n")
+	buf.WriteString("const (\n")
+	buf.WriteString("\tMessageMagic      = sdp.MessageMagic\n")
+	buf.WriteString("\tMessageVersion    = sdp.MessageVersion\n")
+	buf.WriteString("\tMessageHeaderSize = sdp.MessageHeaderSize\n")
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// Error variables for decode failures, shared with package sdp\n")
+	buf.WriteString("var (\n")
+	for _, name := range []string{
+		"ErrUnexpectedEOF",
+		"ErrInvalidUTF8",
+		"ErrDataTooLarge",
+		"ErrArrayTooLarge",
+		"ErrTooManyElements",
+		"ErrInvalidData",
+		"ErrInvalidMagic",
+		"ErrInvalidVersion",
+		"ErrUnknownMessageType",
+		"ErrTrailingBytes",
+		"ErrNonCanonical",
+	} {
+		buf.WriteString(fmt.Sprintf("\t%-21s = sdp.%s\n", name, name))
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString("// DecodeError reports where in the payload decoding failed (see sdp.DecodeError).\n")
+	buf.WriteString("type DecodeError = sdp.DecodeError\n\n")
+
+	buf.WriteString("// decodeError annotates err with the field being decoded.\n")
+	buf.WriteString("func decodeError(err error, field string, offset int) error {\n")
+	buf.WriteString("\treturn sdp.WrapDecodeError(err, field, offset)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// decodeElementError annotates err with the index of the array element being decoded.\n")
+	buf.WriteString("func decodeElementError(err error, index uint32, offset int) error {\n")
+	buf.WriteString("\treturn sdp.WrapElementError(err, index, offset)\n")
+	buf.WriteString("}\n")
 
 	return buf.String()
 }
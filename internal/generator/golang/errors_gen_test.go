@@ -108,8 +108,8 @@ func TestGenerateErrorsAlignment(t *testing.T) {
 		}
 	}
 
-	if len(errorLines) != 9 {
-		t.Fatalf("expected 9 error declaration lines, got %d", len(errorLines))
+	if len(errorLines) != 11 {
+		t.Fatalf("expected 11 error declaration lines, got %d", len(errorLines))
 	}
 
 	// Check that all '=' are at similar positions (allowing some variation for alignment)
@@ -171,10 +171,10 @@ func TestGenerateErrorsNoExtraContent(t *testing.T) {
 		t.Error("should not contain import statements")
 	}
 
-	// Should have exactly 9 error variable declarations (5 original + 1 optional + 3 message mode)
+	// Should have exactly 11 error variable declarations (5 original + 1 optional + 3 message mode + 2 strict mode)
 	errorCount := strings.Count(result, "errors.New(")
-	if errorCount != 9 {
-		t.Errorf("expected 9 errors.New() calls, got %d", errorCount)
+	if errorCount != 11 {
+		t.Errorf("expected 11 errors.New() calls, got %d", errorCount)
 	}
 }
 
@@ -197,3 +197,56 @@ func TestGenerateErrorsMatchesDesignSpec(t *testing.T) {
 		}
 	}
 }
+
+// TestGenerateErrorsDecodeErrorType verifies the DecodeError wrapper and its helpers
+func TestGenerateErrorsDecodeErrorType(t *testing.T) {
+	result := GenerateErrors()
+
+	expected := []string{
+		"type DecodeError struct {",
+		"\tPath   string\n",
+		"\tOffset int\n",
+		"\tErr    error\n",
+		"func (e *DecodeError) Error() string {",
+		"func (e *DecodeError) Unwrap() error {",
+		"func decodeError(err error, field string, offset int) error {",
+		"func decodeElementError(err error, index uint32, offset int) error {",
+	}
+
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+
+	// Nested paths must join with '.' except before an array index
+	if !strings.Contains(result, `de.Path = field + "." + de.Path`) {
+		t.Error("missing dotted path join for nested fields")
+	}
+	if !strings.Contains(result, "de.Path[0] == '['") {
+		t.Error("missing index-aware path join")
+	}
+}
+
+// TestGenerateRuntimeErrors verifies that runtime mode aliases package sdp
+func TestGenerateRuntimeErrors(t *testing.T) {
+	result := GenerateRuntimeErrors()
+
+	expected := []string{
+		"MessageMagic      = sdp.MessageMagic",
+		"ErrUnexpectedEOF      = sdp.ErrUnexpectedEOF",
+		"ErrNonCanonical       = sdp.ErrNonCanonical",
+		"type DecodeError = sdp.DecodeError",
+		"return sdp.WrapDecodeError(err, field, offset)",
+		"return sdp.WrapElementError(err, index, offset)",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+
+	if strings.Contains(result, "errors.New") {
+		t.Error("runtime errors should not declare their own sentinels")
+	}
+}
@@ -0,0 +1,110 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateFuzz(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "all field kinds",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Param",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+							{Name: "label", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+						},
+					},
+					{
+						Name: "Device",
+						Fields: []parser.Field{
+							{Name: "gain", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}},
+							{Name: "active", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "bool"}},
+							{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f64"}}},
+							{Name: "params", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}}},
+							{Name: "main", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param", Optional: true}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				if !strings.Contains(code, "func FuzzDecodeParam(f *testing.F) {") || !strings.Contains(code, "func FuzzDecodeDevice(f *testing.F) {") {
+					t.Errorf("missing fuzz target per struct")
+				}
+
+				// Seeds are the zero value and a populated sample
+				if !strings.Contains(code, "for _, seed := range []*Device{\n\t\t{},\n") {
+					t.Errorf("missing zero value seed")
+				}
+				if !strings.Contains(code, `{Gain: 1.5, Active: true, Samples: []float64{1.5}, Params: []Param{{Id: 1, Label: "sample"}}, Main: &Param{Id: 1, Label: "sample"}},`) {
+					t.Errorf("sample seed should fill every field")
+				}
+				if !strings.Contains(code, "data, err := EncodeDevice(seed)") || !strings.Contains(code, "f.Add(data)") {
+					t.Errorf("seeds should be added in encoded form")
+				}
+
+				// Accepted input must encode, canonical input must round-trip
+				if !strings.Contains(code, "if err := DecodeDevice(&x, data); err != nil {\n\t\t\treturn\n\t\t}") {
+					t.Errorf("rejected input should be skipped")
+				}
+				if !strings.Contains(code, "encoded, err := EncodeDevice(&x)") {
+					t.Errorf("decoded values should be encoded again")
+				}
+				if !strings.Contains(code, "if err := DecodeDeviceStrict(&strict, data); err != nil {") {
+					t.Errorf("round trip should be limited to canonical input")
+				}
+				if !strings.Contains(code, "if !bytes.Equal(encoded, data) {") {
+					t.Errorf("missing byte-for-byte comparison")
+				}
+			},
+		},
+		{
+			name: "unknown struct",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Device",
+						Fields: []parser.Field{
+							{Name: "main", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Missing"}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateFuzz(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateFuzz() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
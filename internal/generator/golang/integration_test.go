@@ -44,13 +44,13 @@ func TestIntegrationSimplePrimitives(t *testing.T) {
 		t.Fatalf("GenerateDecoder failed: %v", err)
 	}
 
-	decodeHelpers, err := GenerateDecodeHelpers(schema)
+	decodeHelpers, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeHelpers failed: %v", err)
 	}
 
 	errors := GenerateErrors()
-	context := GenerateDecodeContext()
+	context := GenerateDecodeContext(false)
 
 	// Verify all code was generated
 	if structs == "" {
@@ -128,7 +128,7 @@ func TestIntegrationWithString(t *testing.T) {
 		t.Fatalf("GenerateDecoder failed: %v", err)
 	}
 
-	decodeHelpers, err := GenerateDecodeHelpers(schema)
+	decodeHelpers, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeHelpers failed: %v", err)
 	}
@@ -205,7 +205,7 @@ func TestIntegrationWithArrays(t *testing.T) {
 		t.Fatalf("GenerateDecoder failed: %v", err)
 	}
 
-	decodeHelpers, err := GenerateDecodeHelpers(schema)
+	decodeHelpers, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeHelpers failed: %v", err)
 	}
@@ -285,7 +285,7 @@ func TestIntegrationWithNestedStructs(t *testing.T) {
 		t.Fatalf("GenerateDecoder failed: %v", err)
 	}
 
-	decodeHelpers, err := GenerateDecodeHelpers(schema)
+	decodeHelpers, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeHelpers failed: %v", err)
 	}
@@ -376,7 +376,7 @@ func TestIntegrationWithStructArrays(t *testing.T) {
 		t.Fatalf("GenerateDecoder failed: %v", err)
 	}
 
-	decodeHelpers, err := GenerateDecodeHelpers(schema)
+	decodeHelpers, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeHelpers failed: %v", err)
 	}
@@ -458,7 +458,7 @@ func TestIntegrationComplexSchema(t *testing.T) {
 		t.Fatalf("GenerateDecoder failed: %v", err)
 	}
 
-	decodeHelpers, err := GenerateDecodeHelpers(schema)
+	decodeHelpers, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeHelpers failed: %v", err)
 	}
@@ -468,7 +468,7 @@ func TestIntegrationComplexSchema(t *testing.T) {
 		t.Fatalf("GenerateErrors failed: %v", err)
 	}
 
-	context := GenerateDecodeContext()
+	context := GenerateDecodeContext(false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeContext failed: %v", err)
 	}
@@ -556,7 +556,7 @@ func TestIntegrationAllPrimitiveTypes(t *testing.T) {
 		t.Fatalf("GenerateEncodeHelpers failed: %v", err)
 	}
 
-	decodeHelpers, err := GenerateDecodeHelpers(schema)
+	decodeHelpers, err := GenerateDecodeHelpers(schema, false)
 	if err != nil {
 		t.Fatalf("GenerateDecodeHelpers failed: %v", err)
 	}
@@ -0,0 +1,179 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateJSON generates the JSON methods that struct tags alone cannot
+// express: the ",string" tag option does not apply to slices, so structs
+// with []u64 or []i64 fields get MarshalJSON and UnmarshalJSON methods that
+// write and read those elements as decimal strings.
+//
+// For each such struct, it generates:
+//   - (x StructName) MarshalJSON() ([]byte, error)
+//   - (x *StructName) UnmarshalJSON(data []byte) error
+//
+// And once per schema the jsonUint64s / jsonInt64s slice types they use.
+// Returns an empty string if no struct needs custom methods.
+func GenerateJSON(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+	used := make(map[string]bool)
+
+	for _, s := range schema.Structs {
+		var quoted []parser.Field
+		for _, field := range s.Fields {
+			if quotedSliceType(&field.Type) != "" {
+				quoted = append(quoted, field)
+				used[quotedSliceType(&field.Type)] = true
+			}
+		}
+		if len(quoted) == 0 {
+			continue
+		}
+		if err := generateJSONMethods(&buf, &s, quoted); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+	}
+
+	if used["jsonUint64s"] {
+		generateQuotedSlice(&buf, "jsonUint64s", "uint64", "strconv.AppendUint(b, v, 10)", "strconv.ParseUint(str, 10, 64)")
+		buf.WriteString("\n")
+	}
+	if used["jsonInt64s"] {
+		generateQuotedSlice(&buf, "jsonInt64s", "int64", "strconv.AppendInt(b, v, 10)", "strconv.ParseInt(str, 10, 64)")
+		buf.WriteString("\n")
+	}
+
+	return buf.String(), nil
+}
+
+// quotedSliceType returns the slice type that quotes the elements of a
+// []u64 or []i64 field, or "" for other types.
+func quotedSliceType(typeExpr *parser.TypeExpr) string {
+	if typeExpr.Kind != parser.TypeKindArray || typeExpr.Elem.Kind != parser.TypeKindPrimitive {
+		return ""
+	}
+	switch typeExpr.Elem.Name {
+	case "u64":
+		return "jsonUint64s"
+	case "i64":
+		return "jsonInt64s"
+	}
+	return ""
+}
+
+// generateJSONMethods generates MarshalJSON and UnmarshalJSON for a struct.
+// MarshalJSON converts the value to an anonymous struct with the same fields
+// and tags, so keys stay in schema order. UnmarshalJSON decodes through a copy
+// of the struct type without methods and shadows the quoted fields with
+// fields of the same JSON key at a shallower depth, which encoding/json
+// prefers.
+func generateJSONMethods(buf *strings.Builder, s *parser.Struct, quoted []parser.Field) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// MarshalJSON writes ")
+	buf.WriteString(structName)
+	buf.WriteString(" with 64-bit array elements as decimal strings.\n")
+	buf.WriteString("func (x ")
+	buf.WriteString(structName)
+	buf.WriteString(") MarshalJSON() ([]byte, error) {\n")
+	buf.WriteString("\treturn json.Marshal(struct {\n")
+	for _, field := range s.Fields {
+		goType := quotedSliceType(&field.Type)
+		if goType == "" {
+			var err error
+			if goType, err = mapFieldType(&field.Type); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+		}
+		buf.WriteString("\t\t" + ToGoName(field.Name) + " " + goType + " " + jsonTag(&field) + "\n")
+	}
+	buf.WriteString("\t}{")
+	for i, field := range s.Fields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if sliceType := quotedSliceType(&field.Type); sliceType != "" {
+			buf.WriteString(sliceType + "(x." + ToGoName(field.Name) + ")")
+		} else {
+			buf.WriteString("x." + ToGoName(field.Name))
+		}
+	}
+	buf.WriteString("})\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// UnmarshalJSON reads ")
+	buf.WriteString(structName)
+	buf.WriteString(" with 64-bit array elements as decimal strings.\n")
+	buf.WriteString("func (x *")
+	buf.WriteString(structName)
+	buf.WriteString(") UnmarshalJSON(data []byte) error {\n")
+	buf.WriteString("\ttype plain ")
+	buf.WriteString(structName)
+	buf.WriteString("\n")
+	buf.WriteString("\treturn json.Unmarshal(data, &struct {\n")
+	buf.WriteString("\t\t*plain\n")
+	for _, field := range quoted {
+		buf.WriteString("\t\t" + ToGoName(field.Name) + " *" + quotedSliceType(&field.Type) + " `json:\"" + field.JSONKey() + "\"`\n")
+	}
+	buf.WriteString("\t}{(*plain)(x)")
+	for _, field := range quoted {
+		buf.WriteString(", (*" + quotedSliceType(&field.Type) + ")(&x." + ToGoName(field.Name) + ")")
+	}
+	buf.WriteString("})\n")
+	buf.WriteString("}\n")
+	return nil
+}
+
+// generateQuotedSlice generates a slice type whose elements are written and
+// read as JSON strings.
+func generateQuotedSlice(buf *strings.Builder, typeName, elemType, appendExpr, parseExpr string) {
+	buf.WriteString("// " + typeName + " is a []" + elemType + " whose JSON elements are decimal strings.\n")
+	buf.WriteString("type " + typeName + " []" + elemType + "\n\n")
+
+	buf.WriteString("func (s " + typeName + ") MarshalJSON() ([]byte, error) {\n")
+	buf.WriteString("\tb := []byte{'['}\n")
+	buf.WriteString("\tfor i, v := range s {\n")
+	buf.WriteString("\t\tif i > 0 {\n")
+	buf.WriteString("\t\t\tb = append(b, ',')\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tb = append(b, '\"')\n")
+	buf.WriteString("\t\tb = " + appendExpr + "\n")
+	buf.WriteString("\t\tb = append(b, '\"')\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn append(b, ']'), nil\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("func (s *" + typeName + ") UnmarshalJSON(data []byte) error {\n")
+	buf.WriteString("\tvar strs []string\n")
+	buf.WriteString("\tif err := json.Unmarshal(data, &strs); err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif strs == nil {\n")
+	buf.WriteString("\t\t*s = nil\n")
+	buf.WriteString("\t\treturn nil\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tvalues := make(" + typeName + ", len(strs))\n")
+	buf.WriteString("\tfor i, str := range strs {\n")
+	buf.WriteString("\t\tv, err := " + parseExpr + "\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\treturn err\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tvalues[i] = v\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\t*s = values\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n")
+}
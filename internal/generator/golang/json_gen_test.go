@@ -0,0 +1,100 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "tags are enough",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Point",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u64"}},
+							{Name: "values", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				if code != "" {
+					t.Errorf("expected no code, got:\n%s", code)
+				}
+			},
+		},
+		{
+			name: "64-bit arrays",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Trace",
+						Fields: []parser.Field{
+							{Name: "name", JSONName: "label", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+							{Name: "stamps", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u64"}}},
+							{Name: "deltas", JSONName: "d", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "i64"}}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				if !strings.Contains(code, "func (x Trace) MarshalJSON() ([]byte, error) {") {
+					t.Errorf("missing MarshalJSON method")
+				}
+				if !strings.Contains(code, "\t\tName string `json:\"label\"`\n\t\tStamps jsonUint64s `json:\"stamps,omitempty\"`\n\t\tDeltas jsonInt64s `json:\"d,omitempty\"`\n") {
+					t.Errorf("MarshalJSON should list all fields in schema order")
+				}
+				if !strings.Contains(code, "}{x.Name, jsonUint64s(x.Stamps), jsonInt64s(x.Deltas)})") {
+					t.Errorf("MarshalJSON should convert the quoted fields")
+				}
+				if !strings.Contains(code, "func (x *Trace) UnmarshalJSON(data []byte) error {") {
+					t.Errorf("missing UnmarshalJSON method")
+				}
+				if !strings.Contains(code, "Deltas *jsonInt64s `json:\"d\"`") {
+					t.Errorf("UnmarshalJSON should shadow quoted fields by JSON key")
+				}
+				if !strings.Contains(code, "(*jsonUint64s)(&x.Stamps)") {
+					t.Errorf("UnmarshalJSON should decode into the struct fields")
+				}
+				if strings.Count(code, "type jsonUint64s []uint64") != 1 || strings.Count(code, "type jsonInt64s []int64") != 1 {
+					t.Errorf("quoted slice types should be generated once per schema")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateJSON(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateJSON() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
@@ -34,7 +34,7 @@ func GenerateMessageDecoders(schema *parser.Schema) (string, error) {
 func generateMessageDecoder(buf *strings.Builder, s *parser.Struct, typeID uint16) error {
 	structName := ToGoName(s.Name)
 	funcName := "Decode" + structName + "Message"
-	decoderFunc := "Decode" + structName
+	decoderFunc := "decode" + structName
 
 	// Function doc comment
 	buf.WriteString("// ")
@@ -43,7 +43,8 @@ func generateMessageDecoder(buf *strings.Builder, s *parser.Struct, typeID uint1
 	buf.WriteString(structName)
 	buf.WriteString(" from self-describing message format.\n")
 	buf.WriteString("// The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]\n")
-	buf.WriteString("// Returns an error if the header is invalid or the payload cannot be decoded.\n")
+	buf.WriteString("// Returns an error if the header is invalid or the payload cannot be decoded,\n")
+	buf.WriteString("// and ErrTrailingBytes if the payload extends past the value.\n")
 	buf.WriteString("func ")
 	buf.WriteString(funcName)
 	buf.WriteString("(data []byte) (*")
@@ -77,7 +78,10 @@ func generateMessageDecoder(buf *strings.Builder, s *parser.Struct, typeID uint1
 
 	// Extract payload length
 	buf.WriteString("\t// Extract payload length\n")
-	buf.WriteString("\tpayloadLength := binary.LittleEndian.Uint32(data[6:10])\n\n")
+	buf.WriteString("\tpayloadLength := binary.LittleEndian.Uint32(data[6:10])\n")
+	buf.WriteString("\tif payloadLength > MaxSerializedSize {\n")
+	buf.WriteString("\t\treturn nil, ErrDataTooLarge\n")
+	buf.WriteString("\t}\n\n")
 
 	// Validate total message size
 	buf.WriteString("\t// Validate total message size\n")
@@ -90,15 +94,20 @@ func generateMessageDecoder(buf *strings.Builder, s *parser.Struct, typeID uint1
 	buf.WriteString("\t// Extract payload\n")
 	buf.WriteString("\tpayload := data[MessageHeaderSize:expectedSize]\n\n")
 
-	// Decode payload
-	buf.WriteString("\t// Decode payload\n")
+	// Decode payload, which must hold exactly one value (as ValidateMessage requires)
+	buf.WriteString("\t// Decode payload; it must end with the value\n")
 	buf.WriteString("\tvar result ")
 	buf.WriteString(structName)
 	buf.WriteString("\n")
+	buf.WriteString("\tctx := &DecodeContext{}\n")
+	buf.WriteString("\toffset := 0\n")
 	buf.WriteString("\tif err := ")
 	buf.WriteString(decoderFunc)
-	buf.WriteString("(&result, payload); err != nil {\n")
+	buf.WriteString("(&result, payload, &offset, ctx); err != nil {\n")
 	buf.WriteString("\t\treturn nil, err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif offset != len(payload) {\n")
+	buf.WriteString("\t\treturn nil, ErrTrailingBytes\n")
 	buf.WriteString("\t}\n\n")
 	buf.WriteString("\treturn &result, nil\n")
 	buf.WriteString("}\n")
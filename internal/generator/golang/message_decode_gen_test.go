@@ -86,9 +86,13 @@ func TestGenerateMessageDecoders(t *testing.T) {
 					t.Errorf("missing payload extraction")
 				}
 				// Check payload decoding
-				if !strings.Contains(code, "DecodePoint(&result, payload)") {
+				if !strings.Contains(code, "decodePoint(&result, payload, &offset, ctx)") {
 					t.Errorf("missing payload decode call")
 				}
+				// The payload must hold exactly one value
+				if !strings.Contains(code, "if offset != len(payload) {\n\t\treturn nil, ErrTrailingBytes") {
+					t.Errorf("missing trailing payload check")
+				}
 			},
 		},
 		{
@@ -157,8 +161,8 @@ func TestGenerateMessageDecoders(t *testing.T) {
 				if !strings.Contains(code, "func DecodeDataPacketMessage(") {
 					t.Errorf("expected DecodeDataPacketMessage, check name conversion")
 				}
-				if !strings.Contains(code, "DecodeDataPacket(&result, payload)") {
-					t.Errorf("expected DecodeDataPacket call")
+				if !strings.Contains(code, "decodeDataPacket(&result, payload, &offset, ctx)") {
+					t.Errorf("expected decodeDataPacket call")
 				}
 			},
 		},
@@ -0,0 +1,211 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateRandom generates random value constructors for property-based
+// testing.
+//
+// For each struct type, it generates:
+//   - RandomStructName(r *rand.Rand, opts *RandomOptions) *StructName
+//   - (x *StructName) randomize(r *rand.Rand, opts *RandomOptions)
+//
+// And once per schema the RandomOptions type and the length and string
+// helpers. Fields are filled in schema order and draw from r exactly like
+// dynamic.Codec.Random, so both produce the same values from the same seed.
+func GenerateRandom(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+
+	generateRandomOptions(&buf)
+	buf.WriteString("\n")
+
+	for _, s := range schema.Structs {
+		if err := generateRandomMethods(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.String(), nil
+}
+
+// generateRandomOptions generates the RandomOptions type, its defaults and
+// the helpers shared by all randomize methods.
+func generateRandomOptions(buf *strings.Builder) {
+	buf.WriteString("// RandomOptions controls the values generated by the Random constructors.\n")
+	buf.WriteString("// A zero field selects its default, so a nil or partially set *RandomOptions\n")
+	buf.WriteString("// gives OptionalProbability 0.5, arrays of 0 to 4 elements and strings of up\n")
+	buf.WriteString("// to 16 ASCII letters and digits unless overridden. A negative\n")
+	buf.WriteString("// OptionalProbability, MaxArrayLen or MaxStringLen asks for absent optionals,\n")
+	buf.WriteString("// arrays of MinArrayLen elements or empty strings.\n")
+	buf.WriteString("type RandomOptions struct {\n")
+	buf.WriteString("\t// OptionalProbability is the chance that an optional field is present.\n")
+	buf.WriteString("\tOptionalProbability float64\n\n")
+	buf.WriteString("\t// MinArrayLen and MaxArrayLen bound the length of every array.\n")
+	buf.WriteString("\tMinArrayLen int\n")
+	buf.WriteString("\tMaxArrayLen int\n\n")
+	buf.WriteString("\t// MaxStringLen bounds the length of every string in runes.\n")
+	buf.WriteString("\tMaxStringLen int\n\n")
+	buf.WriteString("\t// Charset holds the runes strings are drawn from. Empty means ASCII\n")
+	buf.WriteString("\t// letters and digits.\n")
+	buf.WriteString("\tCharset string\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("var defaultRandomOptions = RandomOptions{OptionalProbability: 0.5, MaxArrayLen: 4, MaxStringLen: 16}\n\n")
+
+	buf.WriteString("const randomCharset = \"abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789\"\n\n")
+
+	buf.WriteString("// withDefaults returns a copy of opts with zero fields set to their defaults.\n")
+	buf.WriteString("func (opts *RandomOptions) withDefaults() *RandomOptions {\n")
+	buf.WriteString("\to := defaultRandomOptions\n")
+	buf.WriteString("\tif opts == nil {\n")
+	buf.WriteString("\t\treturn &o\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif opts.OptionalProbability != 0 {\n")
+	buf.WriteString("\t\to.OptionalProbability = opts.OptionalProbability\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\to.MinArrayLen = opts.MinArrayLen\n")
+	buf.WriteString("\tif opts.MaxArrayLen != 0 {\n")
+	buf.WriteString("\t\to.MaxArrayLen = opts.MaxArrayLen\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif opts.MaxStringLen != 0 {\n")
+	buf.WriteString("\t\to.MaxStringLen = opts.MaxStringLen\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\to.Charset = opts.Charset\n")
+	buf.WriteString("\treturn &o\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// randomLen returns an array length between opts.MinArrayLen and opts.MaxArrayLen.\n")
+	buf.WriteString("func randomLen(r *rand.Rand, opts *RandomOptions) int {\n")
+	buf.WriteString("\tn := max(opts.MinArrayLen, 0)\n")
+	buf.WriteString("\tif opts.MaxArrayLen > n {\n")
+	buf.WriteString("\t\tn += r.IntN(opts.MaxArrayLen - n + 1)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn n\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// randomString returns a string of up to opts.MaxStringLen runes from opts.Charset.\n")
+	buf.WriteString("func randomString(r *rand.Rand, opts *RandomOptions) string {\n")
+	buf.WriteString("\tif opts.MaxStringLen <= 0 {\n")
+	buf.WriteString("\t\treturn \"\"\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tcharset := []rune(opts.Charset)\n")
+	buf.WriteString("\tif len(charset) == 0 {\n")
+	buf.WriteString("\t\tcharset = []rune(randomCharset)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\ts := make([]rune, r.IntN(opts.MaxStringLen+1))\n")
+	buf.WriteString("\tfor i := range s {\n")
+	buf.WriteString("\t\ts[i] = charset[r.IntN(len(charset))]\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn string(s)\n")
+	buf.WriteString("}\n")
+}
+
+// generateRandomMethods generates the RandomX constructor and the randomize
+// helper of a struct.
+func generateRandomMethods(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// Random")
+	buf.WriteString(structName)
+	buf.WriteString(" returns a ")
+	buf.WriteString(structName)
+	buf.WriteString(" with every field drawn from r. Floats are finite.\n")
+	buf.WriteString("func Random")
+	buf.WriteString(structName)
+	buf.WriteString("(r *rand.Rand, opts *RandomOptions) *")
+	buf.WriteString(structName)
+	buf.WriteString(" {\n")
+	buf.WriteString("\topts = opts.withDefaults()\n")
+	buf.WriteString("\tx := new(")
+	buf.WriteString(structName)
+	buf.WriteString(")\n")
+	buf.WriteString("\tx.randomize(r, opts)\n")
+	buf.WriteString("\treturn x\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// randomize overwrites every field of x with a random value.\n")
+	buf.WriteString("func (x *")
+	buf.WriteString(structName)
+	buf.WriteString(") randomize(r *rand.Rand, opts *RandomOptions) {\n")
+	for _, field := range s.Fields {
+		if err := generateRandomField(buf, &field.Type, "x."+ToGoName(field.Name), "\t", 0); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+// generateRandomField generates code that assigns a random value to dst.
+func generateRandomField(buf *strings.Builder, typeExpr *parser.TypeExpr, dst, indent string, depth int) error {
+	switch typeExpr.Kind {
+	case parser.TypeKindPrimitive:
+		expr, ok := randomPrimitive[typeExpr.Name]
+		if !ok {
+			return fmt.Errorf("unknown primitive type: %q", typeExpr.Name)
+		}
+		buf.WriteString(indent + dst + " = " + expr + "\n")
+
+	case parser.TypeKindNamed:
+		if typeExpr.Optional {
+			buf.WriteString(indent + "if r.Float64() < opts.OptionalProbability {\n")
+			buf.WriteString(indent + "\t" + dst + " = new(" + ToGoName(typeExpr.Name) + ")\n")
+			buf.WriteString(indent + "\t" + dst + ".randomize(r, opts)\n")
+			buf.WriteString(indent + "} else {\n")
+			buf.WriteString(indent + "\t" + dst + " = nil\n")
+			buf.WriteString(indent + "}\n")
+		} else {
+			buf.WriteString(indent + dst + ".randomize(r, opts)\n")
+		}
+
+	case parser.TypeKindArray:
+		if typeExpr.Elem == nil {
+			return fmt.Errorf("array type has no element type")
+		}
+		goType, err := mapFieldType(typeExpr)
+		if err != nil {
+			return err
+		}
+		i := loopVar(depth)
+		buf.WriteString(indent + dst + " = make(" + goType + ", randomLen(r, opts))\n")
+		buf.WriteString(indent + "for " + i + " := range " + dst + " {\n")
+		if err := generateRandomField(buf, typeExpr.Elem, dst+"["+i+"]", indent+"\t", depth+1); err != nil {
+			return err
+		}
+		buf.WriteString(indent + "}\n")
+
+	default:
+		return fmt.Errorf("unknown type kind: %v", typeExpr.Kind)
+	}
+	return nil
+}
+
+// randomPrimitive maps primitive types to an expression drawing a value from
+// r. Keep in sync with randomPrimitive in sdp/dynamic.
+var randomPrimitive = map[string]string{
+	"u8":   "uint8(r.Uint32())",
+	"u16":  "uint16(r.Uint32())",
+	"u32":  "r.Uint32()",
+	"u64":  "r.Uint64()",
+	"i8":   "int8(r.Uint32())",
+	"i16":  "int16(r.Uint32())",
+	"i32":  "int32(r.Uint32())",
+	"i64":  "int64(r.Uint64())",
+	"f32":  "float32(r.NormFloat64() * 1000)",
+	"f64":  "r.NormFloat64() * 1000",
+	"bool": "r.IntN(2) == 1",
+	"str":  "randomString(r, opts)",
+}
@@ -0,0 +1,99 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateRandom(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "all field kinds",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Param",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+						},
+					},
+					{
+						Name: "Device",
+						Fields: []parser.Field{
+							{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+							{Name: "gain", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}},
+							{Name: "offset", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "i16"}},
+							{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f64"}}},
+							{Name: "params", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}}},
+							{Name: "first", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}},
+							{Name: "main", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param", Optional: true}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				if strings.Count(code, "type RandomOptions struct {") != 1 {
+					t.Errorf("RandomOptions should be generated once per schema")
+				}
+				if strings.Count(code, "func randomLen(") != 1 || strings.Count(code, "func randomString(") != 1 {
+					t.Errorf("helpers should be generated once per schema")
+				}
+
+				if !strings.Contains(code, "func RandomDevice(r *rand.Rand, opts *RandomOptions) *Device {") {
+					t.Errorf("missing RandomDevice constructor")
+				}
+				if !strings.Contains(code, "\topts = opts.withDefaults()\n") {
+					t.Errorf("constructor should fill in default options")
+				}
+				if !strings.Contains(code, "\tif opts.MaxStringLen != 0 {\n\t\to.MaxStringLen = opts.MaxStringLen\n\t}\n") {
+					t.Errorf("zero options should keep their defaults")
+				}
+
+				// Fields are drawn in schema order
+				want := "\tx.Name = randomString(r, opts)\n" +
+					"\tx.Gain = float32(r.NormFloat64() * 1000)\n" +
+					"\tx.Offset = int16(r.Uint32())\n" +
+					"\tx.Samples = make([]float64, randomLen(r, opts))\n" +
+					"\tfor i := range x.Samples {\n\t\tx.Samples[i] = r.NormFloat64() * 1000\n\t}\n" +
+					"\tx.Params = make([]Param, randomLen(r, opts))\n" +
+					"\tfor i := range x.Params {\n\t\tx.Params[i].randomize(r, opts)\n\t}\n" +
+					"\tx.First.randomize(r, opts)\n" +
+					"\tif r.Float64() < opts.OptionalProbability {\n\t\tx.Main = new(Param)\n\t\tx.Main.randomize(r, opts)\n\t} else {\n\t\tx.Main = nil\n\t}\n"
+				if !strings.Contains(code, want) {
+					t.Errorf("unexpected randomize body, want:\n%s", want)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateRandom(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateRandom() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
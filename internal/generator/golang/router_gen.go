@@ -0,0 +1,247 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateRouter generates a Router type that dispatches self-describing
+// messages to typed handlers, plus a MessageReader for reading framed
+// messages from a stream.
+//
+// For each struct type, it generates:
+//   - (r *Router) OnStructName(h func(*StructName) error) *Router
+//
+// And once per schema:
+//   - NewRouter() *Router
+//   - (r *Router) OnUnknown(h func(typeID uint16, data []byte) error) *Router
+//   - (r *Router) Dispatch(data []byte) error
+//   - (r *Router) Serve(mr *MessageReader) error
+//   - MessageReader / NewMessageReader(r io.Reader) / ReadMessage()
+//
+// Example usage:
+//
+//	router := NewRouter().
+//	    OnPoint(func(p *Point) error { ... }).
+//	    OnUnknown(func(typeID uint16, data []byte) error { ... })
+//	err := router.Serve(NewMessageReader(conn))
+//
+// Messages whose type has no registered handler are passed to the OnUnknown
+// handler. Without one, Dispatch returns ErrUnknownMessageType.
+func GenerateRouter(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+
+	generateMessageReader(&buf)
+	buf.WriteString("\n")
+
+	generateRouterType(&buf, schema)
+	buf.WriteString("\n")
+
+	for i, s := range schema.Structs {
+		generateRouterRegistration(&buf, &s)
+		if i < len(schema.Structs)-1 {
+			buf.WriteString("\n")
+		}
+	}
+	buf.WriteString("\n")
+
+	generateRouterDispatch(&buf, schema)
+	buf.WriteString("\n")
+
+	generateRouterServe(&buf)
+
+	return buf.String(), nil
+}
+
+// generateMessageReader generates the MessageReader type that splits a byte
+// stream into complete messages using the length in each 10-byte header.
+func generateMessageReader(buf *strings.Builder) {
+	buf.WriteString("// MessageReader reads self-describing messages from a stream.\n")
+	buf.WriteString("// Each message is framed by its 10-byte header, so messages can be\n")
+	buf.WriteString("// written back-to-back to a file, pipe or network connection.\n")
+	buf.WriteString("type MessageReader struct {\n")
+	buf.WriteString("\tr io.Reader\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// NewMessageReader creates a MessageReader that reads from r.\n")
+	buf.WriteString("func NewMessageReader(r io.Reader) *MessageReader {\n")
+	buf.WriteString("\treturn &MessageReader{r: r}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// ReadMessage reads the next complete message (header + payload).\n")
+	buf.WriteString("// It returns io.EOF when the stream ends cleanly between messages and\n")
+	buf.WriteString("// ErrUnexpectedEOF when the stream ends inside a message.\n")
+	buf.WriteString("func (mr *MessageReader) ReadMessage() ([]byte, error) {\n")
+	buf.WriteString("\tvar header [MessageHeaderSize]byte\n")
+	buf.WriteString("\tn, err := io.ReadFull(mr.r, header[:])\n")
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString("\t\tif err == io.EOF && n == 0 {\n")
+	buf.WriteString("\t\t\treturn nil, io.EOF\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tif err == io.ErrUnexpectedEOF {\n")
+	buf.WriteString("\t\t\treturn nil, ErrUnexpectedEOF\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\treturn nil, err\n")
+	buf.WriteString("\t}\n\n")
+
+	buf.WriteString("\t// Validate header before allocating the payload\n")
+	buf.WriteString("\tif string(header[0:3]) != MessageMagic {\n")
+	buf.WriteString("\t\treturn nil, ErrInvalidMagic\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif header[3] != MessageVersion {\n")
+	buf.WriteString("\t\treturn nil, ErrInvalidVersion\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tpayloadLength := binary.LittleEndian.Uint32(header[6:10])\n")
+	buf.WriteString("\tif payloadLength > MaxSerializedSize {\n")
+	buf.WriteString("\t\treturn nil, ErrDataTooLarge\n")
+	buf.WriteString("\t}\n\n")
+
+	buf.WriteString("\tmessage := make([]byte, MessageHeaderSize+int(payloadLength))\n")
+	buf.WriteString("\tcopy(message, header[:])\n")
+	buf.WriteString("\tif _, err := io.ReadFull(mr.r, message[MessageHeaderSize:]); err != nil {\n")
+	buf.WriteString("\t\tif err == io.EOF || err == io.ErrUnexpectedEOF {\n")
+	buf.WriteString("\t\t\treturn nil, ErrUnexpectedEOF\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\treturn nil, err\n")
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\treturn message, nil\n")
+	buf.WriteString("}\n")
+}
+
+// generateRouterType generates the Router struct with one handler slot per struct.
+func generateRouterType(buf *strings.Builder, schema *parser.Schema) {
+	buf.WriteString("// Router dispatches self-describing messages to typed handlers.\n")
+	buf.WriteString("// Register handlers with the On* methods, then call Dispatch for single\n")
+	buf.WriteString("// messages or Serve to process a stream. A Router is not safe for\n")
+	buf.WriteString("// concurrent registration, but Dispatch may be called concurrently once\n")
+	buf.WriteString("// all handlers are registered.\n")
+	buf.WriteString("type Router struct {\n")
+	for _, s := range schema.Structs {
+		structName := ToGoName(s.Name)
+		buf.WriteString("\ton")
+		buf.WriteString(structName)
+		buf.WriteString(" func(*")
+		buf.WriteString(structName)
+		buf.WriteString(") error\n")
+	}
+	buf.WriteString("\tonUnknown func(typeID uint16, data []byte) error\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// NewRouter creates a Router with no handlers registered.\n")
+	buf.WriteString("func NewRouter() *Router {\n")
+	buf.WriteString("\treturn &Router{}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// OnUnknown registers the fallback handler. It receives the complete message\n")
+	buf.WriteString("// for type IDs not in this schema and for types without a registered handler.\n")
+	buf.WriteString("func (r *Router) OnUnknown(h func(typeID uint16, data []byte) error) *Router {\n")
+	buf.WriteString("\tr.onUnknown = h\n")
+	buf.WriteString("\treturn r\n")
+	buf.WriteString("}\n")
+}
+
+// generateRouterRegistration generates the OnX registration method for a single struct.
+func generateRouterRegistration(buf *strings.Builder, s *parser.Struct) {
+	structName := ToGoName(s.Name)
+	methodName := "On" + structName
+
+	buf.WriteString("// ")
+	buf.WriteString(methodName)
+	buf.WriteString(" registers the handler for ")
+	buf.WriteString(structName)
+	buf.WriteString(" messages.\n")
+	buf.WriteString("func (r *Router) ")
+	buf.WriteString(methodName)
+	buf.WriteString("(h func(*")
+	buf.WriteString(structName)
+	buf.WriteString(") error) *Router {\n")
+	buf.WriteString("\tr.on")
+	buf.WriteString(structName)
+	buf.WriteString(" = h\n")
+	buf.WriteString("\treturn r\n")
+	buf.WriteString("}\n")
+}
+
+// generateRouterDispatch generates the Dispatch method, which switches on the
+// type ID and decodes with the matching DecodeXMessage function.
+func generateRouterDispatch(buf *strings.Builder, schema *parser.Schema) {
+	buf.WriteString("// Dispatch decodes a single message and calls the handler registered for its type.\n")
+	buf.WriteString("// Header and decode errors are returned as-is; handler errors are passed through.\n")
+	buf.WriteString("func (r *Router) Dispatch(data []byte) error {\n")
+
+	buf.WriteString("\t// Check minimum message size\n")
+	buf.WriteString("\tif len(data) < MessageHeaderSize {\n")
+	buf.WriteString("\t\treturn ErrUnexpectedEOF\n")
+	buf.WriteString("\t}\n\n")
+
+	buf.WriteString("\t// Validate magic bytes and version\n")
+	buf.WriteString("\tif string(data[0:3]) != MessageMagic {\n")
+	buf.WriteString("\t\treturn ErrInvalidMagic\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif data[3] != MessageVersion {\n")
+	buf.WriteString("\t\treturn ErrInvalidVersion\n")
+	buf.WriteString("\t}\n\n")
+
+	buf.WriteString("\ttypeID := binary.LittleEndian.Uint16(data[4:6])\n\n")
+
+	buf.WriteString("\t// Dispatch to typed handler\n")
+	buf.WriteString("\tswitch typeID {\n")
+	for i, s := range schema.Structs {
+		structName := ToGoName(s.Name)
+		handler := "r.on" + structName
+
+		buf.WriteString(fmt.Sprintf("\tcase %d:\n", i+1))
+		buf.WriteString("\t\tif ")
+		buf.WriteString(handler)
+		buf.WriteString(" != nil {\n")
+		buf.WriteString("\t\t\tmsg, err := Decode")
+		buf.WriteString(structName)
+		buf.WriteString("Message(data)\n")
+		buf.WriteString("\t\t\tif err != nil {\n")
+		buf.WriteString("\t\t\t\treturn err\n")
+		buf.WriteString("\t\t\t}\n")
+		buf.WriteString("\t\t\treturn ")
+		buf.WriteString(handler)
+		buf.WriteString("(msg)\n")
+		buf.WriteString("\t\t}\n")
+	}
+	buf.WriteString("\t}\n\n")
+
+	buf.WriteString("\t// Unknown type ID or no handler registered\n")
+	buf.WriteString("\tif r.onUnknown != nil {\n")
+	buf.WriteString("\t\treturn r.onUnknown(typeID, data)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn ErrUnknownMessageType\n")
+	buf.WriteString("}\n")
+}
+
+// generateRouterServe generates the Serve loop over a MessageReader.
+func generateRouterServe(buf *strings.Builder) {
+	buf.WriteString("// Serve reads messages from mr and dispatches each one until the stream ends.\n")
+	buf.WriteString("// It returns nil on a clean end of stream, or the first read, decode or\n")
+	buf.WriteString("// handler error.\n")
+	buf.WriteString("func (r *Router) Serve(mr *MessageReader) error {\n")
+	buf.WriteString("\tfor {\n")
+	buf.WriteString("\t\tmsg, err := mr.ReadMessage()\n")
+	buf.WriteString("\t\tif err == io.EOF {\n")
+	buf.WriteString("\t\t\treturn nil\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\treturn err\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tif err := r.Dispatch(msg); err != nil {\n")
+	buf.WriteString("\t\t\treturn err\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n")
+}
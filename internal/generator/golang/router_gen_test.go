@@ -0,0 +1,115 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateRouter(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "multiple structs",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Point",
+						Fields: []parser.Field{
+							{Name: "x", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f64"}},
+						},
+					},
+					{
+						Name: "data_packet",
+						Fields: []parser.Field{
+							{Name: "value", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "i32"}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				// Router type with one handler slot per struct
+				if !strings.Contains(code, "type Router struct") {
+					t.Errorf("missing Router type")
+				}
+				if !strings.Contains(code, "onPoint func(*Point) error") {
+					t.Errorf("missing Point handler slot")
+				}
+				if !strings.Contains(code, "onDataPacket func(*DataPacket) error") {
+					t.Errorf("missing DataPacket handler slot, check name conversion")
+				}
+				if !strings.Contains(code, "func NewRouter() *Router") {
+					t.Errorf("missing NewRouter constructor")
+				}
+				// Registration methods
+				if !strings.Contains(code, "func (r *Router) OnPoint(h func(*Point) error) *Router") {
+					t.Errorf("missing OnPoint registration")
+				}
+				if !strings.Contains(code, "func (r *Router) OnDataPacket(h func(*DataPacket) error) *Router") {
+					t.Errorf("missing OnDataPacket registration")
+				}
+				if !strings.Contains(code, "func (r *Router) OnUnknown(h func(typeID uint16, data []byte) error) *Router") {
+					t.Errorf("missing OnUnknown fallback registration")
+				}
+				// Dispatch uses sequential type IDs and message decoders
+				if !strings.Contains(code, "func (r *Router) Dispatch(data []byte) error") {
+					t.Errorf("missing Dispatch method")
+				}
+				if !strings.Contains(code, "case 1:") || !strings.Contains(code, "case 2:") {
+					t.Errorf("missing type ID cases in Dispatch")
+				}
+				if !strings.Contains(code, "DecodePointMessage(data)") {
+					t.Errorf("Dispatch should decode with DecodePointMessage")
+				}
+				if !strings.Contains(code, "return r.onUnknown(typeID, data)") {
+					t.Errorf("Dispatch should fall back to onUnknown")
+				}
+				if !strings.Contains(code, "return ErrUnknownMessageType") {
+					t.Errorf("Dispatch should return ErrUnknownMessageType without fallback")
+				}
+				// Stream support
+				if !strings.Contains(code, "func NewMessageReader(r io.Reader) *MessageReader") {
+					t.Errorf("missing NewMessageReader")
+				}
+				if !strings.Contains(code, "func (mr *MessageReader) ReadMessage() ([]byte, error)") {
+					t.Errorf("missing ReadMessage")
+				}
+				if !strings.Contains(code, "if payloadLength > MaxSerializedSize") {
+					t.Errorf("ReadMessage should enforce MaxSerializedSize before allocating")
+				}
+				if !strings.Contains(code, "func (r *Router) Serve(mr *MessageReader) error") {
+					t.Errorf("missing Serve method")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateRouter(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateRouter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
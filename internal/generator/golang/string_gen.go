@@ -0,0 +1,283 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateString generates readable output for logs and debugging.
+//
+// For each struct type, it generates:
+//   - (x *StructName) String() string
+//   - (x *StructName) LogValue() slog.Value
+//   - (x *StructName) appendString(b []byte) []byte
+//
+// And once per schema the truncation limits and helpers.
+//
+// String prints fields by schema name, with quoted strings, []u8 as hex and
+// absent optionals as nil, e.g. Device{id: 1, tags: ["a", "b"], main: nil}.
+// LogValue returns a group with one attribute per field; arrays of structs
+// are groups keyed by index, other arrays use the String form. Both show at
+// most stringMaxElems array elements and stringMaxBytes bytes of a []u8, and
+// print fields marked "/// @redact" as [REDACTED].
+func GenerateString(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+
+	generateStringHelpers(&buf)
+	buf.WriteString("\n")
+
+	for _, s := range schema.Structs {
+		if err := generateStringMethods(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+		if err := generateLogValueMethod(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.String(), nil
+}
+
+// generateStringHelpers generates the limits and helpers shared by all
+// String and LogValue methods.
+func generateStringHelpers(buf *strings.Builder) {
+	buf.WriteString("// stringMaxElems is the number of array elements String and LogValue show.\n")
+	buf.WriteString("const stringMaxElems = 16\n\n")
+	buf.WriteString("// stringMaxBytes is the number of []u8 bytes String and LogValue show.\n")
+	buf.WriteString("const stringMaxBytes = 32\n\n")
+	buf.WriteString("// stringRedacted replaces the value of fields marked @redact.\n")
+	buf.WriteString("const stringRedacted = \"[REDACTED]\"\n\n")
+
+	buf.WriteString("// appendStringMore appends the number of elements left out of a truncated array.\n")
+	buf.WriteString("func appendStringMore(b []byte, n int) []byte {\n")
+	buf.WriteString("\tb = append(b, \"... (+\"...)\n")
+	buf.WriteString("\tb = strconv.AppendInt(b, int64(n), 10)\n")
+	buf.WriteString("\treturn append(b, \" more)\"...)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// appendStringBytes appends a []u8 in hex.\n")
+	buf.WriteString("func appendStringBytes(b, v []byte) []byte {\n")
+	buf.WriteString("\tb = append(b, \"0x\"...)\n")
+	buf.WriteString("\tif len(v) <= stringMaxBytes {\n")
+	buf.WriteString("\t\treturn hex.AppendEncode(b, v)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tb = hex.AppendEncode(b, v[:stringMaxBytes])\n")
+	buf.WriteString("\treturn appendStringMore(b, len(v)-stringMaxBytes)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// logValueElems returns the first stringMaxElems elements of an array as a\n")
+	buf.WriteString("// group keyed by index.\n")
+	buf.WriteString("func logValueElems[T any](elems []T, value func(*T) slog.Value) slog.Value {\n")
+	buf.WriteString("\tattrs := make([]slog.Attr, 0, min(len(elems), stringMaxElems+1))\n")
+	buf.WriteString("\tfor i := range elems {\n")
+	buf.WriteString("\t\tif i == stringMaxElems {\n")
+	buf.WriteString("\t\t\tattrs = append(attrs, slog.Int(\"more\", len(elems)-i))\n")
+	buf.WriteString("\t\t\tbreak\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tattrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: value(&elems[i])})\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn slog.GroupValue(attrs...)\n")
+	buf.WriteString("}\n")
+}
+
+// generateStringMethods generates String and the appendString helper of a
+// struct.
+func generateStringMethods(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// String implements fmt.Stringer with a readable form of x for logs and\n")
+	buf.WriteString("// debugging: arrays are truncated and redacted fields hidden.\n")
+	buf.WriteString("func (x *" + structName + ") String() string {\n")
+	buf.WriteString("\tif x == nil {\n")
+	buf.WriteString("\t\treturn \"<nil>\"\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn string(x.appendString(nil))\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// appendString appends the String form of x to b.\n")
+	buf.WriteString("func (x *" + structName + ") appendString(b []byte) []byte {\n")
+	buf.WriteString("\tb = append(b, \"" + s.Name + "{\"...)\n")
+	for i, field := range s.Fields {
+		sep := ", "
+		if i == 0 {
+			sep = ""
+		}
+		buf.WriteString("\tb = append(b, \"" + sep + field.Name + ": \"...)\n")
+		if field.Redact {
+			buf.WriteString("\tb = append(b, stringRedacted...)\n")
+			continue
+		}
+		if err := generateStringValue(buf, &field.Type, "x."+ToGoName(field.Name), "\t", 0); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+	buf.WriteString("\treturn append(b, '}')\n")
+	buf.WriteString("}\n")
+	return nil
+}
+
+// generateStringValue generates code that appends the String form of expr
+// to b.
+func generateStringValue(buf *strings.Builder, typeExpr *parser.TypeExpr, expr, indent string, depth int) error {
+	switch typeExpr.Kind {
+	case parser.TypeKindPrimitive:
+		format, ok := stringPrimitive[typeExpr.Name]
+		if !ok {
+			return fmt.Errorf("unknown primitive type: %q", typeExpr.Name)
+		}
+		buf.WriteString(indent + "b = " + fmt.Sprintf(format, expr) + "\n")
+
+	case parser.TypeKindNamed:
+		if typeExpr.Optional {
+			buf.WriteString(indent + "if " + expr + " == nil {\n")
+			buf.WriteString(indent + "\tb = append(b, \"nil\"...)\n")
+			buf.WriteString(indent + "} else {\n")
+			buf.WriteString(indent + "\tb = " + expr + ".appendString(b)\n")
+			buf.WriteString(indent + "}\n")
+		} else {
+			buf.WriteString(indent + "b = " + expr + ".appendString(b)\n")
+		}
+
+	case parser.TypeKindArray:
+		if typeExpr.Elem == nil {
+			return fmt.Errorf("array type has no element type")
+		}
+		if typeExpr.Elem.Kind == parser.TypeKindPrimitive && typeExpr.Elem.Name == "u8" {
+			buf.WriteString(indent + "b = appendStringBytes(b, " + expr + ")\n")
+			return nil
+		}
+		i := loopVar(depth)
+		buf.WriteString(indent + "b = append(b, '[')\n")
+		buf.WriteString(indent + "for " + i + " := range " + expr + " {\n")
+		buf.WriteString(indent + "\tif " + i + " > 0 {\n")
+		buf.WriteString(indent + "\t\tb = append(b, \", \"...)\n")
+		buf.WriteString(indent + "\t}\n")
+		buf.WriteString(indent + "\tif " + i + " == stringMaxElems {\n")
+		buf.WriteString(indent + "\t\tb = appendStringMore(b, len(" + expr + ")-" + i + ")\n")
+		buf.WriteString(indent + "\t\tbreak\n")
+		buf.WriteString(indent + "\t}\n")
+		if err := generateStringValue(buf, typeExpr.Elem, expr+"["+i+"]", indent+"\t", depth+1); err != nil {
+			return err
+		}
+		buf.WriteString(indent + "}\n")
+		buf.WriteString(indent + "b = append(b, ']')\n")
+
+	default:
+		return fmt.Errorf("unknown type kind: %v", typeExpr.Kind)
+	}
+	return nil
+}
+
+// stringPrimitive maps primitive types to an expression appending the value
+// %s to b.
+var stringPrimitive = map[string]string{
+	"u8":   "strconv.AppendUint(b, uint64(%s), 10)",
+	"u16":  "strconv.AppendUint(b, uint64(%s), 10)",
+	"u32":  "strconv.AppendUint(b, uint64(%s), 10)",
+	"u64":  "strconv.AppendUint(b, %s, 10)",
+	"i8":   "strconv.AppendInt(b, int64(%s), 10)",
+	"i16":  "strconv.AppendInt(b, int64(%s), 10)",
+	"i32":  "strconv.AppendInt(b, int64(%s), 10)",
+	"i64":  "strconv.AppendInt(b, %s, 10)",
+	"f32":  "strconv.AppendFloat(b, float64(%s), 'g', -1, 32)",
+	"f64":  "strconv.AppendFloat(b, %s, 'g', -1, 64)",
+	"bool": "strconv.AppendBool(b, %s)",
+	"str":  "strconv.AppendQuote(b, %s)",
+}
+
+// logValuePrimitive maps primitive types to the slog.Attr constructor and
+// the conversion of the value %s it takes.
+var logValuePrimitive = map[string][2]string{
+	"u8":   {"slog.Uint64", "uint64(%s)"},
+	"u16":  {"slog.Uint64", "uint64(%s)"},
+	"u32":  {"slog.Uint64", "uint64(%s)"},
+	"u64":  {"slog.Uint64", "%s"},
+	"i8":   {"slog.Int64", "int64(%s)"},
+	"i16":  {"slog.Int64", "int64(%s)"},
+	"i32":  {"slog.Int64", "int64(%s)"},
+	"i64":  {"slog.Int64", "%s"},
+	"f32":  {"slog.Float64", "float64(%s)"},
+	"f64":  {"slog.Float64", "%s"},
+	"bool": {"slog.Bool", "%s"},
+	"str":  {"slog.String", "%s"},
+}
+
+// generateLogValueMethod generates the LogValue method of a struct.
+func generateLogValueMethod(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// LogValue implements slog.LogValuer, logging x as a group of its fields.\n")
+	buf.WriteString("func (x *" + structName + ") LogValue() slog.Value {\n")
+	buf.WriteString("\tif x == nil {\n")
+	buf.WriteString("\t\treturn slog.AnyValue(nil)\n")
+	buf.WriteString("\t}\n")
+
+	// Arrays other than []u8 and arrays of structs are formatted into b
+	for _, field := range s.Fields {
+		if !field.Redact && logValueUsesString(&field.Type) {
+			buf.WriteString("\tvar b []byte\n")
+			break
+		}
+	}
+	buf.WriteString("\tattrs := make([]slog.Attr, 0, " + fmt.Sprint(len(s.Fields)) + ")\n")
+
+	for _, field := range s.Fields {
+		expr := "x." + ToGoName(field.Name)
+		t := &field.Type
+		switch {
+		case field.Redact:
+			buf.WriteString("\tattrs = append(attrs, slog.String(\"" + field.Name + "\", stringRedacted))\n")
+
+		case t.Kind == parser.TypeKindPrimitive:
+			p, ok := logValuePrimitive[t.Name]
+			if !ok {
+				return fmt.Errorf("field %q: unknown primitive type: %q", field.Name, t.Name)
+			}
+			buf.WriteString("\tattrs = append(attrs, " + p[0] + "(\"" + field.Name + "\", " + fmt.Sprintf(p[1], expr) + "))\n")
+
+		case t.Kind == parser.TypeKindNamed:
+			buf.WriteString("\tattrs = append(attrs, slog.Attr{Key: \"" + field.Name + "\", Value: " + expr + ".LogValue()})\n")
+
+		case t.Kind == parser.TypeKindArray && t.Elem != nil && t.Elem.Kind == parser.TypeKindNamed:
+			buf.WriteString("\tattrs = append(attrs, slog.Attr{Key: \"" + field.Name + "\", Value: logValueElems(" + expr + ", (*" + ToGoName(t.Elem.Name) + ").LogValue)})\n")
+
+		case t.Kind == parser.TypeKindArray && t.Elem != nil && t.Elem.Kind == parser.TypeKindPrimitive && t.Elem.Name == "u8":
+			buf.WriteString("\tattrs = append(attrs, slog.String(\"" + field.Name + "\", string(appendStringBytes(nil, " + expr + "))))\n")
+
+		default:
+			buf.WriteString("\tb = b[:0]\n")
+			if err := generateStringValue(buf, t, expr, "\t", 0); err != nil {
+				return fmt.Errorf("field %q: %w", field.Name, err)
+			}
+			buf.WriteString("\tattrs = append(attrs, slog.String(\"" + field.Name + "\", string(b)))\n")
+		}
+	}
+
+	buf.WriteString("\treturn slog.GroupValue(attrs...)\n")
+	buf.WriteString("}\n")
+	return nil
+}
+
+// logValueUsesString reports whether LogValue logs a field of this type in
+// its String form.
+func logValueUsesString(t *parser.TypeExpr) bool {
+	if t.Kind != parser.TypeKindArray || t.Elem == nil {
+		return false
+	}
+	if t.Elem.Kind == parser.TypeKindNamed {
+		return false
+	}
+	return t.Elem.Kind != parser.TypeKindPrimitive || t.Elem.Name != "u8"
+}
@@ -0,0 +1,117 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateString(t *testing.T) {
+	u8 := parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u8"}
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Account",
+				Fields: []parser.Field{
+					{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u64"}},
+					{Name: "password", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}, Redact: true},
+					{Name: "avatar", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &u8}},
+					{Name: "scores", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}}},
+					{Name: "owner", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "User", Optional: true}},
+					{Name: "members", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "User"}}},
+				},
+			},
+			{
+				Name: "User",
+				Fields: []parser.Field{
+					{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name:   "all field kinds",
+			schema: schema,
+			checkFunc: func(t *testing.T, code string) {
+				for _, want := range []string{
+					"func (x *Account) String() string {",
+					"func (x *Account) appendString(b []byte) []byte {",
+					"func (x *Account) LogValue() slog.Value {",
+					"func (x *User) String() string {",
+					"func (x *User) LogValue() slog.Value {",
+					`b = append(b, "Account{"...)`,
+					"b = strconv.AppendUint(b, x.Id, 10)",
+					"b = appendStringBytes(b, x.Avatar)",
+					"b = appendStringMore(b, len(x.Scores)-i)",
+					"b = strconv.AppendFloat(b, float64(x.Scores[i]), 'g', -1, 32)",
+					"b = x.Owner.appendString(b)",
+					"b = x.Members[i].appendString(b)",
+					`attrs = append(attrs, slog.Uint64("id", x.Id))`,
+					`attrs = append(attrs, slog.String("avatar", string(appendStringBytes(nil, x.Avatar))))`,
+					`attrs = append(attrs, slog.String("scores", string(b)))`,
+					`attrs = append(attrs, slog.Attr{Key: "owner", Value: x.Owner.LogValue()})`,
+					`attrs = append(attrs, slog.Attr{Key: "members", Value: logValueElems(x.Members, (*User).LogValue)})`,
+				} {
+					if !strings.Contains(code, want) {
+						t.Errorf("missing %q", want)
+					}
+				}
+
+				// Redacted fields never reach the output
+				if strings.Contains(code, "x.Password") {
+					t.Errorf("redacted field is read")
+				}
+				if !strings.Contains(code, `attrs = append(attrs, slog.String("password", stringRedacted))`) {
+					t.Errorf("LogValue should redact password")
+				}
+				if !strings.Contains(code, "b = append(b, stringRedacted...)") {
+					t.Errorf("String should redact password")
+				}
+
+				// Absent optionals print as nil
+				if !strings.Contains(code, "if x.Owner == nil {\n\t\tb = append(b, \"nil\"...)") {
+					t.Errorf("optional should print nil when absent")
+				}
+			},
+		},
+		{
+			name:   "no string-formatted arrays",
+			schema: &parser.Schema{Structs: schema.Structs[1:]},
+			checkFunc: func(t *testing.T, code string) {
+				if strings.Contains(code, "var b []byte") {
+					t.Errorf("LogValue declares b without arrays to format")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateString(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GenerateString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
@@ -13,15 +13,16 @@ import (
 //   - Exported fields (start with capital letter)
 //   - Doc comments preserved from schema
 //   - Proper Go type mappings
+//   - JSON tags for the canonical JSON mapping (see jsonTag)
 //
 // Example output:
 //
 //	// Device represents an audio device.
 //	type Device struct {
 //	    // ID is the unique identifier.
-//	    ID uint32
+//	    ID uint32 `json:"id"`
 //	    // Name is the device name.
-//	    Name string
+//	    Name string `json:"name"`
 //	}
 //
 // Returns an error if type mapping fails or schema is invalid.
@@ -78,6 +79,8 @@ func GenerateStructs(schema *parser.Schema) (string, error) {
 				return "", fmt.Errorf("struct %q, field %q: %w", s.Name, field.Name, err)
 			}
 			buf.WriteString(goType)
+			buf.WriteString(" ")
+			buf.WriteString(jsonTag(&field))
 			buf.WriteString("\n")
 		}
 
@@ -87,6 +90,22 @@ func GenerateStructs(schema *parser.Schema) (string, error) {
 	return buf.String(), nil
 }
 
+// jsonTag returns the struct tag of a field for the canonical JSON mapping:
+// the key is the schema field name (or its @json override), 64-bit integers
+// are quoted so JavaScript readers keep full precision, and empty arrays are
+// omitted (readers treat a missing array as empty). []u8 needs no option:
+// encoding/json writes []byte as base64.
+func jsonTag(field *parser.Field) string {
+	opts := ""
+	switch {
+	case field.Type.Kind == parser.TypeKindArray:
+		opts = ",omitempty"
+	case field.Type.Kind == parser.TypeKindPrimitive && (field.Type.Name == "u64" || field.Type.Name == "i64"):
+		opts = ",string"
+	}
+	return "`json:\"" + field.JSONKey() + opts + "\"`"
+}
+
 // mapFieldType converts a field's type expression to Go type string,
 // applying name conversion to named types.
 func mapFieldType(typeExpr *parser.TypeExpr) (string, error) {
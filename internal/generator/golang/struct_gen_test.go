@@ -336,6 +336,43 @@ func TestGenerateAllPrimitiveTypes(t *testing.T) {
 	}
 }
 
+// TestGenerateJSONTags verifies the canonical JSON mapping tags
+func TestGenerateJSONTags(t *testing.T) {
+	schema := &parser.Schema{
+		Structs: []parser.Struct{
+			{
+				Name: "Parameter",
+				Fields: []parser.Field{
+					{Name: "display_name", JSONName: "displayName", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+					{Name: "address", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u64"}},
+					{Name: "offset", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "i64"}},
+					{Name: "raw", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u8"}}},
+					{Name: "meta", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Parameter", Optional: true}},
+				},
+			},
+		},
+	}
+
+	result, err := GenerateStructs(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedFields := []string{
+		"DisplayName string `json:\"displayName\"`",
+		"Address uint64 `json:\"address,string\"`",
+		"Offset int64 `json:\"offset,string\"`",
+		"Raw []uint8 `json:\"raw,omitempty\"`",
+		"Meta *Parameter `json:\"meta\"`",
+	}
+
+	for _, expected := range expectedFields {
+		if !strings.Contains(result, expected) {
+			t.Errorf("missing field %q, got:\n%s", expected, result)
+		}
+	}
+}
+
 // TestGenerateNilSchema verifies error handling for nil schema
 func TestGenerateNilSchema(t *testing.T) {
 	result, err := GenerateStructs(nil)
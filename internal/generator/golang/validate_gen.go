@@ -0,0 +1,350 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateValidators generates allocation-free validation functions that walk
+// the wire layout without building Go values.
+//
+// For each struct type, it generates:
+//   - ValidateStructName(data []byte) (int, error)
+//   - validateStructName(data []byte, offset *int, ctx *DecodeContext) error
+//
+// And once per schema:
+//   - ValidateMessage(data []byte) (int, error)
+//
+// Validation enforces the same limits and checks as decoding (DecodeContext,
+// presence flags) and additionally checks strings for valid UTF-8, as
+// DecodeXStrict does. Errors carry the same field path and offset as decode
+// errors.
+// On success the number of bytes occupied by the value is returned.
+func GenerateValidators(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	var buf strings.Builder
+
+	for _, s := range schema.Structs {
+		generateValidateEntry(&buf, &s)
+		buf.WriteString("\n")
+	}
+
+	for _, s := range schema.Structs {
+		if err := generateSkipHelper(&buf, &s, false); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		buf.WriteString("\n")
+	}
+
+	generateValidateMessage(&buf, schema)
+
+	return buf.String(), nil
+}
+
+// generateValidateEntry generates the exported ValidateX entry point.
+func generateValidateEntry(buf *strings.Builder, s *parser.Struct) {
+	structName := ToGoName(s.Name)
+	funcName := "Validate" + structName
+	helperName := "validate" + structName
+
+	buf.WriteString("// ")
+	buf.WriteString(funcName)
+	buf.WriteString(" checks that data starts with a well-formed ")
+	buf.WriteString(structName)
+	buf.WriteString(" without decoding it.\n")
+	buf.WriteString("// It accepts the input Decode")
+	buf.WriteString(structName)
+	buf.WriteString(" accepts, except strings that are not valid\n")
+	buf.WriteString("// UTF-8, and returns the number of bytes the value occupies. Nothing is\n")
+	buf.WriteString("// allocated unless validation fails.\n")
+	buf.WriteString("func ")
+	buf.WriteString(funcName)
+	buf.WriteString("(data []byte) (int, error) {\n")
+	buf.WriteString("\tif len(data) > MaxSerializedSize {\n")
+	buf.WriteString("\t\treturn 0, ErrDataTooLarge\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tctx := &DecodeContext{}\n")
+	buf.WriteString("\toffset := 0\n")
+	buf.WriteString("\tif err := ")
+	buf.WriteString(helperName)
+	buf.WriteString("(data, &offset, ctx); err != nil {\n")
+	buf.WriteString("\t\treturn 0, err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn offset, nil\n")
+	buf.WriteString("}\n")
+}
+
+// skipCode generates a walker that advances offset over encoded values
+// without decoding them, with the same checks as the decoder. It produces the
+// validateX helpers and, with measure set, the measureX helpers of arena
+// decoding, which also add up the memory each value needs.
+type skipCode struct {
+	decodeCode
+	measure bool // Generate measureX: count arena memory instead of checking UTF-8
+}
+
+// call returns the call of the walker helper for a struct type.
+func (c skipCode) call(typeName string) string {
+	if c.measure {
+		return "measure" + ToGoName(typeName) + "(data, offset, ctx, size)"
+	}
+	return "validate" + ToGoName(typeName) + "(data, offset, ctx)"
+}
+
+// count writes code that adds n to the size counter when measuring.
+func (c skipCode) count(indent, counter, n string) {
+	if c.measure {
+		c.WriteString(indent + "size." + counter + " += " + n + "\n")
+	}
+}
+
+// generateSkipHelper generates the validateX helper, or with measure the
+// measureX helper, that advances offset over a single struct value.
+func generateSkipHelper(buf *strings.Builder, s *parser.Struct, measure bool) error {
+	structName := ToGoName(s.Name)
+
+	buf.WriteString("// ")
+	if measure {
+		buf.WriteString("measure" + structName + " advances offset over one " + structName + " value and adds the arena memory it needs to size.\n")
+		buf.WriteString("func measure" + structName + "(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {\n")
+	} else {
+		buf.WriteString("validate" + structName + " advances offset over one " + structName + " value.\n")
+		buf.WriteString("func validate" + structName + "(data []byte, offset *int, ctx *DecodeContext) error {\n")
+	}
+	buf.WriteString("\tvar (\n")
+	buf.WriteString("\t\tstrLen uint32  // For string length prefix\n")
+	buf.WriteString("\t\tarrCount uint32  // For array count\n")
+	buf.WriteString("\t\terr error  // For error handling\n")
+	buf.WriteString("\t)\n")
+	buf.WriteString("\t_ = strLen  // Avoid unused variable error\n")
+	buf.WriteString("\t_ = arrCount  // Avoid unused variable error\n")
+	buf.WriteString("\t_ = err  // Avoid unused variable error\n")
+	buf.WriteString("\n")
+
+	for _, field := range s.Fields {
+		code := skipCode{decodeCode{Builder: buf, field: field.Name}, measure}
+		if err := generateFieldSkip(code, &field); err != nil {
+			return fmt.Errorf("field %q: %w", field.Name, err)
+		}
+	}
+
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n")
+
+	return nil
+}
+
+// generateFieldSkip generates the walker code for a single field.
+func generateFieldSkip(buf skipCode, field *parser.Field) error {
+	buf.WriteString("\t// Field: ")
+	buf.WriteString(field.Name)
+	buf.WriteString("\n")
+
+	if !field.Type.Optional {
+		if err := generateTypeSkip(buf, &field.Type, "\t"); err != nil {
+			return err
+		}
+		buf.WriteString("\n")
+		return nil
+	}
+
+	// Presence flag must be exactly 0 or 1, as in the decoder
+	buf.WriteString("\tif *offset >= len(data) {\n")
+	buf.fail("\t\t", "ErrUnexpectedEOF", "*offset")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tswitch data[*offset] {\n")
+	buf.WriteString("\tcase 0:\n")
+	buf.WriteString("\t\t*offset += 1\n")
+	buf.WriteString("\tcase 1:\n")
+	buf.WriteString("\t\t*offset += 1\n")
+	if field.Type.Kind == parser.TypeKindNamed {
+		buf.count("\t\t", ToGoName(field.Type.Name), "1")
+	}
+
+	inner := field.Type
+	inner.Optional = false
+	if err := generateTypeSkip(buf, &inner, "\t\t"); err != nil {
+		return err
+	}
+
+	buf.WriteString("\tdefault:\n")
+	buf.fail("\t\t", "ErrInvalidData", "*offset")
+	buf.WriteString("\t}\n\n")
+
+	return nil
+}
+
+// generateTypeSkip generates code that advances offset over one value of the
+// given (non-optional) type.
+func generateTypeSkip(buf skipCode, typeExpr *parser.TypeExpr, indent string) error {
+	switch typeExpr.Kind {
+	case parser.TypeKindPrimitive:
+		if typeExpr.Name == "str" {
+			generateStringSkip(buf, indent)
+			return nil
+		}
+		size := getPrimitiveSize(typeExpr.Name)
+		if size == 0 {
+			return fmt.Errorf("unknown primitive type: %s", typeExpr.Name)
+		}
+		buf.WriteString(fmt.Sprintf("%sif *offset + %d > len(data) {\n", indent, size))
+		buf.fail(indent+"\t", "ErrUnexpectedEOF", "*offset")
+		buf.WriteString(indent + "}\n")
+		buf.WriteString(fmt.Sprintf("%s*offset += %d\n", indent, size))
+
+	case parser.TypeKindNamed:
+		buf.WriteString(indent + "err = " + buf.call(typeExpr.Name) + "\n")
+		buf.WriteString(indent + "if err != nil {\n")
+		buf.fail(indent+"\t", "err", "*offset")
+		buf.WriteString(indent + "}\n")
+
+	case parser.TypeKindArray:
+		return generateArraySkip(buf, typeExpr, indent)
+
+	default:
+		return fmt.Errorf("unknown type kind: %v", typeExpr.Kind)
+	}
+
+	return nil
+}
+
+// generateStringSkip generates code that bounds-checks a length-prefixed
+// string. Validation also checks UTF-8 validity; measuring adds the length to
+// size.strings and leaves the strict UTF-8 check to the decoding pass.
+func generateStringSkip(buf skipCode, indent string) {
+	buf.WriteString(indent + "if *offset + 4 > len(data) {\n")
+	buf.fail(indent+"\t", "ErrUnexpectedEOF", "*offset")
+	buf.WriteString(indent + "}\n")
+	buf.WriteString(indent + "strLen = binary.LittleEndian.Uint32(data[*offset:])\n")
+	buf.WriteString(indent + "*offset += 4\n")
+	buf.WriteString(indent + "if *offset + int(strLen) > len(data) {\n")
+	buf.fail(indent+"\t", "ErrUnexpectedEOF", "*offset")
+	buf.WriteString(indent + "}\n")
+	if buf.measure {
+		buf.count(indent, "strings", "int(strLen)")
+	} else {
+		buf.WriteString(indent + "if !utf8.Valid(data[*offset:*offset+int(strLen)]) {\n")
+		buf.fail(indent+"\t", "ErrInvalidUTF8", "*offset")
+		buf.WriteString(indent + "}\n")
+	}
+	buf.WriteString(indent + "*offset += int(strLen)\n")
+}
+
+// generateArraySkip generates code that checks an array count against the
+// DecodeContext limits and advances offset over its elements. Fixed-size
+// elements are skipped with a single bounds check.
+func generateArraySkip(buf skipCode, typeExpr *parser.TypeExpr, indent string) error {
+	elem := typeExpr.Elem
+	if elem == nil {
+		return fmt.Errorf("array type has no element type")
+	}
+
+	buf.WriteString(indent + "if *offset + 4 > len(data) {\n")
+	buf.fail(indent+"\t", "ErrUnexpectedEOF", "*offset")
+	buf.WriteString(indent + "}\n")
+	buf.WriteString(indent + "arrCount = binary.LittleEndian.Uint32(data[*offset:])\n")
+	buf.WriteString(indent + "*offset += 4\n")
+	buf.WriteString(indent + "err = ctx.checkArraySize(arrCount)\n")
+	buf.WriteString(indent + "if err != nil {\n")
+	buf.fail(indent+"\t", "err", "*offset-4")
+	buf.WriteString(indent + "}\n")
+
+	switch elem.Kind {
+	case parser.TypeKindPrimitive:
+		if elem.Name == "str" {
+			buf.count(indent, "strs", "int(arrCount)")
+			buf.WriteString(indent + "for i := uint32(0); i < arrCount; i++ {\n")
+			elem := buf
+			elem.element = true
+			generateStringSkip(elem, indent+"\t")
+			buf.WriteString(indent + "}\n")
+			return nil
+		}
+		size := getPrimitiveSize(elem.Name)
+		if size == 0 {
+			return fmt.Errorf("unknown primitive type: %s", elem.Name)
+		}
+		buf.WriteString(fmt.Sprintf("%sif *offset + int(arrCount)*%d > len(data) {\n", indent, size))
+		buf.fail(indent+"\t", "ErrUnexpectedEOF", "*offset")
+		buf.WriteString(indent + "}\n")
+		buf.count(indent, "bytes", fmt.Sprintf("(int(arrCount)*%d + 7) &^ 7", size))
+		buf.WriteString(fmt.Sprintf("%s*offset += int(arrCount)*%d\n", indent, size))
+
+	case parser.TypeKindNamed:
+		buf.count(indent, ToGoName(elem.Name), "int(arrCount)")
+		buf.WriteString(indent + "for i := uint32(0); i < arrCount; i++ {\n")
+		buf.WriteString(indent + "\terr = " + buf.call(elem.Name) + "\n")
+		buf.WriteString(indent + "\tif err != nil {\n")
+		buf.fail(indent+"\t\t", "decodeElementError(err, i, *offset)", "*offset")
+		buf.WriteString(indent + "\t}\n")
+		buf.WriteString(indent + "}\n")
+
+	default:
+		return fmt.Errorf("nested arrays not supported")
+	}
+
+	return nil
+}
+
+// generateValidateMessage generates ValidateMessage, which checks the 10-byte
+// header and validates the payload against the struct selected by the type ID.
+func generateValidateMessage(buf *strings.Builder, schema *parser.Schema) {
+	buf.WriteString("// ValidateMessage checks that data starts with a well-formed self-describing\n")
+	buf.WriteString("// message of any type in this schema, without decoding it. The payload must\n")
+	buf.WriteString("// hold exactly one value of the type; ErrTrailingBytes is returned if it\n")
+	buf.WriteString("// extends past the value. It returns the total message length\n")
+	buf.WriteString("// (header + payload).\n")
+	buf.WriteString("func ValidateMessage(data []byte) (int, error) {\n")
+	buf.WriteString("\tif len(data) < MessageHeaderSize {\n")
+	buf.WriteString("\t\treturn 0, ErrUnexpectedEOF\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif string(data[0:3]) != MessageMagic {\n")
+	buf.WriteString("\t\treturn 0, ErrInvalidMagic\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif data[3] != MessageVersion {\n")
+	buf.WriteString("\t\treturn 0, ErrInvalidVersion\n")
+	buf.WriteString("\t}\n\n")
+
+	buf.WriteString("\ttypeID := binary.LittleEndian.Uint16(data[4:6])\n")
+	buf.WriteString("\tpayloadLength := binary.LittleEndian.Uint32(data[6:10])\n")
+	buf.WriteString("\tif payloadLength > MaxSerializedSize {\n")
+	buf.WriteString("\t\treturn 0, ErrDataTooLarge\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tend := MessageHeaderSize + int(payloadLength)\n")
+	buf.WriteString("\tif len(data) < end {\n")
+	buf.WriteString("\t\treturn 0, ErrUnexpectedEOF\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tpayload := data[MessageHeaderSize:end]\n\n")
+
+	buf.WriteString("\tvar (\n")
+	buf.WriteString("\t\tn   int\n")
+	buf.WriteString("\t\terr error\n")
+	buf.WriteString("\t)\n")
+	buf.WriteString("\tswitch typeID {\n")
+	for i, s := range schema.Structs {
+		buf.WriteString(fmt.Sprintf("\tcase %d:\n", i+1))
+		buf.WriteString("\t\tn, err = Validate")
+		buf.WriteString(ToGoName(s.Name))
+		buf.WriteString("(payload)\n")
+	}
+	buf.WriteString("\tdefault:\n")
+	buf.WriteString("\t\treturn 0, ErrUnknownMessageType\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString("\t\treturn 0, err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif n != len(payload) {\n")
+	buf.WriteString("\t\treturn 0, ErrTrailingBytes\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn end, nil\n")
+	buf.WriteString("}\n")
+}
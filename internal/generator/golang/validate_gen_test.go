@@ -0,0 +1,122 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "all field kinds",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Param",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+						},
+					},
+					{
+						Name: "Device",
+						Fields: []parser.Field{
+							{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+							{Name: "samples", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f64"}}},
+							{Name: "params", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}}},
+							{Name: "main", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param", Optional: true}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				// Entry points return the consumed length
+				if !strings.Contains(code, "func ValidateParam(data []byte) (int, error)") {
+					t.Errorf("missing ValidateParam")
+				}
+				if !strings.Contains(code, "func ValidateDevice(data []byte) (int, error)") {
+					t.Errorf("missing ValidateDevice")
+				}
+				if !strings.Contains(code, "return offset, nil") {
+					t.Errorf("entry point should return consumed length")
+				}
+				if !strings.Contains(code, "func validateDevice(data []byte, offset *int, ctx *DecodeContext) error") {
+					t.Errorf("missing validateDevice helper")
+				}
+				// Strings are checked for UTF-8 without allocating
+				if !strings.Contains(code, "if !utf8.Valid(data[*offset:*offset+int(strLen)]) {") {
+					t.Errorf("missing UTF-8 check")
+				}
+				if !strings.Contains(code, `return decodeError(ErrInvalidUTF8, "name", *offset)`) {
+					t.Errorf("UTF-8 errors should carry the field path")
+				}
+				// Arrays enforce DecodeContext limits
+				if !strings.Contains(code, "err = ctx.checkArraySize(arrCount)") {
+					t.Errorf("missing array limit check")
+				}
+				// Fixed-size arrays are skipped with one bounds check
+				if !strings.Contains(code, "*offset += int(arrCount)*8") {
+					t.Errorf("fixed-size array should be skipped in one step")
+				}
+				// Struct arrays recurse with indexed paths
+				if !strings.Contains(code, "err = validateParam(data, offset, ctx)") {
+					t.Errorf("missing nested validate call")
+				}
+				if !strings.Contains(code, "decodeElementError(err, i, *offset)") {
+					t.Errorf("missing element index annotation")
+				}
+				// Optional presence flag must be 0 or 1
+				if !strings.Contains(code, `return decodeError(ErrInvalidData, "main", *offset)`) {
+					t.Errorf("invalid presence flag should be rejected")
+				}
+				// No allocation of Go values
+				if strings.Contains(code, "make(") || strings.Contains(code, "string(data[*offset") {
+					t.Errorf("validators must not allocate values")
+				}
+				// Message mode
+				if !strings.Contains(code, "func ValidateMessage(data []byte) (int, error)") {
+					t.Errorf("missing ValidateMessage")
+				}
+				if !strings.Contains(code, "case 2:\n\t\tn, err = ValidateDevice(payload)") {
+					t.Errorf("ValidateMessage should dispatch on type ID")
+				}
+				if !strings.Contains(code, "if n != len(payload) {\n\t\treturn 0, ErrTrailingBytes") {
+					t.Errorf("ValidateMessage should reject payload bytes after the value")
+				}
+				if !strings.Contains(code, "return end, nil") {
+					t.Errorf("ValidateMessage should return the message length")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateValidators(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateValidators() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,516 @@
+package golang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateViews generates read-only, zero-copy view types over encoded data.
+//
+// For each struct type, it generates:
+//   - StructNameView, a view over the encoded bytes of one value
+//   - NewStructNameView(data []byte) (StructNameView, error), which validates
+//     data with ValidateStructName before handing out the view
+//   - one accessor method per field, which locates the field lazily
+//   - (v StructNameView) Decode(dest *StructName) error
+//   - skipStructName(data []byte, off int) int, used to step over values
+//
+// And once per schema, the generic ArrayView[T] plus shared read helpers.
+//
+// Example usage:
+//
+//	view, err := NewRegistryView(data)
+//	name := view.Plugins().At(3).Name()
+//
+// Strings are returned with unsafe.String and []u8 fields as sub-slices, so
+// both alias the input buffer, which must not be modified while in use.
+func GenerateViews(schema *parser.Schema) (string, error) {
+	if schema == nil {
+		return "", fmt.Errorf("schema is nil")
+	}
+
+	if len(schema.Structs) == 0 {
+		return "", fmt.Errorf("schema has no structs")
+	}
+
+	g := &viewGenerator{
+		structs: make(map[string]*parser.Struct),
+		sizes:   make(map[string]int),
+	}
+	for i := range schema.Structs {
+		g.structs[schema.Structs[i].Name] = &schema.Structs[i]
+	}
+
+	var buf strings.Builder
+
+	generateViewRuntime(&buf)
+	buf.WriteString("\n")
+
+	for i, s := range schema.Structs {
+		if err := g.generateStructView(&buf, &s); err != nil {
+			return "", fmt.Errorf("struct %q: %w", s.Name, err)
+		}
+		if i < len(schema.Structs)-1 {
+			buf.WriteString("\n")
+		}
+	}
+
+	return buf.String(), nil
+}
+
+// viewGenerator holds the schema lookups needed to compute field offsets.
+type viewGenerator struct {
+	structs map[string]*parser.Struct
+	sizes   map[string]int // memoized fixed encoded sizes (0 = variable)
+}
+
+// fixedSize returns the encoded size of a type if it is the same for every
+// value, or 0 if the size depends on the data (strings, arrays, optionals).
+func (g *viewGenerator) fixedSize(t *parser.TypeExpr) int {
+	if t.Optional {
+		return 0
+	}
+	switch t.Kind {
+	case parser.TypeKindPrimitive:
+		return getPrimitiveSize(t.Name)
+	case parser.TypeKindNamed:
+		return g.structSize(t.Name)
+	default:
+		return 0
+	}
+}
+
+// structSize returns the fixed encoded size of a struct, or 0 if it is variable.
+func (g *viewGenerator) structSize(name string) int {
+	if size, ok := g.sizes[name]; ok {
+		return size
+	}
+	// Guard against recursion; recursive types are always variable-size
+	g.sizes[name] = 0
+
+	s, ok := g.structs[name]
+	if !ok {
+		return 0
+	}
+	total := 0
+	for i := range s.Fields {
+		size := g.fixedSize(&s.Fields[i].Type)
+		if size == 0 {
+			return 0
+		}
+		total += size
+	}
+	g.sizes[name] = total
+	return total
+}
+
+// viewOffset emits the statements that advance `off` over a sequence of
+// fields, folding runs of fixed-size fields into a single constant.
+type viewOffset struct {
+	buf      *strings.Builder
+	g        *viewGenerator
+	indent   string
+	data     string
+	pending  int
+	declared bool
+}
+
+// flush writes out the pending constant advance, declaring off if needed.
+func (o *viewOffset) flush() {
+	if !o.declared {
+		o.buf.WriteString(fmt.Sprintf("%soff := %d\n", o.indent, o.pending))
+		o.declared = true
+	} else if o.pending > 0 {
+		o.buf.WriteString(fmt.Sprintf("%soff += %d\n", o.indent, o.pending))
+	}
+	o.pending = 0
+}
+
+// expr returns the current offset as an expression.
+func (o *viewOffset) expr() string {
+	if !o.declared {
+		return fmt.Sprintf("%d", o.pending)
+	}
+	if o.pending > 0 {
+		return fmt.Sprintf("off+%d", o.pending)
+	}
+	return "off"
+}
+
+// skip advances over one value of the given type.
+func (o *viewOffset) skip(t *parser.TypeExpr) {
+	if size := o.g.fixedSize(t); size > 0 {
+		o.pending += size
+		return
+	}
+	o.flush()
+
+	if t.Optional {
+		inner := *t
+		inner.Optional = false
+		o.buf.WriteString(fmt.Sprintf("%sif %s[off] == 0 {\n", o.indent, o.data))
+		o.buf.WriteString(fmt.Sprintf("%s\toff++\n", o.indent))
+		o.buf.WriteString(fmt.Sprintf("%s} else {\n", o.indent))
+		nested := &viewOffset{buf: o.buf, g: o.g, indent: o.indent + "\t", data: o.data, pending: 1, declared: true}
+		nested.skip(&inner)
+		nested.flush()
+		o.buf.WriteString(fmt.Sprintf("%s}\n", o.indent))
+		return
+	}
+
+	switch t.Kind {
+	case parser.TypeKindPrimitive:
+		// Only str is variable-size
+		o.buf.WriteString(fmt.Sprintf("%soff = viewSkipString(%s, off)\n", o.indent, o.data))
+	case parser.TypeKindNamed:
+		o.buf.WriteString(fmt.Sprintf("%soff = skip%s(%s, off)\n", o.indent, ToGoName(t.Name), o.data))
+	case parser.TypeKindArray:
+		if size := o.g.fixedSize(t.Elem); size > 0 {
+			o.buf.WriteString(fmt.Sprintf("%soff += 4 + int(binary.LittleEndian.Uint32(%s[off:]))*%d\n", o.indent, o.data, size))
+		} else {
+			o.buf.WriteString(fmt.Sprintf("%soff = viewSkipArray(%s, off, %s)\n", o.indent, o.data, viewSkipFunc(t.Elem)))
+		}
+	}
+}
+
+// viewSkipFunc returns the name of the function that skips one variable-size value.
+func viewSkipFunc(t *parser.TypeExpr) string {
+	if t.Kind == parser.TypeKindNamed {
+		return "skip" + ToGoName(t.Name)
+	}
+	return "viewSkipString"
+}
+
+// viewReadExpr returns an expression reading a non-optional value of type t
+// at offset o in data. Arrays of u8 are returned as []byte sub-slices.
+func (g *viewGenerator) viewReadExpr(t *parser.TypeExpr, data, o string) (string, error) {
+	switch t.Kind {
+	case parser.TypeKindPrimitive:
+		return viewPrimitiveReadExpr(t.Name, data, o)
+	case parser.TypeKindNamed:
+		return fmt.Sprintf("%sView{data: %s[%s:]}", ToGoName(t.Name), data, o), nil
+	case parser.TypeKindArray:
+		if t.Elem.Kind == parser.TypeKindPrimitive && t.Elem.Name == "u8" {
+			return fmt.Sprintf("viewBytes(%s, %s)", data, o), nil
+		}
+		elemType, err := g.viewGoType(t.Elem)
+		if err != nil {
+			return "", err
+		}
+		elemRead, err := g.viewReadExpr(t.Elem, "data", "off")
+		if err != nil {
+			return "", err
+		}
+		readFunc := fmt.Sprintf("func(data []byte, off int) %s { return %s }", elemType, elemRead)
+		if size := g.fixedSize(t.Elem); size > 0 {
+			return fmt.Sprintf("newArrayView(%s, %s, %d, %s, nil)", data, o, size, readFunc), nil
+		}
+		return fmt.Sprintf("newArrayView(%s, %s, 0, %s, %s)", data, o, readFunc, viewSkipFunc(t.Elem)), nil
+	default:
+		return "", fmt.Errorf("unknown type kind: %v", t.Kind)
+	}
+}
+
+// viewPrimitiveReadExpr returns an expression reading a primitive at offset o.
+func viewPrimitiveReadExpr(typeName, data, o string) (string, error) {
+	switch typeName {
+	case "u8":
+		return fmt.Sprintf("%s[%s]", data, o), nil
+	case "i8":
+		return fmt.Sprintf("int8(%s[%s])", data, o), nil
+	case "bool":
+		return fmt.Sprintf("%s[%s] != 0", data, o), nil
+	case "u16":
+		return fmt.Sprintf("binary.LittleEndian.Uint16(%s[%s:])", data, o), nil
+	case "u32":
+		return fmt.Sprintf("binary.LittleEndian.Uint32(%s[%s:])", data, o), nil
+	case "u64":
+		return fmt.Sprintf("binary.LittleEndian.Uint64(%s[%s:])", data, o), nil
+	case "i16":
+		return fmt.Sprintf("int16(binary.LittleEndian.Uint16(%s[%s:]))", data, o), nil
+	case "i32":
+		return fmt.Sprintf("int32(binary.LittleEndian.Uint32(%s[%s:]))", data, o), nil
+	case "i64":
+		return fmt.Sprintf("int64(binary.LittleEndian.Uint64(%s[%s:]))", data, o), nil
+	case "f32":
+		return fmt.Sprintf("math.Float32frombits(binary.LittleEndian.Uint32(%s[%s:]))", data, o), nil
+	case "f64":
+		return fmt.Sprintf("math.Float64frombits(binary.LittleEndian.Uint64(%s[%s:]))", data, o), nil
+	case "str":
+		return fmt.Sprintf("viewString(%s, %s)", data, o), nil
+	default:
+		return "", fmt.Errorf("unknown primitive type: %s", typeName)
+	}
+}
+
+// viewGoType returns the Go type an accessor returns for a non-optional type.
+func (g *viewGenerator) viewGoType(t *parser.TypeExpr) (string, error) {
+	switch t.Kind {
+	case parser.TypeKindPrimitive:
+		if t.Name == "str" {
+			return "string", nil
+		}
+		goType, ok := primitiveTypeMap[t.Name]
+		if !ok {
+			return "", fmt.Errorf("unknown primitive type: %s", t.Name)
+		}
+		return goType, nil
+	case parser.TypeKindNamed:
+		return ToGoName(t.Name) + "View", nil
+	case parser.TypeKindArray:
+		if t.Elem.Kind == parser.TypeKindPrimitive && t.Elem.Name == "u8" {
+			return "[]byte", nil
+		}
+		elemType, err := g.viewGoType(t.Elem)
+		if err != nil {
+			return "", err
+		}
+		return "ArrayView[" + elemType + "]", nil
+	default:
+		return "", fmt.Errorf("unknown type kind: %v", t.Kind)
+	}
+}
+
+// generateStructView generates the view type, constructor, accessors and skip
+// function for a single struct.
+func (g *viewGenerator) generateStructView(buf *strings.Builder, s *parser.Struct) error {
+	structName := ToGoName(s.Name)
+	viewName := structName + "View"
+
+	buf.WriteString("// ")
+	buf.WriteString(viewName)
+	buf.WriteString(" is a read-only, zero-copy view of an encoded ")
+	buf.WriteString(structName)
+	buf.WriteString(".\n")
+	buf.WriteString("// Accessors locate fields on demand; the underlying buffer must not be\n")
+	buf.WriteString("// modified while the view or any string or slice obtained from it is in use.\n")
+	buf.WriteString("type ")
+	buf.WriteString(viewName)
+	buf.WriteString(" struct {\n")
+	buf.WriteString("\tdata []byte\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// New")
+	buf.WriteString(viewName)
+	buf.WriteString(" validates data with Validate")
+	buf.WriteString(structName)
+	buf.WriteString(" and returns a view over it.\n")
+	buf.WriteString("func New")
+	buf.WriteString(viewName)
+	buf.WriteString("(data []byte) (")
+	buf.WriteString(viewName)
+	buf.WriteString(", error) {\n")
+	buf.WriteString("\tif _, err := Validate")
+	buf.WriteString(structName)
+	buf.WriteString("(data); err != nil {\n")
+	buf.WriteString("\t\treturn ")
+	buf.WriteString(viewName)
+	buf.WriteString("{}, err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn ")
+	buf.WriteString(viewName)
+	buf.WriteString("{data: data}, nil\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Decode materializes the full ")
+	buf.WriteString(structName)
+	buf.WriteString(" value.\n")
+	buf.WriteString("func (v ")
+	buf.WriteString(viewName)
+	buf.WriteString(") Decode(dest *")
+	buf.WriteString(structName)
+	buf.WriteString(") error {\n")
+	buf.WriteString("\treturn Decode")
+	buf.WriteString(structName)
+	buf.WriteString("(dest, v.data)\n")
+	buf.WriteString("}\n\n")
+
+	for i := range s.Fields {
+		if err := g.generateViewAccessor(buf, viewName, s.Fields[:i], &s.Fields[i]); err != nil {
+			return fmt.Errorf("field %q: %w", s.Fields[i].Name, err)
+		}
+		buf.WriteString("\n")
+	}
+
+	// Skip function, used for arrays of this struct and fields after it
+	buf.WriteString("// skip")
+	buf.WriteString(structName)
+	buf.WriteString(" returns the offset just past the ")
+	buf.WriteString(structName)
+	buf.WriteString(" value starting at off.\n")
+	buf.WriteString("func skip")
+	buf.WriteString(structName)
+	buf.WriteString("(data []byte, off int) int {\n")
+	o := &viewOffset{buf: buf, g: g, indent: "\t", data: "data", declared: true}
+	for i := range s.Fields {
+		o.skip(&s.Fields[i].Type)
+	}
+	o.flush()
+	buf.WriteString("\treturn off\n")
+	buf.WriteString("}\n")
+
+	return nil
+}
+
+// generateViewAccessor generates the accessor method for one field. The
+// preceding fields are skipped to find its offset.
+func (g *viewGenerator) generateViewAccessor(buf *strings.Builder, viewName string, before []parser.Field, field *parser.Field) error {
+	methodName := ToGoName(field.Name)
+
+	inner := field.Type
+	inner.Optional = false
+	goType, err := g.viewGoType(&inner)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString("// ")
+	buf.WriteString(methodName)
+	buf.WriteString(" returns the ")
+	buf.WriteString(field.Name)
+	buf.WriteString(" field")
+	if field.Type.Optional {
+		buf.WriteString(" and whether it is present")
+	}
+	buf.WriteString(".\n")
+	buf.WriteString("func (v ")
+	buf.WriteString(viewName)
+	buf.WriteString(") ")
+	buf.WriteString(methodName)
+	if field.Type.Optional {
+		buf.WriteString("() (value ")
+		buf.WriteString(goType)
+		buf.WriteString(", ok bool) {\n")
+	} else {
+		buf.WriteString("() ")
+		buf.WriteString(goType)
+		buf.WriteString(" {\n")
+	}
+
+	o := &viewOffset{buf: buf, g: g, indent: "\t", data: "v.data"}
+	for i := range before {
+		o.skip(&before[i].Type)
+	}
+
+	if field.Type.Optional {
+		o.flush()
+		buf.WriteString("\tif v.data[off] == 0 {\n")
+		buf.WriteString("\t\treturn\n")
+		buf.WriteString("\t}\n")
+		read, err := g.viewReadExpr(&inner, "v.data", "off+1")
+		if err != nil {
+			return err
+		}
+		buf.WriteString("\treturn ")
+		buf.WriteString(read)
+		buf.WriteString(", true\n")
+	} else {
+		read, err := g.viewReadExpr(&inner, "v.data", o.expr())
+		if err != nil {
+			return err
+		}
+		buf.WriteString("\treturn ")
+		buf.WriteString(read)
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("}\n")
+	return nil
+}
+
+// generateViewRuntime generates the schema-independent parts of the view
+// API: the generic ArrayView and the string and array helpers. Views are
+// only created over validated data, so the helpers do no bounds checking
+// of their own.
+func generateViewRuntime(buf *strings.Builder) {
+	buf.WriteString("// ArrayView is a read-only view of an encoded array. Elements are read\n")
+	buf.WriteString("// from the underlying buffer on access; nothing is copied up front.\n")
+	buf.WriteString("type ArrayView[T any] struct {\n")
+	buf.WriteString("\tdata []byte\n")
+	buf.WriteString("\toff  int  // Offset of the first element\n")
+	buf.WriteString("\tn    int  // Element count\n")
+	buf.WriteString("\tsize int  // Element size, or 0 for variable-size elements\n")
+	buf.WriteString("\tread func(data []byte, off int) T\n")
+	buf.WriteString("\tskip func(data []byte, off int) int\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// newArrayView creates an ArrayView over the array whose count prefix is at off.\n")
+	buf.WriteString("func newArrayView[T any](data []byte, off int, size int, read func([]byte, int) T, skip func([]byte, int) int) ArrayView[T] {\n")
+	buf.WriteString("\tn := int(binary.LittleEndian.Uint32(data[off:]))\n")
+	buf.WriteString("\treturn ArrayView[T]{data: data, off: off + 4, n: n, size: size, read: read, skip: skip}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Len returns the number of elements.\n")
+	buf.WriteString("func (a ArrayView[T]) Len() int {\n")
+	buf.WriteString("\treturn a.n\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// At returns element i. Fixed-size elements are located directly;\n")
+	buf.WriteString("// variable-size elements (strings, structs) are found by skipping the\n")
+	buf.WriteString("// preceding ones, so use All to visit every element. At panics if i is\n")
+	buf.WriteString("// out of range.\n")
+	buf.WriteString("func (a ArrayView[T]) At(i int) T {\n")
+	buf.WriteString("\tif i < 0 || i >= a.n {\n")
+	buf.WriteString("\t\tpanic(\"ArrayView: index out of range\")\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif a.size > 0 {\n")
+	buf.WriteString("\t\treturn a.read(a.data, a.off+i*a.size)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\toff := a.off\n")
+	buf.WriteString("\tfor ; i > 0; i-- {\n")
+	buf.WriteString("\t\toff = a.skip(a.data, off)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn a.read(a.data, off)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// All returns an iterator over the index and value of every element.\n")
+	buf.WriteString("func (a ArrayView[T]) All() iter.Seq2[int, T] {\n")
+	buf.WriteString("\treturn func(yield func(int, T) bool) {\n")
+	buf.WriteString("\t\toff := a.off\n")
+	buf.WriteString("\t\tfor i := 0; i < a.n; i++ {\n")
+	buf.WriteString("\t\t\tif !yield(i, a.read(a.data, off)) {\n")
+	buf.WriteString("\t\t\t\treturn\n")
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\t\tif a.size > 0 {\n")
+	buf.WriteString("\t\t\t\toff += a.size\n")
+	buf.WriteString("\t\t\t} else {\n")
+	buf.WriteString("\t\t\t\toff = a.skip(a.data, off)\n")
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// viewString returns the length-prefixed string at off without copying.\n")
+	buf.WriteString("func viewString(data []byte, off int) string {\n")
+	buf.WriteString("\tn := int(binary.LittleEndian.Uint32(data[off:]))\n")
+	buf.WriteString("\tif n == 0 {\n")
+	buf.WriteString("\t\treturn \"\"\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn unsafe.String(&data[off+4], n)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// viewSkipString returns the offset just past the string at off.\n")
+	buf.WriteString("func viewSkipString(data []byte, off int) int {\n")
+	buf.WriteString("\treturn off + 4 + int(binary.LittleEndian.Uint32(data[off:]))\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// viewBytes returns the []u8 array at off as a sub-slice of data.\n")
+	buf.WriteString("// Its capacity is clipped so appending to it cannot overwrite data.\n")
+	buf.WriteString("func viewBytes(data []byte, off int) []byte {\n")
+	buf.WriteString("\tend := off + 4 + int(binary.LittleEndian.Uint32(data[off:]))\n")
+	buf.WriteString("\treturn data[off+4 : end : end]\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// viewSkipArray returns the offset just past the array of variable-size\n")
+	buf.WriteString("// elements at off, using skip to step over each element.\n")
+	buf.WriteString("func viewSkipArray(data []byte, off int, skip func([]byte, int) int) int {\n")
+	buf.WriteString("\tn := int(binary.LittleEndian.Uint32(data[off:]))\n")
+	buf.WriteString("\toff += 4\n")
+	buf.WriteString("\tfor i := 0; i < n; i++ {\n")
+	buf.WriteString("\t\toff = skip(data, off)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn off\n")
+	buf.WriteString("}\n")
+}
@@ -0,0 +1,125 @@
+package golang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+func TestGenerateViews(t *testing.T) {
+	tests := []struct {
+		name      string
+		schema    *parser.Schema
+		wantErr   bool
+		checkFunc func(t *testing.T, code string)
+	}{
+		{
+			name:    "nil schema",
+			schema:  nil,
+			wantErr: true,
+		},
+		{
+			name:    "empty schema",
+			schema:  &parser.Schema{Structs: []parser.Struct{}},
+			wantErr: true,
+		},
+		{
+			name: "all field kinds",
+			schema: &parser.Schema{
+				Structs: []parser.Struct{
+					{
+						Name: "Param",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+							{Name: "gain", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "f32"}},
+						},
+					},
+					{
+						Name: "Device",
+						Fields: []parser.Field{
+							{Name: "id", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u16"}},
+							{Name: "name", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}},
+							{Name: "blob", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u8"}}},
+							{Name: "params", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param"}}},
+							{Name: "tags", Type: parser.TypeExpr{Kind: parser.TypeKindArray, Elem: &parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "str"}}},
+							{Name: "main", Type: parser.TypeExpr{Kind: parser.TypeKindNamed, Name: "Param", Optional: true}},
+							{Name: "flags", Type: parser.TypeExpr{Kind: parser.TypeKindPrimitive, Name: "u32"}},
+						},
+					},
+				},
+			},
+			wantErr: false,
+			checkFunc: func(t *testing.T, code string) {
+				// Shared runtime
+				if !strings.Contains(code, "type ArrayView[T any] struct {") {
+					t.Errorf("missing ArrayView")
+				}
+				if !strings.Contains(code, "func (a ArrayView[T]) All() iter.Seq2[int, T] {") {
+					t.Errorf("missing ArrayView.All iterator")
+				}
+				if !strings.Contains(code, "return unsafe.String(&data[off+4], n)") {
+					t.Errorf("strings should be returned without copying")
+				}
+				// Constructor shares validation with the decoder
+				if !strings.Contains(code, "func NewDeviceView(data []byte) (DeviceView, error) {") {
+					t.Errorf("missing NewDeviceView")
+				}
+				if !strings.Contains(code, "if _, err := ValidateDevice(data); err != nil {") {
+					t.Errorf("constructor should validate")
+				}
+				if !strings.Contains(code, "func (v DeviceView) Decode(dest *Device) error {") {
+					t.Errorf("missing Decode method")
+				}
+				// Fixed-size prefixes fold into constants
+				if !strings.Contains(code, "func (v DeviceView) Name() string {\n\treturn viewString(v.data, 2)\n}") {
+					t.Errorf("Name offset should be constant")
+				}
+				if !strings.Contains(code, "func (v DeviceView) Blob() []byte {") {
+					t.Errorf("[]u8 should be exposed as []byte")
+				}
+				// Fixed-size struct elements are indexed directly
+				if !strings.Contains(code, "func (v DeviceView) Params() ArrayView[ParamView] {") {
+					t.Errorf("missing Params accessor")
+				}
+				if !strings.Contains(code, "return ParamView{data: data[off:]} }, nil)") {
+					t.Errorf("fixed-size struct array should not need a skip function")
+				}
+				if !strings.Contains(code, "off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*8") {
+					t.Errorf("fixed-size struct array should be skipped in one step")
+				}
+				// Variable-size elements are walked
+				if !strings.Contains(code, "viewSkipString)") {
+					t.Errorf("string array should use viewSkipString")
+				}
+				// Optionals return (value, ok)
+				if !strings.Contains(code, "func (v DeviceView) Main() (value ParamView, ok bool) {") {
+					t.Errorf("missing optional accessor")
+				}
+				if !strings.Contains(code, "return ParamView{data: v.data[off+1:]}, true") {
+					t.Errorf("optional accessor should skip the presence byte")
+				}
+				// Skip functions
+				if !strings.Contains(code, "func skipParam(data []byte, off int) int {\n\toff += 8\n\treturn off\n}") {
+					t.Errorf("skipParam should advance by the fixed size")
+				}
+				if !strings.Contains(code, "func skipDevice(data []byte, off int) int {") {
+					t.Errorf("missing skipDevice")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := GenerateViews(tt.schema)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GenerateViews() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.checkFunc != nil {
+				tt.checkFunc(t, code)
+			}
+		})
+	}
+}
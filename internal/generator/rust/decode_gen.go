@@ -85,19 +85,19 @@ func generateFieldDecode(buf *strings.Builder, field *parser.Field, indent strin
 		fixedSize := FixedSize(field.Type.Name)
 		if fixedSize > 0 {
 			// Fixed-size primitive
-			buf.WriteString(fmt.Sprintf("%slet %s = wire_slice::decode_%s(buf, offset)?;\n",
-				indent, fieldName, wireType))
+			buf.WriteString(fmt.Sprintf("%slet %s = wire_slice::decode_%s(buf, offset)%s;\n",
+				indent, fieldName, wireType, fieldErr(field.Name)))
 			buf.WriteString(fmt.Sprintf("%soffset += %d;\n", indent, fixedSize))
 		} else {
 			// Variable-size (string, bytes)
-			buf.WriteString(fmt.Sprintf("%slet (%s, consumed) = wire_slice::decode_%s(buf, offset)?;\n",
-				indent, fieldName, wireType))
+			buf.WriteString(fmt.Sprintf("%slet (%s, consumed) = wire_slice::decode_%s(buf, offset)%s;\n",
+				indent, fieldName, wireType, fieldErr(field.Name)))
 			buf.WriteString(fmt.Sprintf("%soffset += consumed;\n", indent))
 		}
 	case parser.TypeKindNamed:
 		// Nested struct
-		buf.WriteString(fmt.Sprintf("%slet %s = %s::decode_from_slice(&buf[offset..])?;\n",
-			indent, fieldName, field.Type.Name))
+		buf.WriteString(fmt.Sprintf("%slet %s = %s::decode_from_slice(&buf[offset..])%s;\n",
+			indent, fieldName, field.Type.Name, fieldErr(field.Name)))
 		buf.WriteString(fmt.Sprintf("%soffset += %s.encoded_size();\n", indent, fieldName))
 	}
 
@@ -114,12 +114,12 @@ func generateArrayDecode(buf *strings.Builder, field *parser.Field, indent strin
 	}
 
 	// Decode array length
-	buf.WriteString(fmt.Sprintf("%slet array_len = wire_slice::decode_u32(buf, offset)? as usize;\n", indent))
+	buf.WriteString(fmt.Sprintf("%slet array_len = wire_slice::decode_u32(buf, offset)%s as usize;\n", indent, fieldErr(field.Name)))
 	buf.WriteString(fmt.Sprintf("%soffset += 4;\n", indent))
 
 	// Check if we can use bulk copy optimization for primitive integer arrays
 	if elemType.Kind == parser.TypeKindPrimitive && CanUseBulkCopy(elemType.Name) {
-		generateBulkArrayDecode(buf, elemType.Name, fieldName, indent)
+		generateBulkArrayDecode(buf, elemType.Name, field.Name, fieldName, indent)
 		return nil
 	}
 
@@ -128,7 +128,7 @@ func generateArrayDecode(buf *strings.Builder, field *parser.Field, indent strin
 	buf.WriteString(fmt.Sprintf("%slet mut %s = Vec::with_capacity(array_len);\n", indent, fieldName))
 
 	// Decode array elements
-	buf.WriteString(fmt.Sprintf("%sfor _ in 0..array_len {\n", indent))
+	buf.WriteString(fmt.Sprintf("%sfor i in 0..array_len {\n", indent))
 
 	switch elemType.Kind {
 	case parser.TypeKindPrimitive:
@@ -137,21 +137,22 @@ func generateArrayDecode(buf *strings.Builder, field *parser.Field, indent strin
 
 		if fixedSize > 0 {
 			// Fixed-size element
-			buf.WriteString(fmt.Sprintf("%s    let item = wire_slice::decode_%s(buf, offset)?;\n",
-				indent, wireType))
+			buf.WriteString(fmt.Sprintf("%s    let item = wire_slice::decode_%s(buf, offset)%s;\n",
+				indent, wireType, elementErr(field.Name)))
 			buf.WriteString(fmt.Sprintf("%s    offset += %d;\n", indent, fixedSize))
 		} else {
 			// Variable-size element (string, bytes)
-			buf.WriteString(fmt.Sprintf("%s    let (item, consumed) = wire_slice::decode_%s(buf, offset)?;\n",
-				indent, wireType))
+			buf.WriteString(fmt.Sprintf("%s    let (item, consumed) = wire_slice::decode_%s(buf, offset)%s;\n",
+				indent, wireType, elementErr(field.Name)))
 			buf.WriteString(fmt.Sprintf("%s    offset += consumed;\n", indent))
 		}
 
 		buf.WriteString(fmt.Sprintf("%s    %s.push(item);\n", indent, fieldName))
 	case parser.TypeKindNamed:
 		// Array of structs
-		buf.WriteString(fmt.Sprintf("%s    let item = %s::decode_from_slice(&buf[offset..])?;\n",
-			indent, elemType.Name))
+		// Element errors carry the index in the path, e.g. "parameters[3].name"
+		buf.WriteString(fmt.Sprintf("%s    let item = %s::decode_from_slice(&buf[offset..])\n", indent, elemType.Name))
+		buf.WriteString(fmt.Sprintf("%s        %s;\n", indent, elementErr(field.Name)))
 		buf.WriteString(fmt.Sprintf("%s    offset += item.encoded_size();\n", indent))
 		buf.WriteString(fmt.Sprintf("%s    %s.push(item);\n", indent, fieldName))
 	}
@@ -162,7 +163,7 @@ func generateArrayDecode(buf *strings.Builder, field *parser.Field, indent strin
 }
 
 // generateBulkArrayDecode generates optimized bulk copy code for primitive integer arrays
-func generateBulkArrayDecode(buf *strings.Builder, elemType, fieldName, indent string) {
+func generateBulkArrayDecode(buf *strings.Builder, elemType, schemaFieldName, fieldName, indent string) {
 	elemSize := FixedSize(elemType)
 
 	buf.WriteString(fmt.Sprintf("%s// Bulk decode optimization for primitive arrays\n", indent))
@@ -170,7 +171,7 @@ func generateBulkArrayDecode(buf *strings.Builder, elemType, fieldName, indent s
 
 	if elemType == "u8" || elemType == "i8" {
 		// Single-byte types: direct slice copy
-		buf.WriteString(fmt.Sprintf("%s    wire_slice::check_bounds(buf, offset, array_len)?;\n", indent))
+		buf.WriteString(fmt.Sprintf("%s    wire_slice::check_bounds(buf, offset, array_len)%s;\n", indent, fieldErr(schemaFieldName)))
 		buf.WriteString(fmt.Sprintf("%s    let slice = &buf[offset..offset + array_len];\n", indent))
 		buf.WriteString(fmt.Sprintf("%s    offset += array_len;\n", indent))
 		if elemType == "i8" {
@@ -182,7 +183,7 @@ func generateBulkArrayDecode(buf *strings.Builder, elemType, fieldName, indent s
 		// Multi-byte types: use bytemuck for zero-copy byte view
 		// Note: cast_slice requires proper alignment, so we use try_cast_slice with fallback
 		buf.WriteString(fmt.Sprintf("%s    let byte_len = array_len * %d;\n", indent, elemSize))
-		buf.WriteString(fmt.Sprintf("%s    wire_slice::check_bounds(buf, offset, byte_len)?;\n", indent))
+		buf.WriteString(fmt.Sprintf("%s    wire_slice::check_bounds(buf, offset, byte_len)%s;\n", indent, fieldErr(schemaFieldName)))
 		buf.WriteString(fmt.Sprintf("%s    let bytes = &buf[offset..offset + byte_len];\n", indent))
 		buf.WriteString(fmt.Sprintf("%s    offset += byte_len;\n", indent))
 		// Use try_cast_slice which checks alignment, fallback to pod_read_unaligned if misaligned
@@ -206,7 +207,7 @@ func generateOptionalDecode(buf *strings.Builder, field *parser.Field, indent st
 	fieldName := ToRustName(field.Name)
 
 	// Decode presence flag
-	buf.WriteString(fmt.Sprintf("%slet present = wire_slice::decode_bool(buf, offset)?;\n", indent))
+	buf.WriteString(fmt.Sprintf("%slet present = wire_slice::decode_bool(buf, offset)%s;\n", indent, fieldErr(field.Name)))
 	buf.WriteString(fmt.Sprintf("%soffset += 1;\n", indent))
 
 	// Decode value if present
@@ -229,19 +230,19 @@ func generateOptionalDecode(buf *strings.Builder, field *parser.Field, indent st
 		fixedSize := FixedSize(innerField.Type.Name)
 
 		if fixedSize > 0 {
-			buf.WriteString(fmt.Sprintf("%slet value = wire_slice::decode_%s(buf, offset)?;\n",
-				innerIndent, wireType))
+			buf.WriteString(fmt.Sprintf("%slet value = wire_slice::decode_%s(buf, offset)%s;\n",
+				innerIndent, wireType, fieldErr(field.Name)))
 			buf.WriteString(fmt.Sprintf("%soffset += %d;\n", innerIndent, fixedSize))
 		} else {
-			buf.WriteString(fmt.Sprintf("%slet (value, consumed) = wire_slice::decode_%s(buf, offset)?;\n",
-				innerIndent, wireType))
+			buf.WriteString(fmt.Sprintf("%slet (value, consumed) = wire_slice::decode_%s(buf, offset)%s;\n",
+				innerIndent, wireType, fieldErr(field.Name)))
 			buf.WriteString(fmt.Sprintf("%soffset += consumed;\n", innerIndent))
 		}
 
 		buf.WriteString(fmt.Sprintf("%sSome(value)\n", innerIndent))
 	case parser.TypeKindNamed:
-		buf.WriteString(fmt.Sprintf("%slet value = %s::decode_from_slice(&buf[offset..])?;\n",
-			innerIndent, innerField.Type.Name))
+		buf.WriteString(fmt.Sprintf("%slet value = %s::decode_from_slice(&buf[offset..])%s;\n",
+			innerIndent, innerField.Type.Name, fieldErr(field.Name)))
 		buf.WriteString(fmt.Sprintf("%soffset += value.encoded_size();\n", innerIndent))
 		buf.WriteString(fmt.Sprintf("%sSome(value)\n", innerIndent))
 	}
@@ -252,3 +253,14 @@ func generateOptionalDecode(buf *strings.Builder, field *parser.Field, indent st
 
 	return nil
 }
+
+// fieldErr returns the error propagation suffix that attaches the field name
+// and current offset to any decode error before returning it.
+func fieldErr(schemaFieldName string) string {
+	return fmt.Sprintf(".map_err(|e| wire_slice::field_error(e, %q, offset))?", schemaFieldName)
+}
+
+// elementErr is fieldErr for element i of an array field, e.g. "names[3]".
+func elementErr(schemaFieldName string) string {
+	return fmt.Sprintf(".map_err(|e| wire_slice::field_error(e, &format!(\"%s[{}]\", i), offset))?", schemaFieldName)
+}
@@ -18,6 +18,7 @@ import (
 //   - src/types.rs: Struct definitions with derive macros
 //   - src/encode.rs: Slice-based encoding (fast path for IPC)
 //   - src/decode.rs: Slice-based decoding
+//   - src/json.rs: serde adapters for the optional "serde" feature
 //
 // The generated code uses the sdp crate's wire_slice module for
 // maximum performance (4x faster than trait-based encoding).
@@ -83,6 +84,11 @@ func Generate(schema *parser.Schema, outputDir string, verbose bool) error {
 		return fmt.Errorf("failed to generate wire runtime: %w", err)
 	}
 
+	// Generate json.rs (serde adapters for the canonical JSON mapping)
+	if err := GenerateJSONRuntime(srcDir, verbose); err != nil {
+		return fmt.Errorf("failed to generate json.rs: %w", err)
+	}
+
 	// Note: No example/benchmark server generation - benchmarking is external
 	// Use shell scripts + Make targets for cross-language testing instead
 
@@ -93,16 +99,20 @@ func Generate(schema *parser.Schema, outputDir string, verbose bool) error {
 	return nil
 }
 
+// crateName returns the Cargo package name of the generated crate.
+func crateName(schema *parser.Schema) string {
+	if len(schema.Structs) == 0 {
+		return "sdp-generated"
+	}
+	// Use first struct name as package hint
+	return "sdp-" + toSnakeCase(schema.Structs[0].Name)
+}
+
 // generateCargoToml creates Cargo.toml with aggressive optimizations
 func generateCargoToml(schema *parser.Schema, outputDir string, verbose bool) error {
 	filepath := filepath.Join(outputDir, "Cargo.toml")
 
-	// Determine package name from schema or directory
-	packageName := "sdp-generated"
-	if len(schema.Structs) > 0 {
-		// Use first struct name as package hint
-		packageName = "sdp-" + toSnakeCase(schema.Structs[0].Name)
-	}
+	packageName := crateName(schema)
 	serverName := toSnakeCase(schema.Structs[0].Name) + "_server"
 
 	var content string
@@ -117,7 +127,8 @@ func generateCargoToml(schema *parser.Schema, outputDir string, verbose bool) er
 	content += "[dependencies]\n"
 	content += "# Dependencies for zero-copy operations\n"
 	content += "byteorder = \"1.5\"  # Endianness handling\n"
-	content += "bytemuck = \"1.14\"  # Safe zero-copy byte casting for bulk array operations\n\n"
+	content += "bytemuck = \"1.14\"  # Safe zero-copy byte casting for bulk array operations\n"
+	content += "serde = { version = \"1\", features = [\"derive\"], optional = true }  # JSON mapping (feature \"serde\")\n\n"
 
 	content += "[features]\n"
 	content += "# Benchmark server mode (for cross-language performance testing)\n"
@@ -193,6 +204,10 @@ func generateLib(_ *parser.Schema, outputDir string, verbose bool) error {
 	content += "pub use decode::*;\n"
 	content += "pub use message_encode::*;\n"
 	content += "pub use message_decode::*;\n\n"
+	content += "// Canonical JSON mapping adapters (enable with --features serde)\n"
+	content += "#[cfg(feature = \"serde\")]\n"
+	content += "#[doc(hidden)]\n"
+	content += "pub mod json;\n\n"
 	content += "// Re-export common wire format types\n"
 	content += "pub use wire::{Error, Result, Encoder, Decoder};\n"
 	content += "pub use wire_slice::{SliceError, SliceResult};\n"
@@ -336,6 +351,21 @@ func generateWireRuntime(srcDir string, verbose bool) error {
 	return nil
 }
 
+// GenerateJSONRuntime writes json.rs with the serde adapters used by the
+// optional "serde" feature. The rustexp generator shares it.
+func GenerateJSONRuntime(srcDir string, verbose bool) error {
+	jsonPath := filepath.Join(srcDir, "json.rs")
+	if err := os.WriteFile(jsonPath, []byte(jsonRuntime), 0644); err != nil {
+		return err
+	}
+
+	if verbose {
+		fmt.Printf("  Generated json.rs (serde adapters)\n")
+	}
+
+	return nil
+}
+
 // generateExampleHelper creates the benchmark server example
 func generateExampleHelper(schema *parser.Schema, outputDir string, benchMode bool, verbose bool) error {
 	// Create examples directory
@@ -269,6 +269,14 @@ pub enum SliceError {
     ArrayTooLarge { size: u32, max: u32 },
     /// Invalid boolean value (must be 0 or 1)
     InvalidBool(u8),
+    /// Error inside a field: path is the field path from the outermost
+    /// struct (e.g. "plugins[12].parameters[3].unit") and offset is the
+    /// byte offset of the failing field
+    Field {
+        path: String,
+        offset: usize,
+        source: Box<SliceError>,
+    },
 }
 
 impl std::fmt::Display for SliceError {
@@ -282,11 +290,54 @@ impl std::fmt::Display for SliceError {
                 write!(f, "Array too large: {} > {} max", size, max)
             }
             SliceError::InvalidBool(v) => write!(f, "Invalid boolean value: {}", v),
+            SliceError::Field { path, offset, source } => {
+                write!(f, "decode {} at offset {}: {}", path, offset, source)
+            }
+        }
+    }
+}
+
+impl std::error::Error for SliceError {
+    fn source(&self) -> Option<&(dyn std::error::Error + 'static)> {
+        match self {
+            SliceError::InvalidUtf8(e) => Some(e),
+            SliceError::Field { source, .. } => Some(source.as_ref()),
+            _ => None,
+        }
+    }
+}
+
+impl SliceError {
+    /// Returns the underlying error without field context
+    pub fn root(&self) -> &SliceError {
+        match self {
+            SliceError::Field { source, .. } => source.root(),
+            e => e,
         }
     }
 }
 
-impl std::error::Error for SliceError {}
+/// Attach field context to a decode error.
+/// Nested structs decode from a sub-slice starting at offset, so errors that
+/// already carry context get their path prefixed and their offset rebased.
+#[cold]
+pub fn field_error(err: SliceError, field: &str, offset: usize) -> SliceError {
+    match err {
+        SliceError::Field { path, offset: inner, source } => {
+            let path = if path.starts_with('[') {
+                format!("{}{}", field, path)
+            } else {
+                format!("{}.{}", field, path)
+            };
+            SliceError::Field { path, offset: offset + inner, source }
+        }
+        e => SliceError::Field {
+            path: field.to_string(),
+            offset,
+            source: Box::new(e),
+        },
+    }
+}
 
 // Note: std::str::Utf8Error for slice operations (zero-copy validation)
 // Different from std::string::FromUtf8Error used in Reader API (owned data)
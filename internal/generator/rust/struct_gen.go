@@ -11,14 +11,19 @@ import (
 // It converts all struct definitions to idiomatic Rust code with:
 //   - PascalCase struct names
 //   - snake_case field names
-//   - Proper derive macros (Debug, Clone, PartialEq)
+//   - Proper derive macros (Debug, Clone, PartialEq, Default); structs with
+//     float fields implement PartialEq by hand (see generateFloatEquality)
+//   - serde derives behind the optional "serde" feature, using the
+//     canonical JSON mapping
 //   - Doc comments preserved from schema
 //   - Proper Rust type mappings
+//   - Accessors for optional fields (see generateOptionalAccessors)
 //
 // Example output:
 //
 //	/// Device represents an audio device.
-//	#[derive(Debug, Clone, PartialEq)]
+//	#[derive(Debug, Clone, PartialEq, Default)]
+//	#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 //	pub struct Device {
 //	    /// ID is the unique identifier.
 //	    pub id: u32,
@@ -54,7 +59,12 @@ func GenerateStructs(schema *parser.Schema) (string, error) {
 		}
 
 		// Generate derive macro
-		buf.WriteString("#[derive(Debug, Clone, PartialEq)]\n")
+		if hasFloatField(&s) {
+			buf.WriteString("#[derive(Debug, Clone, Default)]\n")
+		} else {
+			buf.WriteString("#[derive(Debug, Clone, PartialEq, Default)]\n")
+		}
+		buf.WriteString("#[cfg_attr(feature = \"serde\", derive(serde::Serialize, serde::Deserialize))]\n")
 
 		// Generate struct declaration
 		buf.WriteString("pub struct ")
@@ -72,6 +82,13 @@ func GenerateStructs(schema *parser.Schema) (string, error) {
 				buf.WriteString("\n")
 			}
 
+			// JSON mapping where it differs from serde's default
+			if attrs := SerdeAttrs(&field); attrs != "" {
+				buf.WriteString("    ")
+				buf.WriteString(attrs)
+				buf.WriteString("\n")
+			}
+
 			// Field declaration
 			buf.WriteString("    pub ")
 			buf.WriteString(ToRustName(field.Name))
@@ -87,11 +104,118 @@ func GenerateStructs(schema *parser.Schema) (string, error) {
 		}
 
 		buf.WriteString("}\n")
+
+		generateFloatEquality(&buf, &s)
+		generateOptionalAccessors(&buf, &s)
 	}
 
 	return buf.String(), nil
 }
 
+// isFloatType reports whether a type holds f32/f64 values.
+func isFloatType(t *parser.TypeExpr) bool {
+	if t.Kind == parser.TypeKindArray && t.Elem != nil {
+		t = t.Elem
+	}
+	return t.Kind == parser.TypeKindPrimitive && (t.Name == "f32" || t.Name == "f64")
+}
+
+// hasFloatField reports whether a struct has an f32/f64 field or array.
+func hasFloatField(s *parser.Struct) bool {
+	for i := range s.Fields {
+		if isFloatType(&s.Fields[i].Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// generateFloatEquality implements PartialEq for a struct with float fields,
+// comparing floats by bit pattern like the Go and C++ generated code: values
+// are equal exactly when they encode to the same bytes, so a NaN equals an
+// identical NaN and 0.0 differs from -0.0. Other structs derive PartialEq.
+func generateFloatEquality(buf *strings.Builder, s *parser.Struct) {
+	if !hasFloatField(s) {
+		return
+	}
+
+	buf.WriteString("\nimpl PartialEq for ")
+	buf.WriteString(s.Name)
+	buf.WriteString(" {\n")
+	buf.WriteString("    fn eq(&self, other: &Self) -> bool {\n")
+	for i, field := range s.Fields {
+		name := ToRustName(field.Name)
+		buf.WriteString("        ")
+		if i > 0 {
+			buf.WriteString("&& ")
+		}
+		switch {
+		case isFloatType(&field.Type) && field.Type.Kind == parser.TypeKindArray:
+			buf.WriteString("self." + name + ".len() == other." + name + ".len()\n")
+			buf.WriteString("            && self." + name + ".iter().zip(&other." + name + ").all(|(a, b)| a.to_bits() == b.to_bits())")
+		case isFloatType(&field.Type):
+			buf.WriteString("self." + name + ".to_bits() == other." + name + ".to_bits()")
+		default:
+			buf.WriteString("self." + name + " == other." + name)
+		}
+		buf.WriteString("\n")
+	}
+	buf.WriteString("    }\n")
+	buf.WriteString("}\n")
+}
+
+// generateOptionalAccessors generates an impl block with accessors for the
+// optional fields of a struct, if it has any:
+//
+//	pub fn database(&self) -> &DatabaseConfig      // default value if absent
+//	pub fn has_database(&self) -> bool
+//	pub fn set_database(&mut self, value: DatabaseConfig)
+//	pub fn clear_database(&mut self)
+func generateOptionalAccessors(buf *strings.Builder, s *parser.Struct) {
+	var optional []parser.Field
+	for _, field := range s.Fields {
+		if field.Type.Optional && field.Type.Kind == parser.TypeKindNamed {
+			optional = append(optional, field)
+		}
+	}
+	if len(optional) == 0 {
+		return
+	}
+
+	buf.WriteString("\nimpl ")
+	buf.WriteString(s.Name)
+	buf.WriteString(" {\n")
+	for i, field := range optional {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		name := ToRustName(field.Name)
+		typeName := field.Type.Name
+
+		buf.WriteString("    /// Returns " + name + ", or the default value if it is absent.\n")
+		buf.WriteString("    pub fn " + name + "(&self) -> &" + typeName + " {\n")
+		buf.WriteString("        static DEFAULT: std::sync::OnceLock<" + typeName + "> = std::sync::OnceLock::new();\n")
+		buf.WriteString("        self." + name + ".as_ref().unwrap_or_else(|| DEFAULT.get_or_init(" + typeName + "::default))\n")
+		buf.WriteString("    }\n\n")
+
+		buf.WriteString("    /// Reports whether " + name + " is present.\n")
+		buf.WriteString("    pub fn has_" + name + "(&self) -> bool {\n")
+		buf.WriteString("        self." + name + ".is_some()\n")
+		buf.WriteString("    }\n\n")
+
+		buf.WriteString("    /// Sets " + name + ".\n")
+		buf.WriteString("    pub fn set_" + name + "(&mut self, value: " + typeName + ") {\n")
+		buf.WriteString("        self." + name + " = Some(value);\n")
+		buf.WriteString("    }\n\n")
+
+		buf.WriteString("    /// Marks " + name + " absent.\n")
+		buf.WriteString("    pub fn clear_" + name + "(&mut self) {\n")
+		buf.WriteString("        self." + name + " = None;\n")
+		buf.WriteString("    }\n")
+	}
+	buf.WriteString("}\n")
+}
+
 // mapFieldType converts a parser.TypeExpr to a Rust type string
 func mapFieldType(t *parser.TypeExpr) (string, error) {
 	if t == nil {
@@ -0,0 +1,99 @@
+package rustexp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+)
+
+// GenerateDriver generates the Cargo.toml and src/main.rs of a command line
+// program used by the conformance harness to exercise the generated crate at
+// cratePath:
+//
+//	sdp-conformance-driver encode <Struct>   JSON values in, encoded bytes out
+//	sdp-conformance-driver decode <Struct>   encoded bytes in, decoded and re-encoded bytes out
+//
+// Inputs are read from stdin as frames (u32 little-endian length, payload).
+// For each input one output frame is written to stdout: a status byte (0 ok,
+// 1 error), a u32 length and the bytes or the error message.
+func GenerateDriver(schema *parser.Schema, cratePath string) (manifest, main string) {
+	manifest = fmt.Sprintf(`# Conformance test driver
+# Generated by the SDP conformance harness - DO NOT EDIT
+
+[package]
+name = "sdp-conformance-driver"
+version = "0.1.0"
+edition = "2021"
+publish = false
+
+[dependencies]
+sdp = { package = %q, path = %q, features = ["serde"] }
+# The default float parser may be off by one ulp
+serde_json = { version = "1", features = ["float_roundtrip"] }
+`, crateName(schema), cratePath)
+
+	var b strings.Builder
+
+	b.WriteString(`//! Conformance test driver
+//! Generated by the SDP conformance harness - DO NOT EDIT
+
+use std::io::{self, Read, Write};
+
+fn encode<T>(value: &T, size: usize, encode: impl Fn(&T, &mut [u8]) -> sdp::SliceResult<usize>) -> Result<Vec<u8>, String> {
+    let mut buf = vec![0u8; size];
+    let n = encode(value, &mut buf).map_err(|e| e.to_string())?;
+    buf.truncate(n);
+    Ok(buf)
+}
+
+fn run(encode_mode: bool, type_name: &str, input: &[u8]) -> Result<Vec<u8>, String> {
+    match type_name {
+`)
+
+	for _, s := range schema.Structs {
+		fmt.Fprintf(&b, `        "%s" => {
+            let value: sdp::%s = if encode_mode {
+                serde_json::from_slice(input).map_err(|e| e.to_string())?
+            } else {
+                sdp::%s::decode_from_slice(input).map_err(|e| e.to_string())?
+            };
+            encode(&value, value.encoded_size(), sdp::%s::encode_to_slice)
+        }
+`, s.Name, s.Name, s.Name, s.Name)
+	}
+
+	b.WriteString(`        _ => Err(format!("unknown struct {}", type_name)),
+    }
+}
+
+fn main() {
+    let args: Vec<String> = std::env::args().collect();
+    if args.len() != 3 || (args[1] != "encode" && args[1] != "decode") {
+        eprintln!("usage: {} encode|decode <Struct>", args[0]);
+        std::process::exit(2);
+    }
+    let encode_mode = args[1] == "encode";
+
+    let mut stdin = io::stdin().lock();
+    let mut stdout = io::BufWriter::new(io::stdout().lock());
+    let mut header = [0u8; 4];
+    while stdin.read_exact(&mut header).is_ok() {
+        let mut input = vec![0u8; u32::from_le_bytes(header) as usize];
+        if stdin.read_exact(&mut input).is_err() {
+            break;
+        }
+        let (status, out) = match run(encode_mode, &args[2], &input) {
+            Ok(data) => (0u8, data),
+            Err(msg) => (1u8, msg.into_bytes()),
+        };
+        stdout.write_all(&[status]).unwrap();
+        stdout.write_all(&(out.len() as u32).to_le_bytes()).unwrap();
+        stdout.write_all(&out).unwrap();
+    }
+    stdout.flush().unwrap();
+}
+`)
+
+	return manifest, b.String()
+}
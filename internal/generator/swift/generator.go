@@ -59,7 +59,7 @@ func Generate(schema *parser.Schema, outputDir string, verbose bool) error {
 	}
 
 	// Move header files to include/ subdirectory
-	headerFiles := []string{"types.hpp", "encode.hpp", "decode.hpp", "endian.hpp"}
+	headerFiles := []string{"types.hpp", "encode.hpp", "decode.hpp", "json.hpp", "endian.hpp"}
 	for _, header := range headerFiles {
 		srcPath := filepath.Join(packageDir, header)
 		dstPath := filepath.Join(includeDir, header)
@@ -106,6 +106,7 @@ module %s {
     header "types.hpp"
     header "encode.hpp"
     header "decode.hpp"
+    header "json.hpp"
     requires cplusplus
     export *
 }
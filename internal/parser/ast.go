@@ -15,9 +15,20 @@ type Struct struct {
 
 // Field represents a field in a struct.
 type Field struct {
-	Name    string
-	Type    TypeExpr
-	Comment string // Doc comment (from /// lines)
+	Name     string
+	Type     TypeExpr
+	Comment  string // Doc comment (from /// lines)
+	JSONName string // JSON key override (from a "/// @json name" line), empty if none
+	Redact   bool   // Hide the value in String and LogValue output (from a "/// @redact" line)
+}
+
+// JSONKey returns the key of the field in the canonical JSON mapping: the
+// schema field name unless overridden with a "/// @json name" doc line.
+func (f *Field) JSONKey() string {
+	if f.JSONName != "" {
+		return f.JSONName
+	}
+	return f.Name
 }
 
 // TypeExpr represents a type expression (primitive, array, or named type).
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"strings"
 )
 
 // Parser parses tokenized .sdp schema files into an AST.
@@ -122,6 +123,17 @@ func (p *Parser) parseField() (Field, error) {
 	// Collect doc comments
 	f.Comment = p.collectDocComments()
 
+	// Extract the JSON key override and the redaction marker
+	comment, jsonName, found := extractDirective(f.Comment, "@json")
+	if found && jsonName == "" {
+		return f, p.error("@json directive requires a name")
+	}
+	comment, arg, redact := extractDirective(comment, "@redact")
+	if redact && arg != "" {
+		return f, p.error("@redact directive takes no arguments")
+	}
+	f.Comment, f.JSONName, f.Redact = comment, jsonName, redact
+
 	// Expect field name
 	if !p.check(TokenIdent) {
 		return f, p.error("expected field name")
@@ -264,6 +276,28 @@ func (p *Parser) collectDocComments() string {
 	return result
 }
 
+// extractDirective removes a directive line such as "@json name" from a
+// field doc comment and returns the remaining comment and the argument.
+func extractDirective(comment, directive string) (string, string, bool) {
+	if !strings.Contains(comment, directive) {
+		return comment, "", false
+	}
+
+	var (
+		lines []string
+		arg   string
+		found bool
+	)
+	for _, line := range strings.Split(comment, "\n") {
+		if rest, ok := strings.CutPrefix(line, directive); ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t') {
+			arg, found = strings.TrimSpace(rest), true
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), arg, found
+}
+
 // skipRegularComments skips only regular comments, not doc comments.
 func (p *Parser) skipRegularComments() {
 	for p.check(TokenComment) {
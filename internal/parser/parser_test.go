@@ -157,6 +157,66 @@ func TestParseDocComments(t *testing.T) {
 	}
 }
 
+func TestParseJSONDirective(t *testing.T) {
+	input := `struct Parameter {
+		/// Human-readable name.
+		/// @json displayName
+		display_name: str,
+		/// Raw AudioUnit flags.
+		raw_flags: u32,
+	}`
+
+	schema, err := ParseSchema(input)
+	if err != nil {
+		t.Fatalf("ParseSchema failed: %v", err)
+	}
+
+	fields := schema.Structs[0].Fields
+	if fields[0].JSONName != "displayName" || fields[0].JSONKey() != "displayName" {
+		t.Errorf("Expected JSON name 'displayName', got %q", fields[0].JSONName)
+	}
+	if fields[0].Comment != "Human-readable name." {
+		t.Errorf("Directive should be removed from comment, got %q", fields[0].Comment)
+	}
+	if fields[1].JSONName != "" || fields[1].JSONKey() != "raw_flags" {
+		t.Errorf("Expected default JSON key 'raw_flags', got %q", fields[1].JSONKey())
+	}
+
+	if _, err := ParseSchema("struct S {\n/// @json\nx: u8,\n}"); err == nil {
+		t.Error("Expected error for @json without a name")
+	}
+}
+
+func TestParseRedactDirective(t *testing.T) {
+	input := `struct Credentials {
+		user: str,
+		/// Never logged.
+		/// @redact
+		/// @json pw
+		password: str,
+	}`
+
+	schema, err := ParseSchema(input)
+	if err != nil {
+		t.Fatalf("ParseSchema failed: %v", err)
+	}
+
+	fields := schema.Structs[0].Fields
+	if fields[0].Redact {
+		t.Error("Expected user not to be redacted")
+	}
+	if !fields[1].Redact || fields[1].JSONName != "pw" {
+		t.Errorf("Expected redacted password with JSON name 'pw', got Redact=%v JSONName=%q", fields[1].Redact, fields[1].JSONName)
+	}
+	if fields[1].Comment != "Never logged." {
+		t.Errorf("Directives should be removed from comment, got %q", fields[1].Comment)
+	}
+
+	if _, err := ParseSchema("struct S {\n/// @redact always\nx: u8,\n}"); err == nil {
+		t.Error("Expected error for @redact with an argument")
+	}
+}
+
 func TestParseSyntaxError(t *testing.T) {
 	testCases := []struct {
 		input       string
@@ -18,10 +18,12 @@ const (
 	ErrCodeCircularReference = "CIRCULAR_REFERENCE" // Circular struct reference detected
 
 	// Naming validation errors
-	ErrCodeInvalidIdentifier = "INVALID_IDENTIFIER" // Identifier violates naming rules
-	ErrCodeReservedKeyword   = "RESERVED_KEYWORD"   // Identifier is reserved in target language
-	ErrCodeDuplicateStruct   = "DUPLICATE_STRUCT"   // Multiple structs with same name
-	ErrCodeDuplicateField    = "DUPLICATE_FIELD"    // Multiple fields with same name in struct
+	ErrCodeInvalidIdentifier = "INVALID_IDENTIFIER"  // Identifier violates naming rules
+	ErrCodeReservedKeyword   = "RESERVED_KEYWORD"    // Identifier is reserved in target language
+	ErrCodeDuplicateStruct   = "DUPLICATE_STRUCT"    // Multiple structs with same name
+	ErrCodeDuplicateField    = "DUPLICATE_FIELD"     // Multiple fields with same name in struct
+	ErrCodeInvalidJSONName   = "INVALID_JSON_NAME"   // @json name violates naming rules
+	ErrCodeDuplicateJSONName = "DUPLICATE_JSON_NAME" // Multiple fields with same JSON key in struct
 )
 
 // Error constructors for consistent error messages
@@ -77,3 +79,15 @@ func errDuplicateField(structName, fieldName string) ValidationError {
 		Message: fmt.Sprintf("[DUPLICATE_FIELD] struct %q has duplicate field name %q", structName, fieldName),
 	}
 }
+
+func errInvalidJSONName(structName, fieldName, jsonName, reason string) ValidationError {
+	return ValidationError{
+		Message: fmt.Sprintf("[INVALID_JSON_NAME] struct %q field %q: JSON name %q is invalid: %s", structName, fieldName, jsonName, reason),
+	}
+}
+
+func errDuplicateJSONName(structName, jsonName string) ValidationError {
+	return ValidationError{
+		Message: fmt.Sprintf("[DUPLICATE_JSON_NAME] struct %q has duplicate JSON key %q", structName, jsonName),
+	}
+}
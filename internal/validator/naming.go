@@ -12,6 +12,7 @@ import (
 // - Not reserved keywords in any target language
 // - No duplicate struct names
 // - No duplicate field names within a struct
+// - Valid @json names and no duplicate JSON keys within a struct
 //
 // Returns all errors found (does not stop at first error).
 func ValidateNaming(schema *parser.Schema) []error {
@@ -39,6 +40,22 @@ func ValidateNaming(schema *parser.Schema) []error {
 			errors = append(errors, errReservedKeyword("struct", s.Name, langs))
 		}
 
+		// Check JSON keys (field names or @json overrides); clashes between
+		// plain field names are reported as duplicate fields below
+		jsonKeys := make(map[string]bool) // key -> set by @json
+		for _, field := range s.Fields {
+			if field.JSONName != "" {
+				if err := validateJSONName(s.Name, field.Name, field.JSONName); err != nil {
+					errors = append(errors, err)
+				}
+			}
+			key := field.JSONKey()
+			if override, seen := jsonKeys[key]; seen && (override || field.JSONName != "") {
+				errors = append(errors, errDuplicateJSONName(s.Name, key))
+			}
+			jsonKeys[key] = jsonKeys[key] || field.JSONName != ""
+		}
+
 		// Check for duplicate field names
 		fieldNames := make(map[string]bool)
 		for _, field := range s.Fields {
@@ -91,3 +108,16 @@ func validateIdentifier(name, identifierType string) error {
 
 	return nil
 }
+
+// validateJSONName checks that a @json name only uses ASCII letters, digits,
+// underscores and hyphens, so it needs no escaping in any backend.
+func validateJSONName(structName, fieldName, jsonName string) error {
+	for i := 0; i < len(jsonName); i++ {
+		ch := jsonName[i]
+		if (ch < 'a' || ch > 'z') && (ch < 'A' || ch > 'Z') && (ch < '0' || ch > '9') && ch != '_' && ch != '-' {
+			reason := fmt.Sprintf("contains invalid character %q at position %d (only ASCII letters, digits, underscores and hyphens allowed)", string(rune(ch)), i)
+			return errInvalidJSONName(structName, fieldName, jsonName, reason)
+		}
+	}
+	return nil
+}
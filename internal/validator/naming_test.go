@@ -217,6 +217,38 @@ func TestDuplicateFields(t *testing.T) {
 	}
 }
 
+func TestJSONNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"valid override", "struct S {\n/// @json displayName\ndisplay_name: str,\n}", ""},
+		{"invalid character", "struct S {\n/// @json display.name\ndisplay_name: str,\n}", "INVALID_JSON_NAME"},
+		{"clash with field name", "struct S {\n/// @json id\nuser_id: u32,\nid: u32,\n}", "DUPLICATE_JSON_NAME"},
+		{"clash between overrides", "struct S {\n/// @json key\na: u32,\n/// @json key\nb: u32,\n}", "DUPLICATE_JSON_NAME"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := parser.ParseSchema(tt.input)
+			if err != nil {
+				t.Fatalf("ParseSchema failed: %v", err)
+			}
+			errors := ValidateNaming(schema)
+			if tt.wantErr == "" {
+				if len(errors) != 0 {
+					t.Errorf("Expected no errors, got %v", errors)
+				}
+				return
+			}
+			if len(errors) != 1 || !strings.Contains(errors[0].Error(), tt.wantErr) {
+				t.Errorf("Expected one %s error, got %v", tt.wantErr, errors)
+			}
+		})
+	}
+}
+
 func TestDuplicateStructs(t *testing.T) {
 	input := `
 	struct Device {
@@ -0,0 +1,223 @@
+package dynamic
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// decoder walks data with the same checks, in the same order, as generated
+// decoders, so errors carry the same paths and offsets.
+type decoder struct {
+	codec         *Codec
+	data          []byte
+	offset        int
+	strict        bool
+	totalElements int
+
+	// Set by Inspect to record a Span for every field and element
+	tracing bool
+	spans   []Span
+	path    []string
+}
+
+// begin opens a span for the field or element name at the current offset.
+func (d *decoder) begin(name, typ string) int {
+	if !d.tracing {
+		return -1
+	}
+	path := name
+	if n := len(d.path); n > 0 {
+		path = d.path[n-1] + name
+		if name[0] != '[' {
+			path = d.path[n-1] + "." + name
+		}
+	}
+	d.path = append(d.path, path)
+	d.spans = append(d.spans, Span{Path: path, Type: typ, Depth: len(d.path) - 1, Offset: d.offset, Length: -1})
+	return len(d.spans) - 1
+}
+
+// end completes the span opened by begin once its value has been decoded.
+func (d *decoder) end(i int, value any) {
+	if i < 0 {
+		return
+	}
+	d.spans[i].Length = d.offset - d.spans[i].Offset
+	d.spans[i].Value = value
+	d.path = d.path[:len(d.path)-1]
+}
+
+func (d *decoder) decodeStruct(st *schema.Struct) (map[string]any, error) {
+	m := make(map[string]any, len(st.Fields))
+	for i := range st.Fields {
+		f := &st.Fields[i]
+		span := d.begin(f.Name, f.Type.String())
+		v, err := d.decodeField(&f.Type)
+		if err != nil {
+			return nil, sdp.WrapDecodeError(err, f.Name, d.offset)
+		}
+		d.end(span, spanValue(&f.Type, v))
+		m[f.Name] = v
+	}
+	return m, nil
+}
+
+func (d *decoder) decodeField(t *schema.TypeExpr) (any, error) {
+	switch {
+	case t.Optional:
+		if d.offset >= len(d.data) {
+			return nil, sdp.ErrUnexpectedEOF
+		}
+		presence := d.data[d.offset]
+		if presence > 1 {
+			return nil, sdp.ErrInvalidData
+		}
+		d.offset++
+
+		if presence == 0 {
+			return nil, nil
+		}
+		return d.decodeStruct(d.codec.structs[t.Name])
+
+	case t.Kind == schema.TypeKindArray:
+		return d.decodeArray(t.Elem)
+
+	case t.Kind == schema.TypeKindNamed:
+		return d.decodeStruct(d.codec.structs[t.Name])
+
+	default:
+		return d.decodePrimitive(t.Name)
+	}
+}
+
+func (d *decoder) decodeArray(elem *schema.TypeExpr) ([]any, error) {
+	if d.offset+4 > len(d.data) {
+		return nil, sdp.ErrUnexpectedEOF
+	}
+	count := binary.LittleEndian.Uint32(d.data[d.offset:])
+	if err := sdp.CheckArraySize(&d.totalElements, count); err != nil {
+		return nil, err
+	}
+	d.offset += 4
+
+	// Generated decoders bulk-copy integer arrays after one bounds check
+	if size := integerSize(elem); size > 0 && count > 0 {
+		if d.offset+int(count)*size > len(d.data) {
+			return nil, sdp.ErrUnexpectedEOF
+		}
+	}
+
+	arr := make([]any, count)
+	for i := uint32(0); i < count; i++ {
+		span := -1
+		if d.tracing {
+			span = d.begin("["+strconv.FormatUint(uint64(i), 10)+"]", elem.String())
+		}
+
+		if elem.Kind == schema.TypeKindNamed {
+			v, err := d.decodeStruct(d.codec.structs[elem.Name])
+			if err != nil {
+				return nil, sdp.WrapElementError(err, i, d.offset)
+			}
+			arr[i] = v
+			d.end(span, nil)
+			continue
+		}
+
+		v, err := d.decodePrimitive(elem.Name)
+		if err != nil {
+			return nil, sdp.WrapElementError(err, i, d.offset)
+		}
+		arr[i] = v
+		d.end(span, v)
+	}
+	return arr, nil
+}
+
+func (d *decoder) decodePrimitive(name string) (any, error) {
+	if name == "str" {
+		if d.offset+4 > len(d.data) {
+			return nil, sdp.ErrUnexpectedEOF
+		}
+		n := binary.LittleEndian.Uint32(d.data[d.offset:])
+		d.offset += 4
+
+		if d.offset+int(n) > len(d.data) {
+			return nil, sdp.ErrUnexpectedEOF
+		}
+		if d.strict && !utf8.Valid(d.data[d.offset:d.offset+int(n)]) {
+			return nil, sdp.ErrInvalidUTF8
+		}
+		s := string(d.data[d.offset : d.offset+int(n)])
+		d.offset += int(n)
+		return s, nil
+	}
+
+	size := primitiveSizes[name]
+	if d.offset+size > len(d.data) {
+		return nil, sdp.ErrUnexpectedEOF
+	}
+	b := d.data[d.offset:]
+
+	var v any
+	switch name {
+	case "u8":
+		v = b[0]
+	case "u16":
+		v = binary.LittleEndian.Uint16(b)
+	case "u32":
+		v = binary.LittleEndian.Uint32(b)
+	case "u64":
+		v = binary.LittleEndian.Uint64(b)
+	case "i8":
+		v = int8(b[0])
+	case "i16":
+		v = int16(binary.LittleEndian.Uint16(b))
+	case "i32":
+		v = int32(binary.LittleEndian.Uint32(b))
+	case "i64":
+		v = int64(binary.LittleEndian.Uint64(b))
+	case "f32":
+		bits := binary.LittleEndian.Uint32(b)
+		if d.strict && !sdp.IsCanonicalF32(bits) {
+			return nil, sdp.ErrNonCanonical
+		}
+		v = math.Float32frombits(bits)
+	case "f64":
+		bits := binary.LittleEndian.Uint64(b)
+		if d.strict && !sdp.IsCanonicalF64(bits) {
+			return nil, sdp.ErrNonCanonical
+		}
+		v = math.Float64frombits(bits)
+	case "bool":
+		if d.strict && b[0] > 1 {
+			return nil, sdp.ErrNonCanonical
+		}
+		v = b[0] != 0
+	}
+
+	d.offset += size
+	return v, nil
+}
+
+// primitiveSizes holds the wire size of every fixed-size primitive
+var primitiveSizes = map[string]int{
+	"u8": 1, "u16": 2, "u32": 4, "u64": 8,
+	"i8": 1, "i16": 2, "i32": 4, "i64": 8,
+	"f32": 4, "f64": 8,
+	"bool": 1,
+}
+
+// integerSize returns the element size of integer types, or 0 for others.
+func integerSize(t *schema.TypeExpr) int {
+	switch t.Name {
+	case "u8", "u16", "u32", "u64", "i8", "i16", "i32", "i64":
+		return primitiveSizes[t.Name]
+	}
+	return 0
+}
@@ -0,0 +1,137 @@
+package dynamic
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// ChangeKind classifies a difference found by Diff.
+type ChangeKind string
+
+// Kinds of differences between two values
+const (
+	ChangeValue    ChangeKind = "value"    // A primitive field differs
+	ChangeLength   ChangeKind = "length"   // An array has a different number of elements
+	ChangePresence ChangeKind = "presence" // An optional is present in one value only
+)
+
+// Change is one field-level difference between two values.
+type Change struct {
+	Path string     `json:"path"` // Field path, e.g. "plugins[2].name"
+	Kind ChangeKind `json:"kind"`
+
+	// Old and New are the primitive values for ChangeValue, the element
+	// counts (int) for ChangeLength, and the presence flags (bool) for
+	// ChangePresence.
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// DiffOptions configures how Diff compares values.
+type DiffOptions struct {
+	// Floats are equal if they differ by at most AbsTolerance, or by at
+	// most RelTolerance times the larger magnitude. Both NaN counts as equal.
+	AbsTolerance float64
+	RelTolerance float64
+}
+
+// Diff compares two values of the named struct field by field and returns
+// their differences in schema order. Arrays of different lengths report a
+// ChangeLength and are compared up to the shorter length; optionals present
+// on both sides are compared recursively.
+func (c *Codec) Diff(structName string, a, b map[string]any, opts DiffOptions) ([]Change, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &differ{codec: c, opts: opts}
+	d.diffStruct("", st, a, b)
+	return d.changes, nil
+}
+
+type differ struct {
+	codec   *Codec
+	opts    DiffOptions
+	changes []Change
+}
+
+func (d *differ) add(path string, kind ChangeKind, a, b any) {
+	d.changes = append(d.changes, Change{Path: path, Kind: kind, Old: a, New: b})
+}
+
+func (d *differ) diffStruct(prefix string, st *schema.Struct, a, b map[string]any) {
+	for i := range st.Fields {
+		f := &st.Fields[i]
+		path := f.Name
+		if prefix != "" {
+			path = prefix + "." + f.Name
+		}
+		d.diffField(path, &f.Type, a[f.Name], b[f.Name])
+	}
+}
+
+func (d *differ) diffField(path string, t *schema.TypeExpr, a, b any) {
+	switch {
+	case t.Optional:
+		if (a == nil) != (b == nil) {
+			d.add(path, ChangePresence, a != nil, b != nil)
+			return
+		}
+		if a != nil {
+			d.diffStruct(path, d.codec.structs[t.Name], asMap(a), asMap(b))
+		}
+
+	case t.Kind == schema.TypeKindArray:
+		av, bv := asSlice(a), asSlice(b)
+		if av.Len() != bv.Len() {
+			d.add(path, ChangeLength, av.Len(), bv.Len())
+		}
+		for i := 0; i < av.Len() && i < bv.Len(); i++ {
+			d.diffField(path+"["+strconv.Itoa(i)+"]", t.Elem, av.Index(i).Interface(), bv.Index(i).Interface())
+		}
+
+	case t.Kind == schema.TypeKindNamed:
+		d.diffStruct(path, d.codec.structs[t.Name], asMap(a), asMap(b))
+
+	case t.Name == "f32" || t.Name == "f64":
+		x, errA := toFloat(a)
+		y, errB := toFloat(b)
+		if errA != nil || errB != nil || !d.floatsEqual(x, y) {
+			d.add(path, ChangeValue, a, b)
+		}
+
+	default:
+		if a != b {
+			d.add(path, ChangeValue, a, b)
+		}
+	}
+}
+
+func (d *differ) floatsEqual(x, y float64) bool {
+	if x == y || (math.IsNaN(x) && math.IsNaN(y)) {
+		return true
+	}
+	if math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return false
+	}
+	diff := math.Abs(x - y)
+	return diff <= d.opts.AbsTolerance || diff <= d.opts.RelTolerance*math.Max(math.Abs(x), math.Abs(y))
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// asSlice returns v as a reflect slice; nil becomes an empty slice.
+func asSlice(v any) reflect.Value {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return reflect.ValueOf([]any(nil))
+	}
+	return rv
+}
@@ -0,0 +1,220 @@
+// Package dynamic encodes and decodes SDP data for schemas that are only
+// known at runtime, such as debugging tools and generic message buses.
+//
+// Values are represented as a generic tree:
+//
+//	struct        map[string]any keyed by schema field name
+//	[]T           []any
+//	Option<T>     nil when absent, otherwise the struct's map
+//	u8 ... u64    uint8, uint16, uint32, uint64
+//	i8 ... i64    int8, int16, int32, int64
+//	f32, f64      float32, float64
+//	bool, str     bool, string
+//
+// Decoding produces exactly these types and enforces the same size limits
+// with the same errors as generated code, so errors.Is(err,
+// sdp.ErrUnexpectedEOF) and errors.As(err, &*sdp.DecodeError) work as usual.
+// Encoding also accepts any Go integer or float type, json.Number, and any
+// slice type, as long as the value fits the field's wire type.
+package dynamic
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// Errors returned by the dynamic codec in addition to the sdp sentinels
+var (
+	ErrUnknownStruct = errors.New("unknown struct")
+	ErrMissingField  = errors.New("missing field")
+	ErrUnknownField  = errors.New("unknown field")
+	ErrTypeMismatch  = errors.New("value does not match field type")
+	ErrOutOfRange    = errors.New("value out of range for field type")
+)
+
+// Codec converts between SDP bytes and generic values for one schema.
+// It is safe for concurrent use.
+type Codec struct {
+	structs map[string]*schema.Struct
+	names   []string // struct names indexed by type ID - 1
+}
+
+// New returns a Codec for s. The schema is validated first, so every type
+// reference resolves and there are no unbounded cycles.
+func New(s *schema.Schema) (*Codec, error) {
+	if err := schema.Validate(s); err != nil {
+		return nil, err
+	}
+
+	c := &Codec{structs: make(map[string]*schema.Struct, len(s.Structs))}
+	for i := range s.Structs {
+		c.structs[s.Structs[i].Name] = &s.Structs[i]
+		c.names = append(c.names, s.Structs[i].Name)
+	}
+	return c, nil
+}
+
+func (c *Codec) lookup(name string) (*schema.Struct, error) {
+	st, ok := c.structs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStruct, name)
+	}
+	return st, nil
+}
+
+// typeID returns the message mode type ID of the named struct.
+func (c *Codec) typeID(name string) uint16 {
+	for i, n := range c.names {
+		if n == name {
+			return uint16(i + 1)
+		}
+	}
+	return 0
+}
+
+// Decode decodes a value of the named struct from byte mode data.
+func (c *Codec) Decode(structName string, data []byte) (map[string]any, error) {
+	return c.decode(structName, data, false)
+}
+
+// DecodeStrict decodes like Decode, but only accepts the canonical encoding
+// with valid UTF-8 strings, like the generated DecodeXStrict functions.
+func (c *Codec) DecodeStrict(structName string, data []byte) (map[string]any, error) {
+	return c.decode(structName, data, true)
+}
+
+// DecodePrefix decodes one value of the named struct from the start of data
+// and returns it together with the number of bytes it occupies, so that
+// concatenated byte mode values can be decoded one after another.
+func (c *Codec) DecodePrefix(structName string, data []byte) (map[string]any, int, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	d := &decoder{codec: c, data: data}
+	if len(data) > sdp.MaxSerializedSize {
+		d.data = data[:sdp.MaxSerializedSize]
+	}
+	v, err := d.decodeStruct(st)
+	if err != nil {
+		return nil, 0, err
+	}
+	return v, d.offset, nil
+}
+
+func (c *Codec) decode(structName string, data []byte, strict bool) (map[string]any, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > sdp.MaxSerializedSize {
+		return nil, sdp.ErrDataTooLarge
+	}
+
+	d := &decoder{codec: c, data: data, strict: strict}
+	v, err := d.decodeStruct(st)
+	if err != nil {
+		return nil, err
+	}
+	if strict && d.offset != len(data) {
+		return nil, sdp.ErrTrailingBytes
+	}
+	return v, nil
+}
+
+// DecodeMessage decodes a self-describing message and returns the name of
+// the struct identified by its type ID together with the decoded value.
+// Like the generated DecodeMessage, it returns sdp.ErrTrailingBytes if the
+// payload extends past the value.
+func (c *Codec) DecodeMessage(data []byte) (string, map[string]any, error) {
+	// Unknown type IDs are reported before payload errors, as in generated code
+	if len(data) >= sdp.MessageHeaderSize && string(data[0:3]) == sdp.MessageMagic && data[3] == sdp.MessageVersion {
+		if c.TypeName(binary.LittleEndian.Uint16(data[4:6])) == "" {
+			return "", nil, sdp.ErrUnknownMessageType
+		}
+	}
+
+	typeID, payload, err := sdp.ParseMessageHeader(data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	name := c.TypeName(typeID)
+	v, n, err := c.DecodePrefix(name, payload)
+	if err != nil {
+		return "", nil, err
+	}
+	if n != len(payload) {
+		return "", nil, sdp.ErrTrailingBytes
+	}
+	return name, v, nil
+}
+
+// Encode encodes v as the named struct in byte mode.
+func (c *Codec) Encode(structName string, v map[string]any) ([]byte, error) {
+	return c.Append(nil, structName, v)
+}
+
+// Append appends the byte mode encoding of v as the named struct to b.
+func (c *Codec) Append(b []byte, structName string, v map[string]any) ([]byte, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+	return c.appendStruct(b, st, v)
+}
+
+// EncodeMessage encodes v as the named struct in message mode, with the
+// struct's type ID in the header.
+func (c *Codec) EncodeMessage(structName string, v map[string]any) ([]byte, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the header with a placeholder length and patch it afterwards
+	b := sdp.AppendMessageHeader(nil, c.typeID(structName), 0)
+	b, err = c.appendStruct(b, st, v)
+	if err != nil {
+		return nil, err
+	}
+	binary.LittleEndian.PutUint32(b[6:10], uint32(len(b)-sdp.MessageHeaderSize))
+	return b, nil
+}
+
+// EncodeValues encodes values as returned by SplitJSON, or the []byte values
+// of SplitText, and returns the encoding of each. Text values are parsed as
+// structName; JSON values are unpacked with UnpackJSON, so in message mode
+// they may name their struct instead. With message set every value is
+// encoded as a message.
+func (c *Codec) EncodeValues(values []any, structName string, message bool) ([][]byte, error) {
+	encoded := make([][]byte, len(values))
+	for i, v := range values {
+		var (
+			name = structName
+			obj  map[string]any
+			err  error
+		)
+		if text, ok := v.([]byte); ok {
+			obj, err = c.ParseText(name, text)
+		} else if name, obj, err = UnpackJSON(v, structName); err == nil {
+			obj, err = c.FromJSON(name, obj)
+		}
+		if err == nil {
+			if message {
+				encoded[i], err = c.EncodeMessage(name, obj)
+			} else {
+				encoded[i], err = c.Encode(name, obj)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+	}
+	return encoded, nil
+}
@@ -0,0 +1,688 @@
+package dynamic
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+const testSchema = `
+struct Point { x: f32, y: f32 }
+
+struct Shape {
+    id: u32,
+    name: str,
+    offset: i16,
+    visible: bool,
+    points: []Point,
+    tags: []str,
+    weights: []u16,
+    origin: Option<Point>,
+}
+`
+
+func newCodec(t *testing.T) *Codec {
+	t.Helper()
+	s, err := schema.Parse(testSchema)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	c, err := New(s)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func testShape() map[string]any {
+	return map[string]any{
+		"id":      uint32(7),
+		"name":    "tri",
+		"offset":  int16(-3),
+		"visible": true,
+		"points": []any{
+			map[string]any{"x": float32(1), "y": float32(2)},
+			map[string]any{"x": float32(-1), "y": float32(0.5)},
+		},
+		"tags":    []any{"a", "bc"},
+		"weights": []any{uint16(1), uint16(65535)},
+		"origin":  nil,
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	c := newCodec(t)
+	want := testShape()
+
+	data, err := c.Encode("Shape", want)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	got, err := c.DecodeStrict("Shape", data)
+	if err != nil {
+		t.Fatalf("DecodeStrict() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch:\n got %#v\nwant %#v", got, want)
+	}
+
+	want["origin"] = map[string]any{"x": float32(3), "y": float32(4)}
+	data, _ = c.Encode("Shape", want)
+	got, err = c.Decode("Shape", data)
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip with optional: got %#v, %v", got, err)
+	}
+}
+
+func TestEncodeLooseNumbers(t *testing.T) {
+	c := newCodec(t)
+	v := testShape()
+	v["id"] = json.Number("7")
+	v["offset"] = -3.0
+	v["weights"] = []int{1, 65535}
+	v["points"] = []map[string]any{{"x": 1, "y": 2.0}, {"x": -1.0, "y": 0.5}}
+	delete(v, "origin")
+
+	loose, err := c.Encode("Shape", v)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	exact, _ := c.Encode("Shape", testShape())
+	if string(loose) != string(exact) {
+		t.Errorf("loose encoding differs:\n got %x\nwant %x", loose, exact)
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	c := newCodec(t)
+
+	tests := []struct {
+		name    string
+		modify  func(v map[string]any)
+		wantErr error
+		path    string
+	}{
+		{"missing", func(v map[string]any) { delete(v, "name") }, ErrMissingField, "name"},
+		{"unknown", func(v map[string]any) { v["nmae"] = "x" }, ErrUnknownField, "nmae"},
+		{"type", func(v map[string]any) { v["visible"] = 1 }, ErrTypeMismatch, "visible"},
+		{"range", func(v map[string]any) { v["weights"] = []any{70000} }, ErrOutOfRange, "weights[0]"},
+		{"negative", func(v map[string]any) { v["id"] = -1 }, ErrOutOfRange, "id"},
+		{"fraction", func(v map[string]any) { v["offset"] = 1.5 }, ErrOutOfRange, "offset"},
+		{"nested", func(v map[string]any) {
+			v["points"] = []any{map[string]any{"x": "1", "y": 2}}
+		}, ErrTypeMismatch, "points[0].x"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := testShape()
+			tt.modify(v)
+			_, err := c.Encode("Shape", v)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			var ee *EncodeError
+			if !errors.As(err, &ee) || ee.Path != tt.path {
+				t.Errorf("err = %v, want path %q", err, tt.path)
+			}
+		})
+	}
+
+	if _, err := c.Encode("Missing", nil); !errors.Is(err, ErrUnknownStruct) {
+		t.Errorf("err = %v, want ErrUnknownStruct", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	c := newCodec(t)
+	data, _ := c.Encode("Shape", testShape())
+
+	_, err := c.Decode("Shape", data[:len(data)-3])
+	var de *sdp.DecodeError
+	if !errors.Is(err, sdp.ErrUnexpectedEOF) || !errors.As(err, &de) || de.Path != "weights" {
+		t.Errorf("truncated: err = %v", err)
+	}
+
+	if _, err := c.DecodeStrict("Shape", append(data, 0)); !errors.Is(err, sdp.ErrTrailingBytes) {
+		t.Errorf("trailing: err = %v, want ErrTrailingBytes", err)
+	}
+
+	bad := append([]byte(nil), data...)
+	bad[len(bad)-1] = 2 // origin presence byte
+	if _, err := c.Decode("Shape", bad); !errors.Is(err, sdp.ErrInvalidData) {
+		t.Errorf("presence: err = %v, want ErrInvalidData", err)
+	}
+
+	point, _ := c.Encode("Point", map[string]any{"x": float32(math.NaN()), "y": float32(0)})
+	point[0] = 1 // non-canonical NaN payload
+	if _, err := c.DecodeStrict("Point", point); !errors.Is(err, sdp.ErrNonCanonical) {
+		t.Errorf("NaN: err = %v, want ErrNonCanonical", err)
+	}
+	if _, err := c.Decode("Point", point); err != nil {
+		t.Errorf("NaN non-strict: err = %v", err)
+	}
+}
+
+func TestMessages(t *testing.T) {
+	c := newCodec(t)
+	want := map[string]any{"x": float32(1.5), "y": float32(-2)}
+
+	msg, err := c.EncodeMessage("Point", want)
+	if err != nil {
+		t.Fatalf("EncodeMessage() error = %v", err)
+	}
+	typeID, payload, err := sdp.ParseMessageHeader(msg)
+	if err != nil || typeID != 1 || len(payload) != 8 {
+		t.Fatalf("header = %d, %d bytes, %v", typeID, len(payload), err)
+	}
+
+	name, got, err := c.DecodeMessage(msg)
+	if err != nil || name != "Point" || !reflect.DeepEqual(got, want) {
+		t.Errorf("DecodeMessage() = %q, %v, %v", name, got, err)
+	}
+
+	// A payload that extends past the value is rejected
+	padded := sdp.AppendMessageHeader(nil, 1, len(payload)+1)
+	padded = append(append(padded, payload...), 0)
+	if _, _, err := c.DecodeMessage(padded); !errors.Is(err, sdp.ErrTrailingBytes) {
+		t.Errorf("padded: err = %v, want ErrTrailingBytes", err)
+	}
+
+	msg[4] = 9
+	if _, _, err := c.DecodeMessage(msg); !errors.Is(err, sdp.ErrUnknownMessageType) {
+		t.Errorf("err = %v, want ErrUnknownMessageType", err)
+	}
+}
+
+func TestDecodePrefix(t *testing.T) {
+	c := newCodec(t)
+	a, _ := c.Encode("Point", map[string]any{"x": 1, "y": 2})
+	b, _ := c.Encode("Point", map[string]any{"x": 3, "y": 4})
+	data := append(a, b...)
+
+	first, n, err := c.DecodePrefix("Point", data)
+	if err != nil || n != len(a) || first["x"] != float32(1) {
+		t.Fatalf("first = %v, %d, %v", first, n, err)
+	}
+	second, n, err := c.DecodePrefix("Point", data[n:])
+	if err != nil || n != len(b) || second["y"] != float32(4) {
+		t.Errorf("second = %v, %d, %v", second, n, err)
+	}
+}
+
+func TestAppendJSON(t *testing.T) {
+	c := newCodec(t)
+	data, _ := c.Encode("Shape", testShape())
+	v, _ := c.Decode("Shape", data)
+
+	got, err := c.AppendJSON(nil, "Shape", v)
+	if err != nil {
+		t.Fatalf("AppendJSON() error = %v", err)
+	}
+	want := `{"id":7,"name":"tri","offset":-3,"visible":true,"points":[{"x":1,"y":2},{"x":-1,"y":0.5}],"tags":["a","bc"],"weights":[1,65535],"origin":null}`
+	if string(got) != want {
+		t.Errorf("AppendJSON() =\n%s\nwant\n%s", got, want)
+	}
+
+	var parsed map[string]any
+	if err := json.Unmarshal(got, &parsed); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if _, err := c.AppendJSON(nil, "Point", map[string]any{"x": math.NaN(), "y": 0}); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("NaN: err = %v, want ErrOutOfRange", err)
+	}
+}
+
+func TestJSONMapping(t *testing.T) {
+	s, err := schema.Parse(`
+struct Blob {
+    /// @json blobId
+    id: u64,
+    delta: i64,
+    data: []u8,
+    tags: []str,
+}`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	c, err := New(s)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	v := map[string]any{"id": uint64(math.MaxUint64), "delta": int64(-1), "data": []any{uint8(1), uint8(2), uint8(255)}, "tags": []any{}}
+	got, err := c.AppendJSON(nil, "Blob", v)
+	if err != nil {
+		t.Fatalf("AppendJSON() error = %v", err)
+	}
+	want := `{"blobId":"18446744073709551615","delta":"-1","data":"AQL/"}`
+	if string(got) != want {
+		t.Errorf("AppendJSON() =\n%s\nwant\n%s", got, want)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(want))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		t.Fatal(err)
+	}
+	back, err := c.FromJSON("Blob", obj)
+	if err != nil {
+		t.Fatalf("FromJSON() error = %v", err)
+	}
+	if !reflect.DeepEqual(back, v) {
+		t.Errorf("FromJSON() = %#v, want %#v", back, v)
+	}
+
+	// Plain numbers and byte arrays are accepted too
+	lenient := map[string]any{"blobId": json.Number("5"), "delta": json.Number("-5"), "data": []any{json.Number("7")}}
+	if back, err := c.FromJSON("Blob", lenient); err != nil || back["id"] != uint64(5) || !reflect.DeepEqual(back["data"], []any{uint8(7)}) {
+		t.Errorf("lenient FromJSON() = %v, %v", back, err)
+	}
+
+	errs := []struct {
+		obj  map[string]any
+		want error
+	}{
+		{map[string]any{"id": "1", "delta": "0"}, ErrUnknownField},
+		{map[string]any{"blobId": "1"}, ErrMissingField},
+		{map[string]any{"blobId": "x", "delta": "0"}, ErrTypeMismatch},
+		{map[string]any{"blobId": "-1", "delta": "0"}, ErrTypeMismatch},
+		{map[string]any{"blobId": "1", "delta": "0", "data": "not base64!"}, ErrTypeMismatch},
+	}
+	for _, tt := range errs {
+		if _, err := c.FromJSON("Blob", tt.obj); !errors.Is(err, tt.want) {
+			t.Errorf("FromJSON(%v) error = %v, want %v", tt.obj, err, tt.want)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	c := newCodec(t)
+
+	v := testShape()
+	v["origin"] = map[string]any{"x": float32(math.Inf(-1)), "y": math.Float32frombits(0x7fc00001)}
+	got, err := c.AppendText(nil, "Shape", v)
+	if err != nil {
+		t.Fatalf("AppendText() error = %v", err)
+	}
+	want := `id: 7
+name: "tri"
+offset: -3
+visible: true
+points: [
+  {
+    x: 1
+    y: 2
+  },
+  {
+    x: -1
+    y: 0.5
+  },
+]
+tags: ["a", "bc"]
+weights: [1, 65535]
+origin {
+  x: -inf
+  y: nan(0x7fc00001)
+}
+`
+	if string(got) != want {
+		t.Errorf("AppendText() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Parsing the output reproduces the exact bytes
+	back, err := c.ParseText("Shape", got)
+	if err != nil {
+		t.Fatalf("ParseText() error = %v", err)
+	}
+	wantData, _ := c.Encode("Shape", v)
+	gotData, err := c.Encode("Shape", back)
+	if err != nil || string(gotData) != string(wantData) {
+		t.Errorf("Encode(ParseText()) = %x, %v, want %x", gotData, err, wantData)
+	}
+
+	// Hand-written input: comments, separators, integer prefixes, defaults
+	hand := `# a hand-written shape
+id: 0x10, name: ` + "`raw`" + `; offset: -0b11
+visible: false
+points: [{ x: 1e-3 y: 010 }, { x: nan, y: +inf }]
+origin: { x: 0 y: -0 }`
+	m, err := c.ParseText("Shape", []byte(hand))
+	if err != nil {
+		t.Fatalf("ParseText() error = %v", err)
+	}
+	if m["id"] != uint32(16) || m["name"] != "raw" || m["offset"] != int16(-3) || !reflect.DeepEqual(m["tags"], []any{}) {
+		t.Errorf("ParseText() = %v", m)
+	}
+	if y := m["points"].([]any)[0].(map[string]any)["y"]; y != float32(10) {
+		t.Errorf("leading zero should be decimal, got %v", y)
+	}
+	if x := m["points"].([]any)[1].(map[string]any)["x"].(float32); math.Float32bits(x) != sdp.CanonicalNaN32 {
+		t.Errorf("nan should parse to the canonical NaN, got %#x", math.Float32bits(x))
+	}
+
+	errs := []struct {
+		text      string
+		want      error
+		line, col int
+		path      string
+	}{
+		{"id: 1\nname: \"x\"\nvisible: true\nsize: 3", ErrUnknownField, 4, 1, "size"},
+		{"id: 1 name: \"x\" visible: true", ErrMissingField, 1, 1, "offset"},
+		{"id: -1", ErrOutOfRange, 1, 5, "id"},
+		{"id: 1 name: \"x\" offset: 70000", ErrOutOfRange, 1, 25, "offset"},
+		{"id: 1 name: 2", ErrTypeMismatch, 1, 13, "name"},
+		{"id: 1 name: \"x\" offset: 0 visible: true points: [{ x: 1 z: 2 }]", ErrUnknownField, 1, 57, "points[0].z"},
+	}
+	for _, tt := range errs {
+		_, err := c.ParseText("Shape", []byte(tt.text))
+		var te *TextError
+		if !errors.Is(err, tt.want) || !errors.As(err, &te) || te.Line != tt.line || te.Column != tt.col || te.Path != tt.path {
+			t.Errorf("ParseText(%q) error = %v, want %v at %d:%d %s", tt.text, err, tt.want, tt.line, tt.col, tt.path)
+		}
+	}
+
+	// []u8 is a byte string, but element lists are accepted too
+	bs, _ := schema.Parse(`struct Blob { data: []u8, sum: u64 }`)
+	bc, _ := New(bs)
+	blob := map[string]any{"data": []any{uint8(1), uint8('h'), uint8('i'), uint8(255)}, "sum": uint64(math.MaxUint64)}
+	if got, err := bc.AppendText(nil, "Blob", blob); err != nil || string(got) != "data: b\"\\x01hi\\xff\"\nsum: 18446744073709551615\n" {
+		t.Errorf("AppendText() = %q, %v", got, err)
+	}
+	if got, err := bc.ParseText("Blob", []byte("data: [1, 104, 105, 255] sum: 18446744073709551615")); err != nil || !reflect.DeepEqual(got, blob) {
+		t.Errorf("ParseText() = %v, %v", got, err)
+	}
+
+	for _, text := range []string{"id 1", "id: 1 id: 2", "name: \"x", "points: [1]", "id: 1,,"} {
+		if _, err := c.ParseText("Shape", []byte(text)); err == nil {
+			t.Errorf("ParseText(%q) should fail", text)
+		}
+	}
+}
+
+func TestInspect(t *testing.T) {
+	c := newCodec(t)
+	v := testShape()
+	v["origin"] = map[string]any{"x": 3, "y": 4}
+	data, _ := c.Encode("Shape", v)
+
+	spans, n, err := c.Inspect("Shape", append(data, 0xAA))
+	if err != nil || n != len(data) {
+		t.Fatalf("Inspect() = %d bytes, %v", n, err)
+	}
+
+	byPath := make(map[string]Span)
+	for _, sp := range spans {
+		byPath[sp.Path] = sp
+	}
+	tests := []struct {
+		path   string
+		offset int
+		length int
+		depth  int
+		value  any
+	}{
+		{"id", 0, 4, 0, uint32(7)},
+		{"name", 4, 7, 0, "tri"},
+		{"points", 14, 20, 0, uint32(2)},
+		{"points[1]", 26, 8, 1, nil},
+		{"points[1].y", 30, 4, 2, float32(0.5)},
+		{"origin", len(data) - 9, 9, 0, true},
+	}
+	for _, tt := range tests {
+		sp, ok := byPath[tt.path]
+		if !ok {
+			t.Errorf("no span for %s", tt.path)
+			continue
+		}
+		if sp.Offset != tt.offset || sp.Length != tt.length || sp.Depth != tt.depth || sp.Value != tt.value {
+			t.Errorf("%s = %+v, want offset %d length %d depth %d value %v", tt.path, sp, tt.offset, tt.length, tt.depth, tt.value)
+		}
+	}
+
+	spans, n, err = c.Inspect("Shape", data[:20])
+	if !errors.Is(err, sdp.ErrUnexpectedEOF) || n != 18 {
+		t.Fatalf("truncated: %d bytes, %v", n, err)
+	}
+	last := spans[len(spans)-1]
+	if last.Path != "points[0].x" || last.Length != -1 {
+		t.Errorf("last span = %+v, want incomplete points[0].x", last)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	c := newCodec(t)
+
+	a := testShape()
+	b := testShape()
+	b["name"] = "quad"
+	b["points"] = append(b["points"].([]any), map[string]any{"x": float32(0), "y": float32(0)})
+	b["points"].([]any)[1] = map[string]any{"x": float32(-1), "y": float32(0.25)}
+	b["origin"] = map[string]any{"x": float32(0), "y": float32(0)}
+
+	changes, err := c.Diff("Shape", a, b, DiffOptions{})
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	want := []Change{
+		{"name", ChangeValue, "tri", "quad"},
+		{"points", ChangeLength, 2, 3},
+		{"points[1].y", ChangeValue, float32(0.5), float32(0.25)},
+		{"origin", ChangePresence, false, true},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Diff() = %+v, want %+v", changes, want)
+	}
+
+	if changes, _ := c.Diff("Shape", a, testShape(), DiffOptions{}); len(changes) != 0 {
+		t.Errorf("equal values: Diff() = %+v", changes)
+	}
+	if _, err := c.Diff("Nope", a, b, DiffOptions{}); !errors.Is(err, ErrUnknownStruct) {
+		t.Errorf("unknown struct: err = %v", err)
+	}
+
+	floats := []struct {
+		x, y float64
+		opts DiffOptions
+		want bool
+	}{
+		{1, 1.0005, DiffOptions{}, false},
+		{1, 1.0005, DiffOptions{AbsTolerance: 1e-3}, true},
+		{1000, 1000.5, DiffOptions{RelTolerance: 1e-3}, true},
+		{1000, 1002, DiffOptions{RelTolerance: 1e-3}, false},
+		{math.NaN(), math.NaN(), DiffOptions{}, true},
+		{math.Inf(1), math.Inf(1), DiffOptions{}, true},
+		{math.Inf(1), 1e300, DiffOptions{RelTolerance: 1}, false},
+	}
+	for _, tt := range floats {
+		pa := map[string]any{"x": float32(0), "y": tt.x}
+		pb := map[string]any{"x": float32(0), "y": tt.y}
+		changes, err := c.Diff("Point", pa, pb, tt.opts)
+		if err != nil {
+			t.Fatalf("Diff(%v, %v) error = %v", tt.x, tt.y, err)
+		}
+		if got := len(changes) == 0; got != tt.want {
+			t.Errorf("Diff(%v, %v, %+v) equal = %v, want %v", tt.x, tt.y, tt.opts, got, tt.want)
+		}
+	}
+}
+
+func TestSplitText(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"", []string{""}},
+		{"id: 1\n", []string{"id: 1\n"}},
+		{"id: 1\n---\nid: 2\n", []string{"id: 1\n", "id: 2\n"}},
+		{"id: 1\n---\nid: 2\n---\n", []string{"id: 1\n", "id: 2\n"}},
+		{"a: \"---\"\n  ---  \nb: 1", []string{"a: \"---\"\n", "b: 1"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, v := range SplitText([]byte(tt.text)) {
+			got = append(got, string(v))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSplitJSON(t *testing.T) {
+	tests := []struct {
+		input     string
+		selectKey string
+		want      int
+		wantErr   bool
+	}{
+		{`{"id": 1}`, "", 1, false},
+		{`{"id": 1} {"id": 2}`, "", 2, false},
+		{`[{"id": 1}, {"id": 2}] {"id": 3}`, "", 3, false},
+		{`{"points": [{"x": 1}, {"x": 2}]}`, "points", 2, false},
+		{`{"points": []}`, "shapes", 0, true},
+		{`{"id": 1`, "", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := SplitJSON([]byte(tt.input), tt.selectKey)
+		if (err != nil) != tt.wantErr || len(got) != tt.want {
+			t.Errorf("SplitJSON(%q, %q) = %d values, %v; want %d values, error %v", tt.input, tt.selectKey, len(got), err, tt.want, tt.wantErr)
+		}
+	}
+
+	values, _ := SplitJSON([]byte(`{"id": 18446744073709551615}`), "")
+	if _, ok := values[0].(map[string]any)["id"].(json.Number); !ok {
+		t.Errorf("numbers should be json.Number, got %T", values[0].(map[string]any)["id"])
+	}
+}
+
+func TestUnpackJSON(t *testing.T) {
+	obj := map[string]any{"id": json.Number("1")}
+
+	name, got, err := UnpackJSON(obj, "Shape")
+	if err != nil || name != "Shape" || !reflect.DeepEqual(got, obj) {
+		t.Errorf("UnpackJSON(obj, Shape) = %q, %v, %v", name, got, err)
+	}
+	name, got, err = UnpackJSON(map[string]any{"type": "Point", "value": obj}, "")
+	if err != nil || name != "Point" || !reflect.DeepEqual(got, obj) {
+		t.Errorf("UnpackJSON(wrapped) = %q, %v, %v", name, got, err)
+	}
+	for _, v := range []any{obj, []any{obj}, map[string]any{"type": "Point", "value": obj, "x": 1}} {
+		if _, _, err := UnpackJSON(v, ""); err == nil {
+			t.Errorf("UnpackJSON(%v) succeeded without a struct name", v)
+		}
+	}
+}
+
+func TestEncodeValues(t *testing.T) {
+	c := newCodec(t)
+	values, err := SplitJSON([]byte(`{"x": 1, "y": 2} {"x": 3, "y": 4}`), "")
+	if err != nil {
+		t.Fatalf("SplitJSON() error = %v", err)
+	}
+	values = append(values, []byte("x: 5\ny: 6\n"))
+
+	got, err := c.EncodeValues(values, "Point", false)
+	if err != nil || len(got) != 3 {
+		t.Fatalf("EncodeValues() = %d values, %v", len(got), err)
+	}
+	for i, xy := range [][2]float32{{1, 2}, {3, 4}, {5, 6}} {
+		want, _ := c.Encode("Point", map[string]any{"x": xy[0], "y": xy[1]})
+		if !reflect.DeepEqual(got[i], want) {
+			t.Errorf("value %d = %x, want %x", i, got[i], want)
+		}
+	}
+
+	// Message mode JSON values may name their struct
+	wrapped := map[string]any{"type": "Point", "value": map[string]any{"x": json.Number("1"), "y": json.Number("2")}}
+	msgs, err := c.EncodeValues([]any{wrapped}, "", true)
+	if err != nil {
+		t.Fatalf("EncodeValues(message) error = %v", err)
+	}
+	if name, _, err := c.DecodeMessage(msgs[0]); err != nil || name != "Point" {
+		t.Errorf("DecodeMessage() = %q, %v", name, err)
+	}
+
+	if _, err := c.EncodeValues([]any{map[string]any{"x": 1}}, "Point", false); err == nil || !strings.HasPrefix(err.Error(), "value 0: ") {
+		t.Errorf("missing field: err = %v, want value 0 error", err)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	c := newCodec(t)
+
+	for seed := uint64(0); seed < 20; seed++ {
+		v, err := c.Random(rand.New(rand.NewPCG(seed, 0)), "Shape", nil)
+		if err != nil {
+			t.Fatalf("Random() error = %v", err)
+		}
+		again, _ := c.Random(rand.New(rand.NewPCG(seed, 0)), "Shape", nil)
+		if !reflect.DeepEqual(v, again) {
+			t.Fatalf("seed %d: Random() is not deterministic", seed)
+		}
+
+		data, err := c.Encode("Shape", v)
+		if err != nil {
+			t.Fatalf("seed %d: Encode() error = %v", seed, err)
+		}
+		got, err := c.DecodeStrict("Shape", data)
+		if err != nil {
+			t.Fatalf("seed %d: DecodeStrict() error = %v", seed, err)
+		}
+		if !reflect.DeepEqual(got, v) {
+			t.Errorf("seed %d: round trip = %v, want %v", seed, got, v)
+		}
+	}
+
+	opts := &RandomOptions{OptionalProbability: 1, MinArrayLen: 2, MaxArrayLen: 2, MaxStringLen: 3, Charset: "é"}
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 20 {
+		v, err := c.Random(r, "Shape", opts)
+		if err != nil {
+			t.Fatalf("Random() error = %v", err)
+		}
+		if v["origin"] == nil {
+			t.Errorf("origin should be present with OptionalProbability 1")
+		}
+		for _, key := range []string{"points", "tags", "weights"} {
+			if n := len(v[key].([]any)); n != 2 {
+				t.Errorf("len(%s) = %d, want 2", key, n)
+			}
+		}
+		for _, s := range append([]any{v["name"]}, v["tags"].([]any)...) {
+			if s := s.(string); strings.Trim(s, "é") != "" || len([]rune(s)) > 3 {
+				t.Errorf("string %q does not respect Charset and MaxStringLen", s)
+			}
+		}
+	}
+
+	v, _ := c.Random(r, "Shape", &RandomOptions{OptionalProbability: -1, MaxArrayLen: -1, MaxStringLen: -1})
+	if v["origin"] != nil || v["name"] != "" || len(v["points"].([]any)) != 0 {
+		t.Errorf("negative options: Random() = %v, want empty arrays and strings", v)
+	}
+
+	// Unset fields keep their defaults
+	var longest int
+	for range 50 {
+		v, _ := c.Random(r, "Shape", &RandomOptions{Charset: "x"})
+		name := v["name"].(string)
+		if strings.Trim(name, "x") != "" {
+			t.Errorf("name %q does not respect Charset", name)
+		}
+		longest = max(longest, len(name))
+	}
+	if longest == 0 || longest > 16 {
+		t.Errorf("partial options: longest name = %d, want 1 to 16", longest)
+	}
+	if _, err := c.Random(r, "Nope", nil); !errors.Is(err, ErrUnknownStruct) {
+		t.Errorf("unknown struct: err = %v", err)
+	}
+}
@@ -0,0 +1,294 @@
+package dynamic
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// EncodeError reports which field of the input value could not be encoded.
+type EncodeError struct {
+	Path string // Field path, e.g. "plugins[2].name"
+	Err  error  // One of the Err* sentinels of this package
+}
+
+func (e *EncodeError) Error() string {
+	return "encode " + e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying sentinel error for errors.Is.
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// wrapEncodeError prepends field to the path of err.
+func wrapEncodeError(err error, field string) error {
+	if ee, ok := err.(*EncodeError); ok {
+		if ee.Path == "" || ee.Path[0] == '[' {
+			ee.Path = field + ee.Path
+		} else {
+			ee.Path = field + "." + ee.Path
+		}
+		return ee
+	}
+	return &EncodeError{Path: field, Err: err}
+}
+
+func (c *Codec) appendStruct(b []byte, st *schema.Struct, v map[string]any) ([]byte, error) {
+	for key := range v {
+		if !hasField(st, key) {
+			return nil, wrapEncodeError(ErrUnknownField, key)
+		}
+	}
+
+	var err error
+	for i := range st.Fields {
+		f := &st.Fields[i]
+		fv, ok := v[f.Name]
+		if !ok && !f.Type.Optional {
+			return nil, wrapEncodeError(ErrMissingField, f.Name)
+		}
+		b, err = c.appendField(b, &f.Type, fv)
+		if err != nil {
+			return nil, wrapEncodeError(err, f.Name)
+		}
+	}
+	return b, nil
+}
+
+func hasField(st *schema.Struct, name string) bool {
+	for i := range st.Fields {
+		if st.Fields[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Codec) appendField(b []byte, t *schema.TypeExpr, v any) ([]byte, error) {
+	switch {
+	case t.Optional:
+		if v == nil {
+			return append(b, 0), nil
+		}
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		return c.appendStruct(append(b, 1), c.structs[t.Name], m)
+
+	case t.Kind == schema.TypeKindArray:
+		return c.appendArray(b, t.Elem, v)
+
+	case t.Kind == schema.TypeKindNamed:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		return c.appendStruct(b, c.structs[t.Name], m)
+
+	default:
+		return appendPrimitive(b, t.Name, v)
+	}
+}
+
+func (c *Codec) appendArray(b []byte, elem *schema.TypeExpr, v any) ([]byte, error) {
+	if v == nil {
+		return binary.LittleEndian.AppendUint32(b, 0), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, ErrTypeMismatch
+	}
+	n := rv.Len()
+	if uint64(n) > math.MaxUint32 {
+		return nil, ErrOutOfRange
+	}
+	b = binary.LittleEndian.AppendUint32(b, uint32(n))
+
+	var err error
+	for i := 0; i < n; i++ {
+		b, err = c.appendField(b, elem, rv.Index(i).Interface())
+		if err != nil {
+			return nil, wrapEncodeError(err, "["+strconv.Itoa(i)+"]")
+		}
+	}
+	return b, nil
+}
+
+func appendPrimitive(b []byte, name string, v any) ([]byte, error) {
+	switch name {
+	case "str":
+		s, ok := v.(string)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+		return append(b, s...), nil
+
+	case "bool":
+		x, ok := v.(bool)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		if x {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+
+	case "f32":
+		if x, ok := v.(float32); ok {
+			// Keep NaN payloads, which a float64 round trip may change
+			return binary.LittleEndian.AppendUint32(b, math.Float32bits(x)), nil
+		}
+		x, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		if !math.IsInf(x, 0) && !math.IsNaN(x) && math.Abs(x) > math.MaxFloat32 {
+			return nil, ErrOutOfRange
+		}
+		return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(x))), nil
+
+	case "f64":
+		x, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(x)), nil
+
+	case "u8", "u16", "u32", "u64":
+		bits := primitiveSizes[name] * 8
+		x, err := toUint(v, bits)
+		if err != nil {
+			return nil, err
+		}
+		return appendInteger(b, x, bits), nil
+
+	case "i8", "i16", "i32", "i64":
+		bits := primitiveSizes[name] * 8
+		x, err := toInt(v, bits)
+		if err != nil {
+			return nil, err
+		}
+		return appendInteger(b, uint64(x), bits), nil
+	}
+
+	return nil, ErrTypeMismatch
+}
+
+// appendInteger appends the low bits of x in little-endian order.
+func appendInteger(b []byte, x uint64, bits int) []byte {
+	for i := 0; i < bits; i += 8 {
+		b = append(b, byte(x>>i))
+	}
+	return b
+}
+
+// toUint converts any Go number that fits in an unsigned integer of the given size.
+func toUint(v any, bits int) (uint64, error) {
+	if n, ok := v.(json.Number); ok {
+		x, err := strconv.ParseUint(string(n), 10, bits)
+		if err != nil {
+			return 0, numberError(err)
+		}
+		return x, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	var x uint64
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x = rv.Uint()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() < 0 {
+			return 0, ErrOutOfRange
+		}
+		x = uint64(rv.Int())
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.Ldexp(1, bits) {
+			return 0, ErrOutOfRange
+		}
+		x = uint64(f)
+	default:
+		return 0, ErrTypeMismatch
+	}
+
+	if bits < 64 && x>>bits != 0 {
+		return 0, ErrOutOfRange
+	}
+	return x, nil
+}
+
+// toInt converts any Go number that fits in a signed integer of the given size.
+func toInt(v any, bits int) (int64, error) {
+	if n, ok := v.(json.Number); ok {
+		x, err := strconv.ParseInt(string(n), 10, bits)
+		if err != nil {
+			return 0, numberError(err)
+		}
+		return x, nil
+	}
+
+	lo, hi := -int64(1)<<(bits-1), int64(1)<<(bits-1)-1
+	rv := reflect.ValueOf(v)
+	var x int64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x = rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if rv.Uint() > uint64(hi) {
+			return 0, ErrOutOfRange
+		}
+		x = int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f != math.Trunc(f) || f < -math.Ldexp(1, bits-1) || f >= math.Ldexp(1, bits-1) {
+			return 0, ErrOutOfRange
+		}
+		x = int64(f)
+	default:
+		return 0, ErrTypeMismatch
+	}
+
+	if x < lo || x > hi {
+		return 0, ErrOutOfRange
+	}
+	return x, nil
+}
+
+// toFloat converts any Go number to float64.
+func toFloat(v any) (float64, error) {
+	if n, ok := v.(json.Number); ok {
+		x, err := n.Float64()
+		if err != nil {
+			return 0, numberError(err)
+		}
+		return x, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), nil
+	}
+	return 0, ErrTypeMismatch
+}
+
+// numberError maps a strconv failure to ErrOutOfRange or ErrTypeMismatch.
+func numberError(err error) error {
+	if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+		return ErrOutOfRange
+	}
+	return ErrTypeMismatch
+}
@@ -0,0 +1,71 @@
+package dynamic
+
+import (
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// Span describes the bytes occupied by one field or array element, as
+// reported by Inspect.
+type Span struct {
+	Path   string // Field path, e.g. "plugins[2].name"
+	Type   string // Schema type, e.g. "str", "[]Parameter", "Option<Meta>"
+	Depth  int    // Nesting level, 0 for top-level fields
+	Offset int    // Start of the span in the inspected data
+	Length int    // Bytes occupied, or -1 if decoding failed inside the span
+
+	// Value is the decoded primitive, the element count (uint32) of an
+	// array, whether an optional is present (bool), or nil for structs.
+	Value any
+}
+
+// Inspect decodes one value of the named struct from the start of data and
+// returns a Span for every field and array element in wire order, nested
+// spans following their parent. It also returns the number of bytes decoded;
+// on error, the spans and count describe everything up to the failure, and
+// the spans enclosing it have Length -1.
+func (c *Codec) Inspect(structName string, data []byte) ([]Span, int, error) {
+	return c.inspect(structName, data, false)
+}
+
+// InspectStrict inspects like Inspect, but fails at the first non-canonical
+// encoding like DecodeStrict.
+func (c *Codec) InspectStrict(structName string, data []byte) ([]Span, int, error) {
+	return c.inspect(structName, data, true)
+}
+
+func (c *Codec) inspect(structName string, data []byte, strict bool) ([]Span, int, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	d := &decoder{codec: c, data: data, strict: strict, tracing: true}
+	if len(data) > sdp.MaxSerializedSize {
+		d.data = data[:sdp.MaxSerializedSize]
+	}
+	_, err = d.decodeStruct(st)
+	return d.spans, d.offset, err
+}
+
+// TypeName returns the name of the struct with the given message mode type
+// ID, or "" if the schema has none.
+func (c *Codec) TypeName(typeID uint16) string {
+	if typeID == 0 || int(typeID) > len(c.names) {
+		return ""
+	}
+	return c.names[typeID-1]
+}
+
+// spanValue returns the Span.Value for a decoded field value.
+func spanValue(t *schema.TypeExpr, v any) any {
+	switch {
+	case t.Optional:
+		return v != nil
+	case t.Kind == schema.TypeKindArray:
+		return uint32(len(v.([]any)))
+	case t.Kind == schema.TypeKindNamed:
+		return nil
+	}
+	return v
+}
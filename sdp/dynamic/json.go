@@ -0,0 +1,350 @@
+package dynamic
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// AppendJSON appends v, a value of the named struct, to b as compact JSON in
+// the canonical JSON mapping shared with generated code: object keys are the
+// schema field names (or their @json overrides) in schema order, u64 and i64
+// are decimal strings, []u8 is a base64 string, empty arrays are omitted and
+// absent optionals are null.
+// NaN and infinite floats have no JSON representation and are reported as
+// ErrOutOfRange.
+func (c *Codec) AppendJSON(b []byte, structName string, v map[string]any) ([]byte, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+	return c.appendJSONStruct(b, st, v)
+}
+
+func (c *Codec) appendJSONStruct(b []byte, st *schema.Struct, v map[string]any) ([]byte, error) {
+	b = append(b, '{')
+	first := true
+	for i := range st.Fields {
+		f := &st.Fields[i]
+		fv, ok := v[f.Name]
+		if !ok && !f.Type.Optional {
+			return nil, wrapEncodeError(ErrMissingField, f.Name)
+		}
+		if f.Type.Kind == schema.TypeKindArray && isEmpty(fv) {
+			continue
+		}
+
+		if !first {
+			b = append(b, ',')
+		}
+		first = false
+		b = strconv.AppendQuote(b, f.JSONKey())
+		b = append(b, ':')
+
+		var err error
+		b, err = c.appendJSONField(b, &f.Type, fv)
+		if err != nil {
+			return nil, wrapEncodeError(err, f.Name)
+		}
+	}
+	return append(b, '}'), nil
+}
+
+func (c *Codec) appendJSONField(b []byte, t *schema.TypeExpr, v any) ([]byte, error) {
+	switch {
+	case t.Optional && v == nil:
+		return append(b, "null"...), nil
+
+	case t.Kind == schema.TypeKindArray:
+		if v == nil {
+			return append(b, "[]"...), nil
+		}
+		if t.Elem.Kind == schema.TypeKindPrimitive && t.Elem.Name == "u8" {
+			return appendJSONBytes(b, v)
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, ErrTypeMismatch
+		}
+		b = append(b, '[')
+		for i := 0; i < rv.Len(); i++ {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			b, err = c.appendJSONField(b, t.Elem, rv.Index(i).Interface())
+			if err != nil {
+				return nil, wrapEncodeError(err, "["+strconv.Itoa(i)+"]")
+			}
+		}
+		return append(b, ']'), nil
+
+	case t.Kind == schema.TypeKindNamed:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		return c.appendJSONStruct(b, c.structs[t.Name], m)
+	}
+
+	switch t.Name {
+	case "f32", "f64":
+		x, err := toFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil, ErrOutOfRange
+		}
+		bits := 64
+		if t.Name == "f32" {
+			bits = 32
+		}
+		return appendJSONFloat(b, x, bits), nil
+
+	case "u64", "i64":
+		data, err := appendPrimitive(nil, t.Name, v)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, '"')
+		if t.Name == "u64" {
+			b = strconv.AppendUint(b, binary.LittleEndian.Uint64(data), 10)
+		} else {
+			b = strconv.AppendInt(b, int64(binary.LittleEndian.Uint64(data)), 10)
+		}
+		return append(b, '"'), nil
+	}
+
+	// Validate against the wire type, then let encoding/json format the value
+	if _, err := appendPrimitive(nil, t.Name, v); err != nil {
+		return nil, err
+	}
+	if n, ok := v.(json.Number); ok {
+		return append(b, n...), nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, ErrTypeMismatch
+	}
+	return append(b, data...), nil
+}
+
+// isEmpty reports whether v is nil or a slice without elements.
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	return (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Len() == 0
+}
+
+// appendJSONBytes appends a []u8 value as a base64 string.
+func appendJSONBytes(b []byte, v any) ([]byte, error) {
+	raw, ok := v.([]byte)
+	if !ok {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, ErrTypeMismatch
+		}
+		raw = make([]byte, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			var err error
+			raw, err = appendPrimitive(raw, "u8", rv.Index(i).Interface())
+			if err != nil {
+				return nil, wrapEncodeError(err, "["+strconv.Itoa(i)+"]")
+			}
+		}
+	}
+
+	b = append(b, '"')
+	b = base64.StdEncoding.AppendEncode(b, raw)
+	return append(b, '"'), nil
+}
+
+// appendJSONFloat formats a finite float like encoding/json, so generated
+// code and the dynamic codec write identical JSON.
+func appendJSONFloat(b []byte, x float64, bits int) []byte {
+	format := byte('f')
+	if abs := math.Abs(x); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	b = strconv.AppendFloat(b, x, format, -1, bits)
+	if format == 'e' {
+		// Clean up e-09 to e-9
+		n := len(b)
+		if n >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+	return b
+}
+
+// FromJSON converts an object in the canonical JSON mapping, as produced by
+// encoding/json (preferably with UseNumber, so 64-bit integers keep their
+// precision), to a value of the named struct with the types Decode returns.
+// Keys are mapped back to schema field names. Missing arrays become empty and
+// missing or null optionals absent; other missing fields and unknown keys are
+// errors. For convenience, 64-bit integers may also be plain numbers and
+// []u8 may also be an array of numbers.
+func (c *Codec) FromJSON(structName string, v map[string]any) (map[string]any, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+	return c.fromJSONStruct(st, v)
+}
+
+// SplitJSON splits JSON input into values for FromJSON: the elements of a
+// top-level array, or each value of a stream of values. With a selectKey,
+// every value must be an object and the value under that key is used
+// instead. Numbers are decoded as json.Number.
+func SplitJSON(input []byte, selectKey string) ([]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(input))
+	dec.UseNumber()
+
+	var values []any
+	for {
+		var v any
+		err := dec.Decode(&v)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JSON: %w", err)
+		}
+
+		if selectKey != "" {
+			obj, ok := v.(map[string]any)
+			if !ok || obj[selectKey] == nil {
+				return nil, fmt.Errorf("input has no top-level key %q", selectKey)
+			}
+			v = obj[selectKey]
+		}
+
+		if arr, ok := v.([]any); ok {
+			values = append(values, arr...)
+		} else {
+			values = append(values, v)
+		}
+	}
+	return values, nil
+}
+
+// UnpackJSON returns the struct name and object of a value returned by
+// SplitJSON. With a structName, v is an object of that struct; without one,
+// v must name its struct as {"type": "Name", "value": {...}}.
+func UnpackJSON(v any, structName string) (string, map[string]any, error) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return "", nil, fmt.Errorf("expected a JSON object, got %T", v)
+	}
+	if structName != "" {
+		return structName, obj, nil
+	}
+
+	name, _ := obj["type"].(string)
+	value, ok := obj["value"].(map[string]any)
+	if name == "" || !ok || len(obj) != 2 {
+		return "", nil, fmt.Errorf(`expected {"type": ..., "value": {...}} without a struct name`)
+	}
+	return name, value, nil
+}
+
+func (c *Codec) fromJSONStruct(st *schema.Struct, v map[string]any) (map[string]any, error) {
+	for key := range v {
+		if !hasJSONKey(st, key) {
+			return nil, wrapEncodeError(ErrUnknownField, key)
+		}
+	}
+
+	m := make(map[string]any, len(st.Fields))
+	for i := range st.Fields {
+		f := &st.Fields[i]
+		jv, ok := v[f.JSONKey()]
+		switch {
+		case ok && jv != nil:
+			x, err := c.fromJSONField(&f.Type, jv)
+			if err != nil {
+				return nil, wrapEncodeError(err, f.JSONKey())
+			}
+			m[f.Name] = x
+		case f.Type.Optional:
+			m[f.Name] = nil
+		case f.Type.Kind == schema.TypeKindArray:
+			m[f.Name] = []any{}
+		default:
+			return nil, wrapEncodeError(ErrMissingField, f.JSONKey())
+		}
+	}
+	return m, nil
+}
+
+func hasJSONKey(st *schema.Struct, key string) bool {
+	for i := range st.Fields {
+		if st.Fields[i].JSONKey() == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Codec) fromJSONField(t *schema.TypeExpr, v any) (any, error) {
+	switch t.Kind {
+	case schema.TypeKindArray:
+		if s, ok := v.(string); ok && t.Elem.Kind == schema.TypeKindPrimitive && t.Elem.Name == "u8" {
+			raw, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return nil, ErrTypeMismatch
+			}
+			arr := make([]any, len(raw))
+			for i, x := range raw {
+				arr[i] = x
+			}
+			return arr, nil
+		}
+		elems, ok := v.([]any)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		arr := make([]any, len(elems))
+		for i, e := range elems {
+			x, err := c.fromJSONField(t.Elem, e)
+			if err != nil {
+				return nil, wrapEncodeError(err, "["+strconv.Itoa(i)+"]")
+			}
+			arr[i] = x
+		}
+		return arr, nil
+
+	case schema.TypeKindNamed:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		return c.fromJSONStruct(c.structs[t.Name], m)
+	}
+
+	if s, ok := v.(string); ok && (t.Name == "u64" || t.Name == "i64") {
+		v = json.Number(s)
+	}
+	// Encode and decode again to get the wire type with range checks
+	data, err := appendPrimitive(nil, t.Name, v)
+	if err != nil {
+		return nil, err
+	}
+	d := &decoder{data: data}
+	return d.decodePrimitive(t.Name)
+}
@@ -0,0 +1,153 @@
+package dynamic
+
+import (
+	"math/rand/v2"
+
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// RandomOptions controls the values generated by Random. A zero field
+// selects its default, so a nil or partially set *RandomOptions gives
+// OptionalProbability 0.5, arrays of 0 to 4 elements and strings of up to 16
+// ASCII letters and digits unless overridden. A negative OptionalProbability,
+// MaxArrayLen or MaxStringLen asks for absent optionals, arrays of
+// MinArrayLen elements or empty strings.
+type RandomOptions struct {
+	// OptionalProbability is the chance that an optional field is present.
+	OptionalProbability float64
+
+	// MinArrayLen and MaxArrayLen bound the length of every array.
+	MinArrayLen int
+	MaxArrayLen int
+
+	// MaxStringLen bounds the length of every string in runes.
+	MaxStringLen int
+
+	// Charset holds the runes strings are drawn from. Empty means ASCII
+	// letters and digits.
+	Charset string
+}
+
+var defaultRandomOptions = RandomOptions{OptionalProbability: 0.5, MaxArrayLen: 4, MaxStringLen: 16}
+
+const randomCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// withDefaults returns a copy of opts with zero fields set to their defaults.
+// Keep in sync with withDefaults in the Go generator.
+func (opts *RandomOptions) withDefaults() *RandomOptions {
+	o := defaultRandomOptions
+	if opts == nil {
+		return &o
+	}
+	if opts.OptionalProbability != 0 {
+		o.OptionalProbability = opts.OptionalProbability
+	}
+	o.MinArrayLen = opts.MinArrayLen
+	if opts.MaxArrayLen != 0 {
+		o.MaxArrayLen = opts.MaxArrayLen
+	}
+	if opts.MaxStringLen != 0 {
+		o.MaxStringLen = opts.MaxStringLen
+	}
+	o.Charset = opts.Charset
+	return &o
+}
+
+// Random returns a value of the named struct with every field drawn from r,
+// with the types Decode returns. Floats are finite.
+//
+// Fields are filled in schema order and draw from r exactly like the
+// generated RandomX constructors, so both produce the same values from the
+// same seed.
+func (c *Codec) Random(r *rand.Rand, structName string, opts *RandomOptions) (map[string]any, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+	opts = opts.withDefaults()
+
+	g := &randomizer{codec: c, r: r, opts: opts, charset: []rune(opts.Charset)}
+	if len(g.charset) == 0 {
+		g.charset = []rune(randomCharset)
+	}
+	return g.randomStruct(st), nil
+}
+
+type randomizer struct {
+	codec   *Codec
+	r       *rand.Rand
+	opts    *RandomOptions
+	charset []rune
+}
+
+func (g *randomizer) randomStruct(st *schema.Struct) map[string]any {
+	v := make(map[string]any, len(st.Fields))
+	for _, field := range st.Fields {
+		v[field.Name] = g.randomField(&field.Type)
+	}
+	return v
+}
+
+func (g *randomizer) randomField(t *schema.TypeExpr) any {
+	switch t.Kind {
+	case schema.TypeKindNamed:
+		if t.Optional && g.r.Float64() >= g.opts.OptionalProbability {
+			return nil
+		}
+		return g.randomStruct(g.codec.structs[t.Name])
+
+	case schema.TypeKindArray:
+		n := max(g.opts.MinArrayLen, 0)
+		if g.opts.MaxArrayLen > n {
+			n += g.r.IntN(g.opts.MaxArrayLen - n + 1)
+		}
+		arr := make([]any, n)
+		for i := range arr {
+			arr[i] = g.randomField(t.Elem)
+		}
+		return arr
+
+	default:
+		return g.randomPrimitive(t.Name)
+	}
+}
+
+// randomPrimitive draws a primitive value. Keep in sync with randomPrimitive
+// in the Go generator.
+func (g *randomizer) randomPrimitive(name string) any {
+	r := g.r
+	switch name {
+	case "u8":
+		return uint8(r.Uint32())
+	case "u16":
+		return uint16(r.Uint32())
+	case "u32":
+		return r.Uint32()
+	case "u64":
+		return r.Uint64()
+	case "i8":
+		return int8(r.Uint32())
+	case "i16":
+		return int16(r.Uint32())
+	case "i32":
+		return int32(r.Uint32())
+	case "i64":
+		return int64(r.Uint64())
+	case "f32":
+		return float32(r.NormFloat64() * 1000)
+	case "f64":
+		return r.NormFloat64() * 1000
+	case "bool":
+		return r.IntN(2) == 1
+	}
+
+	// str
+	if g.opts.MaxStringLen <= 0 {
+		return ""
+	}
+	s := make([]rune, r.IntN(g.opts.MaxStringLen+1))
+	for i := range s {
+		s[i] = g.charset[r.IntN(len(g.charset))]
+	}
+	return string(s)
+}
@@ -0,0 +1,724 @@
+package dynamic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/sdp"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// The text format is a lossless, human-readable form of SDP values for golden
+// fixtures and hand-written test inputs:
+//
+//	# comments run to the end of the line
+//	id: 42
+//	name: "cutoff"              # Go string syntax, any bytes via \x escapes
+//	address: 18446744073709551615
+//	gain: -0.5                  # also nan, inf, -inf and nan(0x7fc00001)
+//	enabled: true
+//	data: b"\x01\x02hi"         # []u8 as a byte string, or [1, 2]
+//	tags: ["a", "b"]
+//	origin { x: 1 y: 2 }        # nested struct, the colon is optional
+//	meta: none                  # absent optional
+//	points: [
+//	  { x: 1 y: 2 },
+//	]
+//
+// Fields are written as name: value with schema field names, separated by
+// whitespace (a trailing comma or semicolon is allowed). Integers may use
+// 0x, 0o and 0b prefixes and _ separators. Floats are printed in the shortest
+// form that round-trips and NaN payloads other than the canonical NaN are
+// kept as raw bits, so AppendText followed by ParseText reproduces the exact
+// bytes of any value. Files may hold several values separated by "---" lines;
+// see SplitText.
+
+// SplitText splits a file holding several text values into the individual
+// values, which are separated by lines consisting of "---".
+func SplitText(text []byte) [][]byte {
+	var values [][]byte
+	start := 0
+	for i := 0; i <= len(text); {
+		end := bytes.IndexByte(text[i:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += i
+		}
+		if string(bytes.TrimSpace(text[i:end])) == "---" {
+			values = append(values, text[start:i])
+			start = end + 1
+		}
+		i = end + 1
+	}
+	if start < len(text) && len(bytes.TrimSpace(text[start:])) > 0 || len(values) == 0 {
+		values = append(values, text[min(start, len(text)):])
+	}
+	return values
+}
+
+// TextError reports where text could not be parsed.
+type TextError struct {
+	Line   int    // 1-based line of the offending token
+	Column int    // 1-based byte column of the offending token
+	Path   string // Field path, e.g. "plugins[2].name", if known
+	Err    error  // One of the Err* sentinels of this package, or a syntax error
+}
+
+func (e *TextError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d, column %d: %s: %v", e.Line, e.Column, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error for errors.Is.
+func (e *TextError) Unwrap() error {
+	return e.Err
+}
+
+// AppendText appends v, a value of the named struct, to b in the text format,
+// one field per line with nested structs indented by two spaces. Every field
+// is written, absent optionals as none.
+func (c *Codec) AppendText(b []byte, structName string, v map[string]any) ([]byte, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+	return c.appendTextFields(b, st, v, "")
+}
+
+func (c *Codec) appendTextFields(b []byte, st *schema.Struct, v map[string]any, indent string) ([]byte, error) {
+	for key := range v {
+		if !hasField(st, key) {
+			return nil, wrapEncodeError(ErrUnknownField, key)
+		}
+	}
+
+	for i := range st.Fields {
+		f := &st.Fields[i]
+		fv, ok := v[f.Name]
+		if !ok && !f.Type.Optional {
+			return nil, wrapEncodeError(ErrMissingField, f.Name)
+		}
+
+		b = append(b, indent...)
+		b = append(b, f.Name...)
+		if f.Type.Kind != schema.TypeKindNamed || fv == nil {
+			b = append(b, ':')
+		}
+		b = append(b, ' ')
+
+		var err error
+		b, err = c.appendTextField(b, &f.Type, fv, indent)
+		if err != nil {
+			return nil, wrapEncodeError(err, f.Name)
+		}
+		b = append(b, '\n')
+	}
+	return b, nil
+}
+
+func (c *Codec) appendTextField(b []byte, t *schema.TypeExpr, v any, indent string) ([]byte, error) {
+	switch {
+	case t.Optional && v == nil:
+		return append(b, "none"...), nil
+
+	case t.Kind == schema.TypeKindArray:
+		if t.Elem.Kind == schema.TypeKindPrimitive && t.Elem.Name == "u8" {
+			raw, ok := v.([]byte)
+			if !ok {
+				var err error
+				if raw, err = appendArrayBytes(v); err != nil {
+					return nil, err
+				}
+			}
+			b = append(b, 'b')
+			return strconv.AppendQuote(b, string(raw)), nil
+		}
+
+		rv := reflect.ValueOf(v)
+		if v != nil && rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			return nil, ErrTypeMismatch
+		}
+		if v == nil || rv.Len() == 0 {
+			return append(b, "[]"...), nil
+		}
+
+		// Struct elements go on their own lines, primitives on one line
+		multiline := t.Elem.Kind == schema.TypeKindNamed
+		b = append(b, '[')
+		for i := 0; i < rv.Len(); i++ {
+			if multiline {
+				b = append(b, '\n')
+				b = append(b, indent+"  "...)
+			} else if i > 0 {
+				b = append(b, ", "...)
+			}
+			var err error
+			b, err = c.appendTextField(b, t.Elem, rv.Index(i).Interface(), indent+"  ")
+			if err != nil {
+				return nil, wrapEncodeError(err, "["+strconv.Itoa(i)+"]")
+			}
+			if multiline {
+				b = append(b, ',')
+			}
+		}
+		if multiline {
+			b = append(b, '\n')
+			b = append(b, indent...)
+		}
+		return append(b, ']'), nil
+
+	case t.Kind == schema.TypeKindNamed:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, ErrTypeMismatch
+		}
+		b = append(b, "{\n"...)
+		b, err := c.appendTextFields(b, c.structs[t.Name], m, indent+"  ")
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, indent...)
+		return append(b, '}'), nil
+	}
+
+	// Encode and decode again to get the wire type with range checks
+	data, err := appendPrimitive(nil, t.Name, v)
+	if err != nil {
+		return nil, err
+	}
+	x, err := (&decoder{data: data}).decodePrimitive(t.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch x := x.(type) {
+	case string:
+		return strconv.AppendQuote(b, x), nil
+	case float32:
+		return appendTextFloat(b, float64(x), uint64(math.Float32bits(x)), 32), nil
+	case float64:
+		return appendTextFloat(b, x, math.Float64bits(x), 64), nil
+	default:
+		return fmt.Append(b, x), nil
+	}
+}
+
+// appendArrayBytes converts a []u8 value that is not a []byte.
+func appendArrayBytes(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if v == nil {
+		return nil, nil
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, ErrTypeMismatch
+	}
+	raw := make([]byte, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		var err error
+		raw, err = appendPrimitive(raw, "u8", rv.Index(i).Interface())
+		if err != nil {
+			return nil, wrapEncodeError(err, "["+strconv.Itoa(i)+"]")
+		}
+	}
+	return raw, nil
+}
+
+// appendTextFloat writes x in the shortest round-trip form, or as nan, inf,
+// -inf or nan(0x...) with the raw bits of a non-canonical NaN.
+func appendTextFloat(b []byte, x float64, bits uint64, size int) []byte {
+	switch {
+	case math.IsInf(x, 1):
+		return append(b, "inf"...)
+	case math.IsInf(x, -1):
+		return append(b, "-inf"...)
+	case math.IsNaN(x):
+		if size == 32 && sdp.IsCanonicalF32(uint32(bits)) || size == 64 && sdp.IsCanonicalF64(bits) {
+			return append(b, "nan"...)
+		}
+		return fmt.Appendf(b, "nan(%#x)", bits)
+	}
+	return strconv.AppendFloat(b, x, 'g', -1, size)
+}
+
+// ParseText parses a value of the named struct in the text format and returns
+// it with the types Decode returns. As with FromJSON, missing arrays become
+// empty and missing optionals absent; other missing fields, unknown or
+// repeated fields and values that do not fit the field type are errors,
+// reported as *TextError with the line and column.
+func (c *Codec) ParseText(structName string, text []byte) (map[string]any, error) {
+	st, err := c.lookup(structName)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &textParser{c: c, lex: textLexer{src: text, line: 1, col: 1}}
+	p.next()
+	v, err := p.parseFields(st, tokEOF)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Token kinds
+const (
+	tokEOF    = iota
+	tokIdent  // name, true, false, none, nan, inf, nan(0x...)
+	tokNumber // anything starting with a digit, sign or dot
+	tokString // "..." or `...`
+	tokBytes  // b"..."
+	tokPunct  // one of : { } [ ] , ;
+)
+
+type textToken struct {
+	kind      int
+	text      string
+	line, col int
+}
+
+type textLexer struct {
+	src       []byte
+	pos       int
+	line, col int
+}
+
+func (l *textLexer) peekByte(off int) byte {
+	if l.pos+off < len(l.src) {
+		return l.src[l.pos+off]
+	}
+	return 0
+}
+
+func (l *textLexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *textLexer) next() (textToken, error) {
+	// Skip whitespace and comments
+	for l.pos < len(l.src) {
+		ch := l.src[l.pos]
+		if ch == '#' {
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		} else if ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' {
+			l.advance(1)
+		} else {
+			break
+		}
+	}
+
+	tok := textToken{line: l.line, col: l.col}
+	if l.pos >= len(l.src) {
+		tok.kind = tokEOF
+		return tok, nil
+	}
+
+	start := l.pos
+	ch := l.src[l.pos]
+	switch {
+	case strings.IndexByte(":{}[],;", ch) >= 0:
+		tok.kind = tokPunct
+		l.advance(1)
+
+	case ch == '"' || ch == '`' || ch == 'b' && (l.peekByte(1) == '"' || l.peekByte(1) == '`'):
+		tok.kind = tokString
+		if ch == 'b' {
+			tok.kind = tokBytes
+			l.advance(1)
+		}
+		quote := l.src[l.pos]
+		l.advance(1)
+		for {
+			if l.pos >= len(l.src) || quote == '"' && l.src[l.pos] == '\n' {
+				return tok, errors.New("unterminated string")
+			}
+			c := l.src[l.pos]
+			if c == '\\' && quote == '"' {
+				l.advance(2)
+				continue
+			}
+			l.advance(1)
+			if c == quote {
+				break
+			}
+		}
+
+	case isIdentStart(ch):
+		tok.kind = tokIdent
+		for l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+			l.advance(1)
+		}
+		if string(l.src[start:l.pos]) == "nan" && l.peekByte(0) == '(' {
+			for l.pos < len(l.src) && l.src[l.pos] != ')' && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+			if l.peekByte(0) != ')' {
+				return tok, errors.New("unterminated nan(...)")
+			}
+			l.advance(1)
+		}
+
+	case ch == '-' || ch == '+' || ch == '.' || ch >= '0' && ch <= '9':
+		tok.kind = tokNumber
+		l.advance(1)
+		for l.pos < len(l.src) {
+			c := l.src[l.pos]
+			if isIdentPart(c) || c == '.' || (c == '+' || c == '-') && isExponent(l.src[l.pos-1]) {
+				l.advance(1)
+			} else {
+				break
+			}
+		}
+
+	default:
+		return tok, fmt.Errorf("unexpected character %q", ch)
+	}
+
+	tok.text = string(l.src[start:l.pos])
+	return tok, nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// isExponent reports whether a sign after c belongs to an exponent (1e-5, 0x1p-2).
+func isExponent(c byte) bool {
+	return c == 'e' || c == 'E' || c == 'p' || c == 'P'
+}
+
+type textParser struct {
+	c   *Codec
+	lex textLexer
+	tok textToken
+	err error // lexer error for tok
+}
+
+func (p *textParser) next() {
+	p.tok, p.err = p.lex.next()
+}
+
+// fail returns a TextError at the current token.
+func (p *textParser) fail(err error) error {
+	return &TextError{Line: p.tok.line, Column: p.tok.col, Err: err}
+}
+
+// failf returns a syntax error at the current token.
+func (p *textParser) failf(format string, args ...any) error {
+	return p.fail(fmt.Errorf(format, args...))
+}
+
+// check returns the lexer error for the current token, if any.
+func (p *textParser) check() error {
+	if p.err != nil {
+		return p.fail(p.err)
+	}
+	return nil
+}
+
+func (p *textParser) isPunct(s string) bool {
+	return p.err == nil && p.tok.kind == tokPunct && p.tok.text == s
+}
+
+func (p *textParser) expectPunct(s string) error {
+	if err := p.check(); err != nil {
+		return err
+	}
+	if !p.isPunct(s) {
+		return p.failf("expected %q, found %s", s, p.describe())
+	}
+	p.next()
+	return nil
+}
+
+func (p *textParser) describe() string {
+	if p.tok.kind == tokEOF {
+		return "end of input"
+	}
+	return strconv.Quote(p.tok.text)
+}
+
+// wrapTextError prepends field to the path of a TextError.
+func wrapTextError(err error, field string) error {
+	var te *TextError
+	if errors.As(err, &te) {
+		if te.Path == "" || te.Path[0] == '[' {
+			te.Path = field + te.Path
+		} else {
+			te.Path = field + "." + te.Path
+		}
+	}
+	return err
+}
+
+// parseFields parses field assignments until the end token ("}" or EOF).
+func (p *textParser) parseFields(st *schema.Struct, end int) (map[string]any, error) {
+	m := make(map[string]any, len(st.Fields))
+	line, col := p.tok.line, p.tok.col
+
+	for {
+		if err := p.check(); err != nil {
+			return nil, err
+		}
+		if end == tokEOF && p.tok.kind == tokEOF || end == tokPunct && p.isPunct("}") {
+			break
+		}
+		if p.tok.kind != tokIdent {
+			return nil, p.failf("expected field name, found %s", p.describe())
+		}
+
+		name := p.tok.text
+		var f *schema.Field
+		for i := range st.Fields {
+			if st.Fields[i].Name == name {
+				f = &st.Fields[i]
+			}
+		}
+		if f == nil {
+			return nil, wrapTextError(p.fail(ErrUnknownField), name)
+		}
+		if _, dup := m[name]; dup {
+			return nil, wrapTextError(p.failf("field repeated"), name)
+		}
+		p.next()
+
+		// The colon is optional before a struct value
+		if p.isPunct(":") {
+			p.next()
+		} else if f.Type.Kind != schema.TypeKindNamed || !p.isPunct("{") {
+			if err := p.expectPunct(":"); err != nil {
+				return nil, wrapTextError(err, name)
+			}
+		}
+
+		v, err := p.parseValue(&f.Type)
+		if err != nil {
+			return nil, wrapTextError(err, name)
+		}
+		m[name] = v
+
+		if p.isPunct(",") || p.isPunct(";") {
+			p.next()
+		}
+	}
+
+	for i := range st.Fields {
+		f := &st.Fields[i]
+		if _, ok := m[f.Name]; ok {
+			continue
+		}
+		switch {
+		case f.Type.Optional:
+			m[f.Name] = nil
+		case f.Type.Kind == schema.TypeKindArray:
+			m[f.Name] = []any{}
+		default:
+			return nil, &TextError{Line: line, Column: col, Path: f.Name, Err: ErrMissingField}
+		}
+	}
+	return m, nil
+}
+
+func (p *textParser) parseValue(t *schema.TypeExpr) (any, error) {
+	if err := p.check(); err != nil {
+		return nil, err
+	}
+
+	if t.Optional && p.tok.kind == tokIdent && p.tok.text == "none" {
+		p.next()
+		return nil, nil
+	}
+
+	switch t.Kind {
+	case schema.TypeKindArray:
+		if t.Elem.Kind == schema.TypeKindPrimitive && t.Elem.Name == "u8" && p.tok.kind == tokBytes {
+			s, err := strconv.Unquote(p.tok.text[1:])
+			if err != nil {
+				return nil, p.failf("invalid byte string")
+			}
+			arr := make([]any, len(s))
+			for i := 0; i < len(s); i++ {
+				arr[i] = s[i]
+			}
+			p.next()
+			return arr, nil
+		}
+
+		if err := p.expectPunct("["); err != nil {
+			return nil, err
+		}
+		arr := []any{}
+		for !p.isPunct("]") {
+			x, err := p.parseValue(t.Elem)
+			if err != nil {
+				return nil, wrapTextError(err, "["+strconv.Itoa(len(arr))+"]")
+			}
+			arr = append(arr, x)
+			if !p.isPunct(",") {
+				break
+			}
+			p.next()
+		}
+		if err := p.expectPunct("]"); err != nil {
+			return nil, err
+		}
+		return arr, nil
+
+	case schema.TypeKindNamed:
+		if err := p.expectPunct("{"); err != nil {
+			return nil, err
+		}
+		m, err := p.parseFields(p.c.structs[t.Name], tokPunct)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectPunct("}"); err != nil {
+			return nil, err
+		}
+		return m, nil
+	}
+
+	v, err := p.parsePrimitive(t.Name)
+	if err != nil {
+		return nil, p.fail(err)
+	}
+	p.next()
+	return v, nil
+}
+
+// parsePrimitive converts the current token to a value of the wire type.
+func (p *textParser) parsePrimitive(name string) (any, error) {
+	tok := p.tok
+	switch name {
+	case "str":
+		if tok.kind != tokString {
+			return nil, ErrTypeMismatch
+		}
+		s, err := strconv.Unquote(tok.text)
+		if err != nil {
+			return nil, errors.New("invalid string literal")
+		}
+		return s, nil
+
+	case "bool":
+		if tok.kind == tokIdent && (tok.text == "true" || tok.text == "false") {
+			return tok.text == "true", nil
+		}
+		return nil, ErrTypeMismatch
+
+	case "f32", "f64":
+		return parseTextFloat(tok, name)
+	}
+
+	if tok.kind != tokNumber {
+		return nil, ErrTypeMismatch
+	}
+	bits := primitiveSizes[name] * 8
+	s, base := tok.text, 0
+	if digits := strings.TrimLeft(s, "+-"); len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9' {
+		base = 10 // 010 is ten, not octal
+	}
+
+	var x any
+	if name[0] == 'u' {
+		n, err := strconv.ParseUint(strings.TrimPrefix(s, "+"), base, bits)
+		if err != nil {
+			return nil, textNumberError(err, s)
+		}
+		x = n
+	} else {
+		n, err := strconv.ParseInt(s, base, bits)
+		if err != nil {
+			return nil, textNumberError(err, s)
+		}
+		x = n
+	}
+	data, err := appendPrimitive(nil, name, x)
+	if err != nil {
+		return nil, err
+	}
+	return (&decoder{data: data}).decodePrimitive(name)
+}
+
+// textNumberError maps a strconv failure, treating a minus sign on an
+// unsigned field as out of range.
+func textNumberError(err error, s string) error {
+	if strings.HasPrefix(s, "-") {
+		return ErrOutOfRange
+	}
+	return numberError(err)
+}
+
+func parseTextFloat(tok textToken, name string) (any, error) {
+	size := 64
+	if name == "f32" {
+		size = 32
+	}
+
+	var x float64
+	switch {
+	case tok.kind == tokIdent && tok.text == "nan":
+		// The canonical NaN, which AppendText prints as plain nan
+		if size == 32 {
+			return math.Float32frombits(sdp.CanonicalNaN32), nil
+		}
+		return math.Float64frombits(sdp.CanonicalNaN64), nil
+	case tok.kind == tokIdent && tok.text == "inf":
+		x = math.Inf(1)
+	case tok.kind == tokNumber && tok.text == "+inf":
+		x = math.Inf(1)
+	case tok.kind == tokNumber && tok.text == "-inf":
+		x = math.Inf(-1)
+	case tok.kind == tokIdent && strings.HasPrefix(tok.text, "nan("):
+		raw, err := strconv.ParseUint(tok.text[4:len(tok.text)-1], 0, size)
+		if err != nil {
+			return nil, textNumberError(err, tok.text)
+		}
+		if size == 32 {
+			f := math.Float32frombits(uint32(raw))
+			if !math.IsNaN(float64(f)) {
+				return nil, errors.New("nan(...) bits are not a NaN")
+			}
+			return f, nil
+		}
+		f := math.Float64frombits(raw)
+		if !math.IsNaN(f) {
+			return nil, errors.New("nan(...) bits are not a NaN")
+		}
+		return f, nil
+	case tok.kind == tokNumber:
+		f, err := strconv.ParseFloat(tok.text, size)
+		if err != nil {
+			return nil, numberError(err)
+		}
+		x = f
+	default:
+		return nil, ErrTypeMismatch
+	}
+
+	if size == 32 {
+		return float32(x), nil
+	}
+	return x, nil
+}
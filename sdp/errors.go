@@ -0,0 +1,63 @@
+package sdp
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Sentinel errors shared by every generated package that targets this
+// runtime (sdp-gen -runtime). Packages generated without the option declare
+// their own copies with the same messages.
+var (
+	ErrUnexpectedEOF      = errors.New("unexpected end of data")
+	ErrInvalidUTF8        = errors.New("invalid UTF-8 string")
+	ErrDataTooLarge       = errors.New("data exceeds 128MB limit")
+	ErrArrayTooLarge      = errors.New("array count exceeds per-array limit")
+	ErrTooManyElements    = errors.New("total elements exceed limit")
+	ErrInvalidData        = errors.New("invalid or corrupted data")
+	ErrInvalidMagic       = errors.New("invalid magic bytes (expected 'SDP')")
+	ErrInvalidVersion     = errors.New("unsupported protocol version")
+	ErrUnknownMessageType = errors.New("unknown message type ID")
+	ErrTrailingBytes      = errors.New("trailing bytes after value")
+	ErrNonCanonical       = errors.New("non-canonical encoding")
+)
+
+// DecodeError reports where in the payload decoding failed.
+// Path uses schema field names with array indices (e.g. "plugins[12].parameters[3].unit"),
+// Offset is the byte offset into the payload where the failing read started, and
+// Err is one of the sentinel errors above, so errors.Is(err, ErrUnexpectedEOF) still matches.
+type DecodeError struct {
+	Path   string
+	Offset int
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return "decode " + e.Path + " at offset " + strconv.Itoa(e.Offset) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying sentinel error for errors.Is.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// WrapDecodeError annotates err with the field being decoded. Errors coming
+// from nested structs already carry a path and offset, so only the field
+// name is prepended to their path.
+func WrapDecodeError(err error, field string, offset int) error {
+	if de, ok := err.(*DecodeError); ok {
+		if de.Path == "" || de.Path[0] == '[' {
+			de.Path = field + de.Path
+		} else {
+			de.Path = field + "." + de.Path
+		}
+		return de
+	}
+	return &DecodeError{Path: field, Offset: offset, Err: err}
+}
+
+// WrapElementError annotates err with the index of the array element being decoded.
+func WrapElementError(err error, index uint32, offset int) error {
+	return WrapDecodeError(err, "["+strconv.FormatUint(uint64(index), 10)+"]", offset)
+}
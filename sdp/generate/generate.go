@@ -0,0 +1,85 @@
+// Package generate turns validated schemas into source code.
+//
+// Go output is returned in memory so build tools can post-process it or
+// write it wherever they like; the other languages are written directly to
+// an output directory, as sdp-gen does:
+//
+//	files, err := generate.Go(s, generate.GoOptions{Package: "audio"})
+//	if err != nil {
+//		return err
+//	}
+//	err = files.Write("./internal/audio")
+//
+//	err = generate.Rust(s, generate.Options{OutputDir: "./rust/audio"})
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/shaban/serial-data-protocol/internal/generator/cpp"
+	"github.com/shaban/serial-data-protocol/internal/generator/rust"
+	"github.com/shaban/serial-data-protocol/internal/generator/rustexp"
+	"github.com/shaban/serial-data-protocol/internal/generator/swift"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// Files maps generated file names to their contents.
+type Files map[string]string
+
+// Names returns the file names in sorted order.
+func (f Files) Names() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write creates dir if needed and writes every file into it.
+func (f Files) Write(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, name := range f.Names() {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(f[name]), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Options configures the generators that write to a directory.
+type Options struct {
+	// OutputDir receives the generated files (required). Its base name is
+	// used as the C++ namespace and the Rust crate name.
+	OutputDir string
+
+	// Verbose prints each file as it is written.
+	Verbose bool
+}
+
+// Cpp writes C++ headers, sources and a CMakeLists.txt to opts.OutputDir.
+func Cpp(s *schema.Schema, opts Options) error {
+	return cpp.Generate(s, opts.OutputDir, opts.Verbose)
+}
+
+// Rust writes a Cargo crate to opts.OutputDir.
+func Rust(s *schema.Schema, opts Options) error {
+	return rust.Generate(s, opts.OutputDir, opts.Verbose)
+}
+
+// RustExp writes a Cargo crate using the experimental fast-path decoders.
+func RustExp(s *schema.Schema, opts Options) error {
+	return rustexp.Generate(s, opts.OutputDir, opts.Verbose)
+}
+
+// Swift writes a Swift package wrapping generated C++ code to opts.OutputDir.
+func Swift(s *schema.Schema, opts Options) error {
+	return swift.Generate(s, opts.OutputDir, opts.Verbose)
+}
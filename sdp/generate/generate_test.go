@@ -0,0 +1,150 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+func parse(t *testing.T, src string) *schema.Schema {
+	t.Helper()
+	s, err := schema.Parse(src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if err := schema.Validate(s); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	return s
+}
+
+func TestGo(t *testing.T) {
+	s := parse(t, "struct Device { id: u32, name: str }")
+
+	files, err := Go(s, GoOptions{Package: "devices"})
+	if err != nil {
+		t.Fatalf("Go() error = %v", err)
+	}
+
+	want := []string{"decode.go", "encode.go", "equal.go", "errors.go", "random.go", "router.go", "string.go", "types.go", "validate.go", "view.go"}
+	if got := files.Names(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+	for name, content := range files {
+		if !strings.HasPrefix(content, "package devices\n") {
+			t.Errorf("%s: missing package clause", name)
+		}
+	}
+	if !strings.Contains(files["errors.go"], `errors.New("unexpected end of data")`) {
+		t.Error("errors.go should declare its own sentinels")
+	}
+
+	files, err = Go(s, GoOptions{Package: "devices", Runtime: true})
+	if err != nil {
+		t.Fatalf("Go(Runtime) error = %v", err)
+	}
+	if !strings.Contains(files["errors.go"], `"github.com/shaban/serial-data-protocol/sdp"`) {
+		t.Error("runtime errors.go should import package sdp")
+	}
+
+	if strings.Contains(files["decode.go"], "arena") {
+		t.Error("decode.go should have no arena code unless Arena is set")
+	}
+
+	files, err = Go(s, GoOptions{Package: "devices", Arena: true})
+	if err != nil {
+		t.Fatalf("Go(Arena) error = %v", err)
+	}
+	if !strings.Contains(files["arena.go"], "func DecodeDeviceArena(dest *Device, data []byte) error {") {
+		t.Error("arena.go should declare DecodeDeviceArena")
+	}
+	if !strings.Contains(files["decode.go"], "arena         *decodeArena") {
+		t.Error("DecodeContext should carry the arena")
+	}
+
+	files, err = Go(s, GoOptions{Package: "devices", Fuzz: true})
+	if err != nil {
+		t.Fatalf("Go(Fuzz) error = %v", err)
+	}
+	if !strings.Contains(files["fuzz_test.go"], "import (\n\t\"bytes\"\n\t\"testing\"\n)") {
+		t.Error("fuzz_test.go should import bytes and testing")
+	}
+	if !strings.Contains(files["fuzz_test.go"], "func FuzzDecodeDevice(f *testing.F) {") {
+		t.Error("fuzz_test.go should declare FuzzDecodeDevice")
+	}
+	if _, ok := files["bench_test.go"]; ok {
+		t.Error("bench_test.go generated without Bench")
+	}
+
+	files, err = Go(s, GoOptions{Package: "devices", Bench: true, BenchJSON: true})
+	if err != nil {
+		t.Fatalf("Go(Bench) error = %v", err)
+	}
+	for _, imp := range []string{`"encoding/json"`, `"flag"`, `"math/rand/v2"`, `"os"`, `"path/filepath"`, `"testing"`} {
+		if !strings.Contains(files["bench_test.go"], "\t"+imp+"\n") {
+			t.Errorf("bench_test.go should import %s", imp)
+		}
+	}
+	if !strings.Contains(files["bench_test.go"], "func BenchmarkRoundtripDeviceJSON(b *testing.B) {") {
+		t.Error("bench_test.go should declare BenchmarkRoundtripDeviceJSON")
+	}
+}
+
+func TestGoErrors(t *testing.T) {
+	if _, err := Go(nil, GoOptions{Package: "x"}); err == nil {
+		t.Error("expected error for nil schema")
+	}
+	s := parse(t, "struct A { x: u8 }")
+	if _, err := Go(s, GoOptions{}); err == nil {
+		t.Error("expected error for empty package name")
+	}
+}
+
+func TestGoDeterministic(t *testing.T) {
+	s := parse(t, "struct A { x: f64, items: []str }")
+
+	first, err := Go(s, GoOptions{Package: "a"})
+	if err != nil {
+		t.Fatalf("Go() error = %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, _ := Go(s, GoOptions{Package: "a"})
+		for name := range first {
+			if first[name] != again[name] {
+				t.Fatalf("%s differs between runs", name)
+			}
+		}
+	}
+}
+
+func TestFilesWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	files := Files{"a.go": "package a\n"}
+
+	if err := files.Write(dir); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "a.go"))
+	if err != nil || string(data) != "package a\n" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"./gen/audio", "audio"},
+		{"out/my-types", "my_types"},
+		{"3d", "_3d"},
+	}
+	for _, tt := range tests {
+		if got := PackageName(tt.dir); got != tt.want {
+			t.Errorf("PackageName(%q) = %q, want %q", tt.dir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/generator/golang"
+	"github.com/shaban/serial-data-protocol/sdp/schema"
+)
+
+// GoOptions configures Go code generation.
+type GoOptions struct {
+	// Package is the package clause of the generated files (required).
+	// PackageName derives one from an output directory.
+	Package string
+
+	// Runtime imports errors, limits and message constants from package sdp
+	// instead of generating a private copy, so errors.Is works across
+	// packages generated from different schemas.
+	Runtime bool
+
+	// Fuzz adds fuzz_test.go with a FuzzDecodeX target for every struct.
+	Fuzz bool
+
+	// Bench adds bench_test.go with BenchmarkEncodeX, BenchmarkDecodeX and
+	// BenchmarkRoundtripX for every struct.
+	Bench bool
+
+	// BenchJSON adds the same benchmarks using encoding/json to
+	// bench_test.go, named with a JSON suffix. It requires Bench.
+	BenchJSON bool
+
+	// Arena adds arena.go with DecodeXArena for every struct, which carves
+	// strings and slices out of a few up-front allocations. Without it the
+	// decoder contains no arena code.
+	Arena bool
+}
+
+// Go generates a Go package for a validated schema. The returned files
+// (types.go, encode.go, decode.go, ...) form a complete package.
+func Go(s *schema.Schema, opts GoOptions) (Files, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schema is nil")
+	}
+
+	if opts.Package == "" {
+		return nil, fmt.Errorf("package name is empty")
+	}
+
+	packageName := opts.Package
+	files := make(Files)
+
+	// Generate structs
+	structs, err := golang.GenerateStructs(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate structs: %w", err)
+	}
+
+	// Generate JSON methods for fields that struct tags cannot express
+	jsonMethods, err := golang.GenerateJSON(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JSON methods: %w", err)
+	}
+	if jsonMethods != "" {
+		structs += "\n" + jsonMethods
+	}
+
+	// Generate nil-safe getters and optional field helpers
+	accessors, err := golang.GenerateAccessors(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate accessors: %w", err)
+	}
+	structs += "\n" + accessors
+
+	// Generate encoder
+	encoder, err := golang.GenerateEncoder(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate encoder: %w", err)
+	}
+
+	encodeHelpers, err := golang.GenerateEncodeHelpers(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate encode helpers: %w", err)
+	}
+
+	// Generate decoder
+	decoder, err := golang.GenerateDecoder(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate decoder: %w", err)
+	}
+
+	decodeHelpers, err := golang.GenerateDecodeHelpers(s, opts.Arena)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate decode helpers: %w", err)
+	}
+
+	// Generate message mode encoders
+	messageEncoders, err := golang.GenerateMessageEncoders(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate message encoders: %w", err)
+	}
+
+	// Generate message mode decoders
+	messageDecoders, err := golang.GenerateMessageDecoders(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate message decoders: %w", err)
+	}
+
+	// Generate message dispatcher
+	messageDispatcher, err := golang.GenerateMessageDispatcher(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate message dispatcher: %w", err)
+	}
+
+	// Generate writer-based encoders (streaming I/O)
+	writerEncoders, err := golang.GenerateWriterEncoder(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate writer encoders: %w", err)
+	}
+
+	// Generate reader-based decoders (streaming I/O)
+	readerDecoders, err := golang.GenerateReaderDecoder(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate reader decoders: %w", err)
+	}
+
+	// Generate message router (typed handler dispatch)
+	router, err := golang.GenerateRouter(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate router: %w", err)
+	}
+
+	// Generate allocation-free validators
+	validators, err := golang.GenerateValidators(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate validators: %w", err)
+	}
+
+	// Generate zero-copy views
+	views, err := golang.GenerateViews(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate views: %w", err)
+	}
+
+	// Generate Equal, Clone and Diff methods
+	equal, err := golang.GenerateEqual(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate equal methods: %w", err)
+	}
+
+	// Generate random value constructors
+	random, err := golang.GenerateRandom(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate random constructors: %w", err)
+	}
+
+	// Generate String and LogValue methods
+	str, err := golang.GenerateString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate string methods: %w", err)
+	}
+
+	// Generate errors and context
+	errors := golang.GenerateErrors()
+	context := golang.GenerateDecodeContext(opts.Arena)
+	if opts.Runtime {
+		errors = golang.GenerateRuntimeErrors()
+		context = golang.GenerateRuntimeDecodeContext(opts.Arena)
+	}
+
+	// Combine encoder code (regular + helpers + message mode + writer mode)
+	encodeCode := encoder + "\n\n" + encodeHelpers + "\n\n" + messageEncoders + "\n\n" + writerEncoders
+
+	// Combine decoder code (context + regular + helpers + message mode + dispatcher + reader mode)
+	decodeCode := context + "\n\n" + decoder + "\n\n" + decodeHelpers + "\n\n" + messageDecoders + "\n\n" + messageDispatcher + "\n\n" + readerDecoders
+
+	// Determine imports based on content
+	files["types.go"] = formatGoFileWithAutoImports(packageName, structs)
+	files["encode.go"] = formatGoFileWithAutoImports(packageName, encodeCode)
+	files["decode.go"] = formatGoFileWithAutoImports(packageName, decodeCode)
+	files["errors.go"] = formatGoFileWithAutoImports(packageName, errors)
+	files["router.go"] = formatGoFileWithAutoImports(packageName, router)
+	files["validate.go"] = formatGoFileWithAutoImports(packageName, validators)
+	files["view.go"] = formatGoFileWithAutoImports(packageName, views)
+	files["equal.go"] = formatGoFileWithAutoImports(packageName, equal)
+	files["random.go"] = formatGoFileWithAutoImports(packageName, random)
+	files["string.go"] = formatGoFileWithAutoImports(packageName, str)
+
+	if opts.Arena {
+		arena, err := golang.GenerateArena(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate arena decoder: %w", err)
+		}
+		files["arena.go"] = formatGoFileWithAutoImports(packageName, arena)
+	}
+
+	if opts.Fuzz {
+		fuzz, err := golang.GenerateFuzz(s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate fuzz targets: %w", err)
+		}
+		files["fuzz_test.go"] = formatGoFileWithAutoImports(packageName, fuzz)
+	}
+
+	if opts.Bench {
+		bench, err := golang.GenerateBenchmark(s, opts.BenchJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate benchmarks: %w", err)
+		}
+		files["bench_test.go"] = formatGoFileWithAutoImports(packageName, bench)
+	}
+
+	return files, nil
+}
+
+// formatGoFile creates a complete Go source file with package and imports
+func formatGoFile(packageName string, imports []string, body string) string {
+	result := fmt.Sprintf("package %s\n\n", packageName)
+
+	if len(imports) > 0 {
+		result += "import (\n"
+		for _, imp := range imports {
+			result += fmt.Sprintf("\t%q\n", imp)
+		}
+		result += ")\n\n"
+	}
+
+	result += body
+
+	return result
+}
+
+// formatGoFileWithAutoImports creates a Go file and automatically detects needed imports
+func formatGoFileWithAutoImports(packageName string, body string) string {
+	var neededImports []string
+
+	// Check for common imports based on what's in the code
+	importChecks := map[string][]string{
+		"bytes":           {"bytes.Equal"},
+		"encoding/binary": {"binary.LittleEndian"},
+		"encoding/hex":    {"hex.AppendEncode"},
+		"encoding/json":   {"json.Marshal", "json.Unmarshal"},
+		"errors":          {"errors.New"},
+		"math":            {"math.Float"},
+		"strconv":         {"strconv."},
+		"unicode/utf8":    {"utf8.Valid"},
+		"io":              {"io.ReadAll", "io.ReadFull", "w io.Writer", "r io.Reader"}, // For streaming I/O functions
+		"iter":            {"iter.Seq"},                                                // For view iterators
+		"log/slog":        {"slog.Value"},                                              // For LogValue
+		"math/rand/v2":    {"rand.Rand", "rand.NewPCG"},                                // For random values
+		"unsafe":          {"unsafe.Slice", "unsafe.Pointer", "unsafe.String"},         // For bulk array copy and zero-copy views
+		"testing":         {"testing.F", "testing.B"},                                  // For -fuzz and -bench
+		"flag":            {"flag.String"},                                             // For -bench fixtures
+		"os":              {"os.ReadFile"},                                             // For -bench fixtures
+		"path/filepath":   {"filepath.Join"},                                           // For -bench fixtures
+		"github.com/shaban/serial-data-protocol/sdp": {"sdp.ErrUnexpectedEOF", "sdp.CheckArraySize"}, // For -runtime
+	}
+
+	for importPath, markers := range importChecks {
+		for _, marker := range markers {
+			if strings.Contains(body, marker) {
+				neededImports = append(neededImports, importPath)
+				break // Only add the import once
+			}
+		}
+	}
+
+	// Sort so the output is deterministic
+	sort.Strings(neededImports)
+
+	return formatGoFile(packageName, neededImports, body)
+}
+
+// PackageName converts an output directory to a valid Go package name: its
+// base name with invalid characters replaced by underscores.
+func PackageName(dir string) string {
+	name := filepath.Base(dir)
+
+	// Replace hyphens and other invalid characters with underscores
+	result := ""
+	for _, r := range name {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			result += string(r)
+		} else {
+			result += "_"
+		}
+	}
+
+	// Ensure it doesn't start with a digit
+	if len(result) > 0 && result[0] >= '0' && result[0] <= '9' {
+		result = "_" + result
+	}
+
+	return result
+}
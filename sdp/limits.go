@@ -0,0 +1,41 @@
+package sdp
+
+// Size limits enforced by every decoder (DESIGN_SPEC.md Section 5.5).
+const (
+	MaxSerializedSize = 128 * 1024 * 1024
+	MaxArrayElements  = 1_000_000
+	MaxTotalElements  = 10_000_000
+)
+
+// CheckArraySize validates an array count against the per-array limit and
+// adds it to *total, the running element count of one decode call.
+// It returns ErrArrayTooLarge if the count exceeds MaxArrayElements, or
+// ErrTooManyElements if the cumulative total exceeds MaxTotalElements.
+func CheckArraySize(total *int, count uint32) error {
+	if count > MaxArrayElements {
+		return ErrArrayTooLarge
+	}
+
+	*total += int(count)
+	if *total > MaxTotalElements {
+		return ErrTooManyElements
+	}
+
+	return nil
+}
+
+// Canonical NaN bit patterns accepted by strict decoding
+const (
+	CanonicalNaN32 uint32 = 0x7FC00000
+	CanonicalNaN64 uint64 = 0x7FF8000000000000
+)
+
+// IsCanonicalF32 reports whether bits is the canonical encoding of its float32 value.
+func IsCanonicalF32(bits uint32) bool {
+	return bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32
+}
+
+// IsCanonicalF64 reports whether bits is the canonical encoding of its float64 value.
+func IsCanonicalF64(bits uint64) bool {
+	return bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64
+}
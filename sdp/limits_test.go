@@ -0,0 +1,63 @@
+package sdp
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestCheckArraySize(t *testing.T) {
+	total := 0
+	if err := CheckArraySize(&total, MaxArrayElements); err != nil {
+		t.Fatalf("count at limit: %v", err)
+	}
+	if err := CheckArraySize(&total, MaxArrayElements+1); !errors.Is(err, ErrArrayTooLarge) {
+		t.Errorf("err = %v, want ErrArrayTooLarge", err)
+	}
+
+	total = MaxTotalElements
+	if err := CheckArraySize(&total, 1); !errors.Is(err, ErrTooManyElements) {
+		t.Errorf("err = %v, want ErrTooManyElements", err)
+	}
+}
+
+func TestIsCanonical(t *testing.T) {
+	if !IsCanonicalF32(math.Float32bits(1.5)) || !IsCanonicalF32(CanonicalNaN32) {
+		t.Error("expected canonical float32 values")
+	}
+	// Infinities are canonical; NaNs with a sign bit or payload are not
+	for _, bits := range []uint32{0x7F800000, 0xFF800000} {
+		if !IsCanonicalF32(bits) {
+			t.Errorf("IsCanonicalF32(0x%08X) = false, want true", bits)
+		}
+	}
+	for _, bits := range []uint32{0x7FC00001, 0xFFC00000, 0x7F800001} {
+		if IsCanonicalF32(bits) {
+			t.Errorf("IsCanonicalF32(0x%08X) = true, want false", bits)
+		}
+	}
+	if !IsCanonicalF64(math.Float64bits(-2)) || !IsCanonicalF64(CanonicalNaN64) || IsCanonicalF64(0x7FF8000000000001) {
+		t.Error("wrong float64 canonical check")
+	}
+	// math.NaN() carries a payload bit, so it is not canonical
+	if IsCanonicalF64(math.Float64bits(math.NaN())) || IsCanonicalF64(0xFFF8000000000000) {
+		t.Error("expected non-canonical float64 NaNs")
+	}
+}
+
+func TestWrapDecodeError(t *testing.T) {
+	err := WrapDecodeError(ErrUnexpectedEOF, "name", 4)
+	err = WrapElementError(err, 2, 0)
+	err = WrapDecodeError(err, "items", 0)
+
+	var de *DecodeError
+	if !errors.As(err, &de) {
+		t.Fatalf("expected *DecodeError, got %T", err)
+	}
+	if de.Path != "items[2].name" || de.Offset != 4 {
+		t.Errorf("got path %q offset %d, want items[2].name at 4", de.Path, de.Offset)
+	}
+	if !errors.Is(err, ErrUnexpectedEOF) {
+		t.Error("expected errors.Is to find ErrUnexpectedEOF")
+	}
+}
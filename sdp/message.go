@@ -0,0 +1,45 @@
+package sdp
+
+import "encoding/binary"
+
+// Message mode constants for self-describing messages
+const (
+	MessageMagic           = "SDP" // Magic bytes identifying SDP messages
+	MessageVersion    byte = '2'   // Protocol version 0.2.0
+	MessageHeaderSize      = 10    // Total header size: 3+1+2+4 bytes
+)
+
+// AppendMessageHeader appends a message header for a payload of payloadLen
+// bytes with the given type ID (the 1-based index of the struct in its schema).
+func AppendMessageHeader(b []byte, typeID uint16, payloadLen int) []byte {
+	b = append(b, MessageMagic...)
+	b = append(b, MessageVersion)
+	b = binary.LittleEndian.AppendUint16(b, typeID)
+	return binary.LittleEndian.AppendUint32(b, uint32(payloadLen))
+}
+
+// ParseMessageHeader checks the header at the start of data and returns the
+// type ID and the payload it announces. The payload aliases data; any bytes
+// after it are ignored, so len(payload)+MessageHeaderSize is the message length.
+func ParseMessageHeader(data []byte) (typeID uint16, payload []byte, err error) {
+	if len(data) < MessageHeaderSize {
+		return 0, nil, ErrUnexpectedEOF
+	}
+	if string(data[0:3]) != MessageMagic {
+		return 0, nil, ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return 0, nil, ErrInvalidVersion
+	}
+
+	typeID = binary.LittleEndian.Uint16(data[4:6])
+	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return 0, nil, ErrDataTooLarge
+	}
+	end := MessageHeaderSize + int(payloadLength)
+	if len(data) < end {
+		return 0, nil, ErrUnexpectedEOF
+	}
+	return typeID, data[MessageHeaderSize:end], nil
+}
@@ -0,0 +1,45 @@
+package sdp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMessageHeaderRoundTrip(t *testing.T) {
+	msg := AppendMessageHeader(nil, 7, 3)
+	msg = append(msg, 1, 2, 3, 0xFF)
+
+	typeID, payload, err := ParseMessageHeader(msg)
+	if err != nil {
+		t.Fatalf("ParseMessageHeader: %v", err)
+	}
+	if typeID != 7 {
+		t.Errorf("typeID = %d, want 7", typeID)
+	}
+	if string(payload) != "\x01\x02\x03" {
+		t.Errorf("payload = %x, want 010203", payload)
+	}
+}
+
+func TestParseMessageHeaderErrors(t *testing.T) {
+	valid := append(AppendMessageHeader(nil, 1, 2), 0, 0)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"short header", valid[:MessageHeaderSize-1], ErrUnexpectedEOF},
+		{"short payload", valid[:MessageHeaderSize+1], ErrUnexpectedEOF},
+		{"bad magic", append([]byte("XDP"), valid[3:]...), ErrInvalidMagic},
+		{"bad version", append([]byte("SDP1"), valid[4:]...), ErrInvalidVersion},
+		{"too large", AppendMessageHeader(nil, 1, MaxSerializedSize+1), ErrDataTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseMessageHeader(tt.data); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// Package schema loads and validates Serial Data Protocol schemas (.sdp files).
+//
+// It is the public entry point to the schema compiler used by sdp-gen, for
+// build tools that compile schemas in-process and for custom generators:
+//
+//	s, err := schema.Load("audio.sdp")
+//	if err != nil {
+//		return err
+//	}
+//	if err := schema.Validate(s); err != nil {
+//		return err
+//	}
+//	for _, st := range s.Structs {
+//		for _, f := range st.Fields {
+//			fmt.Println(st.Name, f.Name, f.Type.String())
+//		}
+//	}
+//
+// Schemas must be validated before they are passed to package generate.
+package schema
+
+import (
+	"strings"
+
+	"github.com/shaban/serial-data-protocol/internal/parser"
+	"github.com/shaban/serial-data-protocol/internal/validator"
+)
+
+// Schema is a parsed schema: its structs in declaration order. The message
+// mode type ID of a struct is its 1-based index in Structs.
+type Schema = parser.Schema
+
+// Struct is a struct definition with its doc comment and fields.
+type Struct = parser.Struct
+
+// Field is a struct field with its type and doc comment.
+type Field = parser.Field
+
+// TypeExpr describes a field type: a primitive, a named struct, or an array,
+// possibly wrapped in Option<T> or Box<T>.
+type TypeExpr = parser.TypeExpr
+
+// TypeKind identifies the kind of a TypeExpr.
+type TypeKind = parser.TypeKind
+
+// Type expression kinds
+const (
+	TypeKindPrimitive = parser.TypeKindPrimitive // u8, u16, u32, u64, i8, i16, i32, i64, f32, f64, bool, str
+	TypeKindNamed     = parser.TypeKindNamed     // User-defined struct type
+	TypeKindArray     = parser.TypeKindArray     // []T
+)
+
+// ValidationError describes a single schema rule violation.
+type ValidationError = validator.ValidationError
+
+// Load reads and parses the schema file at path. CRLF line endings are
+// accepted, and errors are prefixed with the file name.
+func Load(path string) (*Schema, error) {
+	return parser.LoadSchemaFile(path)
+}
+
+// Parse parses schema source text.
+func Parse(src string) (*Schema, error) {
+	return parser.ParseSchema(strings.ReplaceAll(src, "\r\n", "\n"))
+}
+
+// Validate checks structure, type references, cycles and naming rules, and
+// returns a single error listing every violation, or nil if the schema is valid.
+func Validate(s *Schema) error {
+	return validator.Validate(s)
+}
+
+// Lookup returns the struct named name, or nil if the schema has none.
+func Lookup(s *Schema, name string) *Struct {
+	for i := range s.Structs {
+		if s.Structs[i].Name == name {
+			return &s.Structs[i]
+		}
+	}
+	return nil
+}
+
+// TypeID returns the message mode type ID of the struct named name, or 0 if
+// the schema has none.
+func TypeID(s *Schema, name string) uint16 {
+	for i := range s.Structs {
+		if s.Structs[i].Name == name {
+			return uint16(i + 1)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	s, err := Load(filepath.Join("..", "..", "testdata", "schemas", "valid_crlf.sdp"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := Validate(s); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestParseDescriptors(t *testing.T) {
+	s, err := Parse("struct Point { x: f32, y: f32 }\r\n/// A shape\nstruct Shape { points: []Point, label: Option<Point> }\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	shape := Lookup(s, "Shape")
+	if shape == nil {
+		t.Fatal("Lookup(Shape) = nil")
+	}
+	if shape.Comment != "A shape" {
+		t.Errorf("Comment = %q, want %q", shape.Comment, "A shape")
+	}
+	if got := shape.Fields[0].Type; got.Kind != TypeKindArray || got.Elem.Name != "Point" {
+		t.Errorf("points type = %s, want []Point", got.String())
+	}
+	if got := shape.Fields[1].Type.String(); got != "Option<Point>" {
+		t.Errorf("label type = %s, want Option<Point>", got)
+	}
+
+	if id := TypeID(s, "Shape"); id != 2 {
+		t.Errorf("TypeID(Shape) = %d, want 2", id)
+	}
+	if Lookup(s, "Missing") != nil || TypeID(s, "Missing") != 0 {
+		t.Error("expected no match for unknown struct")
+	}
+}
+
+func TestValidateReportsAllErrors(t *testing.T) {
+	s, err := Parse("struct A { b: Missing, type: u32 }")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	err = Validate(s)
+	if err == nil {
+		t.Fatal("Validate() = nil, want error")
+	}
+	for _, want := range []string{"Missing", "type"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Package sdp is the public runtime API for Serial Data Protocol types.
+//
+// Every struct generated by sdp-gen for Go implements Codec, so
+// infrastructure code (caches, queues, RPC layers) can encode and decode any
+// SDP type through the generic helpers without reflection:
+//
+//	data, err := sdp.Marshal(&device)
+//	device, err := sdp.Unmarshal[audio.AudioDevice](data)
+//
+// The helpers use byte mode: the encoding has no header and the reader must
+// know the type, as with the generated EncodeX and DecodeX functions.
+package sdp
+
+// Codec is implemented by pointers to generated SDP structs.
+type Codec interface {
+	// MarshalBinary encodes the value (encoding.BinaryMarshaler).
+	MarshalBinary() ([]byte, error)
+
+	// AppendBinary appends the encoding to b (encoding.BinaryAppender).
+	AppendBinary(b []byte) ([]byte, error)
+
+	// UnmarshalBinary decodes data into the value (encoding.BinaryUnmarshaler).
+	UnmarshalBinary(data []byte) error
+
+	// EncodedSize returns the exact number of bytes MarshalBinary produces.
+	EncodedSize() int
+}
+
+// Marshal encodes v.
+func Marshal[T Codec](v T) ([]byte, error) {
+	return v.MarshalBinary()
+}
+
+// Append appends the encoding of v to b and returns the extended slice.
+func Append[T Codec](b []byte, v T) ([]byte, error) {
+	return v.AppendBinary(b)
+}
+
+// Unmarshal decodes data into a new value of type T. The pointer type *T
+// must implement Codec, which holds for every generated struct:
+//
+//	plugin, err := sdp.Unmarshal[audio.Plugin](data)
+func Unmarshal[T any, PT interface {
+	*T
+	Codec
+}](data []byte) (T, error) {
+	var v T
+	err := PT(&v).UnmarshalBinary(data)
+	return v, err
+}
+
+// UnmarshalInto decodes data into v.
+func UnmarshalInto[T Codec](data []byte, v T) error {
+	return v.UnmarshalBinary(data)
+}
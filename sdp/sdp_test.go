@@ -0,0 +1,73 @@
+package sdp
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+)
+
+// counter is a hand-written Codec holding a single u32, encoded like a
+// generated struct with one u32 field.
+type counter struct {
+	n uint32
+}
+
+var errShort = errors.New("short buffer")
+
+func (c *counter) EncodedSize() int { return 4 }
+
+func (c *counter) MarshalBinary() ([]byte, error) {
+	return c.AppendBinary(nil)
+}
+
+func (c *counter) AppendBinary(b []byte) ([]byte, error) {
+	return binary.LittleEndian.AppendUint32(b, c.n), nil
+}
+
+func (c *counter) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return errShort
+	}
+	c.n = binary.LittleEndian.Uint32(data)
+	return nil
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	data, err := Marshal(&counter{n: 42})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if len(data) != 4 {
+		t.Fatalf("Marshal produced %d bytes, want 4", len(data))
+	}
+
+	got, err := Unmarshal[counter](data)
+	if err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if got.n != 42 {
+		t.Errorf("Unmarshal = %d, want 42", got.n)
+	}
+
+	if _, err := Unmarshal[counter](data[:3]); !errors.Is(err, errShort) {
+		t.Errorf("expected decode error, got %v", err)
+	}
+}
+
+func TestAppendAndUnmarshalInto(t *testing.T) {
+	b, err := Append([]byte{0xFF}, &counter{n: 7})
+	if err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if len(b) != 5 || b[0] != 0xFF {
+		t.Fatalf("Append = %x, want prefix kept", b)
+	}
+
+	var c counter
+	if err := UnmarshalInto(b[1:], &c); err != nil {
+		t.Fatalf("UnmarshalInto failed: %v", err)
+	}
+	if c.n != 7 {
+		t.Errorf("UnmarshalInto = %d, want 7", c.n)
+	}
+}
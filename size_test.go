@@ -11,73 +11,18 @@ import (
 
 // TestBinarySizeComparison measures the binary size of our real-world data
 func TestBinarySizeComparison(t *testing.T) {
-	// Load the plugins.json test data
-	data, err := os.ReadFile("testdata/data/plugins.json")
+	// Load the AudioUnit test data
+	data, err := os.ReadFile("testdata/data/audiounit.json")
 	if err != nil {
 		t.Fatalf("Failed to read test data: %v", err)
 	}
 
-	// Parse JSON (it's an array of plugins at the root)
-	var plugins []struct {
-		Name             string `json:"name"`
-		ManufacturerID   string `json:"manufacturerID"`
-		ComponentType    string `json:"type"`
-		ComponentSubtype string `json:"subtype"`
-		Parameters       []struct {
-			Address      uint64  `json:"address"`
-			DisplayName  string  `json:"displayName"`
-			Identifier   string  `json:"identifier"`
-			Unit         string  `json:"unit"`
-			MinValue     float32 `json:"minValue"`
-			MaxValue     float32 `json:"maxValue"`
-			DefaultValue float32 `json:"defaultValue"`
-			CurrentValue float32 `json:"currentValue"`
-			RawFlags     uint32  `json:"rawFlags"`
-			IsWritable   bool    `json:"isWritable"`
-			CanRamp      bool    `json:"canRamp"`
-		} `json:"parameters"`
-	}
-
-	if err := json.Unmarshal(data, &plugins); err != nil {
+	// The JSON is a PluginRegistry in the canonical JSON mapping
+	registry := &audiounit.PluginRegistry{}
+	if err := json.Unmarshal(data, registry); err != nil {
 		t.Fatalf("Failed to parse JSON: %v", err)
 	}
 
-	// Convert to SDP format
-	totalParams := 0
-	for _, p := range plugins {
-		totalParams += len(p.Parameters)
-	}
-
-	registry := &audiounit.PluginRegistry{
-		TotalPluginCount:    uint32(len(plugins)),
-		TotalParameterCount: uint32(totalParams),
-	}
-
-	for _, p := range plugins {
-		plugin := audiounit.Plugin{
-			Name:             p.Name,
-			ManufacturerId:   p.ManufacturerID,
-			ComponentType:    p.ComponentType,
-			ComponentSubtype: p.ComponentSubtype,
-		}
-		for _, param := range p.Parameters {
-			plugin.Parameters = append(plugin.Parameters, audiounit.Parameter{
-				Address:      param.Address,
-				DisplayName:  param.DisplayName,
-				Identifier:   param.Identifier,
-				Unit:         param.Unit,
-				MinValue:     param.MinValue,
-				MaxValue:     param.MaxValue,
-				DefaultValue: param.DefaultValue,
-				CurrentValue: param.CurrentValue,
-				RawFlags:     param.RawFlags,
-				IsWritable:   param.IsWritable,
-				CanRamp:      param.CanRamp,
-			})
-		}
-		registry.Plugins = append(registry.Plugins, plugin)
-	}
-
 	// Encode with SDP
 	sdpData, err := audiounit.EncodePluginRegistry(registry)
 	if err != nil {
@@ -21,65 +21,65 @@ static Parameter parameter_decode_impl(const uint8_t* buf, size_t buf_len, size_
     Parameter result;
 
     /* address */
-    if (offset + 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 8 > buf_len) throw DecodeError("Buffer too small", "address", offset);
     result.address = SDP_LE64TOH(*(const uint64_t*)(buf + offset));
     offset += 8;
 
     /* display_name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "display_name", offset);
     uint32_t display_name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + display_name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + display_name_len > buf_len) throw DecodeError("Buffer too small", "display_name", offset);
     result.display_name = std::string(reinterpret_cast<const char*>(buf + offset), display_name_len);
     offset += display_name_len;
 
     /* identifier */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "identifier", offset);
     uint32_t identifier_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + identifier_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + identifier_len > buf_len) throw DecodeError("Buffer too small", "identifier", offset);
     result.identifier = std::string(reinterpret_cast<const char*>(buf + offset), identifier_len);
     offset += identifier_len;
 
     /* unit */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "unit", offset);
     uint32_t unit_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + unit_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + unit_len > buf_len) throw DecodeError("Buffer too small", "unit", offset);
     result.unit = std::string(reinterpret_cast<const char*>(buf + offset), unit_len);
     offset += unit_len;
 
     /* min_value */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "min_value", offset);
     result.min_value = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* max_value */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "max_value", offset);
     result.max_value = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* default_value */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "default_value", offset);
     result.default_value = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* current_value */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "current_value", offset);
     result.current_value = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* raw_flags */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "raw_flags", offset);
     result.raw_flags = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* is_writable */
-    if (offset + 1 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 1 > buf_len) throw DecodeError("Buffer too small", "is_writable", offset);
     result.is_writable = buf[offset++] != 0;
 
     /* can_ramp */
-    if (offset + 1 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 1 > buf_len) throw DecodeError("Buffer too small", "can_ramp", offset);
     result.can_ramp = buf[offset++] != 0;
 
     return result;
@@ -95,47 +95,51 @@ static Plugin plugin_decode_impl(const uint8_t* buf, size_t buf_len, size_t& off
     uint32_t total_elements = 0;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
     /* manufacturer_id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "manufacturer_id", offset);
     uint32_t manufacturer_id_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + manufacturer_id_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + manufacturer_id_len > buf_len) throw DecodeError("Buffer too small", "manufacturer_id", offset);
     result.manufacturer_id = std::string(reinterpret_cast<const char*>(buf + offset), manufacturer_id_len);
     offset += manufacturer_id_len;
 
     /* component_type */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "component_type", offset);
     uint32_t component_type_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + component_type_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + component_type_len > buf_len) throw DecodeError("Buffer too small", "component_type", offset);
     result.component_type = std::string(reinterpret_cast<const char*>(buf + offset), component_type_len);
     offset += component_type_len;
 
     /* component_subtype */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "component_subtype", offset);
     uint32_t component_subtype_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + component_subtype_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + component_subtype_len > buf_len) throw DecodeError("Buffer too small", "component_subtype", offset);
     result.component_subtype = std::string(reinterpret_cast<const char*>(buf + offset), component_subtype_len);
     offset += component_subtype_len;
 
     /* parameters */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "parameters", offset);
     uint32_t parameters_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (parameters_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (parameters_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "parameters", offset - 4);
     total_elements += parameters_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "parameters", offset - 4);
     result.parameters.reserve(parameters_count);
     for (uint32_t i = 0; i < parameters_count; i++) {
-        result.parameters.push_back(parameter_decode_impl(buf, buf_len, offset));
+        try {
+            result.parameters.push_back(parameter_decode_impl(buf, buf_len, offset));
+        } catch (const DecodeError& e) {
+            throw e.within("parameters[" + std::to_string(i) + "]");
+        }
     }
 
     return result;
@@ -151,24 +155,28 @@ static PluginRegistry plugin_registry_decode_impl(const uint8_t* buf, size_t buf
     uint32_t total_elements = 0;
 
     /* plugins */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "plugins", offset);
     uint32_t plugins_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (plugins_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (plugins_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "plugins", offset - 4);
     total_elements += plugins_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "plugins", offset - 4);
     result.plugins.reserve(plugins_count);
     for (uint32_t i = 0; i < plugins_count; i++) {
-        result.plugins.push_back(plugin_decode_impl(buf, buf_len, offset));
+        try {
+            result.plugins.push_back(plugin_decode_impl(buf, buf_len, offset));
+        } catch (const DecodeError& e) {
+            throw e.within("plugins[" + std::to_string(i) + "]");
+        }
     }
 
     /* total_plugin_count */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "total_plugin_count", offset);
     result.total_plugin_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* total_parameter_count */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "total_parameter_count", offset);
     result.total_parameter_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -21,30 +21,30 @@ static Parameter parameter_decode_impl(const uint8_t* buf, size_t buf_len, size_
     Parameter result;
 
     /* id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "id", offset);
     result.id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
     /* value */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "value", offset);
     result.value = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* min */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "min", offset);
     result.min = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* max */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "max", offset);
     result.max = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -61,45 +61,49 @@ static Plugin plugin_decode_impl(const uint8_t* buf, size_t buf_len, size_t& off
     uint32_t total_elements = 0;
 
     /* id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "id", offset);
     result.id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
     /* manufacturer */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "manufacturer", offset);
     uint32_t manufacturer_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + manufacturer_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + manufacturer_len > buf_len) throw DecodeError("Buffer too small", "manufacturer", offset);
     result.manufacturer = std::string(reinterpret_cast<const char*>(buf + offset), manufacturer_len);
     offset += manufacturer_len;
 
     /* version */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "version", offset);
     result.version = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* enabled */
-    if (offset + 1 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 1 > buf_len) throw DecodeError("Buffer too small", "enabled", offset);
     result.enabled = buf[offset++] != 0;
 
     /* parameters */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "parameters", offset);
     uint32_t parameters_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (parameters_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (parameters_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "parameters", offset - 4);
     total_elements += parameters_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "parameters", offset - 4);
     result.parameters.reserve(parameters_count);
     for (uint32_t i = 0; i < parameters_count; i++) {
-        result.parameters.push_back(parameter_decode_impl(buf, buf_len, offset));
+        try {
+            result.parameters.push_back(parameter_decode_impl(buf, buf_len, offset));
+        } catch (const DecodeError& e) {
+            throw e.within("parameters[" + std::to_string(i) + "]");
+        }
     }
 
     return result;
@@ -115,52 +119,56 @@ static AudioDevice audio_device_decode_impl(const uint8_t* buf, size_t buf_len,
     uint32_t total_elements = 0;
 
     /* device_id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "device_id", offset);
     result.device_id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* device_name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "device_name", offset);
     uint32_t device_name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + device_name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + device_name_len > buf_len) throw DecodeError("Buffer too small", "device_name", offset);
     result.device_name = std::string(reinterpret_cast<const char*>(buf + offset), device_name_len);
     offset += device_name_len;
 
     /* sample_rate */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "sample_rate", offset);
     result.sample_rate = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* buffer_size */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "buffer_size", offset);
     result.buffer_size = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* input_channels */
-    if (offset + 2 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 2 > buf_len) throw DecodeError("Buffer too small", "input_channels", offset);
     result.input_channels = SDP_LE16TOH(*(const uint16_t*)(buf + offset));
     offset += 2;
 
     /* output_channels */
-    if (offset + 2 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 2 > buf_len) throw DecodeError("Buffer too small", "output_channels", offset);
     result.output_channels = SDP_LE16TOH(*(const uint16_t*)(buf + offset));
     offset += 2;
 
     /* is_default */
-    if (offset + 1 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 1 > buf_len) throw DecodeError("Buffer too small", "is_default", offset);
     result.is_default = buf[offset++] != 0;
 
     /* active_plugins */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "active_plugins", offset);
     uint32_t active_plugins_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (active_plugins_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (active_plugins_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "active_plugins", offset - 4);
     total_elements += active_plugins_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "active_plugins", offset - 4);
     result.active_plugins.reserve(active_plugins_count);
     for (uint32_t i = 0; i < active_plugins_count; i++) {
-        result.active_plugins.push_back(plugin_decode_impl(buf, buf_len, offset));
+        try {
+            result.active_plugins.push_back(plugin_decode_impl(buf, buf_len, offset));
+        } catch (const DecodeError& e) {
+            throw e.within("active_plugins[" + std::to_string(i) + "]");
+        }
     }
 
     return result;
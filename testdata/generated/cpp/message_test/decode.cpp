@@ -16,12 +16,12 @@ static Point point_decode_impl(const uint8_t* buf, size_t buf_len, size_t& offse
     Point result;
 
     /* x */
-    if (offset + 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 8 > buf_len) throw DecodeError("Buffer too small", "x", offset);
     result.x = sdp_le_to_f64(*(const uint64_t*)(buf + offset));
     offset += 8;
 
     /* y */
-    if (offset + 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 8 > buf_len) throw DecodeError("Buffer too small", "y", offset);
     result.y = sdp_le_to_f64(*(const uint64_t*)(buf + offset));
     offset += 8;
 
@@ -37,15 +37,19 @@ static Rectangle rectangle_decode_impl(const uint8_t* buf, size_t buf_len, size_
     Rectangle result;
 
     /* top_left */
-    result.top_left = point_decode_impl(buf, buf_len, offset);
+    try {
+        result.top_left = point_decode_impl(buf, buf_len, offset);
+    } catch (const DecodeError& e) {
+        throw e.within("top_left");
+    }
 
     /* width */
-    if (offset + 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 8 > buf_len) throw DecodeError("Buffer too small", "width", offset);
     result.width = sdp_le_to_f64(*(const uint64_t*)(buf + offset));
     offset += 8;
 
     /* height */
-    if (offset + 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 8 > buf_len) throw DecodeError("Buffer too small", "height", offset);
     result.height = sdp_le_to_f64(*(const uint64_t*)(buf + offset));
     offset += 8;
 
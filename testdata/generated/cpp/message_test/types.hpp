@@ -16,15 +16,36 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* Point */
 struct Point {
     // x
     double x;
     // y
     double y;
+
+    bool operator==(const Point& other) const {
+        return bits_equal(x, other.x) &&
+               bits_equal(y, other.y);
+    }
+    bool operator!=(const Point& other) const { return !(*this == other); }
 };
 
 /* Rectangle */
@@ -35,6 +56,13 @@ struct Rectangle {
     double width;
     // height
     double height;
+
+    bool operator==(const Rectangle& other) const {
+        return top_left == other.top_left &&
+               bits_equal(width, other.width) &&
+               bits_equal(height, other.height);
+    }
+    bool operator!=(const Rectangle& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
@@ -17,12 +17,12 @@ static Point point_decode_impl(const uint8_t* buf, size_t buf_len, size_t& offse
     Point result;
 
     /* x */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "x", offset);
     result.x = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* y */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "y", offset);
     result.y = sdp_le_to_f32(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -38,13 +38,21 @@ static Rectangle rectangle_decode_impl(const uint8_t* buf, size_t buf_len, size_
     Rectangle result;
 
     /* top_left */
-    result.top_left = point_decode_impl(buf, buf_len, offset);
+    try {
+        result.top_left = point_decode_impl(buf, buf_len, offset);
+    } catch (const DecodeError& e) {
+        throw e.within("top_left");
+    }
 
     /* bottom_right */
-    result.bottom_right = point_decode_impl(buf, buf_len, offset);
+    try {
+        result.bottom_right = point_decode_impl(buf, buf_len, offset);
+    } catch (const DecodeError& e) {
+        throw e.within("bottom_right");
+    }
 
     /* color */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "color", offset);
     result.color = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -60,18 +68,22 @@ static Scene scene_decode_impl(const uint8_t* buf, size_t buf_len, size_t& offse
     Scene result;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
     /* main_rect */
-    result.main_rect = rectangle_decode_impl(buf, buf_len, offset);
+    try {
+        result.main_rect = rectangle_decode_impl(buf, buf_len, offset);
+    } catch (const DecodeError& e) {
+        throw e.within("main_rect");
+    }
 
     /* count */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "count", offset);
     result.count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -0,0 +1,116 @@
+/* json.hpp - Canonical JSON mapping for optional
+ * Generated by sdp-gen - DO NOT EDIT
+ *
+ * Keys are schema field names (or their @json overrides) in schema order,
+ * 64-bit integers are decimal strings, byte arrays are base64, empty
+ * arrays are omitted and absent optionals are null.
+ */
+
+#ifndef OPTIONAL_JSON_HPP
+#define OPTIONAL_JSON_HPP
+
+#include "types.hpp"
+#include <cstddef>
+#include <stdexcept>
+#include <string>
+
+namespace sdp {
+
+/* JSON error exception
+ * Carries the byte offset in the input where reading failed
+ * (0 for write errors).
+ */
+class JsonError : public std::runtime_error {
+public:
+    explicit JsonError(const std::string& msg) : std::runtime_error(msg), offset_(0) {}
+
+    JsonError(const std::string& msg, size_t offset)
+        : std::runtime_error(msg + " (offset " + std::to_string(offset) + ")"), offset_(offset) {}
+
+    /* Byte offset in the input */
+    size_t offset() const noexcept { return offset_; }
+
+private:
+    size_t offset_;
+};
+
+/* Write Request as canonical JSON
+ * Throws JsonError for NaN or infinite floats
+ */
+std::string request_to_json(const Request& value);
+
+/* Read Request from JSON
+ * Missing fields and nulls keep the default value, unknown keys are ignored.
+ * Throws JsonError on malformed input or out-of-range numbers
+ */
+Request request_from_json(const std::string& json);
+
+/* Write Metadata as canonical JSON
+ * Throws JsonError for NaN or infinite floats
+ */
+std::string metadata_to_json(const Metadata& value);
+
+/* Read Metadata from JSON
+ * Missing fields and nulls keep the default value, unknown keys are ignored.
+ * Throws JsonError on malformed input or out-of-range numbers
+ */
+Metadata metadata_from_json(const std::string& json);
+
+/* Write Config as canonical JSON
+ * Throws JsonError for NaN or infinite floats
+ */
+std::string config_to_json(const Config& value);
+
+/* Read Config from JSON
+ * Missing fields and nulls keep the default value, unknown keys are ignored.
+ * Throws JsonError on malformed input or out-of-range numbers
+ */
+Config config_from_json(const std::string& json);
+
+/* Write DatabaseConfig as canonical JSON
+ * Throws JsonError for NaN or infinite floats
+ */
+std::string database_config_to_json(const DatabaseConfig& value);
+
+/* Read DatabaseConfig from JSON
+ * Missing fields and nulls keep the default value, unknown keys are ignored.
+ * Throws JsonError on malformed input or out-of-range numbers
+ */
+DatabaseConfig database_config_from_json(const std::string& json);
+
+/* Write CacheConfig as canonical JSON
+ * Throws JsonError for NaN or infinite floats
+ */
+std::string cache_config_to_json(const CacheConfig& value);
+
+/* Read CacheConfig from JSON
+ * Missing fields and nulls keep the default value, unknown keys are ignored.
+ * Throws JsonError on malformed input or out-of-range numbers
+ */
+CacheConfig cache_config_from_json(const std::string& json);
+
+/* Write Document as canonical JSON
+ * Throws JsonError for NaN or infinite floats
+ */
+std::string document_to_json(const Document& value);
+
+/* Read Document from JSON
+ * Missing fields and nulls keep the default value, unknown keys are ignored.
+ * Throws JsonError on malformed input or out-of-range numbers
+ */
+Document document_from_json(const std::string& json);
+
+/* Write TagList as canonical JSON
+ * Throws JsonError for NaN or infinite floats
+ */
+std::string tag_list_to_json(const TagList& value);
+
+/* Read TagList from JSON
+ * Missing fields and nulls keep the default value, unknown keys are ignored.
+ * Throws JsonError on malformed input or out-of-range numbers
+ */
+TagList tag_list_from_json(const std::string& json);
+
+}  // namespace sdp
+
+#endif  // OPTIONAL_JSON_HPP
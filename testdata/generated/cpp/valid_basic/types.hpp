@@ -16,15 +16,36 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* Device */
 struct Device {
     // id
     uint32_t id;
     // name
     std::string name;
+
+    bool operator==(const Device& other) const {
+        return id == other.id &&
+               name == other.name;
+    }
+    bool operator!=(const Device& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
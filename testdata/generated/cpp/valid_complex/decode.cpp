@@ -21,15 +21,15 @@ static Parameter parameter_decode_impl(const uint8_t* buf, size_t buf_len, size_
     Parameter result;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
     /* value */
-    if (offset + 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 8 > buf_len) throw DecodeError("Buffer too small", "value", offset);
     result.value = sdp_le_to_f64(*(const uint64_t*)(buf + offset));
     offset += 8;
 
@@ -46,28 +46,32 @@ static Device device_decode_impl(const uint8_t* buf, size_t buf_len, size_t& off
     uint32_t total_elements = 0;
 
     /* id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "id", offset);
     result.id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
     /* parameters */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "parameters", offset);
     uint32_t parameters_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (parameters_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (parameters_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "parameters", offset - 4);
     total_elements += parameters_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "parameters", offset - 4);
     result.parameters.reserve(parameters_count);
     for (uint32_t i = 0; i < parameters_count; i++) {
-        result.parameters.push_back(parameter_decode_impl(buf, buf_len, offset));
+        try {
+            result.parameters.push_back(parameter_decode_impl(buf, buf_len, offset));
+        } catch (const DecodeError& e) {
+            throw e.within("parameters[" + std::to_string(i) + "]");
+        }
     }
 
     return result;
@@ -83,15 +87,19 @@ static DeviceList device_list_decode_impl(const uint8_t* buf, size_t buf_len, si
     uint32_t total_elements = 0;
 
     /* devices */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "devices", offset);
     uint32_t devices_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (devices_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (devices_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "devices", offset - 4);
     total_elements += devices_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "devices", offset - 4);
     result.devices.reserve(devices_count);
     for (uint32_t i = 0; i < devices_count; i++) {
-        result.devices.push_back(device_decode_impl(buf, buf_len, offset));
+        try {
+            result.devices.push_back(device_decode_impl(buf, buf_len, offset));
+        } catch (const DecodeError& e) {
+            throw e.within("devices[" + std::to_string(i) + "]");
+        }
     }
 
     return result;
@@ -9,13 +9,47 @@
 #include <cstdint>
 #include <cstddef>
 #include <stdexcept>
+#include <string>
 
 namespace sdp {
 
-/* Decode error exception */
+/* Decode error exception
+ * Carries the field path (e.g. "plugins[12].parameters[3].unit") and the
+ * byte offset where decoding failed, in addition to the failure reason.
+ */
 class DecodeError : public std::runtime_error {
 public:
-    explicit DecodeError(const char* msg) : std::runtime_error(msg) {}
+    explicit DecodeError(const char* msg) : std::runtime_error(msg), reason_(msg), offset_(0) {}
+
+    DecodeError(const char* reason, const std::string& path, size_t offset)
+        : std::runtime_error(describe(reason, path, offset)),
+          reason_(reason), path_(path), offset_(offset) {}
+
+    /* Failure reason without context, e.g. "Buffer too small" */
+    const char* reason() const noexcept { return reason_; }
+
+    /* Field path where decoding failed */
+    const std::string& path() const noexcept { return path_; }
+
+    /* Byte offset of the failing field */
+    size_t offset() const noexcept { return offset_; }
+
+    /* Returns a copy of this error with field prepended to the path */
+    DecodeError within(const std::string& field) const {
+        if (path_.empty() || path_[0] == '[') {
+            return DecodeError(reason_, field + path_, offset_);
+        }
+        return DecodeError(reason_, field + "." + path_, offset_);
+    }
+
+private:
+    static std::string describe(const char* reason, const std::string& path, size_t offset) {
+        return std::string(reason) + " at " + path + " (offset " + std::to_string(offset) + ")";
+    }
+
+    const char* reason_;
+    std::string path_;
+    size_t offset_;
 };
 
 /* Decode Example from buffer
@@ -16,9 +16,24 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* Parameter */
 struct Parameter {
     // address
@@ -43,6 +58,21 @@ struct Parameter {
     bool is_writable;
     // can_ramp
     bool can_ramp;
+
+    bool operator==(const Parameter& other) const {
+        return address == other.address &&
+               display_name == other.display_name &&
+               identifier == other.identifier &&
+               unit == other.unit &&
+               bits_equal(min_value, other.min_value) &&
+               bits_equal(max_value, other.max_value) &&
+               bits_equal(default_value, other.default_value) &&
+               bits_equal(current_value, other.current_value) &&
+               raw_flags == other.raw_flags &&
+               is_writable == other.is_writable &&
+               can_ramp == other.can_ramp;
+    }
+    bool operator!=(const Parameter& other) const { return !(*this == other); }
 };
 
 /* Plugin */
@@ -57,6 +87,15 @@ struct Plugin {
     std::string component_subtype;
     // parameters
     std::vector<Parameter> parameters;
+
+    bool operator==(const Plugin& other) const {
+        return name == other.name &&
+               manufacturer_id == other.manufacturer_id &&
+               component_type == other.component_type &&
+               component_subtype == other.component_subtype &&
+               parameters == other.parameters;
+    }
+    bool operator!=(const Plugin& other) const { return !(*this == other); }
 };
 
 /* PluginRegistry */
@@ -67,6 +106,13 @@ struct PluginRegistry {
     uint32_t total_plugin_count;
     // total_parameter_count
     uint32_t total_parameter_count;
+
+    bool operator==(const PluginRegistry& other) const {
+        return plugins == other.plugins &&
+               total_plugin_count == other.total_plugin_count &&
+               total_parameter_count == other.total_parameter_count;
+    }
+    bool operator!=(const PluginRegistry& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
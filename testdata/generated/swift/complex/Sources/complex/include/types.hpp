@@ -16,9 +16,24 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* Parameter */
 struct Parameter {
     // id
@@ -31,6 +46,15 @@ struct Parameter {
     float min;
     // max
     float max;
+
+    bool operator==(const Parameter& other) const {
+        return id == other.id &&
+               name == other.name &&
+               bits_equal(value, other.value) &&
+               bits_equal(min, other.min) &&
+               bits_equal(max, other.max);
+    }
+    bool operator!=(const Parameter& other) const { return !(*this == other); }
 };
 
 /* Plugin */
@@ -47,6 +71,16 @@ struct Plugin {
     bool enabled;
     // parameters
     std::vector<Parameter> parameters;
+
+    bool operator==(const Plugin& other) const {
+        return id == other.id &&
+               name == other.name &&
+               manufacturer == other.manufacturer &&
+               version == other.version &&
+               enabled == other.enabled &&
+               parameters == other.parameters;
+    }
+    bool operator!=(const Plugin& other) const { return !(*this == other); }
 };
 
 /* AudioDevice */
@@ -67,6 +101,18 @@ struct AudioDevice {
     bool is_default;
     // active_plugins
     std::vector<Plugin> active_plugins;
+
+    bool operator==(const AudioDevice& other) const {
+        return device_id == other.device_id &&
+               device_name == other.device_name &&
+               sample_rate == other.sample_rate &&
+               buffer_size == other.buffer_size &&
+               input_channels == other.input_channels &&
+               output_channels == other.output_channels &&
+               is_default == other.is_default &&
+               active_plugins == other.active_plugins;
+    }
+    bool operator!=(const AudioDevice& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
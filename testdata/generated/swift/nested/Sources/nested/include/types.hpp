@@ -16,15 +16,36 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* Point */
 struct Point {
     // x
     float x;
     // y
     float y;
+
+    bool operator==(const Point& other) const {
+        return bits_equal(x, other.x) &&
+               bits_equal(y, other.y);
+    }
+    bool operator!=(const Point& other) const { return !(*this == other); }
 };
 
 /* Rectangle */
@@ -35,6 +56,13 @@ struct Rectangle {
     Point bottom_right;
     // color
     uint32_t color;
+
+    bool operator==(const Rectangle& other) const {
+        return top_left == other.top_left &&
+               bottom_right == other.bottom_right &&
+               color == other.color;
+    }
+    bool operator!=(const Rectangle& other) const { return !(*this == other); }
 };
 
 /* Scene */
@@ -45,6 +73,13 @@ struct Scene {
     Rectangle main_rect;
     // count
     uint32_t count;
+
+    bool operator==(const Scene& other) const {
+        return name == other.name &&
+               main_rect == other.main_rect &&
+               count == other.count;
+    }
+    bool operator!=(const Scene& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
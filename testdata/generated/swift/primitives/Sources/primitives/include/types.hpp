@@ -16,9 +16,24 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* AllPrimitives */
 struct AllPrimitives {
     // u8_field
@@ -45,6 +60,22 @@ struct AllPrimitives {
     bool bool_field;
     // str_field
     std::string str_field;
+
+    bool operator==(const AllPrimitives& other) const {
+        return u8_field == other.u8_field &&
+               u16_field == other.u16_field &&
+               u32_field == other.u32_field &&
+               u64_field == other.u64_field &&
+               i8_field == other.i8_field &&
+               i16_field == other.i16_field &&
+               i32_field == other.i32_field &&
+               i64_field == other.i64_field &&
+               bits_equal(f32_field, other.f32_field) &&
+               bits_equal(f64_field, other.f64_field) &&
+               bool_field == other.bool_field &&
+               str_field == other.str_field;
+    }
+    bool operator!=(const AllPrimitives& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
@@ -15,15 +15,15 @@ static Device device_decode_impl(const uint8_t* buf, size_t buf_len, size_t& off
     Device result;
 
     /* id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "id", offset);
     result.id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
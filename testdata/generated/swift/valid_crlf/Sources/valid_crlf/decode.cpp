@@ -15,7 +15,7 @@ static Example example_decode_impl(const uint8_t* buf, size_t buf_len, size_t& o
     Example result;
 
     /* field */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "field", offset);
     result.field = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -0,0 +1,314 @@
+package arrays
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// DecodeArraysOfPrimitivesArena decodes a ArraysOfPrimitives like DecodeArraysOfPrimitives, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeArraysOfPrimitivesArena(dest *ArraysOfPrimitives, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureArraysOfPrimitives(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeArraysOfPrimitives(dest, data, &offset, ctx)
+}
+
+// DecodeItemArena decodes a Item like DecodeItem, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeItemArena(dest *Item, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureItem(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeItem(dest, data, &offset, ctx)
+}
+
+// DecodeArraysOfStructsArena decodes a ArraysOfStructs like DecodeArraysOfStructs, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeArraysOfStructsArena(dest *ArraysOfStructs, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureArraysOfStructs(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeArraysOfStructs(dest, data, &offset, ctx)
+}
+
+// decodeArenaSize accumulates the memory a value needs, measured by the
+// first pass of arena decoding.
+type decodeArenaSize struct {
+	bytes   int  // Primitive slices, each rounded up to 8 bytes
+	strings int  // String contents
+	strs    int  // Elements of []str arrays
+	Item int
+}
+
+// decodeArena holds the preallocated memory that arena decoding carves
+// values from. buf holds primitive slices followed by string contents.
+type decodeArena struct {
+	buf    []byte
+	pos    int  // Next free byte for primitive slices
+	strPos int  // Next free byte for string contents
+	strs   []string
+	Item []Item
+}
+
+// init allocates the arena memory for the measured size. The byte block is
+// backed by []uint64 so that every primitive slice is 8-byte aligned.
+func (a *decodeArena) init(size *decodeArenaSize) {
+	if n := size.bytes + size.strings; n > 0 {
+		words := make([]uint64, (n+7)/8)
+		a.buf = unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), n)
+	}
+	a.strPos = size.bytes
+	if size.strs > 0 {
+		a.strs = make([]string, size.strs)
+	}
+	if size.Item > 0 {
+		a.Item = make([]Item, size.Item)
+	}
+}
+
+// string copies b into the arena and returns it as a string.
+func (a *decodeArena) string(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	p := a.strPos
+	a.strPos += copy(a.buf[p:], b)
+	return unsafe.String(&a.buf[p], len(b))
+}
+
+// arenaPrimitives carves a zeroed slice of n elements from the arena's byte
+// block. T must be a primitive type without pointers.
+func arenaPrimitives[T any](a *decodeArena, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	var zero T
+	s := unsafe.Slice((*T)(unsafe.Pointer(&a.buf[a.pos])), n)
+	a.pos += (int(n)*int(unsafe.Sizeof(zero)) + 7) &^ 7
+	return s
+}
+
+// arenaTake carves a zeroed slice of n elements from the front of pool.
+func arenaTake[T any](pool *[]T, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	s := (*pool)[:n:n]
+	*pool = (*pool)[n:]
+	return s
+}
+
+// measureArraysOfPrimitives advances offset over one ArraysOfPrimitives value and adds the arena memory it needs to size.
+func measureArraysOfPrimitives(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: u8_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u8_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "u8_array", *offset-4)
+	}
+	if *offset + int(arrCount)*1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u8_array", *offset)
+	}
+	size.bytes += (int(arrCount)*1 + 7) &^ 7
+	*offset += int(arrCount)*1
+
+	// Field: u32_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u32_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "u32_array", *offset-4)
+	}
+	if *offset + int(arrCount)*4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u32_array", *offset)
+	}
+	size.bytes += (int(arrCount)*4 + 7) &^ 7
+	*offset += int(arrCount)*4
+
+	// Field: f64_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "f64_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "f64_array", *offset-4)
+	}
+	if *offset + int(arrCount)*8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "f64_array", *offset)
+	}
+	size.bytes += (int(arrCount)*8 + 7) &^ 7
+	*offset += int(arrCount)*8
+
+	// Field: str_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "str_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "str_array", *offset-4)
+	}
+	size.strs += int(arrCount)
+	for i := uint32(0); i < arrCount; i++ {
+		if *offset + 4 > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "str_array", *offset)
+		}
+		strLen = binary.LittleEndian.Uint32(data[*offset:])
+		*offset += 4
+		if *offset + int(strLen) > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "str_array", *offset)
+		}
+		size.strings += int(strLen)
+		*offset += int(strLen)
+	}
+
+	// Field: bool_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "bool_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "bool_array", *offset-4)
+	}
+	if *offset + int(arrCount)*1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "bool_array", *offset)
+	}
+	size.bytes += (int(arrCount)*1 + 7) &^ 7
+	*offset += int(arrCount)*1
+
+	return nil
+}
+
+// measureItem advances offset over one Item value and adds the arena memory it needs to size.
+func measureItem(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	return nil
+}
+
+// measureArraysOfStructs advances offset over one ArraysOfStructs value and adds the arena memory it needs to size.
+func measureArraysOfStructs(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: items
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "items", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "items", *offset-4)
+	}
+	size.Item += int(arrCount)
+	for i := uint32(0); i < arrCount; i++ {
+		err = measureItem(data, offset, ctx, size)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "items", *offset)
+		}
+	}
+
+	// Field: count
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "count", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
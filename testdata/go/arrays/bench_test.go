@@ -0,0 +1,412 @@
+package arrays
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var benchData = flag.String("benchdata", "", "directory of <Struct>.sdpb files to benchmark instead of random values")
+
+// benchRandomOptions shapes the random values of structs without a fixture.
+var benchRandomOptions = &RandomOptions{OptionalProbability: 1, MinArrayLen: 8, MaxArrayLen: 8, MaxStringLen: 32}
+
+// benchValueArraysOfPrimitives returns the ArraysOfPrimitives the benchmarks run on.
+func benchValueArraysOfPrimitives(b *testing.B) *ArraysOfPrimitives {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "ArraysOfPrimitives.sdpb"))
+		if err == nil {
+			var x ArraysOfPrimitives
+			if err := DecodeArraysOfPrimitives(&x, data); err != nil {
+				b.Fatalf("ArraysOfPrimitives.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomArraysOfPrimitives(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeArraysOfPrimitives(b *testing.B) {
+	x := benchValueArraysOfPrimitives(b)
+	data, err := EncodeArraysOfPrimitives(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeArraysOfPrimitives(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeArraysOfPrimitives(b *testing.B) {
+	x := benchValueArraysOfPrimitives(b)
+	data, err := EncodeArraysOfPrimitives(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result ArraysOfPrimitives
+		if err := DecodeArraysOfPrimitives(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripArraysOfPrimitives(b *testing.B) {
+	x := benchValueArraysOfPrimitives(b)
+	data, err := EncodeArraysOfPrimitives(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeArraysOfPrimitives(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result ArraysOfPrimitives
+		if err := DecodeArraysOfPrimitives(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeArraysOfPrimitivesJSON(b *testing.B) {
+	x := benchValueArraysOfPrimitives(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeArraysOfPrimitivesJSON(b *testing.B) {
+	x := benchValueArraysOfPrimitives(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result ArraysOfPrimitives
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripArraysOfPrimitivesJSON(b *testing.B) {
+	x := benchValueArraysOfPrimitives(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result ArraysOfPrimitives
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueItem returns the Item the benchmarks run on.
+func benchValueItem(b *testing.B) *Item {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Item.sdpb"))
+		if err == nil {
+			var x Item
+			if err := DecodeItem(&x, data); err != nil {
+				b.Fatalf("Item.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomItem(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeItem(b *testing.B) {
+	x := benchValueItem(b)
+	data, err := EncodeItem(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeItem(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeItem(b *testing.B) {
+	x := benchValueItem(b)
+	data, err := EncodeItem(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Item
+		if err := DecodeItem(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripItem(b *testing.B) {
+	x := benchValueItem(b)
+	data, err := EncodeItem(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeItem(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Item
+		if err := DecodeItem(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeItemJSON(b *testing.B) {
+	x := benchValueItem(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeItemJSON(b *testing.B) {
+	x := benchValueItem(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Item
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripItemJSON(b *testing.B) {
+	x := benchValueItem(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Item
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueArraysOfStructs returns the ArraysOfStructs the benchmarks run on.
+func benchValueArraysOfStructs(b *testing.B) *ArraysOfStructs {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "ArraysOfStructs.sdpb"))
+		if err == nil {
+			var x ArraysOfStructs
+			if err := DecodeArraysOfStructs(&x, data); err != nil {
+				b.Fatalf("ArraysOfStructs.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomArraysOfStructs(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeArraysOfStructs(b *testing.B) {
+	x := benchValueArraysOfStructs(b)
+	data, err := EncodeArraysOfStructs(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeArraysOfStructs(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeArraysOfStructs(b *testing.B) {
+	x := benchValueArraysOfStructs(b)
+	data, err := EncodeArraysOfStructs(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result ArraysOfStructs
+		if err := DecodeArraysOfStructs(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripArraysOfStructs(b *testing.B) {
+	x := benchValueArraysOfStructs(b)
+	data, err := EncodeArraysOfStructs(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeArraysOfStructs(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result ArraysOfStructs
+		if err := DecodeArraysOfStructs(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeArraysOfStructsJSON(b *testing.B) {
+	x := benchValueArraysOfStructs(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeArraysOfStructsJSON(b *testing.B) {
+	x := benchValueArraysOfStructs(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result ArraysOfStructs
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripArraysOfStructsJSON(b *testing.B) {
+	x := benchValueArraysOfStructs(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result ArraysOfStructs
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
@@ -1,10 +1,11 @@
 package arrays
 
 import (
-	"math"
+	"encoding/binary"
 	"io"
+	"math"
+	"unicode/utf8"
 	"unsafe"
-	"encoding/binary"
 )
 
 // Size limit constants for decode validation
@@ -17,8 +18,14 @@ const (
 // DecodeContext tracks state during decoding to enforce size limits.
 // It maintains a count of total elements across all arrays to prevent
 // excessive memory allocation from malicious or corrupted data.
+// In strict mode it also rejects non-canonical encodings; in reuse mode
+// the decoder recycles the slices, structs and strings already in dest;
+// with an arena, strings and slices are carved from preallocated memory.
 type DecodeContext struct {
 	totalElements int
+	strict        bool
+	reuse         bool
+	arena         *decodeArena
 }
 
 // checkArraySize validates an array count against per-array and total limits.
@@ -37,6 +44,22 @@ func (ctx *DecodeContext) checkArraySize(count uint32) error {
 	return nil
 }
 
+// Canonical NaN bit patterns accepted by strict decoding
+const (
+	CanonicalNaN32 uint32 = 0x7FC00000
+	CanonicalNaN64 uint64 = 0x7FF8000000000000
+)
+
+// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.
+func isCanonicalF32(bits uint32) bool {
+	return bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32
+}
+
+// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.
+func isCanonicalF64(bits uint64) bool {
+	return bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64
+}
+
 
 // DecodeArraysOfPrimitives decodes a ArraysOfPrimitives from wire format.
 // It validates the data size and delegates to the decoder implementation.
@@ -49,6 +72,42 @@ func DecodeArraysOfPrimitives(dest *ArraysOfPrimitives, data []byte) error {
 	return decodeArraysOfPrimitives(dest, data, &offset, ctx)
 }
 
+// DecodeArraysOfPrimitivesStrict decodes a ArraysOfPrimitives like DecodeArraysOfPrimitives, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeArraysOfPrimitivesStrict(dest *ArraysOfPrimitives, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeArraysOfPrimitives(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeArraysOfPrimitivesReuse decodes a ArraysOfPrimitives into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeArraysOfPrimitivesReuse(dest *ArraysOfPrimitives, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeArraysOfPrimitives(dest, data, &offset, ctx)
+}
+
 // DecodeItem decodes a Item from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeItem(dest *Item, data []byte) error {
@@ -60,6 +119,42 @@ func DecodeItem(dest *Item, data []byte) error {
 	return decodeItem(dest, data, &offset, ctx)
 }
 
+// DecodeItemStrict decodes a Item like DecodeItem, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeItemStrict(dest *Item, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeItem(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeItemReuse decodes a Item into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeItemReuse(dest *Item, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeItem(dest, data, &offset, ctx)
+}
+
 // DecodeArraysOfStructs decodes a ArraysOfStructs from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeArraysOfStructs(dest *ArraysOfStructs, data []byte) error {
@@ -71,6 +166,42 @@ func DecodeArraysOfStructs(dest *ArraysOfStructs, data []byte) error {
 	return decodeArraysOfStructs(dest, data, &offset, ctx)
 }
 
+// DecodeArraysOfStructsStrict decodes a ArraysOfStructs like DecodeArraysOfStructs, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeArraysOfStructsStrict(dest *ArraysOfStructs, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeArraysOfStructs(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeArraysOfStructsReuse decodes a ArraysOfStructs into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeArraysOfStructsReuse(dest *ArraysOfStructs, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeArraysOfStructs(dest, data, &offset, ctx)
+}
+
 
 // decodeArraysOfPrimitives is the helper function that decodes ArraysOfPrimitives fields.
 func decodeArraysOfPrimitives(dest *ArraysOfPrimitives, data []byte, offset *int, ctx *DecodeContext) error {
@@ -85,21 +216,27 @@ func decodeArraysOfPrimitives(dest *ArraysOfPrimitives, data []byte, offset *int
 
 	// Field: U8Array ([]u8)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "u8_array", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "u8_array", *offset-4)
 	}
 
-	dest.U8Array = make([]uint8, arrCount)
+	if ctx.arena != nil {
+		dest.U8Array = arenaPrimitives[uint8](ctx.arena, arrCount)
+	} else if ctx.reuse && cap(dest.U8Array) >= int(arrCount) {
+		dest.U8Array = dest.U8Array[:arrCount]
+	} else {
+		dest.U8Array = make([]uint8, arrCount)
+	}
 	// Bulk decode optimization for primitive arrays
 	if arrCount > 0 {
 		if *offset + int(arrCount)*1 > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(ErrUnexpectedEOF, "u8_array", *offset)
 		}
 		copy(dest.U8Array, data[*offset:*offset+int(arrCount)])
 		*offset += int(arrCount)*1
@@ -107,21 +244,27 @@ func decodeArraysOfPrimitives(dest *ArraysOfPrimitives, data []byte, offset *int
 
 	// Field: U32Array ([]u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "u32_array", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "u32_array", *offset-4)
 	}
 
-	dest.U32Array = make([]uint32, arrCount)
+	if ctx.arena != nil {
+		dest.U32Array = arenaPrimitives[uint32](ctx.arena, arrCount)
+	} else if ctx.reuse && cap(dest.U32Array) >= int(arrCount) {
+		dest.U32Array = dest.U32Array[:arrCount]
+	} else {
+		dest.U32Array = make([]uint32, arrCount)
+	}
 	// Bulk decode optimization for primitive arrays
 	if arrCount > 0 {
 		if *offset + int(arrCount)*4 > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(ErrUnexpectedEOF, "u32_array", *offset)
 		}
 		bytes := unsafe.Slice((*byte)(unsafe.Pointer(&dest.U32Array[0])), int(arrCount)*4)
 		copy(bytes, data[*offset:*offset+int(arrCount)*4])
@@ -130,20 +273,29 @@ func decodeArraysOfPrimitives(dest *ArraysOfPrimitives, data []byte, offset *int
 
 	// Field: F64Array ([]f64)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "f64_array", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "f64_array", *offset-4)
 	}
 
-	dest.F64Array = make([]float64, arrCount)
+	if ctx.arena != nil {
+		dest.F64Array = arenaPrimitives[float64](ctx.arena, arrCount)
+	} else if ctx.reuse && cap(dest.F64Array) >= int(arrCount) {
+		dest.F64Array = dest.F64Array[:arrCount]
+	} else {
+		dest.F64Array = make([]float64, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		if *offset + 8 > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "f64_array", *offset)
+		}
+		if ctx.strict && !isCanonicalF64(binary.LittleEndian.Uint64(data[*offset:])) {
+			return decodeError(decodeElementError(ErrNonCanonical, i, *offset), "f64_array", *offset)
 		}
 		dest.F64Array[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[*offset:]))
 		*offset += 8
@@ -151,47 +303,69 @@ func decodeArraysOfPrimitives(dest *ArraysOfPrimitives, data []byte, offset *int
 
 	// Field: StrArray ([]str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "str_array", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "str_array", *offset-4)
 	}
 
-	dest.StrArray = make([]string, arrCount)
+	if ctx.arena != nil {
+		dest.StrArray = arenaTake(&ctx.arena.strs, arrCount)
+	} else if ctx.reuse && cap(dest.StrArray) >= int(arrCount) {
+		dest.StrArray = dest.StrArray[:arrCount]
+	} else {
+		dest.StrArray = make([]string, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		if *offset + 4 > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "str_array", *offset)
 		}
 		strLen := binary.LittleEndian.Uint32(data[*offset:])
 		*offset += 4
 		
 		if *offset + int(strLen) > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "str_array", *offset)
+		}
+		if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+			return decodeError(decodeElementError(ErrInvalidUTF8, i, *offset), "str_array", *offset)
+		}
+		if ctx.arena != nil {
+			dest.StrArray[i] = ctx.arena.string(data[*offset:*offset+int(strLen)])
+		} else if !ctx.reuse || dest.StrArray[i] != string(data[*offset:*offset+int(strLen)]) {
+			dest.StrArray[i] = string(data[*offset:*offset+int(strLen)])
 		}
-		dest.StrArray[i] = string(data[*offset:*offset+int(strLen)])
 		*offset += int(strLen)
 	}
 
 	// Field: BoolArray ([]bool)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "bool_array", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "bool_array", *offset-4)
 	}
 
-	dest.BoolArray = make([]bool, arrCount)
+	if ctx.arena != nil {
+		dest.BoolArray = arenaPrimitives[bool](ctx.arena, arrCount)
+	} else if ctx.reuse && cap(dest.BoolArray) >= int(arrCount) {
+		dest.BoolArray = dest.BoolArray[:arrCount]
+	} else {
+		dest.BoolArray = make([]bool, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		if *offset + 1 > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "bool_array", *offset)
+		}
+		if ctx.strict && data[*offset] > 1 {
+			return decodeError(decodeElementError(ErrNonCanonical, i, *offset), "bool_array", *offset)
 		}
 		dest.BoolArray[i] = data[*offset] != 0
 		*offset += 1
@@ -213,22 +387,29 @@ func decodeItem(dest *Item, data []byte, offset *int, ctx *DecodeContext) error
 
 	// Field: Id (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
 	}
 	dest.Id = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: Name (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Name = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {
+		dest.Name = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Name = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	return nil
@@ -247,27 +428,33 @@ func decodeArraysOfStructs(dest *ArraysOfStructs, data []byte, offset *int, ctx
 
 	// Field: Items ([]Item)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "items", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "items", *offset-4)
 	}
 
-	dest.Items = make([]Item, arrCount)
+	if ctx.arena != nil {
+		dest.Items = arenaTake(&ctx.arena.Item, arrCount)
+	} else if ctx.reuse && cap(dest.Items) >= int(arrCount) {
+		dest.Items = dest.Items[:arrCount]
+	} else {
+		dest.Items = make([]Item, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		err = decodeItem(&dest.Items[i], data, offset, ctx)
 		if err != nil {
-			return err
+			return decodeError(decodeElementError(err, i, *offset), "items", *offset)
 		}
 	}
 
 	// Field: Count (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "count", *offset)
 	}
 	dest.Count = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
@@ -278,7 +465,8 @@ func decodeArraysOfStructs(dest *ArraysOfStructs, data []byte, offset *int, ctx
 
 // DecodeArraysOfPrimitivesMessage decodes a ArraysOfPrimitives from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeArraysOfPrimitivesMessage(data []byte) (*ArraysOfPrimitives, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -303,6 +491,9 @@ func DecodeArraysOfPrimitivesMessage(data []byte) (*ArraysOfPrimitives, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -313,18 +504,24 @@ func DecodeArraysOfPrimitivesMessage(data []byte) (*ArraysOfPrimitives, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result ArraysOfPrimitives
-	if err := DecodeArraysOfPrimitives(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeArraysOfPrimitives(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeItemMessage decodes a Item from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeItemMessage(data []byte) (*Item, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -349,6 +546,9 @@ func DecodeItemMessage(data []byte) (*Item, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -359,18 +559,24 @@ func DecodeItemMessage(data []byte) (*Item, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Item
-	if err := DecodeItem(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeItem(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeArraysOfStructsMessage decodes a ArraysOfStructs from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeArraysOfStructsMessage(data []byte) (*ArraysOfStructs, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -395,6 +601,9 @@ func DecodeArraysOfStructsMessage(data []byte) (*ArraysOfStructs, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -405,11 +614,16 @@ func DecodeArraysOfStructsMessage(data []byte) (*ArraysOfStructs, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result ArraysOfStructs
-	if err := DecodeArraysOfStructs(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeArraysOfStructs(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
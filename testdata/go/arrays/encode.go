@@ -1,10 +1,10 @@
 package arrays
 
 import (
-	"math"
+	"encoding/binary"
 	"io"
+	"math"
 	"unsafe"
-	"encoding/binary"
 )
 
 // calculateArraysOfPrimitivesSize calculates the wire format size for ArraysOfPrimitives.
@@ -42,6 +42,40 @@ func EncodeArraysOfPrimitives(src *ArraysOfPrimitives) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeArraysOfPrimitives produces for src.
+func (src *ArraysOfPrimitives) EncodedSize() int {
+	return calculateArraysOfPrimitivesSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeArraysOfPrimitives.
+func (src *ArraysOfPrimitives) MarshalBinary() ([]byte, error) {
+	return EncodeArraysOfPrimitives(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *ArraysOfPrimitives) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateArraysOfPrimitivesSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeArraysOfPrimitives(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeArraysOfPrimitives.
+// The decoded value does not retain data.
+func (dest *ArraysOfPrimitives) UnmarshalBinary(data []byte) error {
+	return DecodeArraysOfPrimitives(dest, data)
+}
+
 // calculateItemSize calculates the wire format size for Item.
 func calculateItemSize(src *Item) int {
 	size := 0
@@ -64,6 +98,40 @@ func EncodeItem(src *Item) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeItem produces for src.
+func (src *Item) EncodedSize() int {
+	return calculateItemSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeItem.
+func (src *Item) MarshalBinary() ([]byte, error) {
+	return EncodeItem(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Item) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateItemSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeItem(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeItem.
+// The decoded value does not retain data.
+func (dest *Item) UnmarshalBinary(data []byte) error {
+	return DecodeItem(dest, data)
+}
+
 // calculateArraysOfStructsSize calculates the wire format size for ArraysOfStructs.
 func calculateArraysOfStructsSize(src *ArraysOfStructs) int {
 	size := 0
@@ -89,6 +157,40 @@ func EncodeArraysOfStructs(src *ArraysOfStructs) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeArraysOfStructs produces for src.
+func (src *ArraysOfStructs) EncodedSize() int {
+	return calculateArraysOfStructsSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeArraysOfStructs.
+func (src *ArraysOfStructs) MarshalBinary() ([]byte, error) {
+	return EncodeArraysOfStructs(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *ArraysOfStructs) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateArraysOfStructsSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeArraysOfStructs(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeArraysOfStructs.
+// The decoded value does not retain data.
+func (dest *ArraysOfStructs) UnmarshalBinary(data []byte) error {
+	return DecodeArraysOfStructs(dest, data)
+}
+
 
 // encodeArraysOfPrimitives is the helper function that encodes ArraysOfPrimitives fields.
 func encodeArraysOfPrimitives(src *ArraysOfPrimitives, buf []byte, offset *int) error {
@@ -0,0 +1,317 @@
+package arrays
+
+import (
+	"math"
+	"strconv"
+)
+
+// FieldChange is one field-level difference reported by Diff.
+type FieldChange struct {
+	// Path is the schema path of the field, e.g. "plugins[2].name".
+	Path string
+	// Kind is "value" if a primitive field differs, "length" if an array
+	// has a different number of elements and "presence" if an optional
+	// is set on one side only.
+	Kind string
+	// Old and New are the field values for "value", the element counts
+	// (int) for "length" and the presence flags (bool) for "presence".
+	Old any
+	New any
+}
+
+// diffPath joins a field name to the path of its struct.
+func diffPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *ArraysOfPrimitives) Equal(other *ArraysOfPrimitives) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if len(x.U8Array) != len(other.U8Array) {
+		return false
+	}
+	for i := range x.U8Array {
+		if x.U8Array[i] != other.U8Array[i] {
+			return false
+		}
+	}
+	if len(x.U32Array) != len(other.U32Array) {
+		return false
+	}
+	for i := range x.U32Array {
+		if x.U32Array[i] != other.U32Array[i] {
+			return false
+		}
+	}
+	if len(x.F64Array) != len(other.F64Array) {
+		return false
+	}
+	for i := range x.F64Array {
+		if math.Float64bits(x.F64Array[i]) != math.Float64bits(other.F64Array[i]) {
+			return false
+		}
+	}
+	if len(x.StrArray) != len(other.StrArray) {
+		return false
+	}
+	for i := range x.StrArray {
+		if x.StrArray[i] != other.StrArray[i] {
+			return false
+		}
+	}
+	if len(x.BoolArray) != len(other.BoolArray) {
+		return false
+	}
+	for i := range x.BoolArray {
+		if x.BoolArray[i] != other.BoolArray[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *ArraysOfPrimitives) Clone() *ArraysOfPrimitives {
+	if x == nil {
+		return nil
+	}
+	c := new(ArraysOfPrimitives)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *ArraysOfPrimitives) cloneTo(c *ArraysOfPrimitives) {
+	*c = *x
+	if x.U8Array != nil {
+		c.U8Array = make([]uint8, len(x.U8Array))
+		copy(c.U8Array, x.U8Array)
+	}
+	if x.U32Array != nil {
+		c.U32Array = make([]uint32, len(x.U32Array))
+		copy(c.U32Array, x.U32Array)
+	}
+	if x.F64Array != nil {
+		c.F64Array = make([]float64, len(x.F64Array))
+		copy(c.F64Array, x.F64Array)
+	}
+	if x.StrArray != nil {
+		c.StrArray = make([]string, len(x.StrArray))
+		copy(c.StrArray, x.StrArray)
+	}
+	if x.BoolArray != nil {
+		c.BoolArray = make([]bool, len(x.BoolArray))
+		copy(c.BoolArray, x.BoolArray)
+	}
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *ArraysOfPrimitives) Diff(other *ArraysOfPrimitives) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *ArraysOfPrimitives) diff(prefix string, other *ArraysOfPrimitives, changes *[]FieldChange) {
+	{
+		path := diffPath(prefix, "u8_array")
+		if len(x.U8Array) != len(other.U8Array) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.U8Array), New: len(other.U8Array)})
+		}
+		for i := 0; i < len(x.U8Array) && i < len(other.U8Array); i++ {
+			if x.U8Array[i] != other.U8Array[i] {
+				*changes = append(*changes, FieldChange{Path: path+"["+strconv.Itoa(i)+"]", Kind: "value", Old: x.U8Array[i], New: other.U8Array[i]})
+			}
+		}
+	}
+	{
+		path := diffPath(prefix, "u32_array")
+		if len(x.U32Array) != len(other.U32Array) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.U32Array), New: len(other.U32Array)})
+		}
+		for i := 0; i < len(x.U32Array) && i < len(other.U32Array); i++ {
+			if x.U32Array[i] != other.U32Array[i] {
+				*changes = append(*changes, FieldChange{Path: path+"["+strconv.Itoa(i)+"]", Kind: "value", Old: x.U32Array[i], New: other.U32Array[i]})
+			}
+		}
+	}
+	{
+		path := diffPath(prefix, "f64_array")
+		if len(x.F64Array) != len(other.F64Array) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.F64Array), New: len(other.F64Array)})
+		}
+		for i := 0; i < len(x.F64Array) && i < len(other.F64Array); i++ {
+			if math.Float64bits(x.F64Array[i]) != math.Float64bits(other.F64Array[i]) {
+				*changes = append(*changes, FieldChange{Path: path+"["+strconv.Itoa(i)+"]", Kind: "value", Old: x.F64Array[i], New: other.F64Array[i]})
+			}
+		}
+	}
+	{
+		path := diffPath(prefix, "str_array")
+		if len(x.StrArray) != len(other.StrArray) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.StrArray), New: len(other.StrArray)})
+		}
+		for i := 0; i < len(x.StrArray) && i < len(other.StrArray); i++ {
+			if x.StrArray[i] != other.StrArray[i] {
+				*changes = append(*changes, FieldChange{Path: path+"["+strconv.Itoa(i)+"]", Kind: "value", Old: x.StrArray[i], New: other.StrArray[i]})
+			}
+		}
+	}
+	{
+		path := diffPath(prefix, "bool_array")
+		if len(x.BoolArray) != len(other.BoolArray) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.BoolArray), New: len(other.BoolArray)})
+		}
+		for i := 0; i < len(x.BoolArray) && i < len(other.BoolArray); i++ {
+			if x.BoolArray[i] != other.BoolArray[i] {
+				*changes = append(*changes, FieldChange{Path: path+"["+strconv.Itoa(i)+"]", Kind: "value", Old: x.BoolArray[i], New: other.BoolArray[i]})
+			}
+		}
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Item) Equal(other *Item) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Id != other.Id {
+		return false
+	}
+	if x.Name != other.Name {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Item) Clone() *Item {
+	if x == nil {
+		return nil
+	}
+	c := new(Item)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Item) cloneTo(c *Item) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Item) Diff(other *Item) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Item) diff(prefix string, other *Item, changes *[]FieldChange) {
+	if x.Id != other.Id {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "id"), Kind: "value", Old: x.Id, New: other.Id})
+	}
+	if x.Name != other.Name {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "name"), Kind: "value", Old: x.Name, New: other.Name})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *ArraysOfStructs) Equal(other *ArraysOfStructs) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if len(x.Items) != len(other.Items) {
+		return false
+	}
+	for i := range x.Items {
+		if !x.Items[i].Equal(&other.Items[i]) {
+			return false
+		}
+	}
+	if x.Count != other.Count {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *ArraysOfStructs) Clone() *ArraysOfStructs {
+	if x == nil {
+		return nil
+	}
+	c := new(ArraysOfStructs)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *ArraysOfStructs) cloneTo(c *ArraysOfStructs) {
+	*c = *x
+	if x.Items != nil {
+		c.Items = make([]Item, len(x.Items))
+		for i := range x.Items {
+			x.Items[i].cloneTo(&c.Items[i])
+		}
+	}
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *ArraysOfStructs) Diff(other *ArraysOfStructs) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *ArraysOfStructs) diff(prefix string, other *ArraysOfStructs, changes *[]FieldChange) {
+	{
+		path := diffPath(prefix, "items")
+		if len(x.Items) != len(other.Items) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.Items), New: len(other.Items)})
+		}
+		for i := 0; i < len(x.Items) && i < len(other.Items); i++ {
+			x.Items[i].diff(path+"["+strconv.Itoa(i)+"]", &other.Items[i], changes)
+		}
+	}
+	if x.Count != other.Count {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "count"), Kind: "value", Old: x.Count, New: other.Count})
+	}
+}
+
@@ -2,6 +2,7 @@ package arrays
 
 import (
 	"errors"
+	"strconv"
 )
 
 // Message mode constants for self-describing messages
@@ -22,4 +23,46 @@ var (
 	ErrInvalidMagic       = errors.New("invalid magic bytes (expected 'SDP')")
 	ErrInvalidVersion     = errors.New("unsupported protocol version")
 	ErrUnknownMessageType = errors.New("unknown message type ID")
+	ErrTrailingBytes      = errors.New("trailing bytes after value")
+	ErrNonCanonical       = errors.New("non-canonical encoding")
 )
+
+// DecodeError reports where in the payload decoding failed.
+// Path uses schema field names with array indices (e.g. "plugins[12].parameters[3].unit"),
+// Offset is the byte offset into the payload where the failing read started, and
+// Err is one of the sentinel errors above, so errors.Is(err, ErrUnexpectedEOF) still matches.
+type DecodeError struct {
+	Path   string
+	Offset int
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return "decode " + e.Path + " at offset " + strconv.Itoa(e.Offset) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying sentinel error for errors.Is.
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// decodeError annotates err with the field being decoded. Errors coming
+// from nested structs already carry a path and offset, so only the field
+// name is prepended to their path.
+func decodeError(err error, field string, offset int) error {
+	if de, ok := err.(*DecodeError); ok {
+		if de.Path == "" || de.Path[0] == '[' {
+			de.Path = field + de.Path
+		} else {
+			de.Path = field + "." + de.Path
+		}
+		return de
+	}
+	return &DecodeError{Path: field, Offset: offset, Err: err}
+}
+
+// decodeElementError annotates err with the index of the array element being decoded.
+func decodeElementError(err error, index uint32, offset int) error {
+	return decodeError(err, "["+strconv.FormatUint(uint64(index), 10)+"]", offset)
+}
@@ -0,0 +1,115 @@
+package arrays
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzDecodeArraysOfPrimitives checks that DecodeArraysOfPrimitives never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeArraysOfPrimitives(f *testing.F) {
+	for _, seed := range []*ArraysOfPrimitives{
+		{},
+		{U8Array: []uint8{1}, U32Array: []uint32{1}, F64Array: []float64{1.5}, StrArray: []string{"sample"}, BoolArray: []bool{true}},
+	} {
+		data, err := EncodeArraysOfPrimitives(seed)
+		if err != nil {
+			f.Fatalf("EncodeArraysOfPrimitives(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x ArraysOfPrimitives
+		if err := DecodeArraysOfPrimitives(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeArraysOfPrimitives(&x)
+		if err != nil {
+			t.Fatalf("EncodeArraysOfPrimitives of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict ArraysOfPrimitives
+		if err := DecodeArraysOfPrimitivesStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeItem checks that DecodeItem never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeItem(f *testing.F) {
+	for _, seed := range []*Item{
+		{},
+		{Id: 1, Name: "sample"},
+	} {
+		data, err := EncodeItem(seed)
+		if err != nil {
+			f.Fatalf("EncodeItem(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Item
+		if err := DecodeItem(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeItem(&x)
+		if err != nil {
+			t.Fatalf("EncodeItem of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Item
+		if err := DecodeItemStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeArraysOfStructs checks that DecodeArraysOfStructs never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeArraysOfStructs(f *testing.F) {
+	for _, seed := range []*ArraysOfStructs{
+		{},
+		{Items: []Item{{Id: 1, Name: "sample"}}, Count: 1},
+	} {
+		data, err := EncodeArraysOfStructs(seed)
+		if err != nil {
+			f.Fatalf("EncodeArraysOfStructs(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x ArraysOfStructs
+		if err := DecodeArraysOfStructs(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeArraysOfStructs(&x)
+		if err != nil {
+			t.Fatalf("EncodeArraysOfStructs of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict ArraysOfStructs
+		if err := DecodeArraysOfStructsStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
@@ -0,0 +1,140 @@
+package arrays
+
+import (
+	"math/rand/v2"
+)
+
+// RandomOptions controls the values generated by the Random constructors.
+// A zero field selects its default, so a nil or partially set *RandomOptions
+// gives OptionalProbability 0.5, arrays of 0 to 4 elements and strings of up
+// to 16 ASCII letters and digits unless overridden. A negative
+// OptionalProbability, MaxArrayLen or MaxStringLen asks for absent optionals,
+// arrays of MinArrayLen elements or empty strings.
+type RandomOptions struct {
+	// OptionalProbability is the chance that an optional field is present.
+	OptionalProbability float64
+
+	// MinArrayLen and MaxArrayLen bound the length of every array.
+	MinArrayLen int
+	MaxArrayLen int
+
+	// MaxStringLen bounds the length of every string in runes.
+	MaxStringLen int
+
+	// Charset holds the runes strings are drawn from. Empty means ASCII
+	// letters and digits.
+	Charset string
+}
+
+var defaultRandomOptions = RandomOptions{OptionalProbability: 0.5, MaxArrayLen: 4, MaxStringLen: 16}
+
+const randomCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// withDefaults returns a copy of opts with zero fields set to their defaults.
+func (opts *RandomOptions) withDefaults() *RandomOptions {
+	o := defaultRandomOptions
+	if opts == nil {
+		return &o
+	}
+	if opts.OptionalProbability != 0 {
+		o.OptionalProbability = opts.OptionalProbability
+	}
+	o.MinArrayLen = opts.MinArrayLen
+	if opts.MaxArrayLen != 0 {
+		o.MaxArrayLen = opts.MaxArrayLen
+	}
+	if opts.MaxStringLen != 0 {
+		o.MaxStringLen = opts.MaxStringLen
+	}
+	o.Charset = opts.Charset
+	return &o
+}
+
+// randomLen returns an array length between opts.MinArrayLen and opts.MaxArrayLen.
+func randomLen(r *rand.Rand, opts *RandomOptions) int {
+	n := max(opts.MinArrayLen, 0)
+	if opts.MaxArrayLen > n {
+		n += r.IntN(opts.MaxArrayLen - n + 1)
+	}
+	return n
+}
+
+// randomString returns a string of up to opts.MaxStringLen runes from opts.Charset.
+func randomString(r *rand.Rand, opts *RandomOptions) string {
+	if opts.MaxStringLen <= 0 {
+		return ""
+	}
+	charset := []rune(opts.Charset)
+	if len(charset) == 0 {
+		charset = []rune(randomCharset)
+	}
+	s := make([]rune, r.IntN(opts.MaxStringLen+1))
+	for i := range s {
+		s[i] = charset[r.IntN(len(charset))]
+	}
+	return string(s)
+}
+
+// RandomArraysOfPrimitives returns a ArraysOfPrimitives with every field drawn from r. Floats are finite.
+func RandomArraysOfPrimitives(r *rand.Rand, opts *RandomOptions) *ArraysOfPrimitives {
+	opts = opts.withDefaults()
+	x := new(ArraysOfPrimitives)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *ArraysOfPrimitives) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.U8Array = make([]uint8, randomLen(r, opts))
+	for i := range x.U8Array {
+		x.U8Array[i] = uint8(r.Uint32())
+	}
+	x.U32Array = make([]uint32, randomLen(r, opts))
+	for i := range x.U32Array {
+		x.U32Array[i] = r.Uint32()
+	}
+	x.F64Array = make([]float64, randomLen(r, opts))
+	for i := range x.F64Array {
+		x.F64Array[i] = r.NormFloat64() * 1000
+	}
+	x.StrArray = make([]string, randomLen(r, opts))
+	for i := range x.StrArray {
+		x.StrArray[i] = randomString(r, opts)
+	}
+	x.BoolArray = make([]bool, randomLen(r, opts))
+	for i := range x.BoolArray {
+		x.BoolArray[i] = r.IntN(2) == 1
+	}
+}
+
+// RandomItem returns a Item with every field drawn from r. Floats are finite.
+func RandomItem(r *rand.Rand, opts *RandomOptions) *Item {
+	opts = opts.withDefaults()
+	x := new(Item)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Item) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Id = r.Uint32()
+	x.Name = randomString(r, opts)
+}
+
+// RandomArraysOfStructs returns a ArraysOfStructs with every field drawn from r. Floats are finite.
+func RandomArraysOfStructs(r *rand.Rand, opts *RandomOptions) *ArraysOfStructs {
+	opts = opts.withDefaults()
+	x := new(ArraysOfStructs)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *ArraysOfStructs) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Items = make([]Item, randomLen(r, opts))
+	for i := range x.Items {
+		x.Items[i].randomize(r, opts)
+	}
+	x.Count = r.Uint32()
+}
+
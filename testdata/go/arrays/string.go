@@ -0,0 +1,255 @@
+package arrays
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+)
+
+// stringMaxElems is the number of array elements String and LogValue show.
+const stringMaxElems = 16
+
+// stringMaxBytes is the number of []u8 bytes String and LogValue show.
+const stringMaxBytes = 32
+
+// stringRedacted replaces the value of fields marked @redact.
+const stringRedacted = "[REDACTED]"
+
+// appendStringMore appends the number of elements left out of a truncated array.
+func appendStringMore(b []byte, n int) []byte {
+	b = append(b, "... (+"...)
+	b = strconv.AppendInt(b, int64(n), 10)
+	return append(b, " more)"...)
+}
+
+// appendStringBytes appends a []u8 in hex.
+func appendStringBytes(b, v []byte) []byte {
+	b = append(b, "0x"...)
+	if len(v) <= stringMaxBytes {
+		return hex.AppendEncode(b, v)
+	}
+	b = hex.AppendEncode(b, v[:stringMaxBytes])
+	return appendStringMore(b, len(v)-stringMaxBytes)
+}
+
+// logValueElems returns the first stringMaxElems elements of an array as a
+// group keyed by index.
+func logValueElems[T any](elems []T, value func(*T) slog.Value) slog.Value {
+	attrs := make([]slog.Attr, 0, min(len(elems), stringMaxElems+1))
+	for i := range elems {
+		if i == stringMaxElems {
+			attrs = append(attrs, slog.Int("more", len(elems)-i))
+			break
+		}
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: value(&elems[i])})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *ArraysOfPrimitives) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *ArraysOfPrimitives) appendString(b []byte) []byte {
+	b = append(b, "ArraysOfPrimitives{"...)
+	b = append(b, "u8_array: "...)
+	b = appendStringBytes(b, x.U8Array)
+	b = append(b, ", u32_array: "...)
+	b = append(b, '[')
+	for i := range x.U32Array {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.U32Array)-i)
+			break
+		}
+		b = strconv.AppendUint(b, uint64(x.U32Array[i]), 10)
+	}
+	b = append(b, ']')
+	b = append(b, ", f64_array: "...)
+	b = append(b, '[')
+	for i := range x.F64Array {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.F64Array)-i)
+			break
+		}
+		b = strconv.AppendFloat(b, x.F64Array[i], 'g', -1, 64)
+	}
+	b = append(b, ']')
+	b = append(b, ", str_array: "...)
+	b = append(b, '[')
+	for i := range x.StrArray {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.StrArray)-i)
+			break
+		}
+		b = strconv.AppendQuote(b, x.StrArray[i])
+	}
+	b = append(b, ']')
+	b = append(b, ", bool_array: "...)
+	b = append(b, '[')
+	for i := range x.BoolArray {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.BoolArray)-i)
+			break
+		}
+		b = strconv.AppendBool(b, x.BoolArray[i])
+	}
+	b = append(b, ']')
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *ArraysOfPrimitives) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	var b []byte
+	attrs := make([]slog.Attr, 0, 5)
+	attrs = append(attrs, slog.String("u8_array", string(appendStringBytes(nil, x.U8Array))))
+	b = b[:0]
+	b = append(b, '[')
+	for i := range x.U32Array {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.U32Array)-i)
+			break
+		}
+		b = strconv.AppendUint(b, uint64(x.U32Array[i]), 10)
+	}
+	b = append(b, ']')
+	attrs = append(attrs, slog.String("u32_array", string(b)))
+	b = b[:0]
+	b = append(b, '[')
+	for i := range x.F64Array {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.F64Array)-i)
+			break
+		}
+		b = strconv.AppendFloat(b, x.F64Array[i], 'g', -1, 64)
+	}
+	b = append(b, ']')
+	attrs = append(attrs, slog.String("f64_array", string(b)))
+	b = b[:0]
+	b = append(b, '[')
+	for i := range x.StrArray {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.StrArray)-i)
+			break
+		}
+		b = strconv.AppendQuote(b, x.StrArray[i])
+	}
+	b = append(b, ']')
+	attrs = append(attrs, slog.String("str_array", string(b)))
+	b = b[:0]
+	b = append(b, '[')
+	for i := range x.BoolArray {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.BoolArray)-i)
+			break
+		}
+		b = strconv.AppendBool(b, x.BoolArray[i])
+	}
+	b = append(b, ']')
+	attrs = append(attrs, slog.String("bool_array", string(b)))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Item) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Item) appendString(b []byte) []byte {
+	b = append(b, "Item{"...)
+	b = append(b, "id: "...)
+	b = strconv.AppendUint(b, uint64(x.Id), 10)
+	b = append(b, ", name: "...)
+	b = strconv.AppendQuote(b, x.Name)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Item) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.Uint64("id", uint64(x.Id)))
+	attrs = append(attrs, slog.String("name", x.Name))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *ArraysOfStructs) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *ArraysOfStructs) appendString(b []byte) []byte {
+	b = append(b, "ArraysOfStructs{"...)
+	b = append(b, "items: "...)
+	b = append(b, '[')
+	for i := range x.Items {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.Items)-i)
+			break
+		}
+		b = x.Items[i].appendString(b)
+	}
+	b = append(b, ']')
+	b = append(b, ", count: "...)
+	b = strconv.AppendUint(b, uint64(x.Count), 10)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *ArraysOfStructs) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.Attr{Key: "items", Value: logValueElems(x.Items, (*Item).LogValue)})
+	attrs = append(attrs, slog.Uint64("count", uint64(x.Count)))
+	return slog.GroupValue(attrs...)
+}
+
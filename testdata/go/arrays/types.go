@@ -1,19 +1,91 @@
 package arrays
 
 type ArraysOfPrimitives struct {
-	U8Array []uint8
-	U32Array []uint32
-	F64Array []float64
-	StrArray []string
-	BoolArray []bool
+	U8Array []uint8 `json:"u8_array,omitempty"`
+	U32Array []uint32 `json:"u32_array,omitempty"`
+	F64Array []float64 `json:"f64_array,omitempty"`
+	StrArray []string `json:"str_array,omitempty"`
+	BoolArray []bool `json:"bool_array,omitempty"`
 }
 
 type Item struct {
-	Id uint32
-	Name string
+	Id uint32 `json:"id"`
+	Name string `json:"name"`
 }
 
 type ArraysOfStructs struct {
-	Items []Item
-	Count uint32
+	Items []Item `json:"items,omitempty"`
+	Count uint32 `json:"count"`
+}
+
+// GetU8Array returns u8_array, or the zero value if x is nil.
+func (x *ArraysOfPrimitives) GetU8Array() []uint8 {
+	if x != nil {
+		return x.U8Array
+	}
+	return nil
+}
+
+// GetU32Array returns u32_array, or the zero value if x is nil.
+func (x *ArraysOfPrimitives) GetU32Array() []uint32 {
+	if x != nil {
+		return x.U32Array
+	}
+	return nil
+}
+
+// GetF64Array returns f64_array, or the zero value if x is nil.
+func (x *ArraysOfPrimitives) GetF64Array() []float64 {
+	if x != nil {
+		return x.F64Array
+	}
+	return nil
+}
+
+// GetStrArray returns str_array, or the zero value if x is nil.
+func (x *ArraysOfPrimitives) GetStrArray() []string {
+	if x != nil {
+		return x.StrArray
+	}
+	return nil
+}
+
+// GetBoolArray returns bool_array, or the zero value if x is nil.
+func (x *ArraysOfPrimitives) GetBoolArray() []bool {
+	if x != nil {
+		return x.BoolArray
+	}
+	return nil
+}
+
+// GetId returns id, or the zero value if x is nil.
+func (x *Item) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetName returns name, or the zero value if x is nil.
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetItems returns items, or the zero value if x is nil.
+func (x *ArraysOfStructs) GetItems() []Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// GetCount returns count, or the zero value if x is nil.
+func (x *ArraysOfStructs) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
 }
@@ -0,0 +1,274 @@
+package arrays
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// ValidateArraysOfPrimitives checks that data starts with a well-formed ArraysOfPrimitives without decoding it.
+// It accepts the input DecodeArraysOfPrimitives accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateArraysOfPrimitives(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateArraysOfPrimitives(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateItem checks that data starts with a well-formed Item without decoding it.
+// It accepts the input DecodeItem accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateItem(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateItem(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateArraysOfStructs checks that data starts with a well-formed ArraysOfStructs without decoding it.
+// It accepts the input DecodeArraysOfStructs accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateArraysOfStructs(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateArraysOfStructs(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// validateArraysOfPrimitives advances offset over one ArraysOfPrimitives value.
+func validateArraysOfPrimitives(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: u8_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u8_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "u8_array", *offset-4)
+	}
+	if *offset + int(arrCount)*1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u8_array", *offset)
+	}
+	*offset += int(arrCount)*1
+
+	// Field: u32_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u32_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "u32_array", *offset-4)
+	}
+	if *offset + int(arrCount)*4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u32_array", *offset)
+	}
+	*offset += int(arrCount)*4
+
+	// Field: f64_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "f64_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "f64_array", *offset-4)
+	}
+	if *offset + int(arrCount)*8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "f64_array", *offset)
+	}
+	*offset += int(arrCount)*8
+
+	// Field: str_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "str_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "str_array", *offset-4)
+	}
+	for i := uint32(0); i < arrCount; i++ {
+		if *offset + 4 > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "str_array", *offset)
+		}
+		strLen = binary.LittleEndian.Uint32(data[*offset:])
+		*offset += 4
+		if *offset + int(strLen) > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "str_array", *offset)
+		}
+		if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+			return decodeError(decodeElementError(ErrInvalidUTF8, i, *offset), "str_array", *offset)
+		}
+		*offset += int(strLen)
+	}
+
+	// Field: bool_array
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "bool_array", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "bool_array", *offset-4)
+	}
+	if *offset + int(arrCount)*1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "bool_array", *offset)
+	}
+	*offset += int(arrCount)*1
+
+	return nil
+}
+
+// validateItem advances offset over one Item value.
+func validateItem(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	*offset += int(strLen)
+
+	return nil
+}
+
+// validateArraysOfStructs advances offset over one ArraysOfStructs value.
+func validateArraysOfStructs(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: items
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "items", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "items", *offset-4)
+	}
+	for i := uint32(0); i < arrCount; i++ {
+		err = validateItem(data, offset, ctx)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "items", *offset)
+		}
+	}
+
+	// Field: count
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "count", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// ValidateMessage checks that data starts with a well-formed self-describing
+// message of any type in this schema, without decoding it. The payload must
+// hold exactly one value of the type; ErrTrailingBytes is returned if it
+// extends past the value. It returns the total message length
+// (header + payload).
+func ValidateMessage(data []byte) (int, error) {
+	if len(data) < MessageHeaderSize {
+		return 0, ErrUnexpectedEOF
+	}
+	if string(data[0:3]) != MessageMagic {
+		return 0, ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return 0, ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	end := MessageHeaderSize + int(payloadLength)
+	if len(data) < end {
+		return 0, ErrUnexpectedEOF
+	}
+	payload := data[MessageHeaderSize:end]
+
+	var (
+		n   int
+		err error
+	)
+	switch typeID {
+	case 1:
+		n, err = ValidateArraysOfPrimitives(payload)
+	case 2:
+		n, err = ValidateItem(payload)
+	case 3:
+		n, err = ValidateArraysOfStructs(payload)
+	default:
+		return 0, ErrUnknownMessageType
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n != len(payload) {
+		return 0, ErrTrailingBytes
+	}
+	return end, nil
+}
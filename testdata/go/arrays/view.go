@@ -0,0 +1,242 @@
+package arrays
+
+import (
+	"encoding/binary"
+	"iter"
+	"math"
+	"unsafe"
+)
+
+// ArrayView is a read-only view of an encoded array. Elements are read
+// from the underlying buffer on access; nothing is copied up front.
+type ArrayView[T any] struct {
+	data []byte
+	off  int  // Offset of the first element
+	n    int  // Element count
+	size int  // Element size, or 0 for variable-size elements
+	read func(data []byte, off int) T
+	skip func(data []byte, off int) int
+}
+
+// newArrayView creates an ArrayView over the array whose count prefix is at off.
+func newArrayView[T any](data []byte, off int, size int, read func([]byte, int) T, skip func([]byte, int) int) ArrayView[T] {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	return ArrayView[T]{data: data, off: off + 4, n: n, size: size, read: read, skip: skip}
+}
+
+// Len returns the number of elements.
+func (a ArrayView[T]) Len() int {
+	return a.n
+}
+
+// At returns element i. Fixed-size elements are located directly;
+// variable-size elements (strings, structs) are found by skipping the
+// preceding ones, so use All to visit every element. At panics if i is
+// out of range.
+func (a ArrayView[T]) At(i int) T {
+	if i < 0 || i >= a.n {
+		panic("ArrayView: index out of range")
+	}
+	if a.size > 0 {
+		return a.read(a.data, a.off+i*a.size)
+	}
+	off := a.off
+	for ; i > 0; i-- {
+		off = a.skip(a.data, off)
+	}
+	return a.read(a.data, off)
+}
+
+// All returns an iterator over the index and value of every element.
+func (a ArrayView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		off := a.off
+		for i := 0; i < a.n; i++ {
+			if !yield(i, a.read(a.data, off)) {
+				return
+			}
+			if a.size > 0 {
+				off += a.size
+			} else {
+				off = a.skip(a.data, off)
+			}
+		}
+	}
+}
+
+// viewString returns the length-prefixed string at off without copying.
+func viewString(data []byte, off int) string {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	if n == 0 {
+		return ""
+	}
+	return unsafe.String(&data[off+4], n)
+}
+
+// viewSkipString returns the offset just past the string at off.
+func viewSkipString(data []byte, off int) int {
+	return off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+}
+
+// viewBytes returns the []u8 array at off as a sub-slice of data.
+// Its capacity is clipped so appending to it cannot overwrite data.
+func viewBytes(data []byte, off int) []byte {
+	end := off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+	return data[off+4 : end : end]
+}
+
+// viewSkipArray returns the offset just past the array of variable-size
+// elements at off, using skip to step over each element.
+func viewSkipArray(data []byte, off int, skip func([]byte, int) int) int {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	for i := 0; i < n; i++ {
+		off = skip(data, off)
+	}
+	return off
+}
+
+// ArraysOfPrimitivesView is a read-only, zero-copy view of an encoded ArraysOfPrimitives.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type ArraysOfPrimitivesView struct {
+	data []byte
+}
+
+// NewArraysOfPrimitivesView validates data with ValidateArraysOfPrimitives and returns a view over it.
+func NewArraysOfPrimitivesView(data []byte) (ArraysOfPrimitivesView, error) {
+	if _, err := ValidateArraysOfPrimitives(data); err != nil {
+		return ArraysOfPrimitivesView{}, err
+	}
+	return ArraysOfPrimitivesView{data: data}, nil
+}
+
+// Decode materializes the full ArraysOfPrimitives value.
+func (v ArraysOfPrimitivesView) Decode(dest *ArraysOfPrimitives) error {
+	return DecodeArraysOfPrimitives(dest, v.data)
+}
+
+// U8Array returns the u8_array field.
+func (v ArraysOfPrimitivesView) U8Array() []byte {
+	return viewBytes(v.data, 0)
+}
+
+// U32Array returns the u32_array field.
+func (v ArraysOfPrimitivesView) U32Array() ArrayView[uint32] {
+	off := 0
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*1
+	return newArrayView(v.data, off, 4, func(data []byte, off int) uint32 { return binary.LittleEndian.Uint32(data[off:]) }, nil)
+}
+
+// F64Array returns the f64_array field.
+func (v ArraysOfPrimitivesView) F64Array() ArrayView[float64] {
+	off := 0
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*1
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*4
+	return newArrayView(v.data, off, 8, func(data []byte, off int) float64 { return math.Float64frombits(binary.LittleEndian.Uint64(data[off:])) }, nil)
+}
+
+// StrArray returns the str_array field.
+func (v ArraysOfPrimitivesView) StrArray() ArrayView[string] {
+	off := 0
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*1
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*4
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*8
+	return newArrayView(v.data, off, 0, func(data []byte, off int) string { return viewString(data, off) }, viewSkipString)
+}
+
+// BoolArray returns the bool_array field.
+func (v ArraysOfPrimitivesView) BoolArray() ArrayView[bool] {
+	off := 0
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*1
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*4
+	off += 4 + int(binary.LittleEndian.Uint32(v.data[off:]))*8
+	off = viewSkipArray(v.data, off, viewSkipString)
+	return newArrayView(v.data, off, 1, func(data []byte, off int) bool { return data[off] != 0 }, nil)
+}
+
+// skipArraysOfPrimitives returns the offset just past the ArraysOfPrimitives value starting at off.
+func skipArraysOfPrimitives(data []byte, off int) int {
+	off += 4 + int(binary.LittleEndian.Uint32(data[off:]))*1
+	off += 4 + int(binary.LittleEndian.Uint32(data[off:]))*4
+	off += 4 + int(binary.LittleEndian.Uint32(data[off:]))*8
+	off = viewSkipArray(data, off, viewSkipString)
+	off += 4 + int(binary.LittleEndian.Uint32(data[off:]))*1
+	return off
+}
+
+// ItemView is a read-only, zero-copy view of an encoded Item.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type ItemView struct {
+	data []byte
+}
+
+// NewItemView validates data with ValidateItem and returns a view over it.
+func NewItemView(data []byte) (ItemView, error) {
+	if _, err := ValidateItem(data); err != nil {
+		return ItemView{}, err
+	}
+	return ItemView{data: data}, nil
+}
+
+// Decode materializes the full Item value.
+func (v ItemView) Decode(dest *Item) error {
+	return DecodeItem(dest, v.data)
+}
+
+// Id returns the id field.
+func (v ItemView) Id() uint32 {
+	return binary.LittleEndian.Uint32(v.data[0:])
+}
+
+// Name returns the name field.
+func (v ItemView) Name() string {
+	return viewString(v.data, 4)
+}
+
+// skipItem returns the offset just past the Item value starting at off.
+func skipItem(data []byte, off int) int {
+	off += 4
+	off = viewSkipString(data, off)
+	return off
+}
+
+// ArraysOfStructsView is a read-only, zero-copy view of an encoded ArraysOfStructs.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type ArraysOfStructsView struct {
+	data []byte
+}
+
+// NewArraysOfStructsView validates data with ValidateArraysOfStructs and returns a view over it.
+func NewArraysOfStructsView(data []byte) (ArraysOfStructsView, error) {
+	if _, err := ValidateArraysOfStructs(data); err != nil {
+		return ArraysOfStructsView{}, err
+	}
+	return ArraysOfStructsView{data: data}, nil
+}
+
+// Decode materializes the full ArraysOfStructs value.
+func (v ArraysOfStructsView) Decode(dest *ArraysOfStructs) error {
+	return DecodeArraysOfStructs(dest, v.data)
+}
+
+// Items returns the items field.
+func (v ArraysOfStructsView) Items() ArrayView[ItemView] {
+	return newArrayView(v.data, 0, 0, func(data []byte, off int) ItemView { return ItemView{data: data[off:]} }, skipItem)
+}
+
+// Count returns the count field.
+func (v ArraysOfStructsView) Count() uint32 {
+	off := 0
+	off = viewSkipArray(v.data, off, skipItem)
+	return binary.LittleEndian.Uint32(v.data[off:])
+}
+
+// skipArraysOfStructs returns the offset just past the ArraysOfStructs value starting at off.
+func skipArraysOfStructs(data []byte, off int) int {
+	off = viewSkipArray(data, off, skipItem)
+	off += 4
+	return off
+}
@@ -0,0 +1,412 @@
+package audiounit
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var benchData = flag.String("benchdata", "", "directory of <Struct>.sdpb files to benchmark instead of random values")
+
+// benchRandomOptions shapes the random values of structs without a fixture.
+var benchRandomOptions = &RandomOptions{OptionalProbability: 1, MinArrayLen: 8, MaxArrayLen: 8, MaxStringLen: 32}
+
+// benchValueParameter returns the Parameter the benchmarks run on.
+func benchValueParameter(b *testing.B) *Parameter {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Parameter.sdpb"))
+		if err == nil {
+			var x Parameter
+			if err := DecodeParameter(&x, data); err != nil {
+				b.Fatalf("Parameter.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomParameter(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeParameter(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := EncodeParameter(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeParameter(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeParameter(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := EncodeParameter(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Parameter
+		if err := DecodeParameter(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripParameter(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := EncodeParameter(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeParameter(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Parameter
+		if err := DecodeParameter(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeParameterJSON(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeParameterJSON(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Parameter
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripParameterJSON(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Parameter
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValuePlugin returns the Plugin the benchmarks run on.
+func benchValuePlugin(b *testing.B) *Plugin {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Plugin.sdpb"))
+		if err == nil {
+			var x Plugin
+			if err := DecodePlugin(&x, data); err != nil {
+				b.Fatalf("Plugin.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomPlugin(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodePlugin(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := EncodePlugin(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodePlugin(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePlugin(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := EncodePlugin(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Plugin
+		if err := DecodePlugin(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPlugin(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := EncodePlugin(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodePlugin(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Plugin
+		if err := DecodePlugin(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodePluginJSON(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePluginJSON(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Plugin
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPluginJSON(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Plugin
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValuePluginRegistry returns the PluginRegistry the benchmarks run on.
+func benchValuePluginRegistry(b *testing.B) *PluginRegistry {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "PluginRegistry.sdpb"))
+		if err == nil {
+			var x PluginRegistry
+			if err := DecodePluginRegistry(&x, data); err != nil {
+				b.Fatalf("PluginRegistry.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomPluginRegistry(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodePluginRegistry(b *testing.B) {
+	x := benchValuePluginRegistry(b)
+	data, err := EncodePluginRegistry(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodePluginRegistry(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePluginRegistry(b *testing.B) {
+	x := benchValuePluginRegistry(b)
+	data, err := EncodePluginRegistry(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result PluginRegistry
+		if err := DecodePluginRegistry(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPluginRegistry(b *testing.B) {
+	x := benchValuePluginRegistry(b)
+	data, err := EncodePluginRegistry(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodePluginRegistry(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result PluginRegistry
+		if err := DecodePluginRegistry(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodePluginRegistryJSON(b *testing.B) {
+	x := benchValuePluginRegistry(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePluginRegistryJSON(b *testing.B) {
+	x := benchValuePluginRegistry(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result PluginRegistry
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPluginRegistryJSON(b *testing.B) {
+	x := benchValuePluginRegistry(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result PluginRegistry
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
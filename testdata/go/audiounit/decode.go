@@ -2,8 +2,9 @@ package audiounit
 
 import (
 	"encoding/binary"
-	"math"
 	"io"
+	"math"
+	"unicode/utf8"
 )
 
 // Size limit constants for decode validation
@@ -16,8 +17,14 @@ const (
 // DecodeContext tracks state during decoding to enforce size limits.
 // It maintains a count of total elements across all arrays to prevent
 // excessive memory allocation from malicious or corrupted data.
+// In strict mode it also rejects non-canonical encodings; in reuse mode
+// the decoder recycles the slices, structs and strings already in dest;
+// with an arena, strings and slices are carved from preallocated memory.
 type DecodeContext struct {
 	totalElements int
+	strict        bool
+	reuse         bool
+	arena         *decodeArena
 }
 
 // checkArraySize validates an array count against per-array and total limits.
@@ -36,6 +43,22 @@ func (ctx *DecodeContext) checkArraySize(count uint32) error {
 	return nil
 }
 
+// Canonical NaN bit patterns accepted by strict decoding
+const (
+	CanonicalNaN32 uint32 = 0x7FC00000
+	CanonicalNaN64 uint64 = 0x7FF8000000000000
+)
+
+// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.
+func isCanonicalF32(bits uint32) bool {
+	return bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32
+}
+
+// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.
+func isCanonicalF64(bits uint64) bool {
+	return bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64
+}
+
 
 // DecodeParameter decodes a Parameter from wire format.
 // It validates the data size and delegates to the decoder implementation.
@@ -48,6 +71,42 @@ func DecodeParameter(dest *Parameter, data []byte) error {
 	return decodeParameter(dest, data, &offset, ctx)
 }
 
+// DecodeParameterStrict decodes a Parameter like DecodeParameter, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeParameterStrict(dest *Parameter, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeParameter(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeParameterReuse decodes a Parameter into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeParameterReuse(dest *Parameter, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeParameter(dest, data, &offset, ctx)
+}
+
 // DecodePlugin decodes a Plugin from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodePlugin(dest *Plugin, data []byte) error {
@@ -59,6 +118,42 @@ func DecodePlugin(dest *Plugin, data []byte) error {
 	return decodePlugin(dest, data, &offset, ctx)
 }
 
+// DecodePluginStrict decodes a Plugin like DecodePlugin, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodePluginStrict(dest *Plugin, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodePlugin(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodePluginReuse decodes a Plugin into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodePluginReuse(dest *Plugin, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodePlugin(dest, data, &offset, ctx)
+}
+
 // DecodePluginRegistry decodes a PluginRegistry from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodePluginRegistry(dest *PluginRegistry, data []byte) error {
@@ -70,6 +165,42 @@ func DecodePluginRegistry(dest *PluginRegistry, data []byte) error {
 	return decodePluginRegistry(dest, data, &offset, ctx)
 }
 
+// DecodePluginRegistryStrict decodes a PluginRegistry like DecodePluginRegistry, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodePluginRegistryStrict(dest *PluginRegistry, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodePluginRegistry(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodePluginRegistryReuse decodes a PluginRegistry into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodePluginRegistryReuse(dest *PluginRegistry, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodePluginRegistry(dest, data, &offset, ctx)
+}
+
 
 // decodeParameter is the helper function that decodes Parameter fields.
 func decodeParameter(dest *Parameter, data []byte, offset *int, ctx *DecodeContext) error {
@@ -84,95 +215,134 @@ func decodeParameter(dest *Parameter, data []byte, offset *int, ctx *DecodeConte
 
 	// Field: Address (u64)
 	if *offset + 8 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "address", *offset)
 	}
 	dest.Address = binary.LittleEndian.Uint64(data[*offset:])
 	*offset += 8
 
 	// Field: DisplayName (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "display_name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "display_name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "display_name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.DisplayName = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.DisplayName != string(data[*offset:*offset+int(strLen)]) {
+		dest.DisplayName = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.DisplayName = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Identifier (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "identifier", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "identifier", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "identifier", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Identifier = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Identifier != string(data[*offset:*offset+int(strLen)]) {
+		dest.Identifier = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Identifier = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Unit (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "unit", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "unit", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "unit", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Unit = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Unit != string(data[*offset:*offset+int(strLen)]) {
+		dest.Unit = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Unit = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: MinValue (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "min_value", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "min_value", *offset)
 	}
 	dest.MinValue = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: MaxValue (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "max_value", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "max_value", *offset)
 	}
 	dest.MaxValue = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: DefaultValue (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "default_value", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "default_value", *offset)
 	}
 	dest.DefaultValue = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: CurrentValue (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "current_value", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "current_value", *offset)
 	}
 	dest.CurrentValue = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: RawFlags (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "raw_flags", *offset)
 	}
 	dest.RawFlags = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: IsWritable (bool)
 	if *offset + 1 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "is_writable", *offset)
+	}
+	if ctx.strict && data[*offset] > 1 {
+		return decodeError(ErrNonCanonical, "is_writable", *offset)
 	}
 	dest.IsWritable = data[*offset] != 0
 	*offset += 1
 
 	// Field: CanRamp (bool)
 	if *offset + 1 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "can_ramp", *offset)
+	}
+	if ctx.strict && data[*offset] > 1 {
+		return decodeError(ErrNonCanonical, "can_ramp", *offset)
 	}
 	dest.CanRamp = data[*offset] != 0
 	*offset += 1
@@ -193,73 +363,107 @@ func decodePlugin(dest *Plugin, data []byte, offset *int, ctx *DecodeContext) er
 
 	// Field: Name (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Name = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {
+		dest.Name = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Name = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: ManufacturerId (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "manufacturer_id", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "manufacturer_id", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "manufacturer_id", *offset)
+	}
+	if ctx.arena != nil {
+		dest.ManufacturerId = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.ManufacturerId != string(data[*offset:*offset+int(strLen)]) {
+		dest.ManufacturerId = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.ManufacturerId = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: ComponentType (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "component_type", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "component_type", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "component_type", *offset)
+	}
+	if ctx.arena != nil {
+		dest.ComponentType = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.ComponentType != string(data[*offset:*offset+int(strLen)]) {
+		dest.ComponentType = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.ComponentType = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: ComponentSubtype (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "component_subtype", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "component_subtype", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "component_subtype", *offset)
+	}
+	if ctx.arena != nil {
+		dest.ComponentSubtype = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.ComponentSubtype != string(data[*offset:*offset+int(strLen)]) {
+		dest.ComponentSubtype = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.ComponentSubtype = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Parameters ([]Parameter)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "parameters", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "parameters", *offset-4)
 	}
 
-	dest.Parameters = make([]Parameter, arrCount)
+	if ctx.arena != nil {
+		dest.Parameters = arenaTake(&ctx.arena.Parameter, arrCount)
+	} else if ctx.reuse && cap(dest.Parameters) >= int(arrCount) {
+		dest.Parameters = dest.Parameters[:arrCount]
+	} else {
+		dest.Parameters = make([]Parameter, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		err = decodeParameter(&dest.Parameters[i], data, offset, ctx)
 		if err != nil {
-			return err
+			return decodeError(decodeElementError(err, i, *offset), "parameters", *offset)
 		}
 	}
 
@@ -279,34 +483,40 @@ func decodePluginRegistry(dest *PluginRegistry, data []byte, offset *int, ctx *D
 
 	// Field: Plugins ([]Plugin)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "plugins", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "plugins", *offset-4)
 	}
 
-	dest.Plugins = make([]Plugin, arrCount)
+	if ctx.arena != nil {
+		dest.Plugins = arenaTake(&ctx.arena.Plugin, arrCount)
+	} else if ctx.reuse && cap(dest.Plugins) >= int(arrCount) {
+		dest.Plugins = dest.Plugins[:arrCount]
+	} else {
+		dest.Plugins = make([]Plugin, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		err = decodePlugin(&dest.Plugins[i], data, offset, ctx)
 		if err != nil {
-			return err
+			return decodeError(decodeElementError(err, i, *offset), "plugins", *offset)
 		}
 	}
 
 	// Field: TotalPluginCount (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "total_plugin_count", *offset)
 	}
 	dest.TotalPluginCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: TotalParameterCount (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "total_parameter_count", *offset)
 	}
 	dest.TotalParameterCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
@@ -317,7 +527,8 @@ func decodePluginRegistry(dest *PluginRegistry, data []byte, offset *int, ctx *D
 
 // DecodeParameterMessage decodes a Parameter from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeParameterMessage(data []byte) (*Parameter, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -342,6 +553,9 @@ func DecodeParameterMessage(data []byte) (*Parameter, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -352,18 +566,24 @@ func DecodeParameterMessage(data []byte) (*Parameter, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Parameter
-	if err := DecodeParameter(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeParameter(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodePluginMessage decodes a Plugin from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodePluginMessage(data []byte) (*Plugin, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -388,6 +608,9 @@ func DecodePluginMessage(data []byte) (*Plugin, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -398,18 +621,24 @@ func DecodePluginMessage(data []byte) (*Plugin, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Plugin
-	if err := DecodePlugin(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodePlugin(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodePluginRegistryMessage decodes a PluginRegistry from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodePluginRegistryMessage(data []byte) (*PluginRegistry, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -434,6 +663,9 @@ func DecodePluginRegistryMessage(data []byte) (*PluginRegistry, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -444,11 +676,16 @@ func DecodePluginRegistryMessage(data []byte) (*PluginRegistry, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result PluginRegistry
-	if err := DecodePluginRegistry(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodePluginRegistry(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
@@ -0,0 +1,309 @@
+package audiounit
+
+import (
+	"math"
+	"strconv"
+)
+
+// FieldChange is one field-level difference reported by Diff.
+type FieldChange struct {
+	// Path is the schema path of the field, e.g. "plugins[2].name".
+	Path string
+	// Kind is "value" if a primitive field differs, "length" if an array
+	// has a different number of elements and "presence" if an optional
+	// is set on one side only.
+	Kind string
+	// Old and New are the field values for "value", the element counts
+	// (int) for "length" and the presence flags (bool) for "presence".
+	Old any
+	New any
+}
+
+// diffPath joins a field name to the path of its struct.
+func diffPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Parameter) Equal(other *Parameter) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Address != other.Address {
+		return false
+	}
+	if x.DisplayName != other.DisplayName {
+		return false
+	}
+	if x.Identifier != other.Identifier {
+		return false
+	}
+	if x.Unit != other.Unit {
+		return false
+	}
+	if math.Float32bits(x.MinValue) != math.Float32bits(other.MinValue) {
+		return false
+	}
+	if math.Float32bits(x.MaxValue) != math.Float32bits(other.MaxValue) {
+		return false
+	}
+	if math.Float32bits(x.DefaultValue) != math.Float32bits(other.DefaultValue) {
+		return false
+	}
+	if math.Float32bits(x.CurrentValue) != math.Float32bits(other.CurrentValue) {
+		return false
+	}
+	if x.RawFlags != other.RawFlags {
+		return false
+	}
+	if x.IsWritable != other.IsWritable {
+		return false
+	}
+	if x.CanRamp != other.CanRamp {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Parameter) Clone() *Parameter {
+	if x == nil {
+		return nil
+	}
+	c := new(Parameter)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Parameter) cloneTo(c *Parameter) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Parameter) Diff(other *Parameter) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Parameter) diff(prefix string, other *Parameter, changes *[]FieldChange) {
+	if x.Address != other.Address {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "address"), Kind: "value", Old: x.Address, New: other.Address})
+	}
+	if x.DisplayName != other.DisplayName {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "display_name"), Kind: "value", Old: x.DisplayName, New: other.DisplayName})
+	}
+	if x.Identifier != other.Identifier {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "identifier"), Kind: "value", Old: x.Identifier, New: other.Identifier})
+	}
+	if x.Unit != other.Unit {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "unit"), Kind: "value", Old: x.Unit, New: other.Unit})
+	}
+	if math.Float32bits(x.MinValue) != math.Float32bits(other.MinValue) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "min_value"), Kind: "value", Old: x.MinValue, New: other.MinValue})
+	}
+	if math.Float32bits(x.MaxValue) != math.Float32bits(other.MaxValue) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "max_value"), Kind: "value", Old: x.MaxValue, New: other.MaxValue})
+	}
+	if math.Float32bits(x.DefaultValue) != math.Float32bits(other.DefaultValue) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "default_value"), Kind: "value", Old: x.DefaultValue, New: other.DefaultValue})
+	}
+	if math.Float32bits(x.CurrentValue) != math.Float32bits(other.CurrentValue) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "current_value"), Kind: "value", Old: x.CurrentValue, New: other.CurrentValue})
+	}
+	if x.RawFlags != other.RawFlags {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "raw_flags"), Kind: "value", Old: x.RawFlags, New: other.RawFlags})
+	}
+	if x.IsWritable != other.IsWritable {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "is_writable"), Kind: "value", Old: x.IsWritable, New: other.IsWritable})
+	}
+	if x.CanRamp != other.CanRamp {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "can_ramp"), Kind: "value", Old: x.CanRamp, New: other.CanRamp})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Plugin) Equal(other *Plugin) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Name != other.Name {
+		return false
+	}
+	if x.ManufacturerId != other.ManufacturerId {
+		return false
+	}
+	if x.ComponentType != other.ComponentType {
+		return false
+	}
+	if x.ComponentSubtype != other.ComponentSubtype {
+		return false
+	}
+	if len(x.Parameters) != len(other.Parameters) {
+		return false
+	}
+	for i := range x.Parameters {
+		if !x.Parameters[i].Equal(&other.Parameters[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Plugin) Clone() *Plugin {
+	if x == nil {
+		return nil
+	}
+	c := new(Plugin)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Plugin) cloneTo(c *Plugin) {
+	*c = *x
+	if x.Parameters != nil {
+		c.Parameters = make([]Parameter, len(x.Parameters))
+		for i := range x.Parameters {
+			x.Parameters[i].cloneTo(&c.Parameters[i])
+		}
+	}
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Plugin) Diff(other *Plugin) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Plugin) diff(prefix string, other *Plugin, changes *[]FieldChange) {
+	if x.Name != other.Name {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "name"), Kind: "value", Old: x.Name, New: other.Name})
+	}
+	if x.ManufacturerId != other.ManufacturerId {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "manufacturer_id"), Kind: "value", Old: x.ManufacturerId, New: other.ManufacturerId})
+	}
+	if x.ComponentType != other.ComponentType {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "component_type"), Kind: "value", Old: x.ComponentType, New: other.ComponentType})
+	}
+	if x.ComponentSubtype != other.ComponentSubtype {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "component_subtype"), Kind: "value", Old: x.ComponentSubtype, New: other.ComponentSubtype})
+	}
+	{
+		path := diffPath(prefix, "parameters")
+		if len(x.Parameters) != len(other.Parameters) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.Parameters), New: len(other.Parameters)})
+		}
+		for i := 0; i < len(x.Parameters) && i < len(other.Parameters); i++ {
+			x.Parameters[i].diff(path+"["+strconv.Itoa(i)+"]", &other.Parameters[i], changes)
+		}
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *PluginRegistry) Equal(other *PluginRegistry) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if len(x.Plugins) != len(other.Plugins) {
+		return false
+	}
+	for i := range x.Plugins {
+		if !x.Plugins[i].Equal(&other.Plugins[i]) {
+			return false
+		}
+	}
+	if x.TotalPluginCount != other.TotalPluginCount {
+		return false
+	}
+	if x.TotalParameterCount != other.TotalParameterCount {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *PluginRegistry) Clone() *PluginRegistry {
+	if x == nil {
+		return nil
+	}
+	c := new(PluginRegistry)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *PluginRegistry) cloneTo(c *PluginRegistry) {
+	*c = *x
+	if x.Plugins != nil {
+		c.Plugins = make([]Plugin, len(x.Plugins))
+		for i := range x.Plugins {
+			x.Plugins[i].cloneTo(&c.Plugins[i])
+		}
+	}
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *PluginRegistry) Diff(other *PluginRegistry) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *PluginRegistry) diff(prefix string, other *PluginRegistry, changes *[]FieldChange) {
+	{
+		path := diffPath(prefix, "plugins")
+		if len(x.Plugins) != len(other.Plugins) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.Plugins), New: len(other.Plugins)})
+		}
+		for i := 0; i < len(x.Plugins) && i < len(other.Plugins); i++ {
+			x.Plugins[i].diff(path+"["+strconv.Itoa(i)+"]", &other.Plugins[i], changes)
+		}
+	}
+	if x.TotalPluginCount != other.TotalPluginCount {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "total_plugin_count"), Kind: "value", Old: x.TotalPluginCount, New: other.TotalPluginCount})
+	}
+	if x.TotalParameterCount != other.TotalParameterCount {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "total_parameter_count"), Kind: "value", Old: x.TotalParameterCount, New: other.TotalParameterCount})
+	}
+}
+
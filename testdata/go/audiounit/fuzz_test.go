@@ -0,0 +1,115 @@
+package audiounit
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzDecodeParameter checks that DecodeParameter never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeParameter(f *testing.F) {
+	for _, seed := range []*Parameter{
+		{},
+		{Address: 1, DisplayName: "sample", Identifier: "sample", Unit: "sample", MinValue: 1.5, MaxValue: 1.5, DefaultValue: 1.5, CurrentValue: 1.5, RawFlags: 1, IsWritable: true, CanRamp: true},
+	} {
+		data, err := EncodeParameter(seed)
+		if err != nil {
+			f.Fatalf("EncodeParameter(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Parameter
+		if err := DecodeParameter(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeParameter(&x)
+		if err != nil {
+			t.Fatalf("EncodeParameter of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Parameter
+		if err := DecodeParameterStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodePlugin checks that DecodePlugin never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodePlugin(f *testing.F) {
+	for _, seed := range []*Plugin{
+		{},
+		{Name: "sample", ManufacturerId: "sample", ComponentType: "sample", ComponentSubtype: "sample", Parameters: []Parameter{{Address: 1, DisplayName: "sample", Identifier: "sample", Unit: "sample", MinValue: 1.5, MaxValue: 1.5, DefaultValue: 1.5, CurrentValue: 1.5, RawFlags: 1, IsWritable: true, CanRamp: true}}},
+	} {
+		data, err := EncodePlugin(seed)
+		if err != nil {
+			f.Fatalf("EncodePlugin(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Plugin
+		if err := DecodePlugin(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodePlugin(&x)
+		if err != nil {
+			t.Fatalf("EncodePlugin of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Plugin
+		if err := DecodePluginStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodePluginRegistry checks that DecodePluginRegistry never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodePluginRegistry(f *testing.F) {
+	for _, seed := range []*PluginRegistry{
+		{},
+		{Plugins: []Plugin{{Name: "sample", ManufacturerId: "sample", ComponentType: "sample", ComponentSubtype: "sample", Parameters: []Parameter{{Address: 1, DisplayName: "sample", Identifier: "sample", Unit: "sample", MinValue: 1.5, MaxValue: 1.5, DefaultValue: 1.5, CurrentValue: 1.5, RawFlags: 1, IsWritable: true, CanRamp: true}}}}, TotalPluginCount: 1, TotalParameterCount: 1},
+	} {
+		data, err := EncodePluginRegistry(seed)
+		if err != nil {
+			f.Fatalf("EncodePluginRegistry(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x PluginRegistry
+		if err := DecodePluginRegistry(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodePluginRegistry(&x)
+		if err != nil {
+			t.Fatalf("EncodePluginRegistry of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict PluginRegistry
+		if err := DecodePluginRegistryStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
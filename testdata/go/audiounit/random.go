@@ -0,0 +1,138 @@
+package audiounit
+
+import (
+	"math/rand/v2"
+)
+
+// RandomOptions controls the values generated by the Random constructors.
+// A zero field selects its default, so a nil or partially set *RandomOptions
+// gives OptionalProbability 0.5, arrays of 0 to 4 elements and strings of up
+// to 16 ASCII letters and digits unless overridden. A negative
+// OptionalProbability, MaxArrayLen or MaxStringLen asks for absent optionals,
+// arrays of MinArrayLen elements or empty strings.
+type RandomOptions struct {
+	// OptionalProbability is the chance that an optional field is present.
+	OptionalProbability float64
+
+	// MinArrayLen and MaxArrayLen bound the length of every array.
+	MinArrayLen int
+	MaxArrayLen int
+
+	// MaxStringLen bounds the length of every string in runes.
+	MaxStringLen int
+
+	// Charset holds the runes strings are drawn from. Empty means ASCII
+	// letters and digits.
+	Charset string
+}
+
+var defaultRandomOptions = RandomOptions{OptionalProbability: 0.5, MaxArrayLen: 4, MaxStringLen: 16}
+
+const randomCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// withDefaults returns a copy of opts with zero fields set to their defaults.
+func (opts *RandomOptions) withDefaults() *RandomOptions {
+	o := defaultRandomOptions
+	if opts == nil {
+		return &o
+	}
+	if opts.OptionalProbability != 0 {
+		o.OptionalProbability = opts.OptionalProbability
+	}
+	o.MinArrayLen = opts.MinArrayLen
+	if opts.MaxArrayLen != 0 {
+		o.MaxArrayLen = opts.MaxArrayLen
+	}
+	if opts.MaxStringLen != 0 {
+		o.MaxStringLen = opts.MaxStringLen
+	}
+	o.Charset = opts.Charset
+	return &o
+}
+
+// randomLen returns an array length between opts.MinArrayLen and opts.MaxArrayLen.
+func randomLen(r *rand.Rand, opts *RandomOptions) int {
+	n := max(opts.MinArrayLen, 0)
+	if opts.MaxArrayLen > n {
+		n += r.IntN(opts.MaxArrayLen - n + 1)
+	}
+	return n
+}
+
+// randomString returns a string of up to opts.MaxStringLen runes from opts.Charset.
+func randomString(r *rand.Rand, opts *RandomOptions) string {
+	if opts.MaxStringLen <= 0 {
+		return ""
+	}
+	charset := []rune(opts.Charset)
+	if len(charset) == 0 {
+		charset = []rune(randomCharset)
+	}
+	s := make([]rune, r.IntN(opts.MaxStringLen+1))
+	for i := range s {
+		s[i] = charset[r.IntN(len(charset))]
+	}
+	return string(s)
+}
+
+// RandomParameter returns a Parameter with every field drawn from r. Floats are finite.
+func RandomParameter(r *rand.Rand, opts *RandomOptions) *Parameter {
+	opts = opts.withDefaults()
+	x := new(Parameter)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Parameter) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Address = r.Uint64()
+	x.DisplayName = randomString(r, opts)
+	x.Identifier = randomString(r, opts)
+	x.Unit = randomString(r, opts)
+	x.MinValue = float32(r.NormFloat64() * 1000)
+	x.MaxValue = float32(r.NormFloat64() * 1000)
+	x.DefaultValue = float32(r.NormFloat64() * 1000)
+	x.CurrentValue = float32(r.NormFloat64() * 1000)
+	x.RawFlags = r.Uint32()
+	x.IsWritable = r.IntN(2) == 1
+	x.CanRamp = r.IntN(2) == 1
+}
+
+// RandomPlugin returns a Plugin with every field drawn from r. Floats are finite.
+func RandomPlugin(r *rand.Rand, opts *RandomOptions) *Plugin {
+	opts = opts.withDefaults()
+	x := new(Plugin)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Plugin) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Name = randomString(r, opts)
+	x.ManufacturerId = randomString(r, opts)
+	x.ComponentType = randomString(r, opts)
+	x.ComponentSubtype = randomString(r, opts)
+	x.Parameters = make([]Parameter, randomLen(r, opts))
+	for i := range x.Parameters {
+		x.Parameters[i].randomize(r, opts)
+	}
+}
+
+// RandomPluginRegistry returns a PluginRegistry with every field drawn from r. Floats are finite.
+func RandomPluginRegistry(r *rand.Rand, opts *RandomOptions) *PluginRegistry {
+	opts = opts.withDefaults()
+	x := new(PluginRegistry)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *PluginRegistry) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Plugins = make([]Plugin, randomLen(r, opts))
+	for i := range x.Plugins {
+		x.Plugins[i].randomize(r, opts)
+	}
+	x.TotalPluginCount = r.Uint32()
+	x.TotalParameterCount = r.Uint32()
+}
+
@@ -0,0 +1,199 @@
+package audiounit
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+)
+
+// stringMaxElems is the number of array elements String and LogValue show.
+const stringMaxElems = 16
+
+// stringMaxBytes is the number of []u8 bytes String and LogValue show.
+const stringMaxBytes = 32
+
+// stringRedacted replaces the value of fields marked @redact.
+const stringRedacted = "[REDACTED]"
+
+// appendStringMore appends the number of elements left out of a truncated array.
+func appendStringMore(b []byte, n int) []byte {
+	b = append(b, "... (+"...)
+	b = strconv.AppendInt(b, int64(n), 10)
+	return append(b, " more)"...)
+}
+
+// appendStringBytes appends a []u8 in hex.
+func appendStringBytes(b, v []byte) []byte {
+	b = append(b, "0x"...)
+	if len(v) <= stringMaxBytes {
+		return hex.AppendEncode(b, v)
+	}
+	b = hex.AppendEncode(b, v[:stringMaxBytes])
+	return appendStringMore(b, len(v)-stringMaxBytes)
+}
+
+// logValueElems returns the first stringMaxElems elements of an array as a
+// group keyed by index.
+func logValueElems[T any](elems []T, value func(*T) slog.Value) slog.Value {
+	attrs := make([]slog.Attr, 0, min(len(elems), stringMaxElems+1))
+	for i := range elems {
+		if i == stringMaxElems {
+			attrs = append(attrs, slog.Int("more", len(elems)-i))
+			break
+		}
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: value(&elems[i])})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Parameter) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Parameter) appendString(b []byte) []byte {
+	b = append(b, "Parameter{"...)
+	b = append(b, "address: "...)
+	b = strconv.AppendUint(b, x.Address, 10)
+	b = append(b, ", display_name: "...)
+	b = strconv.AppendQuote(b, x.DisplayName)
+	b = append(b, ", identifier: "...)
+	b = strconv.AppendQuote(b, x.Identifier)
+	b = append(b, ", unit: "...)
+	b = strconv.AppendQuote(b, x.Unit)
+	b = append(b, ", min_value: "...)
+	b = strconv.AppendFloat(b, float64(x.MinValue), 'g', -1, 32)
+	b = append(b, ", max_value: "...)
+	b = strconv.AppendFloat(b, float64(x.MaxValue), 'g', -1, 32)
+	b = append(b, ", default_value: "...)
+	b = strconv.AppendFloat(b, float64(x.DefaultValue), 'g', -1, 32)
+	b = append(b, ", current_value: "...)
+	b = strconv.AppendFloat(b, float64(x.CurrentValue), 'g', -1, 32)
+	b = append(b, ", raw_flags: "...)
+	b = strconv.AppendUint(b, uint64(x.RawFlags), 10)
+	b = append(b, ", is_writable: "...)
+	b = strconv.AppendBool(b, x.IsWritable)
+	b = append(b, ", can_ramp: "...)
+	b = strconv.AppendBool(b, x.CanRamp)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Parameter) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 11)
+	attrs = append(attrs, slog.Uint64("address", x.Address))
+	attrs = append(attrs, slog.String("display_name", x.DisplayName))
+	attrs = append(attrs, slog.String("identifier", x.Identifier))
+	attrs = append(attrs, slog.String("unit", x.Unit))
+	attrs = append(attrs, slog.Float64("min_value", float64(x.MinValue)))
+	attrs = append(attrs, slog.Float64("max_value", float64(x.MaxValue)))
+	attrs = append(attrs, slog.Float64("default_value", float64(x.DefaultValue)))
+	attrs = append(attrs, slog.Float64("current_value", float64(x.CurrentValue)))
+	attrs = append(attrs, slog.Uint64("raw_flags", uint64(x.RawFlags)))
+	attrs = append(attrs, slog.Bool("is_writable", x.IsWritable))
+	attrs = append(attrs, slog.Bool("can_ramp", x.CanRamp))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Plugin) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Plugin) appendString(b []byte) []byte {
+	b = append(b, "Plugin{"...)
+	b = append(b, "name: "...)
+	b = strconv.AppendQuote(b, x.Name)
+	b = append(b, ", manufacturer_id: "...)
+	b = strconv.AppendQuote(b, x.ManufacturerId)
+	b = append(b, ", component_type: "...)
+	b = strconv.AppendQuote(b, x.ComponentType)
+	b = append(b, ", component_subtype: "...)
+	b = strconv.AppendQuote(b, x.ComponentSubtype)
+	b = append(b, ", parameters: "...)
+	b = append(b, '[')
+	for i := range x.Parameters {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.Parameters)-i)
+			break
+		}
+		b = x.Parameters[i].appendString(b)
+	}
+	b = append(b, ']')
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Plugin) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 5)
+	attrs = append(attrs, slog.String("name", x.Name))
+	attrs = append(attrs, slog.String("manufacturer_id", x.ManufacturerId))
+	attrs = append(attrs, slog.String("component_type", x.ComponentType))
+	attrs = append(attrs, slog.String("component_subtype", x.ComponentSubtype))
+	attrs = append(attrs, slog.Attr{Key: "parameters", Value: logValueElems(x.Parameters, (*Parameter).LogValue)})
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *PluginRegistry) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *PluginRegistry) appendString(b []byte) []byte {
+	b = append(b, "PluginRegistry{"...)
+	b = append(b, "plugins: "...)
+	b = append(b, '[')
+	for i := range x.Plugins {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.Plugins)-i)
+			break
+		}
+		b = x.Plugins[i].appendString(b)
+	}
+	b = append(b, ']')
+	b = append(b, ", total_plugin_count: "...)
+	b = strconv.AppendUint(b, uint64(x.TotalPluginCount), 10)
+	b = append(b, ", total_parameter_count: "...)
+	b = strconv.AppendUint(b, uint64(x.TotalParameterCount), 10)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *PluginRegistry) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 3)
+	attrs = append(attrs, slog.Attr{Key: "plugins", Value: logValueElems(x.Plugins, (*Plugin).LogValue)})
+	attrs = append(attrs, slog.Uint64("total_plugin_count", uint64(x.TotalPluginCount)))
+	attrs = append(attrs, slog.Uint64("total_parameter_count", uint64(x.TotalParameterCount)))
+	return slog.GroupValue(attrs...)
+}
+
@@ -1,29 +1,181 @@
 package audiounit
 
 type Parameter struct {
-	Address uint64
-	DisplayName string
-	Identifier string
-	Unit string
-	MinValue float32
-	MaxValue float32
-	DefaultValue float32
-	CurrentValue float32
-	RawFlags uint32
-	IsWritable bool
-	CanRamp bool
+	Address uint64 `json:"address,string"`
+	DisplayName string `json:"display_name"`
+	Identifier string `json:"identifier"`
+	Unit string `json:"unit"`
+	MinValue float32 `json:"min_value"`
+	MaxValue float32 `json:"max_value"`
+	DefaultValue float32 `json:"default_value"`
+	CurrentValue float32 `json:"current_value"`
+	RawFlags uint32 `json:"raw_flags"`
+	IsWritable bool `json:"is_writable"`
+	CanRamp bool `json:"can_ramp"`
 }
 
 type Plugin struct {
-	Name string
-	ManufacturerId string
-	ComponentType string
-	ComponentSubtype string
-	Parameters []Parameter
+	Name string `json:"name"`
+	ManufacturerId string `json:"manufacturer_id"`
+	ComponentType string `json:"component_type"`
+	ComponentSubtype string `json:"component_subtype"`
+	Parameters []Parameter `json:"parameters,omitempty"`
 }
 
 type PluginRegistry struct {
-	Plugins []Plugin
-	TotalPluginCount uint32
-	TotalParameterCount uint32
+	Plugins []Plugin `json:"plugins,omitempty"`
+	TotalPluginCount uint32 `json:"total_plugin_count"`
+	TotalParameterCount uint32 `json:"total_parameter_count"`
+}
+
+// GetAddress returns address, or the zero value if x is nil.
+func (x *Parameter) GetAddress() uint64 {
+	if x != nil {
+		return x.Address
+	}
+	return 0
+}
+
+// GetDisplayName returns display_name, or the zero value if x is nil.
+func (x *Parameter) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+// GetIdentifier returns identifier, or the zero value if x is nil.
+func (x *Parameter) GetIdentifier() string {
+	if x != nil {
+		return x.Identifier
+	}
+	return ""
+}
+
+// GetUnit returns unit, or the zero value if x is nil.
+func (x *Parameter) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+// GetMinValue returns min_value, or the zero value if x is nil.
+func (x *Parameter) GetMinValue() float32 {
+	if x != nil {
+		return x.MinValue
+	}
+	return 0
+}
+
+// GetMaxValue returns max_value, or the zero value if x is nil.
+func (x *Parameter) GetMaxValue() float32 {
+	if x != nil {
+		return x.MaxValue
+	}
+	return 0
+}
+
+// GetDefaultValue returns default_value, or the zero value if x is nil.
+func (x *Parameter) GetDefaultValue() float32 {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return 0
+}
+
+// GetCurrentValue returns current_value, or the zero value if x is nil.
+func (x *Parameter) GetCurrentValue() float32 {
+	if x != nil {
+		return x.CurrentValue
+	}
+	return 0
+}
+
+// GetRawFlags returns raw_flags, or the zero value if x is nil.
+func (x *Parameter) GetRawFlags() uint32 {
+	if x != nil {
+		return x.RawFlags
+	}
+	return 0
+}
+
+// GetIsWritable returns is_writable, or the zero value if x is nil.
+func (x *Parameter) GetIsWritable() bool {
+	if x != nil {
+		return x.IsWritable
+	}
+	return false
+}
+
+// GetCanRamp returns can_ramp, or the zero value if x is nil.
+func (x *Parameter) GetCanRamp() bool {
+	if x != nil {
+		return x.CanRamp
+	}
+	return false
+}
+
+// GetName returns name, or the zero value if x is nil.
+func (x *Plugin) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetManufacturerId returns manufacturer_id, or the zero value if x is nil.
+func (x *Plugin) GetManufacturerId() string {
+	if x != nil {
+		return x.ManufacturerId
+	}
+	return ""
+}
+
+// GetComponentType returns component_type, or the zero value if x is nil.
+func (x *Plugin) GetComponentType() string {
+	if x != nil {
+		return x.ComponentType
+	}
+	return ""
+}
+
+// GetComponentSubtype returns component_subtype, or the zero value if x is nil.
+func (x *Plugin) GetComponentSubtype() string {
+	if x != nil {
+		return x.ComponentSubtype
+	}
+	return ""
+}
+
+// GetParameters returns parameters, or the zero value if x is nil.
+func (x *Plugin) GetParameters() []Parameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// GetPlugins returns plugins, or the zero value if x is nil.
+func (x *PluginRegistry) GetPlugins() []Plugin {
+	if x != nil {
+		return x.Plugins
+	}
+	return nil
+}
+
+// GetTotalPluginCount returns total_plugin_count, or the zero value if x is nil.
+func (x *PluginRegistry) GetTotalPluginCount() uint32 {
+	if x != nil {
+		return x.TotalPluginCount
+	}
+	return 0
+}
+
+// GetTotalParameterCount returns total_parameter_count, or the zero value if x is nil.
+func (x *PluginRegistry) GetTotalParameterCount() uint32 {
+	if x != nil {
+		return x.TotalParameterCount
+	}
+	return 0
 }
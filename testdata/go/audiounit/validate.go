@@ -0,0 +1,338 @@
+package audiounit
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// ValidateParameter checks that data starts with a well-formed Parameter without decoding it.
+// It accepts the input DecodeParameter accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateParameter(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateParameter(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidatePlugin checks that data starts with a well-formed Plugin without decoding it.
+// It accepts the input DecodePlugin accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidatePlugin(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validatePlugin(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidatePluginRegistry checks that data starts with a well-formed PluginRegistry without decoding it.
+// It accepts the input DecodePluginRegistry accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidatePluginRegistry(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validatePluginRegistry(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// validateParameter advances offset over one Parameter value.
+func validateParameter(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: address
+	if *offset + 8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "address", *offset)
+	}
+	*offset += 8
+
+	// Field: display_name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "display_name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "display_name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "display_name", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: identifier
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "identifier", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "identifier", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "identifier", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: unit
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "unit", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "unit", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "unit", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: min_value
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "min_value", *offset)
+	}
+	*offset += 4
+
+	// Field: max_value
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "max_value", *offset)
+	}
+	*offset += 4
+
+	// Field: default_value
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "default_value", *offset)
+	}
+	*offset += 4
+
+	// Field: current_value
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "current_value", *offset)
+	}
+	*offset += 4
+
+	// Field: raw_flags
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "raw_flags", *offset)
+	}
+	*offset += 4
+
+	// Field: is_writable
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "is_writable", *offset)
+	}
+	*offset += 1
+
+	// Field: can_ramp
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "can_ramp", *offset)
+	}
+	*offset += 1
+
+	return nil
+}
+
+// validatePlugin advances offset over one Plugin value.
+func validatePlugin(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: manufacturer_id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "manufacturer_id", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "manufacturer_id", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "manufacturer_id", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: component_type
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "component_type", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "component_type", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "component_type", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: component_subtype
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "component_subtype", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "component_subtype", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "component_subtype", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: parameters
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "parameters", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "parameters", *offset-4)
+	}
+	for i := uint32(0); i < arrCount; i++ {
+		err = validateParameter(data, offset, ctx)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "parameters", *offset)
+		}
+	}
+
+	return nil
+}
+
+// validatePluginRegistry advances offset over one PluginRegistry value.
+func validatePluginRegistry(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: plugins
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "plugins", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "plugins", *offset-4)
+	}
+	for i := uint32(0); i < arrCount; i++ {
+		err = validatePlugin(data, offset, ctx)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "plugins", *offset)
+		}
+	}
+
+	// Field: total_plugin_count
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "total_plugin_count", *offset)
+	}
+	*offset += 4
+
+	// Field: total_parameter_count
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "total_parameter_count", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// ValidateMessage checks that data starts with a well-formed self-describing
+// message of any type in this schema, without decoding it. The payload must
+// hold exactly one value of the type; ErrTrailingBytes is returned if it
+// extends past the value. It returns the total message length
+// (header + payload).
+func ValidateMessage(data []byte) (int, error) {
+	if len(data) < MessageHeaderSize {
+		return 0, ErrUnexpectedEOF
+	}
+	if string(data[0:3]) != MessageMagic {
+		return 0, ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return 0, ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	end := MessageHeaderSize + int(payloadLength)
+	if len(data) < end {
+		return 0, ErrUnexpectedEOF
+	}
+	payload := data[MessageHeaderSize:end]
+
+	var (
+		n   int
+		err error
+	)
+	switch typeID {
+	case 1:
+		n, err = ValidateParameter(payload)
+	case 2:
+		n, err = ValidatePlugin(payload)
+	case 3:
+		n, err = ValidatePluginRegistry(payload)
+	default:
+		return 0, ErrUnknownMessageType
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n != len(payload) {
+		return 0, ErrTrailingBytes
+	}
+	return end, nil
+}
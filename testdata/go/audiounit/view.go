@@ -0,0 +1,330 @@
+package audiounit
+
+import (
+	"encoding/binary"
+	"iter"
+	"math"
+	"unsafe"
+)
+
+// ArrayView is a read-only view of an encoded array. Elements are read
+// from the underlying buffer on access; nothing is copied up front.
+type ArrayView[T any] struct {
+	data []byte
+	off  int  // Offset of the first element
+	n    int  // Element count
+	size int  // Element size, or 0 for variable-size elements
+	read func(data []byte, off int) T
+	skip func(data []byte, off int) int
+}
+
+// newArrayView creates an ArrayView over the array whose count prefix is at off.
+func newArrayView[T any](data []byte, off int, size int, read func([]byte, int) T, skip func([]byte, int) int) ArrayView[T] {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	return ArrayView[T]{data: data, off: off + 4, n: n, size: size, read: read, skip: skip}
+}
+
+// Len returns the number of elements.
+func (a ArrayView[T]) Len() int {
+	return a.n
+}
+
+// At returns element i. Fixed-size elements are located directly;
+// variable-size elements (strings, structs) are found by skipping the
+// preceding ones, so use All to visit every element. At panics if i is
+// out of range.
+func (a ArrayView[T]) At(i int) T {
+	if i < 0 || i >= a.n {
+		panic("ArrayView: index out of range")
+	}
+	if a.size > 0 {
+		return a.read(a.data, a.off+i*a.size)
+	}
+	off := a.off
+	for ; i > 0; i-- {
+		off = a.skip(a.data, off)
+	}
+	return a.read(a.data, off)
+}
+
+// All returns an iterator over the index and value of every element.
+func (a ArrayView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		off := a.off
+		for i := 0; i < a.n; i++ {
+			if !yield(i, a.read(a.data, off)) {
+				return
+			}
+			if a.size > 0 {
+				off += a.size
+			} else {
+				off = a.skip(a.data, off)
+			}
+		}
+	}
+}
+
+// viewString returns the length-prefixed string at off without copying.
+func viewString(data []byte, off int) string {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	if n == 0 {
+		return ""
+	}
+	return unsafe.String(&data[off+4], n)
+}
+
+// viewSkipString returns the offset just past the string at off.
+func viewSkipString(data []byte, off int) int {
+	return off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+}
+
+// viewBytes returns the []u8 array at off as a sub-slice of data.
+// Its capacity is clipped so appending to it cannot overwrite data.
+func viewBytes(data []byte, off int) []byte {
+	end := off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+	return data[off+4 : end : end]
+}
+
+// viewSkipArray returns the offset just past the array of variable-size
+// elements at off, using skip to step over each element.
+func viewSkipArray(data []byte, off int, skip func([]byte, int) int) int {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	for i := 0; i < n; i++ {
+		off = skip(data, off)
+	}
+	return off
+}
+
+// ParameterView is a read-only, zero-copy view of an encoded Parameter.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type ParameterView struct {
+	data []byte
+}
+
+// NewParameterView validates data with ValidateParameter and returns a view over it.
+func NewParameterView(data []byte) (ParameterView, error) {
+	if _, err := ValidateParameter(data); err != nil {
+		return ParameterView{}, err
+	}
+	return ParameterView{data: data}, nil
+}
+
+// Decode materializes the full Parameter value.
+func (v ParameterView) Decode(dest *Parameter) error {
+	return DecodeParameter(dest, v.data)
+}
+
+// Address returns the address field.
+func (v ParameterView) Address() uint64 {
+	return binary.LittleEndian.Uint64(v.data[0:])
+}
+
+// DisplayName returns the display_name field.
+func (v ParameterView) DisplayName() string {
+	return viewString(v.data, 8)
+}
+
+// Identifier returns the identifier field.
+func (v ParameterView) Identifier() string {
+	off := 8
+	off = viewSkipString(v.data, off)
+	return viewString(v.data, off)
+}
+
+// Unit returns the unit field.
+func (v ParameterView) Unit() string {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return viewString(v.data, off)
+}
+
+// MinValue returns the min_value field.
+func (v ParameterView) MinValue() float32 {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[off:]))
+}
+
+// MaxValue returns the max_value field.
+func (v ParameterView) MaxValue() float32 {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[off+4:]))
+}
+
+// DefaultValue returns the default_value field.
+func (v ParameterView) DefaultValue() float32 {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[off+8:]))
+}
+
+// CurrentValue returns the current_value field.
+func (v ParameterView) CurrentValue() float32 {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[off+12:]))
+}
+
+// RawFlags returns the raw_flags field.
+func (v ParameterView) RawFlags() uint32 {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint32(v.data[off+16:])
+}
+
+// IsWritable returns the is_writable field.
+func (v ParameterView) IsWritable() bool {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return v.data[off+20] != 0
+}
+
+// CanRamp returns the can_ramp field.
+func (v ParameterView) CanRamp() bool {
+	off := 8
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return v.data[off+21] != 0
+}
+
+// skipParameter returns the offset just past the Parameter value starting at off.
+func skipParameter(data []byte, off int) int {
+	off += 8
+	off = viewSkipString(data, off)
+	off = viewSkipString(data, off)
+	off = viewSkipString(data, off)
+	off += 22
+	return off
+}
+
+// PluginView is a read-only, zero-copy view of an encoded Plugin.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type PluginView struct {
+	data []byte
+}
+
+// NewPluginView validates data with ValidatePlugin and returns a view over it.
+func NewPluginView(data []byte) (PluginView, error) {
+	if _, err := ValidatePlugin(data); err != nil {
+		return PluginView{}, err
+	}
+	return PluginView{data: data}, nil
+}
+
+// Decode materializes the full Plugin value.
+func (v PluginView) Decode(dest *Plugin) error {
+	return DecodePlugin(dest, v.data)
+}
+
+// Name returns the name field.
+func (v PluginView) Name() string {
+	return viewString(v.data, 0)
+}
+
+// ManufacturerId returns the manufacturer_id field.
+func (v PluginView) ManufacturerId() string {
+	off := 0
+	off = viewSkipString(v.data, off)
+	return viewString(v.data, off)
+}
+
+// ComponentType returns the component_type field.
+func (v PluginView) ComponentType() string {
+	off := 0
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return viewString(v.data, off)
+}
+
+// ComponentSubtype returns the component_subtype field.
+func (v PluginView) ComponentSubtype() string {
+	off := 0
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return viewString(v.data, off)
+}
+
+// Parameters returns the parameters field.
+func (v PluginView) Parameters() ArrayView[ParameterView] {
+	off := 0
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return newArrayView(v.data, off, 0, func(data []byte, off int) ParameterView { return ParameterView{data: data[off:]} }, skipParameter)
+}
+
+// skipPlugin returns the offset just past the Plugin value starting at off.
+func skipPlugin(data []byte, off int) int {
+	off = viewSkipString(data, off)
+	off = viewSkipString(data, off)
+	off = viewSkipString(data, off)
+	off = viewSkipString(data, off)
+	off = viewSkipArray(data, off, skipParameter)
+	return off
+}
+
+// PluginRegistryView is a read-only, zero-copy view of an encoded PluginRegistry.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type PluginRegistryView struct {
+	data []byte
+}
+
+// NewPluginRegistryView validates data with ValidatePluginRegistry and returns a view over it.
+func NewPluginRegistryView(data []byte) (PluginRegistryView, error) {
+	if _, err := ValidatePluginRegistry(data); err != nil {
+		return PluginRegistryView{}, err
+	}
+	return PluginRegistryView{data: data}, nil
+}
+
+// Decode materializes the full PluginRegistry value.
+func (v PluginRegistryView) Decode(dest *PluginRegistry) error {
+	return DecodePluginRegistry(dest, v.data)
+}
+
+// Plugins returns the plugins field.
+func (v PluginRegistryView) Plugins() ArrayView[PluginView] {
+	return newArrayView(v.data, 0, 0, func(data []byte, off int) PluginView { return PluginView{data: data[off:]} }, skipPlugin)
+}
+
+// TotalPluginCount returns the total_plugin_count field.
+func (v PluginRegistryView) TotalPluginCount() uint32 {
+	off := 0
+	off = viewSkipArray(v.data, off, skipPlugin)
+	return binary.LittleEndian.Uint32(v.data[off:])
+}
+
+// TotalParameterCount returns the total_parameter_count field.
+func (v PluginRegistryView) TotalParameterCount() uint32 {
+	off := 0
+	off = viewSkipArray(v.data, off, skipPlugin)
+	return binary.LittleEndian.Uint32(v.data[off+4:])
+}
+
+// skipPluginRegistry returns the offset just past the PluginRegistry value starting at off.
+func skipPluginRegistry(data []byte, off int) int {
+	off = viewSkipArray(data, off, skipPlugin)
+	off += 8
+	return off
+}
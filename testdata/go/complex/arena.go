@@ -0,0 +1,351 @@
+package complex
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// DecodeParameterArena decodes a Parameter like DecodeParameter, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeParameterArena(dest *Parameter, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureParameter(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeParameter(dest, data, &offset, ctx)
+}
+
+// DecodePluginArena decodes a Plugin like DecodePlugin, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodePluginArena(dest *Plugin, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measurePlugin(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodePlugin(dest, data, &offset, ctx)
+}
+
+// DecodeAudioDeviceArena decodes a AudioDevice like DecodeAudioDevice, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeAudioDeviceArena(dest *AudioDevice, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureAudioDevice(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeAudioDevice(dest, data, &offset, ctx)
+}
+
+// decodeArenaSize accumulates the memory a value needs, measured by the
+// first pass of arena decoding.
+type decodeArenaSize struct {
+	bytes   int  // Primitive slices, each rounded up to 8 bytes
+	strings int  // String contents
+	strs    int  // Elements of []str arrays
+	Parameter int
+	Plugin int
+}
+
+// decodeArena holds the preallocated memory that arena decoding carves
+// values from. buf holds primitive slices followed by string contents.
+type decodeArena struct {
+	buf    []byte
+	pos    int  // Next free byte for primitive slices
+	strPos int  // Next free byte for string contents
+	strs   []string
+	Parameter []Parameter
+	Plugin []Plugin
+}
+
+// init allocates the arena memory for the measured size. The byte block is
+// backed by []uint64 so that every primitive slice is 8-byte aligned.
+func (a *decodeArena) init(size *decodeArenaSize) {
+	if n := size.bytes + size.strings; n > 0 {
+		words := make([]uint64, (n+7)/8)
+		a.buf = unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), n)
+	}
+	a.strPos = size.bytes
+	if size.strs > 0 {
+		a.strs = make([]string, size.strs)
+	}
+	if size.Parameter > 0 {
+		a.Parameter = make([]Parameter, size.Parameter)
+	}
+	if size.Plugin > 0 {
+		a.Plugin = make([]Plugin, size.Plugin)
+	}
+}
+
+// string copies b into the arena and returns it as a string.
+func (a *decodeArena) string(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	p := a.strPos
+	a.strPos += copy(a.buf[p:], b)
+	return unsafe.String(&a.buf[p], len(b))
+}
+
+// arenaPrimitives carves a zeroed slice of n elements from the arena's byte
+// block. T must be a primitive type without pointers.
+func arenaPrimitives[T any](a *decodeArena, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	var zero T
+	s := unsafe.Slice((*T)(unsafe.Pointer(&a.buf[a.pos])), n)
+	a.pos += (int(n)*int(unsafe.Sizeof(zero)) + 7) &^ 7
+	return s
+}
+
+// arenaTake carves a zeroed slice of n elements from the front of pool.
+func arenaTake[T any](pool *[]T, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	s := (*pool)[:n:n]
+	*pool = (*pool)[n:]
+	return s
+}
+
+// measureParameter advances offset over one Parameter value and adds the arena memory it needs to size.
+func measureParameter(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	// Field: value
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "value", *offset)
+	}
+	*offset += 4
+
+	// Field: min
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "min", *offset)
+	}
+	*offset += 4
+
+	// Field: max
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "max", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// measurePlugin advances offset over one Plugin value and adds the arena memory it needs to size.
+func measurePlugin(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	// Field: manufacturer
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "manufacturer", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "manufacturer", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	// Field: version
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "version", *offset)
+	}
+	*offset += 4
+
+	// Field: enabled
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "enabled", *offset)
+	}
+	*offset += 1
+
+	// Field: parameters
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "parameters", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "parameters", *offset-4)
+	}
+	size.Parameter += int(arrCount)
+	for i := uint32(0); i < arrCount; i++ {
+		err = measureParameter(data, offset, ctx, size)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "parameters", *offset)
+		}
+	}
+
+	return nil
+}
+
+// measureAudioDevice advances offset over one AudioDevice value and adds the arena memory it needs to size.
+func measureAudioDevice(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: device_id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "device_id", *offset)
+	}
+	*offset += 4
+
+	// Field: device_name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "device_name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "device_name", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	// Field: sample_rate
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "sample_rate", *offset)
+	}
+	*offset += 4
+
+	// Field: buffer_size
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "buffer_size", *offset)
+	}
+	*offset += 4
+
+	// Field: input_channels
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "input_channels", *offset)
+	}
+	*offset += 2
+
+	// Field: output_channels
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "output_channels", *offset)
+	}
+	*offset += 2
+
+	// Field: is_default
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "is_default", *offset)
+	}
+	*offset += 1
+
+	// Field: active_plugins
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "active_plugins", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "active_plugins", *offset-4)
+	}
+	size.Plugin += int(arrCount)
+	for i := uint32(0); i < arrCount; i++ {
+		err = measurePlugin(data, offset, ctx, size)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "active_plugins", *offset)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,412 @@
+package complex
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var benchData = flag.String("benchdata", "", "directory of <Struct>.sdpb files to benchmark instead of random values")
+
+// benchRandomOptions shapes the random values of structs without a fixture.
+var benchRandomOptions = &RandomOptions{OptionalProbability: 1, MinArrayLen: 8, MaxArrayLen: 8, MaxStringLen: 32}
+
+// benchValueParameter returns the Parameter the benchmarks run on.
+func benchValueParameter(b *testing.B) *Parameter {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Parameter.sdpb"))
+		if err == nil {
+			var x Parameter
+			if err := DecodeParameter(&x, data); err != nil {
+				b.Fatalf("Parameter.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomParameter(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeParameter(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := EncodeParameter(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeParameter(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeParameter(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := EncodeParameter(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Parameter
+		if err := DecodeParameter(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripParameter(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := EncodeParameter(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeParameter(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Parameter
+		if err := DecodeParameter(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeParameterJSON(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeParameterJSON(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Parameter
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripParameterJSON(b *testing.B) {
+	x := benchValueParameter(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Parameter
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValuePlugin returns the Plugin the benchmarks run on.
+func benchValuePlugin(b *testing.B) *Plugin {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Plugin.sdpb"))
+		if err == nil {
+			var x Plugin
+			if err := DecodePlugin(&x, data); err != nil {
+				b.Fatalf("Plugin.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomPlugin(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodePlugin(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := EncodePlugin(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodePlugin(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePlugin(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := EncodePlugin(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Plugin
+		if err := DecodePlugin(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPlugin(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := EncodePlugin(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodePlugin(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Plugin
+		if err := DecodePlugin(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodePluginJSON(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePluginJSON(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Plugin
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPluginJSON(b *testing.B) {
+	x := benchValuePlugin(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Plugin
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueAudioDevice returns the AudioDevice the benchmarks run on.
+func benchValueAudioDevice(b *testing.B) *AudioDevice {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "AudioDevice.sdpb"))
+		if err == nil {
+			var x AudioDevice
+			if err := DecodeAudioDevice(&x, data); err != nil {
+				b.Fatalf("AudioDevice.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomAudioDevice(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeAudioDevice(b *testing.B) {
+	x := benchValueAudioDevice(b)
+	data, err := EncodeAudioDevice(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeAudioDevice(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeAudioDevice(b *testing.B) {
+	x := benchValueAudioDevice(b)
+	data, err := EncodeAudioDevice(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result AudioDevice
+		if err := DecodeAudioDevice(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripAudioDevice(b *testing.B) {
+	x := benchValueAudioDevice(b)
+	data, err := EncodeAudioDevice(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeAudioDevice(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result AudioDevice
+		if err := DecodeAudioDevice(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeAudioDeviceJSON(b *testing.B) {
+	x := benchValueAudioDevice(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeAudioDeviceJSON(b *testing.B) {
+	x := benchValueAudioDevice(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result AudioDevice
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripAudioDeviceJSON(b *testing.B) {
+	x := benchValueAudioDevice(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result AudioDevice
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
@@ -1,9 +1,10 @@
 package complex
 
 import (
-	"math"
-	"io"
 	"encoding/binary"
+	"io"
+	"math"
+	"unicode/utf8"
 )
 
 // Size limit constants for decode validation
@@ -16,8 +17,14 @@ const (
 // DecodeContext tracks state during decoding to enforce size limits.
 // It maintains a count of total elements across all arrays to prevent
 // excessive memory allocation from malicious or corrupted data.
+// In strict mode it also rejects non-canonical encodings; in reuse mode
+// the decoder recycles the slices, structs and strings already in dest;
+// with an arena, strings and slices are carved from preallocated memory.
 type DecodeContext struct {
 	totalElements int
+	strict        bool
+	reuse         bool
+	arena         *decodeArena
 }
 
 // checkArraySize validates an array count against per-array and total limits.
@@ -36,6 +43,22 @@ func (ctx *DecodeContext) checkArraySize(count uint32) error {
 	return nil
 }
 
+// Canonical NaN bit patterns accepted by strict decoding
+const (
+	CanonicalNaN32 uint32 = 0x7FC00000
+	CanonicalNaN64 uint64 = 0x7FF8000000000000
+)
+
+// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.
+func isCanonicalF32(bits uint32) bool {
+	return bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32
+}
+
+// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.
+func isCanonicalF64(bits uint64) bool {
+	return bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64
+}
+
 
 // DecodeParameter decodes a Parameter from wire format.
 // It validates the data size and delegates to the decoder implementation.
@@ -48,6 +71,42 @@ func DecodeParameter(dest *Parameter, data []byte) error {
 	return decodeParameter(dest, data, &offset, ctx)
 }
 
+// DecodeParameterStrict decodes a Parameter like DecodeParameter, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeParameterStrict(dest *Parameter, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeParameter(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeParameterReuse decodes a Parameter into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeParameterReuse(dest *Parameter, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeParameter(dest, data, &offset, ctx)
+}
+
 // DecodePlugin decodes a Plugin from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodePlugin(dest *Plugin, data []byte) error {
@@ -59,6 +118,42 @@ func DecodePlugin(dest *Plugin, data []byte) error {
 	return decodePlugin(dest, data, &offset, ctx)
 }
 
+// DecodePluginStrict decodes a Plugin like DecodePlugin, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodePluginStrict(dest *Plugin, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodePlugin(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodePluginReuse decodes a Plugin into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodePluginReuse(dest *Plugin, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodePlugin(dest, data, &offset, ctx)
+}
+
 // DecodeAudioDevice decodes a AudioDevice from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeAudioDevice(dest *AudioDevice, data []byte) error {
@@ -70,6 +165,42 @@ func DecodeAudioDevice(dest *AudioDevice, data []byte) error {
 	return decodeAudioDevice(dest, data, &offset, ctx)
 }
 
+// DecodeAudioDeviceStrict decodes a AudioDevice like DecodeAudioDevice, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeAudioDeviceStrict(dest *AudioDevice, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeAudioDevice(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeAudioDeviceReuse decodes a AudioDevice into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeAudioDeviceReuse(dest *AudioDevice, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeAudioDevice(dest, data, &offset, ctx)
+}
+
 
 // decodeParameter is the helper function that decodes Parameter fields.
 func decodeParameter(dest *Parameter, data []byte, offset *int, ctx *DecodeContext) error {
@@ -84,41 +215,57 @@ func decodeParameter(dest *Parameter, data []byte, offset *int, ctx *DecodeConte
 
 	// Field: Id (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
 	}
 	dest.Id = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: Name (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Name = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {
+		dest.Name = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Name = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Value (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "value", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "value", *offset)
 	}
 	dest.Value = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: Min (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "min", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "min", *offset)
 	}
 	dest.Min = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: Max (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "max", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "max", *offset)
 	}
 	dest.Max = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
@@ -139,68 +286,91 @@ func decodePlugin(dest *Plugin, data []byte, offset *int, ctx *DecodeContext) er
 
 	// Field: Id (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
 	}
 	dest.Id = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: Name (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Name = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {
+		dest.Name = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Name = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Manufacturer (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "manufacturer", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "manufacturer", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "manufacturer", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Manufacturer = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Manufacturer != string(data[*offset:*offset+int(strLen)]) {
+		dest.Manufacturer = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Manufacturer = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Version (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "version", *offset)
 	}
 	dest.Version = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: Enabled (bool)
 	if *offset + 1 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "enabled", *offset)
+	}
+	if ctx.strict && data[*offset] > 1 {
+		return decodeError(ErrNonCanonical, "enabled", *offset)
 	}
 	dest.Enabled = data[*offset] != 0
 	*offset += 1
 
 	// Field: Parameters ([]Parameter)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "parameters", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "parameters", *offset-4)
 	}
 
-	dest.Parameters = make([]Parameter, arrCount)
+	if ctx.arena != nil {
+		dest.Parameters = arenaTake(&ctx.arena.Parameter, arrCount)
+	} else if ctx.reuse && cap(dest.Parameters) >= int(arrCount) {
+		dest.Parameters = dest.Parameters[:arrCount]
+	} else {
+		dest.Parameters = make([]Parameter, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		err = decodeParameter(&dest.Parameters[i], data, offset, ctx)
 		if err != nil {
-			return err
+			return decodeError(decodeElementError(err, i, *offset), "parameters", *offset)
 		}
 	}
 
@@ -220,76 +390,92 @@ func decodeAudioDevice(dest *AudioDevice, data []byte, offset *int, ctx *DecodeC
 
 	// Field: DeviceId (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "device_id", *offset)
 	}
 	dest.DeviceId = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: DeviceName (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "device_name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "device_name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "device_name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.DeviceName = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.DeviceName != string(data[*offset:*offset+int(strLen)]) {
+		dest.DeviceName = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.DeviceName = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: SampleRate (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "sample_rate", *offset)
 	}
 	dest.SampleRate = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: BufferSize (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "buffer_size", *offset)
 	}
 	dest.BufferSize = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: InputChannels (u16)
 	if *offset + 2 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "input_channels", *offset)
 	}
 	dest.InputChannels = binary.LittleEndian.Uint16(data[*offset:])
 	*offset += 2
 
 	// Field: OutputChannels (u16)
 	if *offset + 2 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "output_channels", *offset)
 	}
 	dest.OutputChannels = binary.LittleEndian.Uint16(data[*offset:])
 	*offset += 2
 
 	// Field: IsDefault (bool)
 	if *offset + 1 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "is_default", *offset)
+	}
+	if ctx.strict && data[*offset] > 1 {
+		return decodeError(ErrNonCanonical, "is_default", *offset)
 	}
 	dest.IsDefault = data[*offset] != 0
 	*offset += 1
 
 	// Field: ActivePlugins ([]Plugin)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "active_plugins", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "active_plugins", *offset-4)
 	}
 
-	dest.ActivePlugins = make([]Plugin, arrCount)
+	if ctx.arena != nil {
+		dest.ActivePlugins = arenaTake(&ctx.arena.Plugin, arrCount)
+	} else if ctx.reuse && cap(dest.ActivePlugins) >= int(arrCount) {
+		dest.ActivePlugins = dest.ActivePlugins[:arrCount]
+	} else {
+		dest.ActivePlugins = make([]Plugin, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		err = decodePlugin(&dest.ActivePlugins[i], data, offset, ctx)
 		if err != nil {
-			return err
+			return decodeError(decodeElementError(err, i, *offset), "active_plugins", *offset)
 		}
 	}
 
@@ -299,7 +485,8 @@ func decodeAudioDevice(dest *AudioDevice, data []byte, offset *int, ctx *DecodeC
 
 // DecodeParameterMessage decodes a Parameter from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeParameterMessage(data []byte) (*Parameter, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -324,6 +511,9 @@ func DecodeParameterMessage(data []byte) (*Parameter, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -334,18 +524,24 @@ func DecodeParameterMessage(data []byte) (*Parameter, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Parameter
-	if err := DecodeParameter(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeParameter(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodePluginMessage decodes a Plugin from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodePluginMessage(data []byte) (*Plugin, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -370,6 +566,9 @@ func DecodePluginMessage(data []byte) (*Plugin, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -380,18 +579,24 @@ func DecodePluginMessage(data []byte) (*Plugin, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Plugin
-	if err := DecodePlugin(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodePlugin(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeAudioDeviceMessage decodes a AudioDevice from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeAudioDeviceMessage(data []byte) (*AudioDevice, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -416,6 +621,9 @@ func DecodeAudioDeviceMessage(data []byte) (*AudioDevice, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -426,11 +634,16 @@ func DecodeAudioDeviceMessage(data []byte) (*AudioDevice, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result AudioDevice
-	if err := DecodeAudioDevice(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeAudioDevice(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
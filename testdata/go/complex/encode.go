@@ -1,9 +1,9 @@
 package complex
 
 import (
-	"math"
-	"io"
 	"encoding/binary"
+	"io"
+	"math"
 )
 
 // calculateParameterSize calculates the wire format size for Parameter.
@@ -34,6 +34,40 @@ func EncodeParameter(src *Parameter) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeParameter produces for src.
+func (src *Parameter) EncodedSize() int {
+	return calculateParameterSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeParameter.
+func (src *Parameter) MarshalBinary() ([]byte, error) {
+	return EncodeParameter(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Parameter) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateParameterSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeParameter(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeParameter.
+// The decoded value does not retain data.
+func (dest *Parameter) UnmarshalBinary(data []byte) error {
+	return DecodeParameter(dest, data)
+}
+
 // calculatePluginSize calculates the wire format size for Plugin.
 func calculatePluginSize(src *Plugin) int {
 	size := 0
@@ -67,6 +101,40 @@ func EncodePlugin(src *Plugin) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodePlugin produces for src.
+func (src *Plugin) EncodedSize() int {
+	return calculatePluginSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodePlugin.
+func (src *Plugin) MarshalBinary() ([]byte, error) {
+	return EncodePlugin(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Plugin) AppendBinary(b []byte) ([]byte, error) {
+	size := calculatePluginSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodePlugin(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodePlugin.
+// The decoded value does not retain data.
+func (dest *Plugin) UnmarshalBinary(data []byte) error {
+	return DecodePlugin(dest, data)
+}
+
 // calculateAudioDeviceSize calculates the wire format size for AudioDevice.
 func calculateAudioDeviceSize(src *AudioDevice) int {
 	size := 0
@@ -104,6 +172,40 @@ func EncodeAudioDevice(src *AudioDevice) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeAudioDevice produces for src.
+func (src *AudioDevice) EncodedSize() int {
+	return calculateAudioDeviceSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeAudioDevice.
+func (src *AudioDevice) MarshalBinary() ([]byte, error) {
+	return EncodeAudioDevice(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *AudioDevice) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateAudioDeviceSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeAudioDevice(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeAudioDevice.
+// The decoded value does not retain data.
+func (dest *AudioDevice) UnmarshalBinary(data []byte) error {
+	return DecodeAudioDevice(dest, data)
+}
+
 
 // encodeParameter is the helper function that encodes Parameter fields.
 func encodeParameter(src *Parameter, buf []byte, offset *int) error {
@@ -0,0 +1,309 @@
+package complex
+
+import (
+	"math"
+	"strconv"
+)
+
+// FieldChange is one field-level difference reported by Diff.
+type FieldChange struct {
+	// Path is the schema path of the field, e.g. "plugins[2].name".
+	Path string
+	// Kind is "value" if a primitive field differs, "length" if an array
+	// has a different number of elements and "presence" if an optional
+	// is set on one side only.
+	Kind string
+	// Old and New are the field values for "value", the element counts
+	// (int) for "length" and the presence flags (bool) for "presence".
+	Old any
+	New any
+}
+
+// diffPath joins a field name to the path of its struct.
+func diffPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Parameter) Equal(other *Parameter) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Id != other.Id {
+		return false
+	}
+	if x.Name != other.Name {
+		return false
+	}
+	if math.Float32bits(x.Value) != math.Float32bits(other.Value) {
+		return false
+	}
+	if math.Float32bits(x.Min) != math.Float32bits(other.Min) {
+		return false
+	}
+	if math.Float32bits(x.Max) != math.Float32bits(other.Max) {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Parameter) Clone() *Parameter {
+	if x == nil {
+		return nil
+	}
+	c := new(Parameter)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Parameter) cloneTo(c *Parameter) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Parameter) Diff(other *Parameter) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Parameter) diff(prefix string, other *Parameter, changes *[]FieldChange) {
+	if x.Id != other.Id {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "id"), Kind: "value", Old: x.Id, New: other.Id})
+	}
+	if x.Name != other.Name {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "name"), Kind: "value", Old: x.Name, New: other.Name})
+	}
+	if math.Float32bits(x.Value) != math.Float32bits(other.Value) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "value"), Kind: "value", Old: x.Value, New: other.Value})
+	}
+	if math.Float32bits(x.Min) != math.Float32bits(other.Min) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "min"), Kind: "value", Old: x.Min, New: other.Min})
+	}
+	if math.Float32bits(x.Max) != math.Float32bits(other.Max) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "max"), Kind: "value", Old: x.Max, New: other.Max})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Plugin) Equal(other *Plugin) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Id != other.Id {
+		return false
+	}
+	if x.Name != other.Name {
+		return false
+	}
+	if x.Manufacturer != other.Manufacturer {
+		return false
+	}
+	if x.Version != other.Version {
+		return false
+	}
+	if x.Enabled != other.Enabled {
+		return false
+	}
+	if len(x.Parameters) != len(other.Parameters) {
+		return false
+	}
+	for i := range x.Parameters {
+		if !x.Parameters[i].Equal(&other.Parameters[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Plugin) Clone() *Plugin {
+	if x == nil {
+		return nil
+	}
+	c := new(Plugin)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Plugin) cloneTo(c *Plugin) {
+	*c = *x
+	if x.Parameters != nil {
+		c.Parameters = make([]Parameter, len(x.Parameters))
+		for i := range x.Parameters {
+			x.Parameters[i].cloneTo(&c.Parameters[i])
+		}
+	}
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Plugin) Diff(other *Plugin) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Plugin) diff(prefix string, other *Plugin, changes *[]FieldChange) {
+	if x.Id != other.Id {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "id"), Kind: "value", Old: x.Id, New: other.Id})
+	}
+	if x.Name != other.Name {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "name"), Kind: "value", Old: x.Name, New: other.Name})
+	}
+	if x.Manufacturer != other.Manufacturer {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "manufacturer"), Kind: "value", Old: x.Manufacturer, New: other.Manufacturer})
+	}
+	if x.Version != other.Version {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "version"), Kind: "value", Old: x.Version, New: other.Version})
+	}
+	if x.Enabled != other.Enabled {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "enabled"), Kind: "value", Old: x.Enabled, New: other.Enabled})
+	}
+	{
+		path := diffPath(prefix, "parameters")
+		if len(x.Parameters) != len(other.Parameters) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.Parameters), New: len(other.Parameters)})
+		}
+		for i := 0; i < len(x.Parameters) && i < len(other.Parameters); i++ {
+			x.Parameters[i].diff(path+"["+strconv.Itoa(i)+"]", &other.Parameters[i], changes)
+		}
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *AudioDevice) Equal(other *AudioDevice) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.DeviceId != other.DeviceId {
+		return false
+	}
+	if x.DeviceName != other.DeviceName {
+		return false
+	}
+	if x.SampleRate != other.SampleRate {
+		return false
+	}
+	if x.BufferSize != other.BufferSize {
+		return false
+	}
+	if x.InputChannels != other.InputChannels {
+		return false
+	}
+	if x.OutputChannels != other.OutputChannels {
+		return false
+	}
+	if x.IsDefault != other.IsDefault {
+		return false
+	}
+	if len(x.ActivePlugins) != len(other.ActivePlugins) {
+		return false
+	}
+	for i := range x.ActivePlugins {
+		if !x.ActivePlugins[i].Equal(&other.ActivePlugins[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *AudioDevice) Clone() *AudioDevice {
+	if x == nil {
+		return nil
+	}
+	c := new(AudioDevice)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *AudioDevice) cloneTo(c *AudioDevice) {
+	*c = *x
+	if x.ActivePlugins != nil {
+		c.ActivePlugins = make([]Plugin, len(x.ActivePlugins))
+		for i := range x.ActivePlugins {
+			x.ActivePlugins[i].cloneTo(&c.ActivePlugins[i])
+		}
+	}
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *AudioDevice) Diff(other *AudioDevice) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *AudioDevice) diff(prefix string, other *AudioDevice, changes *[]FieldChange) {
+	if x.DeviceId != other.DeviceId {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "device_id"), Kind: "value", Old: x.DeviceId, New: other.DeviceId})
+	}
+	if x.DeviceName != other.DeviceName {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "device_name"), Kind: "value", Old: x.DeviceName, New: other.DeviceName})
+	}
+	if x.SampleRate != other.SampleRate {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "sample_rate"), Kind: "value", Old: x.SampleRate, New: other.SampleRate})
+	}
+	if x.BufferSize != other.BufferSize {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "buffer_size"), Kind: "value", Old: x.BufferSize, New: other.BufferSize})
+	}
+	if x.InputChannels != other.InputChannels {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "input_channels"), Kind: "value", Old: x.InputChannels, New: other.InputChannels})
+	}
+	if x.OutputChannels != other.OutputChannels {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "output_channels"), Kind: "value", Old: x.OutputChannels, New: other.OutputChannels})
+	}
+	if x.IsDefault != other.IsDefault {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "is_default"), Kind: "value", Old: x.IsDefault, New: other.IsDefault})
+	}
+	{
+		path := diffPath(prefix, "active_plugins")
+		if len(x.ActivePlugins) != len(other.ActivePlugins) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.ActivePlugins), New: len(other.ActivePlugins)})
+		}
+		for i := 0; i < len(x.ActivePlugins) && i < len(other.ActivePlugins); i++ {
+			x.ActivePlugins[i].diff(path+"["+strconv.Itoa(i)+"]", &other.ActivePlugins[i], changes)
+		}
+	}
+}
+
@@ -0,0 +1,115 @@
+package complex
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzDecodeParameter checks that DecodeParameter never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeParameter(f *testing.F) {
+	for _, seed := range []*Parameter{
+		{},
+		{Id: 1, Name: "sample", Value: 1.5, Min: 1.5, Max: 1.5},
+	} {
+		data, err := EncodeParameter(seed)
+		if err != nil {
+			f.Fatalf("EncodeParameter(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Parameter
+		if err := DecodeParameter(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeParameter(&x)
+		if err != nil {
+			t.Fatalf("EncodeParameter of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Parameter
+		if err := DecodeParameterStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodePlugin checks that DecodePlugin never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodePlugin(f *testing.F) {
+	for _, seed := range []*Plugin{
+		{},
+		{Id: 1, Name: "sample", Manufacturer: "sample", Version: 1, Enabled: true, Parameters: []Parameter{{Id: 1, Name: "sample", Value: 1.5, Min: 1.5, Max: 1.5}}},
+	} {
+		data, err := EncodePlugin(seed)
+		if err != nil {
+			f.Fatalf("EncodePlugin(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Plugin
+		if err := DecodePlugin(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodePlugin(&x)
+		if err != nil {
+			t.Fatalf("EncodePlugin of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Plugin
+		if err := DecodePluginStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeAudioDevice checks that DecodeAudioDevice never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeAudioDevice(f *testing.F) {
+	for _, seed := range []*AudioDevice{
+		{},
+		{DeviceId: 1, DeviceName: "sample", SampleRate: 1, BufferSize: 1, InputChannels: 1, OutputChannels: 1, IsDefault: true, ActivePlugins: []Plugin{{Id: 1, Name: "sample", Manufacturer: "sample", Version: 1, Enabled: true, Parameters: []Parameter{{Id: 1, Name: "sample", Value: 1.5, Min: 1.5, Max: 1.5}}}}},
+	} {
+		data, err := EncodeAudioDevice(seed)
+		if err != nil {
+			f.Fatalf("EncodeAudioDevice(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x AudioDevice
+		if err := DecodeAudioDevice(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeAudioDevice(&x)
+		if err != nil {
+			t.Fatalf("EncodeAudioDevice of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict AudioDevice
+		if err := DecodeAudioDeviceStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
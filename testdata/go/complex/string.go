@@ -0,0 +1,199 @@
+package complex
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+)
+
+// stringMaxElems is the number of array elements String and LogValue show.
+const stringMaxElems = 16
+
+// stringMaxBytes is the number of []u8 bytes String and LogValue show.
+const stringMaxBytes = 32
+
+// stringRedacted replaces the value of fields marked @redact.
+const stringRedacted = "[REDACTED]"
+
+// appendStringMore appends the number of elements left out of a truncated array.
+func appendStringMore(b []byte, n int) []byte {
+	b = append(b, "... (+"...)
+	b = strconv.AppendInt(b, int64(n), 10)
+	return append(b, " more)"...)
+}
+
+// appendStringBytes appends a []u8 in hex.
+func appendStringBytes(b, v []byte) []byte {
+	b = append(b, "0x"...)
+	if len(v) <= stringMaxBytes {
+		return hex.AppendEncode(b, v)
+	}
+	b = hex.AppendEncode(b, v[:stringMaxBytes])
+	return appendStringMore(b, len(v)-stringMaxBytes)
+}
+
+// logValueElems returns the first stringMaxElems elements of an array as a
+// group keyed by index.
+func logValueElems[T any](elems []T, value func(*T) slog.Value) slog.Value {
+	attrs := make([]slog.Attr, 0, min(len(elems), stringMaxElems+1))
+	for i := range elems {
+		if i == stringMaxElems {
+			attrs = append(attrs, slog.Int("more", len(elems)-i))
+			break
+		}
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: value(&elems[i])})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Parameter) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Parameter) appendString(b []byte) []byte {
+	b = append(b, "Parameter{"...)
+	b = append(b, "id: "...)
+	b = strconv.AppendUint(b, uint64(x.Id), 10)
+	b = append(b, ", name: "...)
+	b = strconv.AppendQuote(b, x.Name)
+	b = append(b, ", value: "...)
+	b = strconv.AppendFloat(b, float64(x.Value), 'g', -1, 32)
+	b = append(b, ", min: "...)
+	b = strconv.AppendFloat(b, float64(x.Min), 'g', -1, 32)
+	b = append(b, ", max: "...)
+	b = strconv.AppendFloat(b, float64(x.Max), 'g', -1, 32)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Parameter) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 5)
+	attrs = append(attrs, slog.Uint64("id", uint64(x.Id)))
+	attrs = append(attrs, slog.String("name", x.Name))
+	attrs = append(attrs, slog.Float64("value", float64(x.Value)))
+	attrs = append(attrs, slog.Float64("min", float64(x.Min)))
+	attrs = append(attrs, slog.Float64("max", float64(x.Max)))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Plugin) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Plugin) appendString(b []byte) []byte {
+	b = append(b, "Plugin{"...)
+	b = append(b, "id: "...)
+	b = strconv.AppendUint(b, uint64(x.Id), 10)
+	b = append(b, ", name: "...)
+	b = strconv.AppendQuote(b, x.Name)
+	b = append(b, ", manufacturer: "...)
+	b = strconv.AppendQuote(b, x.Manufacturer)
+	b = append(b, ", version: "...)
+	b = strconv.AppendUint(b, uint64(x.Version), 10)
+	b = append(b, ", enabled: "...)
+	b = strconv.AppendBool(b, x.Enabled)
+	b = append(b, ", parameters: "...)
+	b = append(b, '[')
+	for i := range x.Parameters {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.Parameters)-i)
+			break
+		}
+		b = x.Parameters[i].appendString(b)
+	}
+	b = append(b, ']')
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Plugin) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 6)
+	attrs = append(attrs, slog.Uint64("id", uint64(x.Id)))
+	attrs = append(attrs, slog.String("name", x.Name))
+	attrs = append(attrs, slog.String("manufacturer", x.Manufacturer))
+	attrs = append(attrs, slog.Uint64("version", uint64(x.Version)))
+	attrs = append(attrs, slog.Bool("enabled", x.Enabled))
+	attrs = append(attrs, slog.Attr{Key: "parameters", Value: logValueElems(x.Parameters, (*Parameter).LogValue)})
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *AudioDevice) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *AudioDevice) appendString(b []byte) []byte {
+	b = append(b, "AudioDevice{"...)
+	b = append(b, "device_id: "...)
+	b = strconv.AppendUint(b, uint64(x.DeviceId), 10)
+	b = append(b, ", device_name: "...)
+	b = strconv.AppendQuote(b, x.DeviceName)
+	b = append(b, ", sample_rate: "...)
+	b = strconv.AppendUint(b, uint64(x.SampleRate), 10)
+	b = append(b, ", buffer_size: "...)
+	b = strconv.AppendUint(b, uint64(x.BufferSize), 10)
+	b = append(b, ", input_channels: "...)
+	b = strconv.AppendUint(b, uint64(x.InputChannels), 10)
+	b = append(b, ", output_channels: "...)
+	b = strconv.AppendUint(b, uint64(x.OutputChannels), 10)
+	b = append(b, ", is_default: "...)
+	b = strconv.AppendBool(b, x.IsDefault)
+	b = append(b, ", active_plugins: "...)
+	b = append(b, '[')
+	for i := range x.ActivePlugins {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.ActivePlugins)-i)
+			break
+		}
+		b = x.ActivePlugins[i].appendString(b)
+	}
+	b = append(b, ']')
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *AudioDevice) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 8)
+	attrs = append(attrs, slog.Uint64("device_id", uint64(x.DeviceId)))
+	attrs = append(attrs, slog.String("device_name", x.DeviceName))
+	attrs = append(attrs, slog.Uint64("sample_rate", uint64(x.SampleRate)))
+	attrs = append(attrs, slog.Uint64("buffer_size", uint64(x.BufferSize)))
+	attrs = append(attrs, slog.Uint64("input_channels", uint64(x.InputChannels)))
+	attrs = append(attrs, slog.Uint64("output_channels", uint64(x.OutputChannels)))
+	attrs = append(attrs, slog.Bool("is_default", x.IsDefault))
+	attrs = append(attrs, slog.Attr{Key: "active_plugins", Value: logValueElems(x.ActivePlugins, (*Plugin).LogValue)})
+	return slog.GroupValue(attrs...)
+}
+
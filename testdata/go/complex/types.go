@@ -1,29 +1,181 @@
 package complex
 
 type Parameter struct {
-	Id uint32
-	Name string
-	Value float32
-	Min float32
-	Max float32
+	Id uint32 `json:"id"`
+	Name string `json:"name"`
+	Value float32 `json:"value"`
+	Min float32 `json:"min"`
+	Max float32 `json:"max"`
 }
 
 type Plugin struct {
-	Id uint32
-	Name string
-	Manufacturer string
-	Version uint32
-	Enabled bool
-	Parameters []Parameter
+	Id uint32 `json:"id"`
+	Name string `json:"name"`
+	Manufacturer string `json:"manufacturer"`
+	Version uint32 `json:"version"`
+	Enabled bool `json:"enabled"`
+	Parameters []Parameter `json:"parameters,omitempty"`
 }
 
 type AudioDevice struct {
-	DeviceId uint32
-	DeviceName string
-	SampleRate uint32
-	BufferSize uint32
-	InputChannels uint16
-	OutputChannels uint16
-	IsDefault bool
-	ActivePlugins []Plugin
+	DeviceId uint32 `json:"device_id"`
+	DeviceName string `json:"device_name"`
+	SampleRate uint32 `json:"sample_rate"`
+	BufferSize uint32 `json:"buffer_size"`
+	InputChannels uint16 `json:"input_channels"`
+	OutputChannels uint16 `json:"output_channels"`
+	IsDefault bool `json:"is_default"`
+	ActivePlugins []Plugin `json:"active_plugins,omitempty"`
+}
+
+// GetId returns id, or the zero value if x is nil.
+func (x *Parameter) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetName returns name, or the zero value if x is nil.
+func (x *Parameter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetValue returns value, or the zero value if x is nil.
+func (x *Parameter) GetValue() float32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// GetMin returns min, or the zero value if x is nil.
+func (x *Parameter) GetMin() float32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+// GetMax returns max, or the zero value if x is nil.
+func (x *Parameter) GetMax() float32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// GetId returns id, or the zero value if x is nil.
+func (x *Plugin) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetName returns name, or the zero value if x is nil.
+func (x *Plugin) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetManufacturer returns manufacturer, or the zero value if x is nil.
+func (x *Plugin) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+// GetVersion returns version, or the zero value if x is nil.
+func (x *Plugin) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// GetEnabled returns enabled, or the zero value if x is nil.
+func (x *Plugin) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// GetParameters returns parameters, or the zero value if x is nil.
+func (x *Plugin) GetParameters() []Parameter {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// GetDeviceId returns device_id, or the zero value if x is nil.
+func (x *AudioDevice) GetDeviceId() uint32 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+// GetDeviceName returns device_name, or the zero value if x is nil.
+func (x *AudioDevice) GetDeviceName() string {
+	if x != nil {
+		return x.DeviceName
+	}
+	return ""
+}
+
+// GetSampleRate returns sample_rate, or the zero value if x is nil.
+func (x *AudioDevice) GetSampleRate() uint32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+// GetBufferSize returns buffer_size, or the zero value if x is nil.
+func (x *AudioDevice) GetBufferSize() uint32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+// GetInputChannels returns input_channels, or the zero value if x is nil.
+func (x *AudioDevice) GetInputChannels() uint16 {
+	if x != nil {
+		return x.InputChannels
+	}
+	return 0
+}
+
+// GetOutputChannels returns output_channels, or the zero value if x is nil.
+func (x *AudioDevice) GetOutputChannels() uint16 {
+	if x != nil {
+		return x.OutputChannels
+	}
+	return 0
+}
+
+// GetIsDefault returns is_default, or the zero value if x is nil.
+func (x *AudioDevice) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+// GetActivePlugins returns active_plugins, or the zero value if x is nil.
+func (x *AudioDevice) GetActivePlugins() []Plugin {
+	if x != nil {
+		return x.ActivePlugins
+	}
+	return nil
 }
@@ -0,0 +1,314 @@
+package complex
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// ValidateParameter checks that data starts with a well-formed Parameter without decoding it.
+// It accepts the input DecodeParameter accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateParameter(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateParameter(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidatePlugin checks that data starts with a well-formed Plugin without decoding it.
+// It accepts the input DecodePlugin accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidatePlugin(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validatePlugin(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateAudioDevice checks that data starts with a well-formed AudioDevice without decoding it.
+// It accepts the input DecodeAudioDevice accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateAudioDevice(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateAudioDevice(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// validateParameter advances offset over one Parameter value.
+func validateParameter(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: value
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "value", *offset)
+	}
+	*offset += 4
+
+	// Field: min
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "min", *offset)
+	}
+	*offset += 4
+
+	// Field: max
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "max", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// validatePlugin advances offset over one Plugin value.
+func validatePlugin(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: manufacturer
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "manufacturer", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "manufacturer", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "manufacturer", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: version
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "version", *offset)
+	}
+	*offset += 4
+
+	// Field: enabled
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "enabled", *offset)
+	}
+	*offset += 1
+
+	// Field: parameters
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "parameters", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "parameters", *offset-4)
+	}
+	for i := uint32(0); i < arrCount; i++ {
+		err = validateParameter(data, offset, ctx)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "parameters", *offset)
+		}
+	}
+
+	return nil
+}
+
+// validateAudioDevice advances offset over one AudioDevice value.
+func validateAudioDevice(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: device_id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "device_id", *offset)
+	}
+	*offset += 4
+
+	// Field: device_name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "device_name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "device_name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "device_name", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: sample_rate
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "sample_rate", *offset)
+	}
+	*offset += 4
+
+	// Field: buffer_size
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "buffer_size", *offset)
+	}
+	*offset += 4
+
+	// Field: input_channels
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "input_channels", *offset)
+	}
+	*offset += 2
+
+	// Field: output_channels
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "output_channels", *offset)
+	}
+	*offset += 2
+
+	// Field: is_default
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "is_default", *offset)
+	}
+	*offset += 1
+
+	// Field: active_plugins
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "active_plugins", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "active_plugins", *offset-4)
+	}
+	for i := uint32(0); i < arrCount; i++ {
+		err = validatePlugin(data, offset, ctx)
+		if err != nil {
+			return decodeError(decodeElementError(err, i, *offset), "active_plugins", *offset)
+		}
+	}
+
+	return nil
+}
+
+// ValidateMessage checks that data starts with a well-formed self-describing
+// message of any type in this schema, without decoding it. The payload must
+// hold exactly one value of the type; ErrTrailingBytes is returned if it
+// extends past the value. It returns the total message length
+// (header + payload).
+func ValidateMessage(data []byte) (int, error) {
+	if len(data) < MessageHeaderSize {
+		return 0, ErrUnexpectedEOF
+	}
+	if string(data[0:3]) != MessageMagic {
+		return 0, ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return 0, ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	end := MessageHeaderSize + int(payloadLength)
+	if len(data) < end {
+		return 0, ErrUnexpectedEOF
+	}
+	payload := data[MessageHeaderSize:end]
+
+	var (
+		n   int
+		err error
+	)
+	switch typeID {
+	case 1:
+		n, err = ValidateParameter(payload)
+	case 2:
+		n, err = ValidatePlugin(payload)
+	case 3:
+		n, err = ValidateAudioDevice(payload)
+	default:
+		return 0, ErrUnknownMessageType
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n != len(payload) {
+		return 0, ErrTrailingBytes
+	}
+	return end, nil
+}
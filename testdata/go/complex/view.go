@@ -0,0 +1,308 @@
+package complex
+
+import (
+	"encoding/binary"
+	"iter"
+	"math"
+	"unsafe"
+)
+
+// ArrayView is a read-only view of an encoded array. Elements are read
+// from the underlying buffer on access; nothing is copied up front.
+type ArrayView[T any] struct {
+	data []byte
+	off  int  // Offset of the first element
+	n    int  // Element count
+	size int  // Element size, or 0 for variable-size elements
+	read func(data []byte, off int) T
+	skip func(data []byte, off int) int
+}
+
+// newArrayView creates an ArrayView over the array whose count prefix is at off.
+func newArrayView[T any](data []byte, off int, size int, read func([]byte, int) T, skip func([]byte, int) int) ArrayView[T] {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	return ArrayView[T]{data: data, off: off + 4, n: n, size: size, read: read, skip: skip}
+}
+
+// Len returns the number of elements.
+func (a ArrayView[T]) Len() int {
+	return a.n
+}
+
+// At returns element i. Fixed-size elements are located directly;
+// variable-size elements (strings, structs) are found by skipping the
+// preceding ones, so use All to visit every element. At panics if i is
+// out of range.
+func (a ArrayView[T]) At(i int) T {
+	if i < 0 || i >= a.n {
+		panic("ArrayView: index out of range")
+	}
+	if a.size > 0 {
+		return a.read(a.data, a.off+i*a.size)
+	}
+	off := a.off
+	for ; i > 0; i-- {
+		off = a.skip(a.data, off)
+	}
+	return a.read(a.data, off)
+}
+
+// All returns an iterator over the index and value of every element.
+func (a ArrayView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		off := a.off
+		for i := 0; i < a.n; i++ {
+			if !yield(i, a.read(a.data, off)) {
+				return
+			}
+			if a.size > 0 {
+				off += a.size
+			} else {
+				off = a.skip(a.data, off)
+			}
+		}
+	}
+}
+
+// viewString returns the length-prefixed string at off without copying.
+func viewString(data []byte, off int) string {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	if n == 0 {
+		return ""
+	}
+	return unsafe.String(&data[off+4], n)
+}
+
+// viewSkipString returns the offset just past the string at off.
+func viewSkipString(data []byte, off int) int {
+	return off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+}
+
+// viewBytes returns the []u8 array at off as a sub-slice of data.
+// Its capacity is clipped so appending to it cannot overwrite data.
+func viewBytes(data []byte, off int) []byte {
+	end := off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+	return data[off+4 : end : end]
+}
+
+// viewSkipArray returns the offset just past the array of variable-size
+// elements at off, using skip to step over each element.
+func viewSkipArray(data []byte, off int, skip func([]byte, int) int) int {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	for i := 0; i < n; i++ {
+		off = skip(data, off)
+	}
+	return off
+}
+
+// ParameterView is a read-only, zero-copy view of an encoded Parameter.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type ParameterView struct {
+	data []byte
+}
+
+// NewParameterView validates data with ValidateParameter and returns a view over it.
+func NewParameterView(data []byte) (ParameterView, error) {
+	if _, err := ValidateParameter(data); err != nil {
+		return ParameterView{}, err
+	}
+	return ParameterView{data: data}, nil
+}
+
+// Decode materializes the full Parameter value.
+func (v ParameterView) Decode(dest *Parameter) error {
+	return DecodeParameter(dest, v.data)
+}
+
+// Id returns the id field.
+func (v ParameterView) Id() uint32 {
+	return binary.LittleEndian.Uint32(v.data[0:])
+}
+
+// Name returns the name field.
+func (v ParameterView) Name() string {
+	return viewString(v.data, 4)
+}
+
+// Value returns the value field.
+func (v ParameterView) Value() float32 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[off:]))
+}
+
+// Min returns the min field.
+func (v ParameterView) Min() float32 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[off+4:]))
+}
+
+// Max returns the max field.
+func (v ParameterView) Max() float32 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[off+8:]))
+}
+
+// skipParameter returns the offset just past the Parameter value starting at off.
+func skipParameter(data []byte, off int) int {
+	off += 4
+	off = viewSkipString(data, off)
+	off += 12
+	return off
+}
+
+// PluginView is a read-only, zero-copy view of an encoded Plugin.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type PluginView struct {
+	data []byte
+}
+
+// NewPluginView validates data with ValidatePlugin and returns a view over it.
+func NewPluginView(data []byte) (PluginView, error) {
+	if _, err := ValidatePlugin(data); err != nil {
+		return PluginView{}, err
+	}
+	return PluginView{data: data}, nil
+}
+
+// Decode materializes the full Plugin value.
+func (v PluginView) Decode(dest *Plugin) error {
+	return DecodePlugin(dest, v.data)
+}
+
+// Id returns the id field.
+func (v PluginView) Id() uint32 {
+	return binary.LittleEndian.Uint32(v.data[0:])
+}
+
+// Name returns the name field.
+func (v PluginView) Name() string {
+	return viewString(v.data, 4)
+}
+
+// Manufacturer returns the manufacturer field.
+func (v PluginView) Manufacturer() string {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return viewString(v.data, off)
+}
+
+// Version returns the version field.
+func (v PluginView) Version() uint32 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint32(v.data[off:])
+}
+
+// Enabled returns the enabled field.
+func (v PluginView) Enabled() bool {
+	off := 4
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return v.data[off+4] != 0
+}
+
+// Parameters returns the parameters field.
+func (v PluginView) Parameters() ArrayView[ParameterView] {
+	off := 4
+	off = viewSkipString(v.data, off)
+	off = viewSkipString(v.data, off)
+	return newArrayView(v.data, off+5, 0, func(data []byte, off int) ParameterView { return ParameterView{data: data[off:]} }, skipParameter)
+}
+
+// skipPlugin returns the offset just past the Plugin value starting at off.
+func skipPlugin(data []byte, off int) int {
+	off += 4
+	off = viewSkipString(data, off)
+	off = viewSkipString(data, off)
+	off += 5
+	off = viewSkipArray(data, off, skipParameter)
+	return off
+}
+
+// AudioDeviceView is a read-only, zero-copy view of an encoded AudioDevice.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type AudioDeviceView struct {
+	data []byte
+}
+
+// NewAudioDeviceView validates data with ValidateAudioDevice and returns a view over it.
+func NewAudioDeviceView(data []byte) (AudioDeviceView, error) {
+	if _, err := ValidateAudioDevice(data); err != nil {
+		return AudioDeviceView{}, err
+	}
+	return AudioDeviceView{data: data}, nil
+}
+
+// Decode materializes the full AudioDevice value.
+func (v AudioDeviceView) Decode(dest *AudioDevice) error {
+	return DecodeAudioDevice(dest, v.data)
+}
+
+// DeviceId returns the device_id field.
+func (v AudioDeviceView) DeviceId() uint32 {
+	return binary.LittleEndian.Uint32(v.data[0:])
+}
+
+// DeviceName returns the device_name field.
+func (v AudioDeviceView) DeviceName() string {
+	return viewString(v.data, 4)
+}
+
+// SampleRate returns the sample_rate field.
+func (v AudioDeviceView) SampleRate() uint32 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint32(v.data[off:])
+}
+
+// BufferSize returns the buffer_size field.
+func (v AudioDeviceView) BufferSize() uint32 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint32(v.data[off+4:])
+}
+
+// InputChannels returns the input_channels field.
+func (v AudioDeviceView) InputChannels() uint16 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint16(v.data[off+8:])
+}
+
+// OutputChannels returns the output_channels field.
+func (v AudioDeviceView) OutputChannels() uint16 {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint16(v.data[off+10:])
+}
+
+// IsDefault returns the is_default field.
+func (v AudioDeviceView) IsDefault() bool {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return v.data[off+12] != 0
+}
+
+// ActivePlugins returns the active_plugins field.
+func (v AudioDeviceView) ActivePlugins() ArrayView[PluginView] {
+	off := 4
+	off = viewSkipString(v.data, off)
+	return newArrayView(v.data, off+13, 0, func(data []byte, off int) PluginView { return PluginView{data: data[off:]} }, skipPlugin)
+}
+
+// skipAudioDevice returns the offset just past the AudioDevice value starting at off.
+func skipAudioDevice(data []byte, off int) int {
+	off += 4
+	off = viewSkipString(data, off)
+	off += 13
+	off = viewSkipArray(data, off, skipPlugin)
+	return off
+}
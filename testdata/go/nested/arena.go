@@ -0,0 +1,233 @@
+package nested
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// DecodePointArena decodes a Point like DecodePoint, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodePointArena(dest *Point, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measurePoint(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodePoint(dest, data, &offset, ctx)
+}
+
+// DecodeRectangleArena decodes a Rectangle like DecodeRectangle, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeRectangleArena(dest *Rectangle, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureRectangle(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeRectangle(dest, data, &offset, ctx)
+}
+
+// DecodeSceneArena decodes a Scene like DecodeScene, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeSceneArena(dest *Scene, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureScene(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeScene(dest, data, &offset, ctx)
+}
+
+// decodeArenaSize accumulates the memory a value needs, measured by the
+// first pass of arena decoding.
+type decodeArenaSize struct {
+	bytes   int  // Primitive slices, each rounded up to 8 bytes
+	strings int  // String contents
+	strs    int  // Elements of []str arrays
+}
+
+// decodeArena holds the preallocated memory that arena decoding carves
+// values from. buf holds primitive slices followed by string contents.
+type decodeArena struct {
+	buf    []byte
+	pos    int  // Next free byte for primitive slices
+	strPos int  // Next free byte for string contents
+	strs   []string
+}
+
+// init allocates the arena memory for the measured size. The byte block is
+// backed by []uint64 so that every primitive slice is 8-byte aligned.
+func (a *decodeArena) init(size *decodeArenaSize) {
+	if n := size.bytes + size.strings; n > 0 {
+		words := make([]uint64, (n+7)/8)
+		a.buf = unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), n)
+	}
+	a.strPos = size.bytes
+	if size.strs > 0 {
+		a.strs = make([]string, size.strs)
+	}
+}
+
+// string copies b into the arena and returns it as a string.
+func (a *decodeArena) string(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	p := a.strPos
+	a.strPos += copy(a.buf[p:], b)
+	return unsafe.String(&a.buf[p], len(b))
+}
+
+// arenaPrimitives carves a zeroed slice of n elements from the arena's byte
+// block. T must be a primitive type without pointers.
+func arenaPrimitives[T any](a *decodeArena, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	var zero T
+	s := unsafe.Slice((*T)(unsafe.Pointer(&a.buf[a.pos])), n)
+	a.pos += (int(n)*int(unsafe.Sizeof(zero)) + 7) &^ 7
+	return s
+}
+
+// arenaTake carves a zeroed slice of n elements from the front of pool.
+func arenaTake[T any](pool *[]T, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	s := (*pool)[:n:n]
+	*pool = (*pool)[n:]
+	return s
+}
+
+// measurePoint advances offset over one Point value and adds the arena memory it needs to size.
+func measurePoint(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: x
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "x", *offset)
+	}
+	*offset += 4
+
+	// Field: y
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "y", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// measureRectangle advances offset over one Rectangle value and adds the arena memory it needs to size.
+func measureRectangle(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: top_left
+	err = measurePoint(data, offset, ctx, size)
+	if err != nil {
+		return decodeError(err, "top_left", *offset)
+	}
+
+	// Field: bottom_right
+	err = measurePoint(data, offset, ctx, size)
+	if err != nil {
+		return decodeError(err, "bottom_right", *offset)
+	}
+
+	// Field: color
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "color", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// measureScene advances offset over one Scene value and adds the arena memory it needs to size.
+func measureScene(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	// Field: main_rect
+	err = measureRectangle(data, offset, ctx, size)
+	if err != nil {
+		return decodeError(err, "main_rect", *offset)
+	}
+
+	// Field: count
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "count", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
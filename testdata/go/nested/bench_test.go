@@ -0,0 +1,412 @@
+package nested
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var benchData = flag.String("benchdata", "", "directory of <Struct>.sdpb files to benchmark instead of random values")
+
+// benchRandomOptions shapes the random values of structs without a fixture.
+var benchRandomOptions = &RandomOptions{OptionalProbability: 1, MinArrayLen: 8, MaxArrayLen: 8, MaxStringLen: 32}
+
+// benchValuePoint returns the Point the benchmarks run on.
+func benchValuePoint(b *testing.B) *Point {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Point.sdpb"))
+		if err == nil {
+			var x Point
+			if err := DecodePoint(&x, data); err != nil {
+				b.Fatalf("Point.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomPoint(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodePoint(b *testing.B) {
+	x := benchValuePoint(b)
+	data, err := EncodePoint(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodePoint(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePoint(b *testing.B) {
+	x := benchValuePoint(b)
+	data, err := EncodePoint(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Point
+		if err := DecodePoint(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPoint(b *testing.B) {
+	x := benchValuePoint(b)
+	data, err := EncodePoint(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodePoint(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Point
+		if err := DecodePoint(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodePointJSON(b *testing.B) {
+	x := benchValuePoint(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodePointJSON(b *testing.B) {
+	x := benchValuePoint(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Point
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripPointJSON(b *testing.B) {
+	x := benchValuePoint(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Point
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueRectangle returns the Rectangle the benchmarks run on.
+func benchValueRectangle(b *testing.B) *Rectangle {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Rectangle.sdpb"))
+		if err == nil {
+			var x Rectangle
+			if err := DecodeRectangle(&x, data); err != nil {
+				b.Fatalf("Rectangle.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomRectangle(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeRectangle(b *testing.B) {
+	x := benchValueRectangle(b)
+	data, err := EncodeRectangle(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeRectangle(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeRectangle(b *testing.B) {
+	x := benchValueRectangle(b)
+	data, err := EncodeRectangle(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Rectangle
+		if err := DecodeRectangle(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripRectangle(b *testing.B) {
+	x := benchValueRectangle(b)
+	data, err := EncodeRectangle(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeRectangle(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Rectangle
+		if err := DecodeRectangle(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeRectangleJSON(b *testing.B) {
+	x := benchValueRectangle(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeRectangleJSON(b *testing.B) {
+	x := benchValueRectangle(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Rectangle
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripRectangleJSON(b *testing.B) {
+	x := benchValueRectangle(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Rectangle
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueScene returns the Scene the benchmarks run on.
+func benchValueScene(b *testing.B) *Scene {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Scene.sdpb"))
+		if err == nil {
+			var x Scene
+			if err := DecodeScene(&x, data); err != nil {
+				b.Fatalf("Scene.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomScene(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeScene(b *testing.B) {
+	x := benchValueScene(b)
+	data, err := EncodeScene(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeScene(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeScene(b *testing.B) {
+	x := benchValueScene(b)
+	data, err := EncodeScene(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Scene
+		if err := DecodeScene(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripScene(b *testing.B) {
+	x := benchValueScene(b)
+	data, err := EncodeScene(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeScene(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Scene
+		if err := DecodeScene(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeSceneJSON(b *testing.B) {
+	x := benchValueScene(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeSceneJSON(b *testing.B) {
+	x := benchValueScene(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Scene
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripSceneJSON(b *testing.B) {
+	x := benchValueScene(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Scene
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
@@ -1,9 +1,10 @@
 package nested
 
 import (
-	"math"
-	"io"
 	"encoding/binary"
+	"io"
+	"math"
+	"unicode/utf8"
 )
 
 // Size limit constants for decode validation
@@ -16,8 +17,14 @@ const (
 // DecodeContext tracks state during decoding to enforce size limits.
 // It maintains a count of total elements across all arrays to prevent
 // excessive memory allocation from malicious or corrupted data.
+// In strict mode it also rejects non-canonical encodings; in reuse mode
+// the decoder recycles the slices, structs and strings already in dest;
+// with an arena, strings and slices are carved from preallocated memory.
 type DecodeContext struct {
 	totalElements int
+	strict        bool
+	reuse         bool
+	arena         *decodeArena
 }
 
 // checkArraySize validates an array count against per-array and total limits.
@@ -36,6 +43,22 @@ func (ctx *DecodeContext) checkArraySize(count uint32) error {
 	return nil
 }
 
+// Canonical NaN bit patterns accepted by strict decoding
+const (
+	CanonicalNaN32 uint32 = 0x7FC00000
+	CanonicalNaN64 uint64 = 0x7FF8000000000000
+)
+
+// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.
+func isCanonicalF32(bits uint32) bool {
+	return bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32
+}
+
+// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.
+func isCanonicalF64(bits uint64) bool {
+	return bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64
+}
+
 
 // DecodePoint decodes a Point from wire format.
 // It validates the data size and delegates to the decoder implementation.
@@ -48,6 +71,42 @@ func DecodePoint(dest *Point, data []byte) error {
 	return decodePoint(dest, data, &offset, ctx)
 }
 
+// DecodePointStrict decodes a Point like DecodePoint, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodePointStrict(dest *Point, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodePoint(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodePointReuse decodes a Point into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodePointReuse(dest *Point, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodePoint(dest, data, &offset, ctx)
+}
+
 // DecodeRectangle decodes a Rectangle from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeRectangle(dest *Rectangle, data []byte) error {
@@ -59,6 +118,42 @@ func DecodeRectangle(dest *Rectangle, data []byte) error {
 	return decodeRectangle(dest, data, &offset, ctx)
 }
 
+// DecodeRectangleStrict decodes a Rectangle like DecodeRectangle, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeRectangleStrict(dest *Rectangle, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeRectangle(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeRectangleReuse decodes a Rectangle into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeRectangleReuse(dest *Rectangle, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeRectangle(dest, data, &offset, ctx)
+}
+
 // DecodeScene decodes a Scene from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeScene(dest *Scene, data []byte) error {
@@ -70,6 +165,42 @@ func DecodeScene(dest *Scene, data []byte) error {
 	return decodeScene(dest, data, &offset, ctx)
 }
 
+// DecodeSceneStrict decodes a Scene like DecodeScene, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeSceneStrict(dest *Scene, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeScene(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeSceneReuse decodes a Scene into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeSceneReuse(dest *Scene, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeScene(dest, data, &offset, ctx)
+}
+
 
 // decodePoint is the helper function that decodes Point fields.
 func decodePoint(dest *Point, data []byte, offset *int, ctx *DecodeContext) error {
@@ -84,14 +215,20 @@ func decodePoint(dest *Point, data []byte, offset *int, ctx *DecodeContext) erro
 
 	// Field: X (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "x", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "x", *offset)
 	}
 	dest.X = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: Y (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "y", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "y", *offset)
 	}
 	dest.Y = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
@@ -113,18 +250,18 @@ func decodeRectangle(dest *Rectangle, data []byte, offset *int, ctx *DecodeConte
 	// Field: TopLeft (Point)
 	err = decodePoint(&dest.TopLeft, data, offset, ctx)
 	if err != nil {
-		return err
+		return decodeError(err, "top_left", *offset)
 	}
 
 	// Field: BottomRight (Point)
 	err = decodePoint(&dest.BottomRight, data, offset, ctx)
 	if err != nil {
-		return err
+		return decodeError(err, "bottom_right", *offset)
 	}
 
 	// Field: Color (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "color", *offset)
 	}
 	dest.Color = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
@@ -145,26 +282,33 @@ func decodeScene(dest *Scene, data []byte, offset *int, ctx *DecodeContext) erro
 
 	// Field: Name (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Name = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {
+		dest.Name = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Name = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: MainRect (Rectangle)
 	err = decodeRectangle(&dest.MainRect, data, offset, ctx)
 	if err != nil {
-		return err
+		return decodeError(err, "main_rect", *offset)
 	}
 
 	// Field: Count (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "count", *offset)
 	}
 	dest.Count = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
@@ -175,7 +319,8 @@ func decodeScene(dest *Scene, data []byte, offset *int, ctx *DecodeContext) erro
 
 // DecodePointMessage decodes a Point from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodePointMessage(data []byte) (*Point, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -200,6 +345,9 @@ func DecodePointMessage(data []byte) (*Point, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -210,18 +358,24 @@ func DecodePointMessage(data []byte) (*Point, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Point
-	if err := DecodePoint(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodePoint(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeRectangleMessage decodes a Rectangle from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeRectangleMessage(data []byte) (*Rectangle, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -246,6 +400,9 @@ func DecodeRectangleMessage(data []byte) (*Rectangle, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -256,18 +413,24 @@ func DecodeRectangleMessage(data []byte) (*Rectangle, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Rectangle
-	if err := DecodeRectangle(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeRectangle(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeSceneMessage decodes a Scene from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeSceneMessage(data []byte) (*Scene, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -292,6 +455,9 @@ func DecodeSceneMessage(data []byte) (*Scene, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -302,11 +468,16 @@ func DecodeSceneMessage(data []byte) (*Scene, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Scene
-	if err := DecodeScene(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeScene(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
@@ -2,8 +2,8 @@ package nested
 
 import (
 	"encoding/binary"
-	"math"
 	"io"
+	"math"
 )
 
 // calculatePointSize calculates the wire format size for Point.
@@ -28,6 +28,40 @@ func EncodePoint(src *Point) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodePoint produces for src.
+func (src *Point) EncodedSize() int {
+	return calculatePointSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodePoint.
+func (src *Point) MarshalBinary() ([]byte, error) {
+	return EncodePoint(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Point) AppendBinary(b []byte) ([]byte, error) {
+	size := calculatePointSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodePoint(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodePoint.
+// The decoded value does not retain data.
+func (dest *Point) UnmarshalBinary(data []byte) error {
+	return DecodePoint(dest, data)
+}
+
 // calculateRectangleSize calculates the wire format size for Rectangle.
 func calculateRectangleSize(src *Rectangle) int {
 	size := 0
@@ -52,6 +86,40 @@ func EncodeRectangle(src *Rectangle) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeRectangle produces for src.
+func (src *Rectangle) EncodedSize() int {
+	return calculateRectangleSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeRectangle.
+func (src *Rectangle) MarshalBinary() ([]byte, error) {
+	return EncodeRectangle(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Rectangle) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateRectangleSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeRectangle(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeRectangle.
+// The decoded value does not retain data.
+func (dest *Rectangle) UnmarshalBinary(data []byte) error {
+	return DecodeRectangle(dest, data)
+}
+
 // calculateSceneSize calculates the wire format size for Scene.
 func calculateSceneSize(src *Scene) int {
 	size := 0
@@ -76,6 +144,40 @@ func EncodeScene(src *Scene) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeScene produces for src.
+func (src *Scene) EncodedSize() int {
+	return calculateSceneSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeScene.
+func (src *Scene) MarshalBinary() ([]byte, error) {
+	return EncodeScene(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Scene) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateSceneSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeScene(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeScene.
+// The decoded value does not retain data.
+func (dest *Scene) UnmarshalBinary(data []byte) error {
+	return DecodeScene(dest, data)
+}
+
 
 // encodePoint is the helper function that encodes Point fields.
 func encodePoint(src *Point, buf []byte, offset *int) error {
@@ -0,0 +1,205 @@
+package nested
+
+import (
+	"math"
+)
+
+// FieldChange is one field-level difference reported by Diff.
+type FieldChange struct {
+	// Path is the schema path of the field, e.g. "plugins[2].name".
+	Path string
+	// Kind is "value" if a primitive field differs, "length" if an array
+	// has a different number of elements and "presence" if an optional
+	// is set on one side only.
+	Kind string
+	// Old and New are the field values for "value", the element counts
+	// (int) for "length" and the presence flags (bool) for "presence".
+	Old any
+	New any
+}
+
+// diffPath joins a field name to the path of its struct.
+func diffPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Point) Equal(other *Point) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if math.Float32bits(x.X) != math.Float32bits(other.X) {
+		return false
+	}
+	if math.Float32bits(x.Y) != math.Float32bits(other.Y) {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Point) Clone() *Point {
+	if x == nil {
+		return nil
+	}
+	c := new(Point)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Point) cloneTo(c *Point) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Point) Diff(other *Point) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Point) diff(prefix string, other *Point, changes *[]FieldChange) {
+	if math.Float32bits(x.X) != math.Float32bits(other.X) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "x"), Kind: "value", Old: x.X, New: other.X})
+	}
+	if math.Float32bits(x.Y) != math.Float32bits(other.Y) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "y"), Kind: "value", Old: x.Y, New: other.Y})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Rectangle) Equal(other *Rectangle) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if !x.TopLeft.Equal(&other.TopLeft) {
+		return false
+	}
+	if !x.BottomRight.Equal(&other.BottomRight) {
+		return false
+	}
+	if x.Color != other.Color {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Rectangle) Clone() *Rectangle {
+	if x == nil {
+		return nil
+	}
+	c := new(Rectangle)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Rectangle) cloneTo(c *Rectangle) {
+	*c = *x
+	x.TopLeft.cloneTo(&c.TopLeft)
+	x.BottomRight.cloneTo(&c.BottomRight)
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Rectangle) Diff(other *Rectangle) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Rectangle) diff(prefix string, other *Rectangle, changes *[]FieldChange) {
+	x.TopLeft.diff(diffPath(prefix, "top_left"), &other.TopLeft, changes)
+	x.BottomRight.diff(diffPath(prefix, "bottom_right"), &other.BottomRight, changes)
+	if x.Color != other.Color {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "color"), Kind: "value", Old: x.Color, New: other.Color})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Scene) Equal(other *Scene) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Name != other.Name {
+		return false
+	}
+	if !x.MainRect.Equal(&other.MainRect) {
+		return false
+	}
+	if x.Count != other.Count {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Scene) Clone() *Scene {
+	if x == nil {
+		return nil
+	}
+	c := new(Scene)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Scene) cloneTo(c *Scene) {
+	*c = *x
+	x.MainRect.cloneTo(&c.MainRect)
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Scene) Diff(other *Scene) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Scene) diff(prefix string, other *Scene, changes *[]FieldChange) {
+	if x.Name != other.Name {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "name"), Kind: "value", Old: x.Name, New: other.Name})
+	}
+	x.MainRect.diff(diffPath(prefix, "main_rect"), &other.MainRect, changes)
+	if x.Count != other.Count {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "count"), Kind: "value", Old: x.Count, New: other.Count})
+	}
+}
+
@@ -0,0 +1,115 @@
+package nested
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzDecodePoint checks that DecodePoint never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodePoint(f *testing.F) {
+	for _, seed := range []*Point{
+		{},
+		{X: 1.5, Y: 1.5},
+	} {
+		data, err := EncodePoint(seed)
+		if err != nil {
+			f.Fatalf("EncodePoint(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Point
+		if err := DecodePoint(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodePoint(&x)
+		if err != nil {
+			t.Fatalf("EncodePoint of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Point
+		if err := DecodePointStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeRectangle checks that DecodeRectangle never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeRectangle(f *testing.F) {
+	for _, seed := range []*Rectangle{
+		{},
+		{TopLeft: Point{X: 1.5, Y: 1.5}, BottomRight: Point{X: 1.5, Y: 1.5}, Color: 1},
+	} {
+		data, err := EncodeRectangle(seed)
+		if err != nil {
+			f.Fatalf("EncodeRectangle(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Rectangle
+		if err := DecodeRectangle(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeRectangle(&x)
+		if err != nil {
+			t.Fatalf("EncodeRectangle of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Rectangle
+		if err := DecodeRectangleStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeScene checks that DecodeScene never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeScene(f *testing.F) {
+	for _, seed := range []*Scene{
+		{},
+		{Name: "sample", MainRect: Rectangle{TopLeft: Point{X: 1.5, Y: 1.5}, BottomRight: Point{X: 1.5, Y: 1.5}, Color: 1}, Count: 1},
+	} {
+		data, err := EncodeScene(seed)
+		if err != nil {
+			f.Fatalf("EncodeScene(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Scene
+		if err := DecodeScene(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeScene(&x)
+		if err != nil {
+			t.Fatalf("EncodeScene of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Scene
+		if err := DecodeSceneStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
@@ -0,0 +1,121 @@
+package nested
+
+import (
+	"math/rand/v2"
+)
+
+// RandomOptions controls the values generated by the Random constructors.
+// A zero field selects its default, so a nil or partially set *RandomOptions
+// gives OptionalProbability 0.5, arrays of 0 to 4 elements and strings of up
+// to 16 ASCII letters and digits unless overridden. A negative
+// OptionalProbability, MaxArrayLen or MaxStringLen asks for absent optionals,
+// arrays of MinArrayLen elements or empty strings.
+type RandomOptions struct {
+	// OptionalProbability is the chance that an optional field is present.
+	OptionalProbability float64
+
+	// MinArrayLen and MaxArrayLen bound the length of every array.
+	MinArrayLen int
+	MaxArrayLen int
+
+	// MaxStringLen bounds the length of every string in runes.
+	MaxStringLen int
+
+	// Charset holds the runes strings are drawn from. Empty means ASCII
+	// letters and digits.
+	Charset string
+}
+
+var defaultRandomOptions = RandomOptions{OptionalProbability: 0.5, MaxArrayLen: 4, MaxStringLen: 16}
+
+const randomCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// withDefaults returns a copy of opts with zero fields set to their defaults.
+func (opts *RandomOptions) withDefaults() *RandomOptions {
+	o := defaultRandomOptions
+	if opts == nil {
+		return &o
+	}
+	if opts.OptionalProbability != 0 {
+		o.OptionalProbability = opts.OptionalProbability
+	}
+	o.MinArrayLen = opts.MinArrayLen
+	if opts.MaxArrayLen != 0 {
+		o.MaxArrayLen = opts.MaxArrayLen
+	}
+	if opts.MaxStringLen != 0 {
+		o.MaxStringLen = opts.MaxStringLen
+	}
+	o.Charset = opts.Charset
+	return &o
+}
+
+// randomLen returns an array length between opts.MinArrayLen and opts.MaxArrayLen.
+func randomLen(r *rand.Rand, opts *RandomOptions) int {
+	n := max(opts.MinArrayLen, 0)
+	if opts.MaxArrayLen > n {
+		n += r.IntN(opts.MaxArrayLen - n + 1)
+	}
+	return n
+}
+
+// randomString returns a string of up to opts.MaxStringLen runes from opts.Charset.
+func randomString(r *rand.Rand, opts *RandomOptions) string {
+	if opts.MaxStringLen <= 0 {
+		return ""
+	}
+	charset := []rune(opts.Charset)
+	if len(charset) == 0 {
+		charset = []rune(randomCharset)
+	}
+	s := make([]rune, r.IntN(opts.MaxStringLen+1))
+	for i := range s {
+		s[i] = charset[r.IntN(len(charset))]
+	}
+	return string(s)
+}
+
+// RandomPoint returns a Point with every field drawn from r. Floats are finite.
+func RandomPoint(r *rand.Rand, opts *RandomOptions) *Point {
+	opts = opts.withDefaults()
+	x := new(Point)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Point) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.X = float32(r.NormFloat64() * 1000)
+	x.Y = float32(r.NormFloat64() * 1000)
+}
+
+// RandomRectangle returns a Rectangle with every field drawn from r. Floats are finite.
+func RandomRectangle(r *rand.Rand, opts *RandomOptions) *Rectangle {
+	opts = opts.withDefaults()
+	x := new(Rectangle)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Rectangle) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.TopLeft.randomize(r, opts)
+	x.BottomRight.randomize(r, opts)
+	x.Color = r.Uint32()
+}
+
+// RandomScene returns a Scene with every field drawn from r. Floats are finite.
+func RandomScene(r *rand.Rand, opts *RandomOptions) *Scene {
+	opts = opts.withDefaults()
+	x := new(Scene)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Scene) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Name = randomString(r, opts)
+	x.MainRect.randomize(r, opts)
+	x.Count = r.Uint32()
+}
+
@@ -0,0 +1,171 @@
+package nested
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageReader reads self-describing messages from a stream.
+// Each message is framed by its 10-byte header, so messages can be
+// written back-to-back to a file, pipe or network connection.
+type MessageReader struct {
+	r io.Reader
+}
+
+// NewMessageReader creates a MessageReader that reads from r.
+func NewMessageReader(r io.Reader) *MessageReader {
+	return &MessageReader{r: r}
+}
+
+// ReadMessage reads the next complete message (header + payload).
+// It returns io.EOF when the stream ends cleanly between messages and
+// ErrUnexpectedEOF when the stream ends inside a message.
+func (mr *MessageReader) ReadMessage() ([]byte, error) {
+	var header [MessageHeaderSize]byte
+	n, err := io.ReadFull(mr.r, header[:])
+	if err != nil {
+		if err == io.EOF && n == 0 {
+			return nil, io.EOF
+		}
+		if err == io.ErrUnexpectedEOF {
+			return nil, ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	// Validate header before allocating the payload
+	if string(header[0:3]) != MessageMagic {
+		return nil, ErrInvalidMagic
+	}
+	if header[3] != MessageVersion {
+		return nil, ErrInvalidVersion
+	}
+	payloadLength := binary.LittleEndian.Uint32(header[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
+
+	message := make([]byte, MessageHeaderSize+int(payloadLength))
+	copy(message, header[:])
+	if _, err := io.ReadFull(mr.r, message[MessageHeaderSize:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Router dispatches self-describing messages to typed handlers.
+// Register handlers with the On* methods, then call Dispatch for single
+// messages or Serve to process a stream. A Router is not safe for
+// concurrent registration, but Dispatch may be called concurrently once
+// all handlers are registered.
+type Router struct {
+	onPoint func(*Point) error
+	onRectangle func(*Rectangle) error
+	onScene func(*Scene) error
+	onUnknown func(typeID uint16, data []byte) error
+}
+
+// NewRouter creates a Router with no handlers registered.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// OnUnknown registers the fallback handler. It receives the complete message
+// for type IDs not in this schema and for types without a registered handler.
+func (r *Router) OnUnknown(h func(typeID uint16, data []byte) error) *Router {
+	r.onUnknown = h
+	return r
+}
+
+// OnPoint registers the handler for Point messages.
+func (r *Router) OnPoint(h func(*Point) error) *Router {
+	r.onPoint = h
+	return r
+}
+
+// OnRectangle registers the handler for Rectangle messages.
+func (r *Router) OnRectangle(h func(*Rectangle) error) *Router {
+	r.onRectangle = h
+	return r
+}
+
+// OnScene registers the handler for Scene messages.
+func (r *Router) OnScene(h func(*Scene) error) *Router {
+	r.onScene = h
+	return r
+}
+
+// Dispatch decodes a single message and calls the handler registered for its type.
+// Header and decode errors are returned as-is; handler errors are passed through.
+func (r *Router) Dispatch(data []byte) error {
+	// Check minimum message size
+	if len(data) < MessageHeaderSize {
+		return ErrUnexpectedEOF
+	}
+
+	// Validate magic bytes and version
+	if string(data[0:3]) != MessageMagic {
+		return ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+
+	// Dispatch to typed handler
+	switch typeID {
+	case 1:
+		if r.onPoint != nil {
+			msg, err := DecodePointMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onPoint(msg)
+		}
+	case 2:
+		if r.onRectangle != nil {
+			msg, err := DecodeRectangleMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onRectangle(msg)
+		}
+	case 3:
+		if r.onScene != nil {
+			msg, err := DecodeSceneMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onScene(msg)
+		}
+	}
+
+	// Unknown type ID or no handler registered
+	if r.onUnknown != nil {
+		return r.onUnknown(typeID, data)
+	}
+	return ErrUnknownMessageType
+}
+
+// Serve reads messages from mr and dispatches each one until the stream ends.
+// It returns nil on a clean end of stream, or the first read, decode or
+// handler error.
+func (r *Router) Serve(mr *MessageReader) error {
+	for {
+		msg, err := mr.ReadMessage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.Dispatch(msg); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package nested
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+)
+
+// stringMaxElems is the number of array elements String and LogValue show.
+const stringMaxElems = 16
+
+// stringMaxBytes is the number of []u8 bytes String and LogValue show.
+const stringMaxBytes = 32
+
+// stringRedacted replaces the value of fields marked @redact.
+const stringRedacted = "[REDACTED]"
+
+// appendStringMore appends the number of elements left out of a truncated array.
+func appendStringMore(b []byte, n int) []byte {
+	b = append(b, "... (+"...)
+	b = strconv.AppendInt(b, int64(n), 10)
+	return append(b, " more)"...)
+}
+
+// appendStringBytes appends a []u8 in hex.
+func appendStringBytes(b, v []byte) []byte {
+	b = append(b, "0x"...)
+	if len(v) <= stringMaxBytes {
+		return hex.AppendEncode(b, v)
+	}
+	b = hex.AppendEncode(b, v[:stringMaxBytes])
+	return appendStringMore(b, len(v)-stringMaxBytes)
+}
+
+// logValueElems returns the first stringMaxElems elements of an array as a
+// group keyed by index.
+func logValueElems[T any](elems []T, value func(*T) slog.Value) slog.Value {
+	attrs := make([]slog.Attr, 0, min(len(elems), stringMaxElems+1))
+	for i := range elems {
+		if i == stringMaxElems {
+			attrs = append(attrs, slog.Int("more", len(elems)-i))
+			break
+		}
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: value(&elems[i])})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Point) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Point) appendString(b []byte) []byte {
+	b = append(b, "Point{"...)
+	b = append(b, "x: "...)
+	b = strconv.AppendFloat(b, float64(x.X), 'g', -1, 32)
+	b = append(b, ", y: "...)
+	b = strconv.AppendFloat(b, float64(x.Y), 'g', -1, 32)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Point) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.Float64("x", float64(x.X)))
+	attrs = append(attrs, slog.Float64("y", float64(x.Y)))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Rectangle) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Rectangle) appendString(b []byte) []byte {
+	b = append(b, "Rectangle{"...)
+	b = append(b, "top_left: "...)
+	b = x.TopLeft.appendString(b)
+	b = append(b, ", bottom_right: "...)
+	b = x.BottomRight.appendString(b)
+	b = append(b, ", color: "...)
+	b = strconv.AppendUint(b, uint64(x.Color), 10)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Rectangle) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 3)
+	attrs = append(attrs, slog.Attr{Key: "top_left", Value: x.TopLeft.LogValue()})
+	attrs = append(attrs, slog.Attr{Key: "bottom_right", Value: x.BottomRight.LogValue()})
+	attrs = append(attrs, slog.Uint64("color", uint64(x.Color)))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Scene) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Scene) appendString(b []byte) []byte {
+	b = append(b, "Scene{"...)
+	b = append(b, "name: "...)
+	b = strconv.AppendQuote(b, x.Name)
+	b = append(b, ", main_rect: "...)
+	b = x.MainRect.appendString(b)
+	b = append(b, ", count: "...)
+	b = strconv.AppendUint(b, uint64(x.Count), 10)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Scene) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 3)
+	attrs = append(attrs, slog.String("name", x.Name))
+	attrs = append(attrs, slog.Attr{Key: "main_rect", Value: x.MainRect.LogValue()})
+	attrs = append(attrs, slog.Uint64("count", uint64(x.Count)))
+	return slog.GroupValue(attrs...)
+}
+
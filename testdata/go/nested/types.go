@@ -1,18 +1,82 @@
 package nested
 
 type Point struct {
-	X float32
-	Y float32
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
 }
 
 type Rectangle struct {
-	TopLeft Point
-	BottomRight Point
-	Color uint32
+	TopLeft Point `json:"top_left"`
+	BottomRight Point `json:"bottom_right"`
+	Color uint32 `json:"color"`
 }
 
 type Scene struct {
-	Name string
-	MainRect Rectangle
-	Count uint32
+	Name string `json:"name"`
+	MainRect Rectangle `json:"main_rect"`
+	Count uint32 `json:"count"`
+}
+
+// GetX returns x, or the zero value if x is nil.
+func (x *Point) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+// GetY returns y, or the zero value if x is nil.
+func (x *Point) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// GetTopLeft returns top_left, or the zero value if x is nil.
+func (x *Rectangle) GetTopLeft() *Point {
+	if x != nil {
+		return &x.TopLeft
+	}
+	return nil
+}
+
+// GetBottomRight returns bottom_right, or the zero value if x is nil.
+func (x *Rectangle) GetBottomRight() *Point {
+	if x != nil {
+		return &x.BottomRight
+	}
+	return nil
+}
+
+// GetColor returns color, or the zero value if x is nil.
+func (x *Rectangle) GetColor() uint32 {
+	if x != nil {
+		return x.Color
+	}
+	return 0
+}
+
+// GetName returns name, or the zero value if x is nil.
+func (x *Scene) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetMainRect returns main_rect, or the zero value if x is nil.
+func (x *Scene) GetMainRect() *Rectangle {
+	if x != nil {
+		return &x.MainRect
+	}
+	return nil
+}
+
+// GetCount returns count, or the zero value if x is nil.
+func (x *Scene) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
 }
@@ -0,0 +1,202 @@
+package nested
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// ValidatePoint checks that data starts with a well-formed Point without decoding it.
+// It accepts the input DecodePoint accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidatePoint(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validatePoint(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateRectangle checks that data starts with a well-formed Rectangle without decoding it.
+// It accepts the input DecodeRectangle accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateRectangle(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateRectangle(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateScene checks that data starts with a well-formed Scene without decoding it.
+// It accepts the input DecodeScene accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateScene(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateScene(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// validatePoint advances offset over one Point value.
+func validatePoint(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: x
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "x", *offset)
+	}
+	*offset += 4
+
+	// Field: y
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "y", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// validateRectangle advances offset over one Rectangle value.
+func validateRectangle(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: top_left
+	err = validatePoint(data, offset, ctx)
+	if err != nil {
+		return decodeError(err, "top_left", *offset)
+	}
+
+	// Field: bottom_right
+	err = validatePoint(data, offset, ctx)
+	if err != nil {
+		return decodeError(err, "bottom_right", *offset)
+	}
+
+	// Field: color
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "color", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// validateScene advances offset over one Scene value.
+func validateScene(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: main_rect
+	err = validateRectangle(data, offset, ctx)
+	if err != nil {
+		return decodeError(err, "main_rect", *offset)
+	}
+
+	// Field: count
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "count", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// ValidateMessage checks that data starts with a well-formed self-describing
+// message of any type in this schema, without decoding it. The payload must
+// hold exactly one value of the type; ErrTrailingBytes is returned if it
+// extends past the value. It returns the total message length
+// (header + payload).
+func ValidateMessage(data []byte) (int, error) {
+	if len(data) < MessageHeaderSize {
+		return 0, ErrUnexpectedEOF
+	}
+	if string(data[0:3]) != MessageMagic {
+		return 0, ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return 0, ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	end := MessageHeaderSize + int(payloadLength)
+	if len(data) < end {
+		return 0, ErrUnexpectedEOF
+	}
+	payload := data[MessageHeaderSize:end]
+
+	var (
+		n   int
+		err error
+	)
+	switch typeID {
+	case 1:
+		n, err = ValidatePoint(payload)
+	case 2:
+		n, err = ValidateRectangle(payload)
+	case 3:
+		n, err = ValidateScene(payload)
+	default:
+		return 0, ErrUnknownMessageType
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n != len(payload) {
+		return 0, ErrTrailingBytes
+	}
+	return end, nil
+}
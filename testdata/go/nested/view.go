@@ -0,0 +1,220 @@
+package nested
+
+import (
+	"encoding/binary"
+	"iter"
+	"math"
+	"unsafe"
+)
+
+// ArrayView is a read-only view of an encoded array. Elements are read
+// from the underlying buffer on access; nothing is copied up front.
+type ArrayView[T any] struct {
+	data []byte
+	off  int  // Offset of the first element
+	n    int  // Element count
+	size int  // Element size, or 0 for variable-size elements
+	read func(data []byte, off int) T
+	skip func(data []byte, off int) int
+}
+
+// newArrayView creates an ArrayView over the array whose count prefix is at off.
+func newArrayView[T any](data []byte, off int, size int, read func([]byte, int) T, skip func([]byte, int) int) ArrayView[T] {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	return ArrayView[T]{data: data, off: off + 4, n: n, size: size, read: read, skip: skip}
+}
+
+// Len returns the number of elements.
+func (a ArrayView[T]) Len() int {
+	return a.n
+}
+
+// At returns element i. Fixed-size elements are located directly;
+// variable-size elements (strings, structs) are found by skipping the
+// preceding ones, so use All to visit every element. At panics if i is
+// out of range.
+func (a ArrayView[T]) At(i int) T {
+	if i < 0 || i >= a.n {
+		panic("ArrayView: index out of range")
+	}
+	if a.size > 0 {
+		return a.read(a.data, a.off+i*a.size)
+	}
+	off := a.off
+	for ; i > 0; i-- {
+		off = a.skip(a.data, off)
+	}
+	return a.read(a.data, off)
+}
+
+// All returns an iterator over the index and value of every element.
+func (a ArrayView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		off := a.off
+		for i := 0; i < a.n; i++ {
+			if !yield(i, a.read(a.data, off)) {
+				return
+			}
+			if a.size > 0 {
+				off += a.size
+			} else {
+				off = a.skip(a.data, off)
+			}
+		}
+	}
+}
+
+// viewString returns the length-prefixed string at off without copying.
+func viewString(data []byte, off int) string {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	if n == 0 {
+		return ""
+	}
+	return unsafe.String(&data[off+4], n)
+}
+
+// viewSkipString returns the offset just past the string at off.
+func viewSkipString(data []byte, off int) int {
+	return off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+}
+
+// viewBytes returns the []u8 array at off as a sub-slice of data.
+// Its capacity is clipped so appending to it cannot overwrite data.
+func viewBytes(data []byte, off int) []byte {
+	end := off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+	return data[off+4 : end : end]
+}
+
+// viewSkipArray returns the offset just past the array of variable-size
+// elements at off, using skip to step over each element.
+func viewSkipArray(data []byte, off int, skip func([]byte, int) int) int {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	for i := 0; i < n; i++ {
+		off = skip(data, off)
+	}
+	return off
+}
+
+// PointView is a read-only, zero-copy view of an encoded Point.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type PointView struct {
+	data []byte
+}
+
+// NewPointView validates data with ValidatePoint and returns a view over it.
+func NewPointView(data []byte) (PointView, error) {
+	if _, err := ValidatePoint(data); err != nil {
+		return PointView{}, err
+	}
+	return PointView{data: data}, nil
+}
+
+// Decode materializes the full Point value.
+func (v PointView) Decode(dest *Point) error {
+	return DecodePoint(dest, v.data)
+}
+
+// X returns the x field.
+func (v PointView) X() float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[0:]))
+}
+
+// Y returns the y field.
+func (v PointView) Y() float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[4:]))
+}
+
+// skipPoint returns the offset just past the Point value starting at off.
+func skipPoint(data []byte, off int) int {
+	off += 8
+	return off
+}
+
+// RectangleView is a read-only, zero-copy view of an encoded Rectangle.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type RectangleView struct {
+	data []byte
+}
+
+// NewRectangleView validates data with ValidateRectangle and returns a view over it.
+func NewRectangleView(data []byte) (RectangleView, error) {
+	if _, err := ValidateRectangle(data); err != nil {
+		return RectangleView{}, err
+	}
+	return RectangleView{data: data}, nil
+}
+
+// Decode materializes the full Rectangle value.
+func (v RectangleView) Decode(dest *Rectangle) error {
+	return DecodeRectangle(dest, v.data)
+}
+
+// TopLeft returns the top_left field.
+func (v RectangleView) TopLeft() PointView {
+	return PointView{data: v.data[0:]}
+}
+
+// BottomRight returns the bottom_right field.
+func (v RectangleView) BottomRight() PointView {
+	return PointView{data: v.data[8:]}
+}
+
+// Color returns the color field.
+func (v RectangleView) Color() uint32 {
+	return binary.LittleEndian.Uint32(v.data[16:])
+}
+
+// skipRectangle returns the offset just past the Rectangle value starting at off.
+func skipRectangle(data []byte, off int) int {
+	off += 20
+	return off
+}
+
+// SceneView is a read-only, zero-copy view of an encoded Scene.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type SceneView struct {
+	data []byte
+}
+
+// NewSceneView validates data with ValidateScene and returns a view over it.
+func NewSceneView(data []byte) (SceneView, error) {
+	if _, err := ValidateScene(data); err != nil {
+		return SceneView{}, err
+	}
+	return SceneView{data: data}, nil
+}
+
+// Decode materializes the full Scene value.
+func (v SceneView) Decode(dest *Scene) error {
+	return DecodeScene(dest, v.data)
+}
+
+// Name returns the name field.
+func (v SceneView) Name() string {
+	return viewString(v.data, 0)
+}
+
+// MainRect returns the main_rect field.
+func (v SceneView) MainRect() RectangleView {
+	off := 0
+	off = viewSkipString(v.data, off)
+	return RectangleView{data: v.data[off:]}
+}
+
+// Count returns the count field.
+func (v SceneView) Count() uint32 {
+	off := 0
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint32(v.data[off+20:])
+}
+
+// skipScene returns the offset just past the Scene value starting at off.
+func skipScene(data []byte, off int) int {
+	off = viewSkipString(data, off)
+	off += 24
+	return off
+}
@@ -0,0 +1,515 @@
+package optional
+
+import (
+	"encoding/binary"
+	"unsafe"
+)
+
+// DecodeRequestArena decodes a Request like DecodeRequest, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeRequestArena(dest *Request, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureRequest(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeRequest(dest, data, &offset, ctx)
+}
+
+// DecodeMetadataArena decodes a Metadata like DecodeMetadata, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeMetadataArena(dest *Metadata, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureMetadata(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeMetadata(dest, data, &offset, ctx)
+}
+
+// DecodeConfigArena decodes a Config like DecodeConfig, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeConfigArena(dest *Config, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureConfig(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeConfig(dest, data, &offset, ctx)
+}
+
+// DecodeDatabaseConfigArena decodes a DatabaseConfig like DecodeDatabaseConfig, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeDatabaseConfigArena(dest *DatabaseConfig, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureDatabaseConfig(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeDatabaseConfig(dest, data, &offset, ctx)
+}
+
+// DecodeCacheConfigArena decodes a CacheConfig like DecodeCacheConfig, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeCacheConfigArena(dest *CacheConfig, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureCacheConfig(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeCacheConfig(dest, data, &offset, ctx)
+}
+
+// DecodeDocumentArena decodes a Document like DecodeDocument, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeDocumentArena(dest *Document, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureDocument(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeDocument(dest, data, &offset, ctx)
+}
+
+// DecodeTagListArena decodes a TagList like DecodeTagList, but carves all strings and
+// slices out of a few up-front allocations sized by a first pass over data.
+//
+// The decoded value never aliases data. Strings and primitive slices share
+// one block of memory, so retaining any one of them keeps the whole block
+// alive; copy long-lived pieces out of large values. Slices have no spare
+// capacity, so appending to them reallocates.
+func DecodeTagListArena(dest *TagList, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	var size decodeArenaSize
+	offset := 0
+	if err := measureTagList(data, &offset, &DecodeContext{}, &size); err != nil {
+		return err
+	}
+	var arena decodeArena
+	arena.init(&size)
+	ctx := &DecodeContext{arena: &arena}
+	offset = 0
+	return decodeTagList(dest, data, &offset, ctx)
+}
+
+// decodeArenaSize accumulates the memory a value needs, measured by the
+// first pass of arena decoding.
+type decodeArenaSize struct {
+	bytes   int  // Primitive slices, each rounded up to 8 bytes
+	strings int  // String contents
+	strs    int  // Elements of []str arrays
+	Metadata int
+	DatabaseConfig int
+	CacheConfig int
+	TagList int
+}
+
+// decodeArena holds the preallocated memory that arena decoding carves
+// values from. buf holds primitive slices followed by string contents.
+type decodeArena struct {
+	buf    []byte
+	pos    int  // Next free byte for primitive slices
+	strPos int  // Next free byte for string contents
+	strs   []string
+	Metadata []Metadata
+	DatabaseConfig []DatabaseConfig
+	CacheConfig []CacheConfig
+	TagList []TagList
+}
+
+// init allocates the arena memory for the measured size. The byte block is
+// backed by []uint64 so that every primitive slice is 8-byte aligned.
+func (a *decodeArena) init(size *decodeArenaSize) {
+	if n := size.bytes + size.strings; n > 0 {
+		words := make([]uint64, (n+7)/8)
+		a.buf = unsafe.Slice((*byte)(unsafe.Pointer(&words[0])), n)
+	}
+	a.strPos = size.bytes
+	if size.strs > 0 {
+		a.strs = make([]string, size.strs)
+	}
+	if size.Metadata > 0 {
+		a.Metadata = make([]Metadata, size.Metadata)
+	}
+	if size.DatabaseConfig > 0 {
+		a.DatabaseConfig = make([]DatabaseConfig, size.DatabaseConfig)
+	}
+	if size.CacheConfig > 0 {
+		a.CacheConfig = make([]CacheConfig, size.CacheConfig)
+	}
+	if size.TagList > 0 {
+		a.TagList = make([]TagList, size.TagList)
+	}
+}
+
+// string copies b into the arena and returns it as a string.
+func (a *decodeArena) string(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	p := a.strPos
+	a.strPos += copy(a.buf[p:], b)
+	return unsafe.String(&a.buf[p], len(b))
+}
+
+// arenaPrimitives carves a zeroed slice of n elements from the arena's byte
+// block. T must be a primitive type without pointers.
+func arenaPrimitives[T any](a *decodeArena, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	var zero T
+	s := unsafe.Slice((*T)(unsafe.Pointer(&a.buf[a.pos])), n)
+	a.pos += (int(n)*int(unsafe.Sizeof(zero)) + 7) &^ 7
+	return s
+}
+
+// arenaTake carves a zeroed slice of n elements from the front of pool.
+func arenaTake[T any](pool *[]T, n uint32) []T {
+	if n == 0 {
+		return []T{}
+	}
+	s := (*pool)[:n:n]
+	*pool = (*pool)[n:]
+	return s
+}
+
+// measureRequest advances offset over one Request value and adds the arena memory it needs to size.
+func measureRequest(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: metadata
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "metadata", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		size.Metadata += 1
+		err = measureMetadata(data, offset, ctx, size)
+		if err != nil {
+			return decodeError(err, "metadata", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "metadata", *offset)
+	}
+
+	return nil
+}
+
+// measureMetadata advances offset over one Metadata value and adds the arena memory it needs to size.
+func measureMetadata(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: user_id
+	if *offset + 8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "user_id", *offset)
+	}
+	*offset += 8
+
+	// Field: username
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "username", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "username", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	return nil
+}
+
+// measureConfig advances offset over one Config value and adds the arena memory it needs to size.
+func measureConfig(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	// Field: database
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "database", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		size.DatabaseConfig += 1
+		err = measureDatabaseConfig(data, offset, ctx, size)
+		if err != nil {
+			return decodeError(err, "database", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "database", *offset)
+	}
+
+	// Field: cache
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "cache", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		size.CacheConfig += 1
+		err = measureCacheConfig(data, offset, ctx, size)
+		if err != nil {
+			return decodeError(err, "cache", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "cache", *offset)
+	}
+
+	return nil
+}
+
+// measureDatabaseConfig advances offset over one DatabaseConfig value and adds the arena memory it needs to size.
+func measureDatabaseConfig(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: host
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "host", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "host", *offset)
+	}
+	size.strings += int(strLen)
+	*offset += int(strLen)
+
+	// Field: port
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "port", *offset)
+	}
+	*offset += 2
+
+	return nil
+}
+
+// measureCacheConfig advances offset over one CacheConfig value and adds the arena memory it needs to size.
+func measureCacheConfig(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: size_mb
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "size_mb", *offset)
+	}
+	*offset += 4
+
+	// Field: ttl_seconds
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "ttl_seconds", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// measureDocument advances offset over one Document value and adds the arena memory it needs to size.
+func measureDocument(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: tags
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "tags", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		size.TagList += 1
+		err = measureTagList(data, offset, ctx, size)
+		if err != nil {
+			return decodeError(err, "tags", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "tags", *offset)
+	}
+
+	return nil
+}
+
+// measureTagList advances offset over one TagList value and adds the arena memory it needs to size.
+func measureTagList(data []byte, offset *int, ctx *DecodeContext, size *decodeArenaSize) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: items
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "items", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "items", *offset-4)
+	}
+	size.strs += int(arrCount)
+	for i := uint32(0); i < arrCount; i++ {
+		if *offset + 4 > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "items", *offset)
+		}
+		strLen = binary.LittleEndian.Uint32(data[*offset:])
+		*offset += 4
+		if *offset + int(strLen) > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "items", *offset)
+		}
+		size.strings += int(strLen)
+		*offset += int(strLen)
+	}
+
+	return nil
+}
@@ -0,0 +1,940 @@
+package optional
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var benchData = flag.String("benchdata", "", "directory of <Struct>.sdpb files to benchmark instead of random values")
+
+// benchRandomOptions shapes the random values of structs without a fixture.
+var benchRandomOptions = &RandomOptions{OptionalProbability: 1, MinArrayLen: 8, MaxArrayLen: 8, MaxStringLen: 32}
+
+// benchValueRequest returns the Request the benchmarks run on.
+func benchValueRequest(b *testing.B) *Request {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Request.sdpb"))
+		if err == nil {
+			var x Request
+			if err := DecodeRequest(&x, data); err != nil {
+				b.Fatalf("Request.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomRequest(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeRequest(b *testing.B) {
+	x := benchValueRequest(b)
+	data, err := EncodeRequest(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeRequest(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeRequest(b *testing.B) {
+	x := benchValueRequest(b)
+	data, err := EncodeRequest(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Request
+		if err := DecodeRequest(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripRequest(b *testing.B) {
+	x := benchValueRequest(b)
+	data, err := EncodeRequest(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeRequest(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Request
+		if err := DecodeRequest(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeRequestJSON(b *testing.B) {
+	x := benchValueRequest(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeRequestJSON(b *testing.B) {
+	x := benchValueRequest(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Request
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripRequestJSON(b *testing.B) {
+	x := benchValueRequest(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Request
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueMetadata returns the Metadata the benchmarks run on.
+func benchValueMetadata(b *testing.B) *Metadata {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Metadata.sdpb"))
+		if err == nil {
+			var x Metadata
+			if err := DecodeMetadata(&x, data); err != nil {
+				b.Fatalf("Metadata.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomMetadata(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeMetadata(b *testing.B) {
+	x := benchValueMetadata(b)
+	data, err := EncodeMetadata(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeMetadata(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeMetadata(b *testing.B) {
+	x := benchValueMetadata(b)
+	data, err := EncodeMetadata(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Metadata
+		if err := DecodeMetadata(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripMetadata(b *testing.B) {
+	x := benchValueMetadata(b)
+	data, err := EncodeMetadata(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeMetadata(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Metadata
+		if err := DecodeMetadata(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeMetadataJSON(b *testing.B) {
+	x := benchValueMetadata(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeMetadataJSON(b *testing.B) {
+	x := benchValueMetadata(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Metadata
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripMetadataJSON(b *testing.B) {
+	x := benchValueMetadata(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Metadata
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueConfig returns the Config the benchmarks run on.
+func benchValueConfig(b *testing.B) *Config {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Config.sdpb"))
+		if err == nil {
+			var x Config
+			if err := DecodeConfig(&x, data); err != nil {
+				b.Fatalf("Config.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomConfig(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeConfig(b *testing.B) {
+	x := benchValueConfig(b)
+	data, err := EncodeConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeConfig(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeConfig(b *testing.B) {
+	x := benchValueConfig(b)
+	data, err := EncodeConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Config
+		if err := DecodeConfig(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripConfig(b *testing.B) {
+	x := benchValueConfig(b)
+	data, err := EncodeConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeConfig(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Config
+		if err := DecodeConfig(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeConfigJSON(b *testing.B) {
+	x := benchValueConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeConfigJSON(b *testing.B) {
+	x := benchValueConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Config
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripConfigJSON(b *testing.B) {
+	x := benchValueConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Config
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueDatabaseConfig returns the DatabaseConfig the benchmarks run on.
+func benchValueDatabaseConfig(b *testing.B) *DatabaseConfig {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "DatabaseConfig.sdpb"))
+		if err == nil {
+			var x DatabaseConfig
+			if err := DecodeDatabaseConfig(&x, data); err != nil {
+				b.Fatalf("DatabaseConfig.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomDatabaseConfig(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeDatabaseConfig(b *testing.B) {
+	x := benchValueDatabaseConfig(b)
+	data, err := EncodeDatabaseConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeDatabaseConfig(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeDatabaseConfig(b *testing.B) {
+	x := benchValueDatabaseConfig(b)
+	data, err := EncodeDatabaseConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result DatabaseConfig
+		if err := DecodeDatabaseConfig(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripDatabaseConfig(b *testing.B) {
+	x := benchValueDatabaseConfig(b)
+	data, err := EncodeDatabaseConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeDatabaseConfig(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result DatabaseConfig
+		if err := DecodeDatabaseConfig(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeDatabaseConfigJSON(b *testing.B) {
+	x := benchValueDatabaseConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeDatabaseConfigJSON(b *testing.B) {
+	x := benchValueDatabaseConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result DatabaseConfig
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripDatabaseConfigJSON(b *testing.B) {
+	x := benchValueDatabaseConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result DatabaseConfig
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueCacheConfig returns the CacheConfig the benchmarks run on.
+func benchValueCacheConfig(b *testing.B) *CacheConfig {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "CacheConfig.sdpb"))
+		if err == nil {
+			var x CacheConfig
+			if err := DecodeCacheConfig(&x, data); err != nil {
+				b.Fatalf("CacheConfig.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomCacheConfig(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeCacheConfig(b *testing.B) {
+	x := benchValueCacheConfig(b)
+	data, err := EncodeCacheConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeCacheConfig(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeCacheConfig(b *testing.B) {
+	x := benchValueCacheConfig(b)
+	data, err := EncodeCacheConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result CacheConfig
+		if err := DecodeCacheConfig(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripCacheConfig(b *testing.B) {
+	x := benchValueCacheConfig(b)
+	data, err := EncodeCacheConfig(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeCacheConfig(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result CacheConfig
+		if err := DecodeCacheConfig(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeCacheConfigJSON(b *testing.B) {
+	x := benchValueCacheConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeCacheConfigJSON(b *testing.B) {
+	x := benchValueCacheConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result CacheConfig
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripCacheConfigJSON(b *testing.B) {
+	x := benchValueCacheConfig(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result CacheConfig
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueDocument returns the Document the benchmarks run on.
+func benchValueDocument(b *testing.B) *Document {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "Document.sdpb"))
+		if err == nil {
+			var x Document
+			if err := DecodeDocument(&x, data); err != nil {
+				b.Fatalf("Document.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomDocument(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeDocument(b *testing.B) {
+	x := benchValueDocument(b)
+	data, err := EncodeDocument(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeDocument(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeDocument(b *testing.B) {
+	x := benchValueDocument(b)
+	data, err := EncodeDocument(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Document
+		if err := DecodeDocument(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripDocument(b *testing.B) {
+	x := benchValueDocument(b)
+	data, err := EncodeDocument(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeDocument(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Document
+		if err := DecodeDocument(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeDocumentJSON(b *testing.B) {
+	x := benchValueDocument(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeDocumentJSON(b *testing.B) {
+	x := benchValueDocument(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result Document
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripDocumentJSON(b *testing.B) {
+	x := benchValueDocument(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result Document
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchValueTagList returns the TagList the benchmarks run on.
+func benchValueTagList(b *testing.B) *TagList {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "TagList.sdpb"))
+		if err == nil {
+			var x TagList
+			if err := DecodeTagList(&x, data); err != nil {
+				b.Fatalf("TagList.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomTagList(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeTagList(b *testing.B) {
+	x := benchValueTagList(b)
+	data, err := EncodeTagList(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeTagList(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeTagList(b *testing.B) {
+	x := benchValueTagList(b)
+	data, err := EncodeTagList(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result TagList
+		if err := DecodeTagList(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripTagList(b *testing.B) {
+	x := benchValueTagList(b)
+	data, err := EncodeTagList(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeTagList(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result TagList
+		if err := DecodeTagList(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeTagListJSON(b *testing.B) {
+	x := benchValueTagList(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeTagListJSON(b *testing.B) {
+	x := benchValueTagList(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result TagList
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripTagListJSON(b *testing.B) {
+	x := benchValueTagList(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result TagList
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
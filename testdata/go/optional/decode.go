@@ -3,6 +3,7 @@ package optional
 import (
 	"encoding/binary"
 	"io"
+	"unicode/utf8"
 )
 
 // Size limit constants for decode validation
@@ -15,8 +16,14 @@ const (
 // DecodeContext tracks state during decoding to enforce size limits.
 // It maintains a count of total elements across all arrays to prevent
 // excessive memory allocation from malicious or corrupted data.
+// In strict mode it also rejects non-canonical encodings; in reuse mode
+// the decoder recycles the slices, structs and strings already in dest;
+// with an arena, strings and slices are carved from preallocated memory.
 type DecodeContext struct {
 	totalElements int
+	strict        bool
+	reuse         bool
+	arena         *decodeArena
 }
 
 // checkArraySize validates an array count against per-array and total limits.
@@ -35,6 +42,22 @@ func (ctx *DecodeContext) checkArraySize(count uint32) error {
 	return nil
 }
 
+// Canonical NaN bit patterns accepted by strict decoding
+const (
+	CanonicalNaN32 uint32 = 0x7FC00000
+	CanonicalNaN64 uint64 = 0x7FF8000000000000
+)
+
+// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.
+func isCanonicalF32(bits uint32) bool {
+	return bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32
+}
+
+// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.
+func isCanonicalF64(bits uint64) bool {
+	return bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64
+}
+
 
 // DecodeRequest decodes a Request from wire format.
 // It validates the data size and delegates to the decoder implementation.
@@ -47,6 +70,42 @@ func DecodeRequest(dest *Request, data []byte) error {
 	return decodeRequest(dest, data, &offset, ctx)
 }
 
+// DecodeRequestStrict decodes a Request like DecodeRequest, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeRequestStrict(dest *Request, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeRequest(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeRequestReuse decodes a Request into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeRequestReuse(dest *Request, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeRequest(dest, data, &offset, ctx)
+}
+
 // DecodeMetadata decodes a Metadata from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeMetadata(dest *Metadata, data []byte) error {
@@ -58,6 +117,42 @@ func DecodeMetadata(dest *Metadata, data []byte) error {
 	return decodeMetadata(dest, data, &offset, ctx)
 }
 
+// DecodeMetadataStrict decodes a Metadata like DecodeMetadata, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeMetadataStrict(dest *Metadata, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeMetadata(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeMetadataReuse decodes a Metadata into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeMetadataReuse(dest *Metadata, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeMetadata(dest, data, &offset, ctx)
+}
+
 // DecodeConfig decodes a Config from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeConfig(dest *Config, data []byte) error {
@@ -69,6 +164,42 @@ func DecodeConfig(dest *Config, data []byte) error {
 	return decodeConfig(dest, data, &offset, ctx)
 }
 
+// DecodeConfigStrict decodes a Config like DecodeConfig, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeConfigStrict(dest *Config, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeConfig(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeConfigReuse decodes a Config into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeConfigReuse(dest *Config, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeConfig(dest, data, &offset, ctx)
+}
+
 // DecodeDatabaseConfig decodes a DatabaseConfig from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeDatabaseConfig(dest *DatabaseConfig, data []byte) error {
@@ -80,6 +211,42 @@ func DecodeDatabaseConfig(dest *DatabaseConfig, data []byte) error {
 	return decodeDatabaseConfig(dest, data, &offset, ctx)
 }
 
+// DecodeDatabaseConfigStrict decodes a DatabaseConfig like DecodeDatabaseConfig, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeDatabaseConfigStrict(dest *DatabaseConfig, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeDatabaseConfig(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeDatabaseConfigReuse decodes a DatabaseConfig into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeDatabaseConfigReuse(dest *DatabaseConfig, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeDatabaseConfig(dest, data, &offset, ctx)
+}
+
 // DecodeCacheConfig decodes a CacheConfig from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeCacheConfig(dest *CacheConfig, data []byte) error {
@@ -91,6 +258,42 @@ func DecodeCacheConfig(dest *CacheConfig, data []byte) error {
 	return decodeCacheConfig(dest, data, &offset, ctx)
 }
 
+// DecodeCacheConfigStrict decodes a CacheConfig like DecodeCacheConfig, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeCacheConfigStrict(dest *CacheConfig, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeCacheConfig(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeCacheConfigReuse decodes a CacheConfig into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeCacheConfigReuse(dest *CacheConfig, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeCacheConfig(dest, data, &offset, ctx)
+}
+
 // DecodeDocument decodes a Document from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeDocument(dest *Document, data []byte) error {
@@ -102,6 +305,42 @@ func DecodeDocument(dest *Document, data []byte) error {
 	return decodeDocument(dest, data, &offset, ctx)
 }
 
+// DecodeDocumentStrict decodes a Document like DecodeDocument, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeDocumentStrict(dest *Document, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeDocument(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeDocumentReuse decodes a Document into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeDocumentReuse(dest *Document, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeDocument(dest, data, &offset, ctx)
+}
+
 // DecodeTagList decodes a TagList from wire format.
 // It validates the data size and delegates to the decoder implementation.
 func DecodeTagList(dest *TagList, data []byte) error {
@@ -113,6 +352,42 @@ func DecodeTagList(dest *TagList, data []byte) error {
 	return decodeTagList(dest, data, &offset, ctx)
 }
 
+// DecodeTagListStrict decodes a TagList like DecodeTagList, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeTagListStrict(dest *TagList, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeTagList(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeTagListReuse decodes a TagList into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeTagListReuse(dest *TagList, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeTagList(dest, data, &offset, ctx)
+}
+
 
 // decodeRequest is the helper function that decodes Request fields.
 func decodeRequest(dest *Request, data []byte, offset *int, ctx *DecodeContext) error {
@@ -129,28 +404,33 @@ func decodeRequest(dest *Request, data []byte, offset *int, ctx *DecodeContext)
 
 	// Field: Id (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
 	}
 	dest.Id = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: Metadata (optional)
 	if *offset >= len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "metadata", *offset)
 	}
 	presence = data[*offset]
+	if presence > 1 {
+		return decodeError(ErrInvalidData, "metadata", *offset)
+	}
 	*offset += 1
 
 	if presence == 0 {
 		dest.Metadata = nil
-	} else if presence == 1 {
-			dest.Metadata = &Metadata{}
+	} else {
+			if ctx.arena != nil {
+				dest.Metadata = &arenaTake(&ctx.arena.Metadata, 1)[0]
+			} else if !ctx.reuse || dest.Metadata == nil {
+				dest.Metadata = &Metadata{}
+			}
 			err = decodeMetadata(dest.Metadata, data, offset, ctx)
 			if err != nil {
-				return err
+				return decodeError(err, "metadata", *offset)
 			}
-	} else {
-		return ErrInvalidData
 	}
 
 	return nil
@@ -169,22 +449,29 @@ func decodeMetadata(dest *Metadata, data []byte, offset *int, ctx *DecodeContext
 
 	// Field: UserId (u64)
 	if *offset + 8 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "user_id", *offset)
 	}
 	dest.UserId = binary.LittleEndian.Uint64(data[*offset:])
 	*offset += 8
 
 	// Field: Username (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "username", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "username", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "username", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Username = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Username != string(data[*offset:*offset+int(strLen)]) {
+		dest.Username = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Username = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	return nil
@@ -205,53 +492,70 @@ func decodeConfig(dest *Config, data []byte, offset *int, ctx *DecodeContext) er
 
 	// Field: Name (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Name = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Name != string(data[*offset:*offset+int(strLen)]) {
+		dest.Name = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Name = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Database (optional)
 	if *offset >= len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "database", *offset)
 	}
 	presence = data[*offset]
+	if presence > 1 {
+		return decodeError(ErrInvalidData, "database", *offset)
+	}
 	*offset += 1
 
 	if presence == 0 {
 		dest.Database = nil
-	} else if presence == 1 {
-			dest.Database = &DatabaseConfig{}
+	} else {
+			if ctx.arena != nil {
+				dest.Database = &arenaTake(&ctx.arena.DatabaseConfig, 1)[0]
+			} else if !ctx.reuse || dest.Database == nil {
+				dest.Database = &DatabaseConfig{}
+			}
 			err = decodeDatabaseConfig(dest.Database, data, offset, ctx)
 			if err != nil {
-				return err
+				return decodeError(err, "database", *offset)
 			}
-	} else {
-		return ErrInvalidData
 	}
 
 	// Field: Cache (optional)
 	if *offset >= len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "cache", *offset)
 	}
 	presence = data[*offset]
+	if presence > 1 {
+		return decodeError(ErrInvalidData, "cache", *offset)
+	}
 	*offset += 1
 
 	if presence == 0 {
 		dest.Cache = nil
-	} else if presence == 1 {
-			dest.Cache = &CacheConfig{}
+	} else {
+			if ctx.arena != nil {
+				dest.Cache = &arenaTake(&ctx.arena.CacheConfig, 1)[0]
+			} else if !ctx.reuse || dest.Cache == nil {
+				dest.Cache = &CacheConfig{}
+			}
 			err = decodeCacheConfig(dest.Cache, data, offset, ctx)
 			if err != nil {
-				return err
+				return decodeError(err, "cache", *offset)
 			}
-	} else {
-		return ErrInvalidData
 	}
 
 	return nil
@@ -270,20 +574,27 @@ func decodeDatabaseConfig(dest *DatabaseConfig, data []byte, offset *int, ctx *D
 
 	// Field: Host (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "host", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "host", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "host", *offset)
+	}
+	if ctx.arena != nil {
+		dest.Host = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.Host != string(data[*offset:*offset+int(strLen)]) {
+		dest.Host = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.Host = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	// Field: Port (u16)
 	if *offset + 2 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "port", *offset)
 	}
 	dest.Port = binary.LittleEndian.Uint16(data[*offset:])
 	*offset += 2
@@ -304,14 +615,14 @@ func decodeCacheConfig(dest *CacheConfig, data []byte, offset *int, ctx *DecodeC
 
 	// Field: SizeMb (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "size_mb", *offset)
 	}
 	dest.SizeMb = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: TtlSeconds (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "ttl_seconds", *offset)
 	}
 	dest.TtlSeconds = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
@@ -334,28 +645,33 @@ func decodeDocument(dest *Document, data []byte, offset *int, ctx *DecodeContext
 
 	// Field: Id (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
 	}
 	dest.Id = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: Tags (optional)
 	if *offset >= len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "tags", *offset)
 	}
 	presence = data[*offset]
+	if presence > 1 {
+		return decodeError(ErrInvalidData, "tags", *offset)
+	}
 	*offset += 1
 
 	if presence == 0 {
 		dest.Tags = nil
-	} else if presence == 1 {
-			dest.Tags = &TagList{}
+	} else {
+			if ctx.arena != nil {
+				dest.Tags = &arenaTake(&ctx.arena.TagList, 1)[0]
+			} else if !ctx.reuse || dest.Tags == nil {
+				dest.Tags = &TagList{}
+			}
 			err = decodeTagList(dest.Tags, data, offset, ctx)
 			if err != nil {
-				return err
+				return decodeError(err, "tags", *offset)
 			}
-	} else {
-		return ErrInvalidData
 	}
 
 	return nil
@@ -374,28 +690,41 @@ func decodeTagList(dest *TagList, data []byte, offset *int, ctx *DecodeContext)
 
 	// Field: Items ([]str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "items", *offset)
 	}
 	arrCount = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	err = ctx.checkArraySize(arrCount)
 	if err != nil {
-		return err
+		return decodeError(err, "items", *offset-4)
 	}
 
-	dest.Items = make([]string, arrCount)
+	if ctx.arena != nil {
+		dest.Items = arenaTake(&ctx.arena.strs, arrCount)
+	} else if ctx.reuse && cap(dest.Items) >= int(arrCount) {
+		dest.Items = dest.Items[:arrCount]
+	} else {
+		dest.Items = make([]string, arrCount)
+	}
 	for i := uint32(0); i < arrCount; i++ {
 		if *offset + 4 > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "items", *offset)
 		}
 		strLen := binary.LittleEndian.Uint32(data[*offset:])
 		*offset += 4
 		
 		if *offset + int(strLen) > len(data) {
-			return ErrUnexpectedEOF
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "items", *offset)
+		}
+		if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+			return decodeError(decodeElementError(ErrInvalidUTF8, i, *offset), "items", *offset)
+		}
+		if ctx.arena != nil {
+			dest.Items[i] = ctx.arena.string(data[*offset:*offset+int(strLen)])
+		} else if !ctx.reuse || dest.Items[i] != string(data[*offset:*offset+int(strLen)]) {
+			dest.Items[i] = string(data[*offset:*offset+int(strLen)])
 		}
-		dest.Items[i] = string(data[*offset:*offset+int(strLen)])
 		*offset += int(strLen)
 	}
 
@@ -405,7 +734,8 @@ func decodeTagList(dest *TagList, data []byte, offset *int, ctx *DecodeContext)
 
 // DecodeRequestMessage decodes a Request from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeRequestMessage(data []byte) (*Request, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -430,6 +760,9 @@ func DecodeRequestMessage(data []byte) (*Request, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -440,18 +773,24 @@ func DecodeRequestMessage(data []byte) (*Request, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Request
-	if err := DecodeRequest(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeRequest(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeMetadataMessage decodes a Metadata from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeMetadataMessage(data []byte) (*Metadata, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -476,6 +815,9 @@ func DecodeMetadataMessage(data []byte) (*Metadata, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -486,18 +828,24 @@ func DecodeMetadataMessage(data []byte) (*Metadata, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Metadata
-	if err := DecodeMetadata(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeMetadata(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeConfigMessage decodes a Config from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeConfigMessage(data []byte) (*Config, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -522,6 +870,9 @@ func DecodeConfigMessage(data []byte) (*Config, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -532,18 +883,24 @@ func DecodeConfigMessage(data []byte) (*Config, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Config
-	if err := DecodeConfig(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeConfig(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeDatabaseConfigMessage decodes a DatabaseConfig from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeDatabaseConfigMessage(data []byte) (*DatabaseConfig, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -568,6 +925,9 @@ func DecodeDatabaseConfigMessage(data []byte) (*DatabaseConfig, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -578,18 +938,24 @@ func DecodeDatabaseConfigMessage(data []byte) (*DatabaseConfig, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result DatabaseConfig
-	if err := DecodeDatabaseConfig(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeDatabaseConfig(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeCacheConfigMessage decodes a CacheConfig from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeCacheConfigMessage(data []byte) (*CacheConfig, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -614,6 +980,9 @@ func DecodeCacheConfigMessage(data []byte) (*CacheConfig, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -624,18 +993,24 @@ func DecodeCacheConfigMessage(data []byte) (*CacheConfig, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result CacheConfig
-	if err := DecodeCacheConfig(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeCacheConfig(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeDocumentMessage decodes a Document from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeDocumentMessage(data []byte) (*Document, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -660,6 +1035,9 @@ func DecodeDocumentMessage(data []byte) (*Document, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -670,18 +1048,24 @@ func DecodeDocumentMessage(data []byte) (*Document, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result Document
-	if err := DecodeDocument(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeDocument(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
 
 // DecodeTagListMessage decodes a TagList from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeTagListMessage(data []byte) (*TagList, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -706,6 +1090,9 @@ func DecodeTagListMessage(data []byte) (*TagList, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -716,11 +1103,16 @@ func DecodeTagListMessage(data []byte) (*TagList, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result TagList
-	if err := DecodeTagList(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeTagList(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
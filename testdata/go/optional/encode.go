@@ -1,8 +1,8 @@
 package optional
 
 import (
-	"io"
 	"encoding/binary"
+	"io"
 )
 
 // calculateRequestSize calculates the wire format size for Request.
@@ -30,6 +30,40 @@ func EncodeRequest(src *Request) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeRequest produces for src.
+func (src *Request) EncodedSize() int {
+	return calculateRequestSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeRequest.
+func (src *Request) MarshalBinary() ([]byte, error) {
+	return EncodeRequest(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Request) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateRequestSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeRequest(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeRequest.
+// The decoded value does not retain data.
+func (dest *Request) UnmarshalBinary(data []byte) error {
+	return DecodeRequest(dest, data)
+}
+
 // calculateMetadataSize calculates the wire format size for Metadata.
 func calculateMetadataSize(src *Metadata) int {
 	size := 0
@@ -52,6 +86,40 @@ func EncodeMetadata(src *Metadata) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeMetadata produces for src.
+func (src *Metadata) EncodedSize() int {
+	return calculateMetadataSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeMetadata.
+func (src *Metadata) MarshalBinary() ([]byte, error) {
+	return EncodeMetadata(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Metadata) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateMetadataSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeMetadata(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeMetadata.
+// The decoded value does not retain data.
+func (dest *Metadata) UnmarshalBinary(data []byte) error {
+	return DecodeMetadata(dest, data)
+}
+
 // calculateConfigSize calculates the wire format size for Config.
 func calculateConfigSize(src *Config) int {
 	size := 0
@@ -82,6 +150,40 @@ func EncodeConfig(src *Config) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeConfig produces for src.
+func (src *Config) EncodedSize() int {
+	return calculateConfigSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeConfig.
+func (src *Config) MarshalBinary() ([]byte, error) {
+	return EncodeConfig(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Config) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateConfigSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeConfig(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeConfig.
+// The decoded value does not retain data.
+func (dest *Config) UnmarshalBinary(data []byte) error {
+	return DecodeConfig(dest, data)
+}
+
 // calculateDatabaseConfigSize calculates the wire format size for DatabaseConfig.
 func calculateDatabaseConfigSize(src *DatabaseConfig) int {
 	size := 0
@@ -104,6 +206,40 @@ func EncodeDatabaseConfig(src *DatabaseConfig) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeDatabaseConfig produces for src.
+func (src *DatabaseConfig) EncodedSize() int {
+	return calculateDatabaseConfigSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeDatabaseConfig.
+func (src *DatabaseConfig) MarshalBinary() ([]byte, error) {
+	return EncodeDatabaseConfig(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *DatabaseConfig) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateDatabaseConfigSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeDatabaseConfig(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeDatabaseConfig.
+// The decoded value does not retain data.
+func (dest *DatabaseConfig) UnmarshalBinary(data []byte) error {
+	return DecodeDatabaseConfig(dest, data)
+}
+
 // calculateCacheConfigSize calculates the wire format size for CacheConfig.
 func calculateCacheConfigSize(src *CacheConfig) int {
 	size := 0
@@ -126,6 +262,40 @@ func EncodeCacheConfig(src *CacheConfig) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeCacheConfig produces for src.
+func (src *CacheConfig) EncodedSize() int {
+	return calculateCacheConfigSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeCacheConfig.
+func (src *CacheConfig) MarshalBinary() ([]byte, error) {
+	return EncodeCacheConfig(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *CacheConfig) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateCacheConfigSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeCacheConfig(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeCacheConfig.
+// The decoded value does not retain data.
+func (dest *CacheConfig) UnmarshalBinary(data []byte) error {
+	return DecodeCacheConfig(dest, data)
+}
+
 // calculateDocumentSize calculates the wire format size for Document.
 func calculateDocumentSize(src *Document) int {
 	size := 0
@@ -151,6 +321,40 @@ func EncodeDocument(src *Document) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeDocument produces for src.
+func (src *Document) EncodedSize() int {
+	return calculateDocumentSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeDocument.
+func (src *Document) MarshalBinary() ([]byte, error) {
+	return EncodeDocument(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *Document) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateDocumentSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeDocument(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeDocument.
+// The decoded value does not retain data.
+func (dest *Document) UnmarshalBinary(data []byte) error {
+	return DecodeDocument(dest, data)
+}
+
 // calculateTagListSize calculates the wire format size for TagList.
 func calculateTagListSize(src *TagList) int {
 	size := 0
@@ -174,6 +378,40 @@ func EncodeTagList(src *TagList) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeTagList produces for src.
+func (src *TagList) EncodedSize() int {
+	return calculateTagListSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeTagList.
+func (src *TagList) MarshalBinary() ([]byte, error) {
+	return EncodeTagList(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *TagList) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateTagListSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeTagList(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeTagList.
+// The decoded value does not retain data.
+func (dest *TagList) UnmarshalBinary(data []byte) error {
+	return DecodeTagList(dest, data)
+}
+
 
 // encodeRequest is the helper function that encodes Request fields.
 func encodeRequest(src *Request, buf []byte, offset *int) error {
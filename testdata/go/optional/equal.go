@@ -0,0 +1,449 @@
+package optional
+
+import (
+	"strconv"
+)
+
+// FieldChange is one field-level difference reported by Diff.
+type FieldChange struct {
+	// Path is the schema path of the field, e.g. "plugins[2].name".
+	Path string
+	// Kind is "value" if a primitive field differs, "length" if an array
+	// has a different number of elements and "presence" if an optional
+	// is set on one side only.
+	Kind string
+	// Old and New are the field values for "value", the element counts
+	// (int) for "length" and the presence flags (bool) for "presence".
+	Old any
+	New any
+}
+
+// diffPath joins a field name to the path of its struct.
+func diffPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Request) Equal(other *Request) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Id != other.Id {
+		return false
+	}
+	if !x.Metadata.Equal(other.Metadata) {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Request) Clone() *Request {
+	if x == nil {
+		return nil
+	}
+	c := new(Request)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Request) cloneTo(c *Request) {
+	*c = *x
+	c.Metadata = x.Metadata.Clone()
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Request) Diff(other *Request) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Request) diff(prefix string, other *Request, changes *[]FieldChange) {
+	if x.Id != other.Id {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "id"), Kind: "value", Old: x.Id, New: other.Id})
+	}
+	if (x.Metadata == nil) != (other.Metadata == nil) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "metadata"), Kind: "presence", Old: x.Metadata != nil, New: other.Metadata != nil})
+	} else if x.Metadata != nil {
+		x.Metadata.diff(diffPath(prefix, "metadata"), other.Metadata, changes)
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Metadata) Equal(other *Metadata) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.UserId != other.UserId {
+		return false
+	}
+	if x.Username != other.Username {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Metadata) Clone() *Metadata {
+	if x == nil {
+		return nil
+	}
+	c := new(Metadata)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Metadata) cloneTo(c *Metadata) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Metadata) Diff(other *Metadata) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Metadata) diff(prefix string, other *Metadata, changes *[]FieldChange) {
+	if x.UserId != other.UserId {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "user_id"), Kind: "value", Old: x.UserId, New: other.UserId})
+	}
+	if x.Username != other.Username {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "username"), Kind: "value", Old: x.Username, New: other.Username})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Config) Equal(other *Config) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Name != other.Name {
+		return false
+	}
+	if !x.Database.Equal(other.Database) {
+		return false
+	}
+	if !x.Cache.Equal(other.Cache) {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Config) Clone() *Config {
+	if x == nil {
+		return nil
+	}
+	c := new(Config)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Config) cloneTo(c *Config) {
+	*c = *x
+	c.Database = x.Database.Clone()
+	c.Cache = x.Cache.Clone()
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Config) Diff(other *Config) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Config) diff(prefix string, other *Config, changes *[]FieldChange) {
+	if x.Name != other.Name {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "name"), Kind: "value", Old: x.Name, New: other.Name})
+	}
+	if (x.Database == nil) != (other.Database == nil) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "database"), Kind: "presence", Old: x.Database != nil, New: other.Database != nil})
+	} else if x.Database != nil {
+		x.Database.diff(diffPath(prefix, "database"), other.Database, changes)
+	}
+	if (x.Cache == nil) != (other.Cache == nil) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "cache"), Kind: "presence", Old: x.Cache != nil, New: other.Cache != nil})
+	} else if x.Cache != nil {
+		x.Cache.diff(diffPath(prefix, "cache"), other.Cache, changes)
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *DatabaseConfig) Equal(other *DatabaseConfig) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Host != other.Host {
+		return false
+	}
+	if x.Port != other.Port {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *DatabaseConfig) Clone() *DatabaseConfig {
+	if x == nil {
+		return nil
+	}
+	c := new(DatabaseConfig)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *DatabaseConfig) cloneTo(c *DatabaseConfig) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *DatabaseConfig) Diff(other *DatabaseConfig) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *DatabaseConfig) diff(prefix string, other *DatabaseConfig, changes *[]FieldChange) {
+	if x.Host != other.Host {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "host"), Kind: "value", Old: x.Host, New: other.Host})
+	}
+	if x.Port != other.Port {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "port"), Kind: "value", Old: x.Port, New: other.Port})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *CacheConfig) Equal(other *CacheConfig) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.SizeMb != other.SizeMb {
+		return false
+	}
+	if x.TtlSeconds != other.TtlSeconds {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *CacheConfig) Clone() *CacheConfig {
+	if x == nil {
+		return nil
+	}
+	c := new(CacheConfig)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *CacheConfig) cloneTo(c *CacheConfig) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *CacheConfig) Diff(other *CacheConfig) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *CacheConfig) diff(prefix string, other *CacheConfig, changes *[]FieldChange) {
+	if x.SizeMb != other.SizeMb {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "size_mb"), Kind: "value", Old: x.SizeMb, New: other.SizeMb})
+	}
+	if x.TtlSeconds != other.TtlSeconds {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "ttl_seconds"), Kind: "value", Old: x.TtlSeconds, New: other.TtlSeconds})
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *Document) Equal(other *Document) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.Id != other.Id {
+		return false
+	}
+	if !x.Tags.Equal(other.Tags) {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *Document) Clone() *Document {
+	if x == nil {
+		return nil
+	}
+	c := new(Document)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *Document) cloneTo(c *Document) {
+	*c = *x
+	c.Tags = x.Tags.Clone()
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *Document) Diff(other *Document) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *Document) diff(prefix string, other *Document, changes *[]FieldChange) {
+	if x.Id != other.Id {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "id"), Kind: "value", Old: x.Id, New: other.Id})
+	}
+	if (x.Tags == nil) != (other.Tags == nil) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "tags"), Kind: "presence", Old: x.Tags != nil, New: other.Tags != nil})
+	} else if x.Tags != nil {
+		x.Tags.diff(diffPath(prefix, "tags"), other.Tags, changes)
+	}
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *TagList) Equal(other *TagList) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if len(x.Items) != len(other.Items) {
+		return false
+	}
+	for i := range x.Items {
+		if x.Items[i] != other.Items[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *TagList) Clone() *TagList {
+	if x == nil {
+		return nil
+	}
+	c := new(TagList)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *TagList) cloneTo(c *TagList) {
+	*c = *x
+	if x.Items != nil {
+		c.Items = make([]string, len(x.Items))
+		copy(c.Items, x.Items)
+	}
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *TagList) Diff(other *TagList) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *TagList) diff(prefix string, other *TagList, changes *[]FieldChange) {
+	{
+		path := diffPath(prefix, "items")
+		if len(x.Items) != len(other.Items) {
+			*changes = append(*changes, FieldChange{Path: path, Kind: "length", Old: len(x.Items), New: len(other.Items)})
+		}
+		for i := 0; i < len(x.Items) && i < len(other.Items); i++ {
+			if x.Items[i] != other.Items[i] {
+				*changes = append(*changes, FieldChange{Path: path+"["+strconv.Itoa(i)+"]", Kind: "value", Old: x.Items[i], New: other.Items[i]})
+			}
+		}
+	}
+}
+
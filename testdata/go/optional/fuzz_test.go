@@ -0,0 +1,259 @@
+package optional
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzDecodeRequest checks that DecodeRequest never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeRequest(f *testing.F) {
+	for _, seed := range []*Request{
+		{},
+		{Id: 1, Metadata: &Metadata{UserId: 1, Username: "sample"}},
+	} {
+		data, err := EncodeRequest(seed)
+		if err != nil {
+			f.Fatalf("EncodeRequest(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Request
+		if err := DecodeRequest(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeRequest(&x)
+		if err != nil {
+			t.Fatalf("EncodeRequest of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Request
+		if err := DecodeRequestStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeMetadata checks that DecodeMetadata never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeMetadata(f *testing.F) {
+	for _, seed := range []*Metadata{
+		{},
+		{UserId: 1, Username: "sample"},
+	} {
+		data, err := EncodeMetadata(seed)
+		if err != nil {
+			f.Fatalf("EncodeMetadata(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Metadata
+		if err := DecodeMetadata(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeMetadata(&x)
+		if err != nil {
+			t.Fatalf("EncodeMetadata of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Metadata
+		if err := DecodeMetadataStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeConfig checks that DecodeConfig never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeConfig(f *testing.F) {
+	for _, seed := range []*Config{
+		{},
+		{Name: "sample", Database: &DatabaseConfig{Host: "sample", Port: 1}, Cache: &CacheConfig{SizeMb: 1, TtlSeconds: 1}},
+	} {
+		data, err := EncodeConfig(seed)
+		if err != nil {
+			f.Fatalf("EncodeConfig(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Config
+		if err := DecodeConfig(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeConfig(&x)
+		if err != nil {
+			t.Fatalf("EncodeConfig of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Config
+		if err := DecodeConfigStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeDatabaseConfig checks that DecodeDatabaseConfig never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeDatabaseConfig(f *testing.F) {
+	for _, seed := range []*DatabaseConfig{
+		{},
+		{Host: "sample", Port: 1},
+	} {
+		data, err := EncodeDatabaseConfig(seed)
+		if err != nil {
+			f.Fatalf("EncodeDatabaseConfig(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x DatabaseConfig
+		if err := DecodeDatabaseConfig(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeDatabaseConfig(&x)
+		if err != nil {
+			t.Fatalf("EncodeDatabaseConfig of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict DatabaseConfig
+		if err := DecodeDatabaseConfigStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeCacheConfig checks that DecodeCacheConfig never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeCacheConfig(f *testing.F) {
+	for _, seed := range []*CacheConfig{
+		{},
+		{SizeMb: 1, TtlSeconds: 1},
+	} {
+		data, err := EncodeCacheConfig(seed)
+		if err != nil {
+			f.Fatalf("EncodeCacheConfig(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x CacheConfig
+		if err := DecodeCacheConfig(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeCacheConfig(&x)
+		if err != nil {
+			t.Fatalf("EncodeCacheConfig of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict CacheConfig
+		if err := DecodeCacheConfigStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeDocument checks that DecodeDocument never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeDocument(f *testing.F) {
+	for _, seed := range []*Document{
+		{},
+		{Id: 1, Tags: &TagList{Items: []string{"sample"}}},
+	} {
+		data, err := EncodeDocument(seed)
+		if err != nil {
+			f.Fatalf("EncodeDocument(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x Document
+		if err := DecodeDocument(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeDocument(&x)
+		if err != nil {
+			t.Fatalf("EncodeDocument of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict Document
+		if err := DecodeDocumentStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
+// FuzzDecodeTagList checks that DecodeTagList never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeTagList(f *testing.F) {
+	for _, seed := range []*TagList{
+		{},
+		{Items: []string{"sample"}},
+	} {
+		data, err := EncodeTagList(seed)
+		if err != nil {
+			f.Fatalf("EncodeTagList(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x TagList
+		if err := DecodeTagList(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeTagList(&x)
+		if err != nil {
+			t.Fatalf("EncodeTagList of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict TagList
+		if err := DecodeTagListStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
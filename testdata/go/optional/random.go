@@ -0,0 +1,198 @@
+package optional
+
+import (
+	"math/rand/v2"
+)
+
+// RandomOptions controls the values generated by the Random constructors.
+// A zero field selects its default, so a nil or partially set *RandomOptions
+// gives OptionalProbability 0.5, arrays of 0 to 4 elements and strings of up
+// to 16 ASCII letters and digits unless overridden. A negative
+// OptionalProbability, MaxArrayLen or MaxStringLen asks for absent optionals,
+// arrays of MinArrayLen elements or empty strings.
+type RandomOptions struct {
+	// OptionalProbability is the chance that an optional field is present.
+	OptionalProbability float64
+
+	// MinArrayLen and MaxArrayLen bound the length of every array.
+	MinArrayLen int
+	MaxArrayLen int
+
+	// MaxStringLen bounds the length of every string in runes.
+	MaxStringLen int
+
+	// Charset holds the runes strings are drawn from. Empty means ASCII
+	// letters and digits.
+	Charset string
+}
+
+var defaultRandomOptions = RandomOptions{OptionalProbability: 0.5, MaxArrayLen: 4, MaxStringLen: 16}
+
+const randomCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// withDefaults returns a copy of opts with zero fields set to their defaults.
+func (opts *RandomOptions) withDefaults() *RandomOptions {
+	o := defaultRandomOptions
+	if opts == nil {
+		return &o
+	}
+	if opts.OptionalProbability != 0 {
+		o.OptionalProbability = opts.OptionalProbability
+	}
+	o.MinArrayLen = opts.MinArrayLen
+	if opts.MaxArrayLen != 0 {
+		o.MaxArrayLen = opts.MaxArrayLen
+	}
+	if opts.MaxStringLen != 0 {
+		o.MaxStringLen = opts.MaxStringLen
+	}
+	o.Charset = opts.Charset
+	return &o
+}
+
+// randomLen returns an array length between opts.MinArrayLen and opts.MaxArrayLen.
+func randomLen(r *rand.Rand, opts *RandomOptions) int {
+	n := max(opts.MinArrayLen, 0)
+	if opts.MaxArrayLen > n {
+		n += r.IntN(opts.MaxArrayLen - n + 1)
+	}
+	return n
+}
+
+// randomString returns a string of up to opts.MaxStringLen runes from opts.Charset.
+func randomString(r *rand.Rand, opts *RandomOptions) string {
+	if opts.MaxStringLen <= 0 {
+		return ""
+	}
+	charset := []rune(opts.Charset)
+	if len(charset) == 0 {
+		charset = []rune(randomCharset)
+	}
+	s := make([]rune, r.IntN(opts.MaxStringLen+1))
+	for i := range s {
+		s[i] = charset[r.IntN(len(charset))]
+	}
+	return string(s)
+}
+
+// RandomRequest returns a Request with every field drawn from r. Floats are finite.
+func RandomRequest(r *rand.Rand, opts *RandomOptions) *Request {
+	opts = opts.withDefaults()
+	x := new(Request)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Request) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Id = r.Uint32()
+	if r.Float64() < opts.OptionalProbability {
+		x.Metadata = new(Metadata)
+		x.Metadata.randomize(r, opts)
+	} else {
+		x.Metadata = nil
+	}
+}
+
+// RandomMetadata returns a Metadata with every field drawn from r. Floats are finite.
+func RandomMetadata(r *rand.Rand, opts *RandomOptions) *Metadata {
+	opts = opts.withDefaults()
+	x := new(Metadata)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Metadata) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.UserId = r.Uint64()
+	x.Username = randomString(r, opts)
+}
+
+// RandomConfig returns a Config with every field drawn from r. Floats are finite.
+func RandomConfig(r *rand.Rand, opts *RandomOptions) *Config {
+	opts = opts.withDefaults()
+	x := new(Config)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Config) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Name = randomString(r, opts)
+	if r.Float64() < opts.OptionalProbability {
+		x.Database = new(DatabaseConfig)
+		x.Database.randomize(r, opts)
+	} else {
+		x.Database = nil
+	}
+	if r.Float64() < opts.OptionalProbability {
+		x.Cache = new(CacheConfig)
+		x.Cache.randomize(r, opts)
+	} else {
+		x.Cache = nil
+	}
+}
+
+// RandomDatabaseConfig returns a DatabaseConfig with every field drawn from r. Floats are finite.
+func RandomDatabaseConfig(r *rand.Rand, opts *RandomOptions) *DatabaseConfig {
+	opts = opts.withDefaults()
+	x := new(DatabaseConfig)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *DatabaseConfig) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Host = randomString(r, opts)
+	x.Port = uint16(r.Uint32())
+}
+
+// RandomCacheConfig returns a CacheConfig with every field drawn from r. Floats are finite.
+func RandomCacheConfig(r *rand.Rand, opts *RandomOptions) *CacheConfig {
+	opts = opts.withDefaults()
+	x := new(CacheConfig)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *CacheConfig) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.SizeMb = r.Uint32()
+	x.TtlSeconds = r.Uint32()
+}
+
+// RandomDocument returns a Document with every field drawn from r. Floats are finite.
+func RandomDocument(r *rand.Rand, opts *RandomOptions) *Document {
+	opts = opts.withDefaults()
+	x := new(Document)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *Document) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Id = r.Uint32()
+	if r.Float64() < opts.OptionalProbability {
+		x.Tags = new(TagList)
+		x.Tags.randomize(r, opts)
+	} else {
+		x.Tags = nil
+	}
+}
+
+// RandomTagList returns a TagList with every field drawn from r. Floats are finite.
+func RandomTagList(r *rand.Rand, opts *RandomOptions) *TagList {
+	opts = opts.withDefaults()
+	x := new(TagList)
+	x.randomize(r, opts)
+	return x
+}
+
+// randomize overwrites every field of x with a random value.
+func (x *TagList) randomize(r *rand.Rand, opts *RandomOptions) {
+	x.Items = make([]string, randomLen(r, opts))
+	for i := range x.Items {
+		x.Items[i] = randomString(r, opts)
+	}
+}
+
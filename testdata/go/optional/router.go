@@ -0,0 +1,231 @@
+package optional
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MessageReader reads self-describing messages from a stream.
+// Each message is framed by its 10-byte header, so messages can be
+// written back-to-back to a file, pipe or network connection.
+type MessageReader struct {
+	r io.Reader
+}
+
+// NewMessageReader creates a MessageReader that reads from r.
+func NewMessageReader(r io.Reader) *MessageReader {
+	return &MessageReader{r: r}
+}
+
+// ReadMessage reads the next complete message (header + payload).
+// It returns io.EOF when the stream ends cleanly between messages and
+// ErrUnexpectedEOF when the stream ends inside a message.
+func (mr *MessageReader) ReadMessage() ([]byte, error) {
+	var header [MessageHeaderSize]byte
+	n, err := io.ReadFull(mr.r, header[:])
+	if err != nil {
+		if err == io.EOF && n == 0 {
+			return nil, io.EOF
+		}
+		if err == io.ErrUnexpectedEOF {
+			return nil, ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	// Validate header before allocating the payload
+	if string(header[0:3]) != MessageMagic {
+		return nil, ErrInvalidMagic
+	}
+	if header[3] != MessageVersion {
+		return nil, ErrInvalidVersion
+	}
+	payloadLength := binary.LittleEndian.Uint32(header[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
+
+	message := make([]byte, MessageHeaderSize+int(payloadLength))
+	copy(message, header[:])
+	if _, err := io.ReadFull(mr.r, message[MessageHeaderSize:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Router dispatches self-describing messages to typed handlers.
+// Register handlers with the On* methods, then call Dispatch for single
+// messages or Serve to process a stream. A Router is not safe for
+// concurrent registration, but Dispatch may be called concurrently once
+// all handlers are registered.
+type Router struct {
+	onRequest func(*Request) error
+	onMetadata func(*Metadata) error
+	onConfig func(*Config) error
+	onDatabaseConfig func(*DatabaseConfig) error
+	onCacheConfig func(*CacheConfig) error
+	onDocument func(*Document) error
+	onTagList func(*TagList) error
+	onUnknown func(typeID uint16, data []byte) error
+}
+
+// NewRouter creates a Router with no handlers registered.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// OnUnknown registers the fallback handler. It receives the complete message
+// for type IDs not in this schema and for types without a registered handler.
+func (r *Router) OnUnknown(h func(typeID uint16, data []byte) error) *Router {
+	r.onUnknown = h
+	return r
+}
+
+// OnRequest registers the handler for Request messages.
+func (r *Router) OnRequest(h func(*Request) error) *Router {
+	r.onRequest = h
+	return r
+}
+
+// OnMetadata registers the handler for Metadata messages.
+func (r *Router) OnMetadata(h func(*Metadata) error) *Router {
+	r.onMetadata = h
+	return r
+}
+
+// OnConfig registers the handler for Config messages.
+func (r *Router) OnConfig(h func(*Config) error) *Router {
+	r.onConfig = h
+	return r
+}
+
+// OnDatabaseConfig registers the handler for DatabaseConfig messages.
+func (r *Router) OnDatabaseConfig(h func(*DatabaseConfig) error) *Router {
+	r.onDatabaseConfig = h
+	return r
+}
+
+// OnCacheConfig registers the handler for CacheConfig messages.
+func (r *Router) OnCacheConfig(h func(*CacheConfig) error) *Router {
+	r.onCacheConfig = h
+	return r
+}
+
+// OnDocument registers the handler for Document messages.
+func (r *Router) OnDocument(h func(*Document) error) *Router {
+	r.onDocument = h
+	return r
+}
+
+// OnTagList registers the handler for TagList messages.
+func (r *Router) OnTagList(h func(*TagList) error) *Router {
+	r.onTagList = h
+	return r
+}
+
+// Dispatch decodes a single message and calls the handler registered for its type.
+// Header and decode errors are returned as-is; handler errors are passed through.
+func (r *Router) Dispatch(data []byte) error {
+	// Check minimum message size
+	if len(data) < MessageHeaderSize {
+		return ErrUnexpectedEOF
+	}
+
+	// Validate magic bytes and version
+	if string(data[0:3]) != MessageMagic {
+		return ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+
+	// Dispatch to typed handler
+	switch typeID {
+	case 1:
+		if r.onRequest != nil {
+			msg, err := DecodeRequestMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onRequest(msg)
+		}
+	case 2:
+		if r.onMetadata != nil {
+			msg, err := DecodeMetadataMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onMetadata(msg)
+		}
+	case 3:
+		if r.onConfig != nil {
+			msg, err := DecodeConfigMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onConfig(msg)
+		}
+	case 4:
+		if r.onDatabaseConfig != nil {
+			msg, err := DecodeDatabaseConfigMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onDatabaseConfig(msg)
+		}
+	case 5:
+		if r.onCacheConfig != nil {
+			msg, err := DecodeCacheConfigMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onCacheConfig(msg)
+		}
+	case 6:
+		if r.onDocument != nil {
+			msg, err := DecodeDocumentMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onDocument(msg)
+		}
+	case 7:
+		if r.onTagList != nil {
+			msg, err := DecodeTagListMessage(data)
+			if err != nil {
+				return err
+			}
+			return r.onTagList(msg)
+		}
+	}
+
+	// Unknown type ID or no handler registered
+	if r.onUnknown != nil {
+		return r.onUnknown(typeID, data)
+	}
+	return ErrUnknownMessageType
+}
+
+// Serve reads messages from mr and dispatches each one until the stream ends.
+// It returns nil on a clean end of stream, or the first read, decode or
+// handler error.
+func (r *Router) Serve(mr *MessageReader) error {
+	for {
+		msg, err := mr.ReadMessage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.Dispatch(msg); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package optional
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+)
+
+// stringMaxElems is the number of array elements String and LogValue show.
+const stringMaxElems = 16
+
+// stringMaxBytes is the number of []u8 bytes String and LogValue show.
+const stringMaxBytes = 32
+
+// stringRedacted replaces the value of fields marked @redact.
+const stringRedacted = "[REDACTED]"
+
+// appendStringMore appends the number of elements left out of a truncated array.
+func appendStringMore(b []byte, n int) []byte {
+	b = append(b, "... (+"...)
+	b = strconv.AppendInt(b, int64(n), 10)
+	return append(b, " more)"...)
+}
+
+// appendStringBytes appends a []u8 in hex.
+func appendStringBytes(b, v []byte) []byte {
+	b = append(b, "0x"...)
+	if len(v) <= stringMaxBytes {
+		return hex.AppendEncode(b, v)
+	}
+	b = hex.AppendEncode(b, v[:stringMaxBytes])
+	return appendStringMore(b, len(v)-stringMaxBytes)
+}
+
+// logValueElems returns the first stringMaxElems elements of an array as a
+// group keyed by index.
+func logValueElems[T any](elems []T, value func(*T) slog.Value) slog.Value {
+	attrs := make([]slog.Attr, 0, min(len(elems), stringMaxElems+1))
+	for i := range elems {
+		if i == stringMaxElems {
+			attrs = append(attrs, slog.Int("more", len(elems)-i))
+			break
+		}
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: value(&elems[i])})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Request) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Request) appendString(b []byte) []byte {
+	b = append(b, "Request{"...)
+	b = append(b, "id: "...)
+	b = strconv.AppendUint(b, uint64(x.Id), 10)
+	b = append(b, ", metadata: "...)
+	if x.Metadata == nil {
+		b = append(b, "nil"...)
+	} else {
+		b = x.Metadata.appendString(b)
+	}
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Request) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.Uint64("id", uint64(x.Id)))
+	attrs = append(attrs, slog.Attr{Key: "metadata", Value: x.Metadata.LogValue()})
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Metadata) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Metadata) appendString(b []byte) []byte {
+	b = append(b, "Metadata{"...)
+	b = append(b, "user_id: "...)
+	b = strconv.AppendUint(b, x.UserId, 10)
+	b = append(b, ", username: "...)
+	b = append(b, stringRedacted...)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Metadata) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.Uint64("user_id", x.UserId))
+	attrs = append(attrs, slog.String("username", stringRedacted))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Config) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Config) appendString(b []byte) []byte {
+	b = append(b, "Config{"...)
+	b = append(b, "name: "...)
+	b = strconv.AppendQuote(b, x.Name)
+	b = append(b, ", database: "...)
+	if x.Database == nil {
+		b = append(b, "nil"...)
+	} else {
+		b = x.Database.appendString(b)
+	}
+	b = append(b, ", cache: "...)
+	if x.Cache == nil {
+		b = append(b, "nil"...)
+	} else {
+		b = x.Cache.appendString(b)
+	}
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Config) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 3)
+	attrs = append(attrs, slog.String("name", x.Name))
+	attrs = append(attrs, slog.Attr{Key: "database", Value: x.Database.LogValue()})
+	attrs = append(attrs, slog.Attr{Key: "cache", Value: x.Cache.LogValue()})
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *DatabaseConfig) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *DatabaseConfig) appendString(b []byte) []byte {
+	b = append(b, "DatabaseConfig{"...)
+	b = append(b, "host: "...)
+	b = strconv.AppendQuote(b, x.Host)
+	b = append(b, ", port: "...)
+	b = strconv.AppendUint(b, uint64(x.Port), 10)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *DatabaseConfig) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.String("host", x.Host))
+	attrs = append(attrs, slog.Uint64("port", uint64(x.Port)))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *CacheConfig) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *CacheConfig) appendString(b []byte) []byte {
+	b = append(b, "CacheConfig{"...)
+	b = append(b, "size_mb: "...)
+	b = strconv.AppendUint(b, uint64(x.SizeMb), 10)
+	b = append(b, ", ttl_seconds: "...)
+	b = strconv.AppendUint(b, uint64(x.TtlSeconds), 10)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *CacheConfig) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.Uint64("size_mb", uint64(x.SizeMb)))
+	attrs = append(attrs, slog.Uint64("ttl_seconds", uint64(x.TtlSeconds)))
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *Document) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *Document) appendString(b []byte) []byte {
+	b = append(b, "Document{"...)
+	b = append(b, "id: "...)
+	b = strconv.AppendUint(b, uint64(x.Id), 10)
+	b = append(b, ", tags: "...)
+	if x.Tags == nil {
+		b = append(b, "nil"...)
+	} else {
+		b = x.Tags.appendString(b)
+	}
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *Document) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 2)
+	attrs = append(attrs, slog.Uint64("id", uint64(x.Id)))
+	attrs = append(attrs, slog.Attr{Key: "tags", Value: x.Tags.LogValue()})
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *TagList) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *TagList) appendString(b []byte) []byte {
+	b = append(b, "TagList{"...)
+	b = append(b, "items: "...)
+	b = append(b, '[')
+	for i := range x.Items {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.Items)-i)
+			break
+		}
+		b = strconv.AppendQuote(b, x.Items[i])
+	}
+	b = append(b, ']')
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *TagList) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	var b []byte
+	attrs := make([]slog.Attr, 0, 1)
+	b = b[:0]
+	b = append(b, '[')
+	for i := range x.Items {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		if i == stringMaxElems {
+			b = appendStringMore(b, len(x.Items)-i)
+			break
+		}
+		b = strconv.AppendQuote(b, x.Items[i])
+	}
+	b = append(b, ']')
+	attrs = append(attrs, slog.String("items", string(b)))
+	return slog.GroupValue(attrs...)
+}
+
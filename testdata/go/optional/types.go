@@ -3,55 +3,227 @@ package optional
 // Request Basic struct with optional metadata (RC Feature 1 test)
 type Request struct {
 	// Id Request ID (always present)
-	Id uint32
+	Id uint32 `json:"id"`
 	// Metadata Optional user metadata
-	Metadata *Metadata
+	Metadata *Metadata `json:"metadata"`
 }
 
 // Metadata User metadata
 type Metadata struct {
 	// UserId User ID
-	UserId uint64
-	// Username Username
-	Username string
+	UserId uint64 `json:"user_id,string"`
+	// Username Username, kept out of logs
+	Username string `json:"username"`
 }
 
 // Config Complex example with nested optional fields
 type Config struct {
 	// Name Configuration name
-	Name string
+	Name string `json:"name"`
 	// Database Optional database settings
-	Database *DatabaseConfig
+	Database *DatabaseConfig `json:"database"`
 	// Cache Optional cache settings
-	Cache *CacheConfig
+	Cache *CacheConfig `json:"cache"`
 }
 
 // DatabaseConfig Database configuration
 type DatabaseConfig struct {
 	// Host Database host
-	Host string
+	Host string `json:"host"`
 	// Port Database port
-	Port uint16
+	Port uint16 `json:"port"`
 }
 
 // CacheConfig Cache configuration
 type CacheConfig struct {
 	// SizeMb Cache size in MB
-	SizeMb uint32
+	SizeMb uint32 `json:"size_mb"`
 	// TtlSeconds Time to live in seconds
-	TtlSeconds uint32
+	TtlSeconds uint32 `json:"ttl_seconds"`
 }
 
 // Document Example with optional array (wrapped struct containing array)
 type Document struct {
 	// Id Document ID
-	Id uint32
+	Id uint32 `json:"id"`
 	// Tags Optional tags wrapper
-	Tags *TagList
+	Tags *TagList `json:"tags"`
 }
 
 // TagList Wrapper for tag array
 type TagList struct {
 	// Items Array of tags
-	Items []string
+	Items []string `json:"items,omitempty"`
+}
+
+// GetId returns id, or the zero value if x is nil.
+func (x *Request) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetMetadata returns metadata, or the zero value if x is nil.
+func (x *Request) GetMetadata() *Metadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// HasMetadata reports whether metadata is present.
+func (x *Request) HasMetadata() bool {
+	return x != nil && x.Metadata != nil
+}
+
+// SetMetadata sets metadata to a copy of v.
+func (x *Request) SetMetadata(v Metadata) {
+	x.Metadata = &v
+}
+
+// ClearMetadata marks metadata absent.
+func (x *Request) ClearMetadata() {
+	x.Metadata = nil
+}
+
+// GetUserId returns user_id, or the zero value if x is nil.
+func (x *Metadata) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+// GetUsername returns username, or the zero value if x is nil.
+func (x *Metadata) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// GetName returns name, or the zero value if x is nil.
+func (x *Config) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GetDatabase returns database, or the zero value if x is nil.
+func (x *Config) GetDatabase() *DatabaseConfig {
+	if x != nil {
+		return x.Database
+	}
+	return nil
+}
+
+// HasDatabase reports whether database is present.
+func (x *Config) HasDatabase() bool {
+	return x != nil && x.Database != nil
+}
+
+// SetDatabase sets database to a copy of v.
+func (x *Config) SetDatabase(v DatabaseConfig) {
+	x.Database = &v
+}
+
+// ClearDatabase marks database absent.
+func (x *Config) ClearDatabase() {
+	x.Database = nil
+}
+
+// GetCache returns cache, or the zero value if x is nil.
+func (x *Config) GetCache() *CacheConfig {
+	if x != nil {
+		return x.Cache
+	}
+	return nil
+}
+
+// HasCache reports whether cache is present.
+func (x *Config) HasCache() bool {
+	return x != nil && x.Cache != nil
+}
+
+// SetCache sets cache to a copy of v.
+func (x *Config) SetCache(v CacheConfig) {
+	x.Cache = &v
+}
+
+// ClearCache marks cache absent.
+func (x *Config) ClearCache() {
+	x.Cache = nil
+}
+
+// GetHost returns host, or the zero value if x is nil.
+func (x *DatabaseConfig) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+// GetPort returns port, or the zero value if x is nil.
+func (x *DatabaseConfig) GetPort() uint16 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+// GetSizeMb returns size_mb, or the zero value if x is nil.
+func (x *CacheConfig) GetSizeMb() uint32 {
+	if x != nil {
+		return x.SizeMb
+	}
+	return 0
+}
+
+// GetTtlSeconds returns ttl_seconds, or the zero value if x is nil.
+func (x *CacheConfig) GetTtlSeconds() uint32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+// GetId returns id, or the zero value if x is nil.
+func (x *Document) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetTags returns tags, or the zero value if x is nil.
+func (x *Document) GetTags() *TagList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// HasTags reports whether tags is present.
+func (x *Document) HasTags() bool {
+	return x != nil && x.Tags != nil
+}
+
+// SetTags sets tags to a copy of v.
+func (x *Document) SetTags(v TagList) {
+	x.Tags = &v
+}
+
+// ClearTags marks tags absent.
+func (x *Document) ClearTags() {
+	x.Tags = nil
+}
+
+// GetItems returns items, or the zero value if x is nil.
+func (x *TagList) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
 }
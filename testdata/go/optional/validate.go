@@ -0,0 +1,445 @@
+package optional
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// ValidateRequest checks that data starts with a well-formed Request without decoding it.
+// It accepts the input DecodeRequest accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateRequest(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateRequest(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateMetadata checks that data starts with a well-formed Metadata without decoding it.
+// It accepts the input DecodeMetadata accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateMetadata(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateMetadata(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateConfig checks that data starts with a well-formed Config without decoding it.
+// It accepts the input DecodeConfig accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateConfig(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateConfig(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateDatabaseConfig checks that data starts with a well-formed DatabaseConfig without decoding it.
+// It accepts the input DecodeDatabaseConfig accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateDatabaseConfig(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateDatabaseConfig(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateCacheConfig checks that data starts with a well-formed CacheConfig without decoding it.
+// It accepts the input DecodeCacheConfig accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateCacheConfig(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateCacheConfig(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateDocument checks that data starts with a well-formed Document without decoding it.
+// It accepts the input DecodeDocument accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateDocument(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateDocument(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// ValidateTagList checks that data starts with a well-formed TagList without decoding it.
+// It accepts the input DecodeTagList accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateTagList(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateTagList(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// validateRequest advances offset over one Request value.
+func validateRequest(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: metadata
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "metadata", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		err = validateMetadata(data, offset, ctx)
+		if err != nil {
+			return decodeError(err, "metadata", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "metadata", *offset)
+	}
+
+	return nil
+}
+
+// validateMetadata advances offset over one Metadata value.
+func validateMetadata(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: user_id
+	if *offset + 8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "user_id", *offset)
+	}
+	*offset += 8
+
+	// Field: username
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "username", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "username", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "username", *offset)
+	}
+	*offset += int(strLen)
+
+	return nil
+}
+
+// validateConfig advances offset over one Config value.
+func validateConfig(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: name
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "name", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "name", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: database
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "database", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		err = validateDatabaseConfig(data, offset, ctx)
+		if err != nil {
+			return decodeError(err, "database", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "database", *offset)
+	}
+
+	// Field: cache
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "cache", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		err = validateCacheConfig(data, offset, ctx)
+		if err != nil {
+			return decodeError(err, "cache", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "cache", *offset)
+	}
+
+	return nil
+}
+
+// validateDatabaseConfig advances offset over one DatabaseConfig value.
+func validateDatabaseConfig(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: host
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "host", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "host", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "host", *offset)
+	}
+	*offset += int(strLen)
+
+	// Field: port
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "port", *offset)
+	}
+	*offset += 2
+
+	return nil
+}
+
+// validateCacheConfig advances offset over one CacheConfig value.
+func validateCacheConfig(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: size_mb
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "size_mb", *offset)
+	}
+	*offset += 4
+
+	// Field: ttl_seconds
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "ttl_seconds", *offset)
+	}
+	*offset += 4
+
+	return nil
+}
+
+// validateDocument advances offset over one Document value.
+func validateDocument(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: id
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "id", *offset)
+	}
+	*offset += 4
+
+	// Field: tags
+	if *offset >= len(data) {
+		return decodeError(ErrUnexpectedEOF, "tags", *offset)
+	}
+	switch data[*offset] {
+	case 0:
+		*offset += 1
+	case 1:
+		*offset += 1
+		err = validateTagList(data, offset, ctx)
+		if err != nil {
+			return decodeError(err, "tags", *offset)
+		}
+	default:
+		return decodeError(ErrInvalidData, "tags", *offset)
+	}
+
+	return nil
+}
+
+// validateTagList advances offset over one TagList value.
+func validateTagList(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: items
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "items", *offset)
+	}
+	arrCount = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	err = ctx.checkArraySize(arrCount)
+	if err != nil {
+		return decodeError(err, "items", *offset-4)
+	}
+	for i := uint32(0); i < arrCount; i++ {
+		if *offset + 4 > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "items", *offset)
+		}
+		strLen = binary.LittleEndian.Uint32(data[*offset:])
+		*offset += 4
+		if *offset + int(strLen) > len(data) {
+			return decodeError(decodeElementError(ErrUnexpectedEOF, i, *offset), "items", *offset)
+		}
+		if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+			return decodeError(decodeElementError(ErrInvalidUTF8, i, *offset), "items", *offset)
+		}
+		*offset += int(strLen)
+	}
+
+	return nil
+}
+
+// ValidateMessage checks that data starts with a well-formed self-describing
+// message of any type in this schema, without decoding it. The payload must
+// hold exactly one value of the type; ErrTrailingBytes is returned if it
+// extends past the value. It returns the total message length
+// (header + payload).
+func ValidateMessage(data []byte) (int, error) {
+	if len(data) < MessageHeaderSize {
+		return 0, ErrUnexpectedEOF
+	}
+	if string(data[0:3]) != MessageMagic {
+		return 0, ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return 0, ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	end := MessageHeaderSize + int(payloadLength)
+	if len(data) < end {
+		return 0, ErrUnexpectedEOF
+	}
+	payload := data[MessageHeaderSize:end]
+
+	var (
+		n   int
+		err error
+	)
+	switch typeID {
+	case 1:
+		n, err = ValidateRequest(payload)
+	case 2:
+		n, err = ValidateMetadata(payload)
+	case 3:
+		n, err = ValidateConfig(payload)
+	case 4:
+		n, err = ValidateDatabaseConfig(payload)
+	case 5:
+		n, err = ValidateCacheConfig(payload)
+	case 6:
+		n, err = ValidateDocument(payload)
+	case 7:
+		n, err = ValidateTagList(payload)
+	default:
+		return 0, ErrUnknownMessageType
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n != len(payload) {
+		return 0, ErrTrailingBytes
+	}
+	return end, nil
+}
@@ -0,0 +1,399 @@
+package optional
+
+import (
+	"encoding/binary"
+	"iter"
+	"unsafe"
+)
+
+// ArrayView is a read-only view of an encoded array. Elements are read
+// from the underlying buffer on access; nothing is copied up front.
+type ArrayView[T any] struct {
+	data []byte
+	off  int  // Offset of the first element
+	n    int  // Element count
+	size int  // Element size, or 0 for variable-size elements
+	read func(data []byte, off int) T
+	skip func(data []byte, off int) int
+}
+
+// newArrayView creates an ArrayView over the array whose count prefix is at off.
+func newArrayView[T any](data []byte, off int, size int, read func([]byte, int) T, skip func([]byte, int) int) ArrayView[T] {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	return ArrayView[T]{data: data, off: off + 4, n: n, size: size, read: read, skip: skip}
+}
+
+// Len returns the number of elements.
+func (a ArrayView[T]) Len() int {
+	return a.n
+}
+
+// At returns element i. Fixed-size elements are located directly;
+// variable-size elements (strings, structs) are found by skipping the
+// preceding ones, so use All to visit every element. At panics if i is
+// out of range.
+func (a ArrayView[T]) At(i int) T {
+	if i < 0 || i >= a.n {
+		panic("ArrayView: index out of range")
+	}
+	if a.size > 0 {
+		return a.read(a.data, a.off+i*a.size)
+	}
+	off := a.off
+	for ; i > 0; i-- {
+		off = a.skip(a.data, off)
+	}
+	return a.read(a.data, off)
+}
+
+// All returns an iterator over the index and value of every element.
+func (a ArrayView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		off := a.off
+		for i := 0; i < a.n; i++ {
+			if !yield(i, a.read(a.data, off)) {
+				return
+			}
+			if a.size > 0 {
+				off += a.size
+			} else {
+				off = a.skip(a.data, off)
+			}
+		}
+	}
+}
+
+// viewString returns the length-prefixed string at off without copying.
+func viewString(data []byte, off int) string {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	if n == 0 {
+		return ""
+	}
+	return unsafe.String(&data[off+4], n)
+}
+
+// viewSkipString returns the offset just past the string at off.
+func viewSkipString(data []byte, off int) int {
+	return off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+}
+
+// viewBytes returns the []u8 array at off as a sub-slice of data.
+// Its capacity is clipped so appending to it cannot overwrite data.
+func viewBytes(data []byte, off int) []byte {
+	end := off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+	return data[off+4 : end : end]
+}
+
+// viewSkipArray returns the offset just past the array of variable-size
+// elements at off, using skip to step over each element.
+func viewSkipArray(data []byte, off int, skip func([]byte, int) int) int {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	for i := 0; i < n; i++ {
+		off = skip(data, off)
+	}
+	return off
+}
+
+// RequestView is a read-only, zero-copy view of an encoded Request.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type RequestView struct {
+	data []byte
+}
+
+// NewRequestView validates data with ValidateRequest and returns a view over it.
+func NewRequestView(data []byte) (RequestView, error) {
+	if _, err := ValidateRequest(data); err != nil {
+		return RequestView{}, err
+	}
+	return RequestView{data: data}, nil
+}
+
+// Decode materializes the full Request value.
+func (v RequestView) Decode(dest *Request) error {
+	return DecodeRequest(dest, v.data)
+}
+
+// Id returns the id field.
+func (v RequestView) Id() uint32 {
+	return binary.LittleEndian.Uint32(v.data[0:])
+}
+
+// Metadata returns the metadata field and whether it is present.
+func (v RequestView) Metadata() (value MetadataView, ok bool) {
+	off := 4
+	if v.data[off] == 0 {
+		return
+	}
+	return MetadataView{data: v.data[off+1:]}, true
+}
+
+// skipRequest returns the offset just past the Request value starting at off.
+func skipRequest(data []byte, off int) int {
+	off += 4
+	if data[off] == 0 {
+		off++
+	} else {
+		off += 1
+		off = skipMetadata(data, off)
+	}
+	return off
+}
+
+// MetadataView is a read-only, zero-copy view of an encoded Metadata.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type MetadataView struct {
+	data []byte
+}
+
+// NewMetadataView validates data with ValidateMetadata and returns a view over it.
+func NewMetadataView(data []byte) (MetadataView, error) {
+	if _, err := ValidateMetadata(data); err != nil {
+		return MetadataView{}, err
+	}
+	return MetadataView{data: data}, nil
+}
+
+// Decode materializes the full Metadata value.
+func (v MetadataView) Decode(dest *Metadata) error {
+	return DecodeMetadata(dest, v.data)
+}
+
+// UserId returns the user_id field.
+func (v MetadataView) UserId() uint64 {
+	return binary.LittleEndian.Uint64(v.data[0:])
+}
+
+// Username returns the username field.
+func (v MetadataView) Username() string {
+	return viewString(v.data, 8)
+}
+
+// skipMetadata returns the offset just past the Metadata value starting at off.
+func skipMetadata(data []byte, off int) int {
+	off += 8
+	off = viewSkipString(data, off)
+	return off
+}
+
+// ConfigView is a read-only, zero-copy view of an encoded Config.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type ConfigView struct {
+	data []byte
+}
+
+// NewConfigView validates data with ValidateConfig and returns a view over it.
+func NewConfigView(data []byte) (ConfigView, error) {
+	if _, err := ValidateConfig(data); err != nil {
+		return ConfigView{}, err
+	}
+	return ConfigView{data: data}, nil
+}
+
+// Decode materializes the full Config value.
+func (v ConfigView) Decode(dest *Config) error {
+	return DecodeConfig(dest, v.data)
+}
+
+// Name returns the name field.
+func (v ConfigView) Name() string {
+	return viewString(v.data, 0)
+}
+
+// Database returns the database field and whether it is present.
+func (v ConfigView) Database() (value DatabaseConfigView, ok bool) {
+	off := 0
+	off = viewSkipString(v.data, off)
+	if v.data[off] == 0 {
+		return
+	}
+	return DatabaseConfigView{data: v.data[off+1:]}, true
+}
+
+// Cache returns the cache field and whether it is present.
+func (v ConfigView) Cache() (value CacheConfigView, ok bool) {
+	off := 0
+	off = viewSkipString(v.data, off)
+	if v.data[off] == 0 {
+		off++
+	} else {
+		off += 1
+		off = skipDatabaseConfig(v.data, off)
+	}
+	if v.data[off] == 0 {
+		return
+	}
+	return CacheConfigView{data: v.data[off+1:]}, true
+}
+
+// skipConfig returns the offset just past the Config value starting at off.
+func skipConfig(data []byte, off int) int {
+	off = viewSkipString(data, off)
+	if data[off] == 0 {
+		off++
+	} else {
+		off += 1
+		off = skipDatabaseConfig(data, off)
+	}
+	if data[off] == 0 {
+		off++
+	} else {
+		off += 9
+	}
+	return off
+}
+
+// DatabaseConfigView is a read-only, zero-copy view of an encoded DatabaseConfig.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type DatabaseConfigView struct {
+	data []byte
+}
+
+// NewDatabaseConfigView validates data with ValidateDatabaseConfig and returns a view over it.
+func NewDatabaseConfigView(data []byte) (DatabaseConfigView, error) {
+	if _, err := ValidateDatabaseConfig(data); err != nil {
+		return DatabaseConfigView{}, err
+	}
+	return DatabaseConfigView{data: data}, nil
+}
+
+// Decode materializes the full DatabaseConfig value.
+func (v DatabaseConfigView) Decode(dest *DatabaseConfig) error {
+	return DecodeDatabaseConfig(dest, v.data)
+}
+
+// Host returns the host field.
+func (v DatabaseConfigView) Host() string {
+	return viewString(v.data, 0)
+}
+
+// Port returns the port field.
+func (v DatabaseConfigView) Port() uint16 {
+	off := 0
+	off = viewSkipString(v.data, off)
+	return binary.LittleEndian.Uint16(v.data[off:])
+}
+
+// skipDatabaseConfig returns the offset just past the DatabaseConfig value starting at off.
+func skipDatabaseConfig(data []byte, off int) int {
+	off = viewSkipString(data, off)
+	off += 2
+	return off
+}
+
+// CacheConfigView is a read-only, zero-copy view of an encoded CacheConfig.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type CacheConfigView struct {
+	data []byte
+}
+
+// NewCacheConfigView validates data with ValidateCacheConfig and returns a view over it.
+func NewCacheConfigView(data []byte) (CacheConfigView, error) {
+	if _, err := ValidateCacheConfig(data); err != nil {
+		return CacheConfigView{}, err
+	}
+	return CacheConfigView{data: data}, nil
+}
+
+// Decode materializes the full CacheConfig value.
+func (v CacheConfigView) Decode(dest *CacheConfig) error {
+	return DecodeCacheConfig(dest, v.data)
+}
+
+// SizeMb returns the size_mb field.
+func (v CacheConfigView) SizeMb() uint32 {
+	return binary.LittleEndian.Uint32(v.data[0:])
+}
+
+// TtlSeconds returns the ttl_seconds field.
+func (v CacheConfigView) TtlSeconds() uint32 {
+	return binary.LittleEndian.Uint32(v.data[4:])
+}
+
+// skipCacheConfig returns the offset just past the CacheConfig value starting at off.
+func skipCacheConfig(data []byte, off int) int {
+	off += 8
+	return off
+}
+
+// DocumentView is a read-only, zero-copy view of an encoded Document.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type DocumentView struct {
+	data []byte
+}
+
+// NewDocumentView validates data with ValidateDocument and returns a view over it.
+func NewDocumentView(data []byte) (DocumentView, error) {
+	if _, err := ValidateDocument(data); err != nil {
+		return DocumentView{}, err
+	}
+	return DocumentView{data: data}, nil
+}
+
+// Decode materializes the full Document value.
+func (v DocumentView) Decode(dest *Document) error {
+	return DecodeDocument(dest, v.data)
+}
+
+// Id returns the id field.
+func (v DocumentView) Id() uint32 {
+	return binary.LittleEndian.Uint32(v.data[0:])
+}
+
+// Tags returns the tags field and whether it is present.
+func (v DocumentView) Tags() (value TagListView, ok bool) {
+	off := 4
+	if v.data[off] == 0 {
+		return
+	}
+	return TagListView{data: v.data[off+1:]}, true
+}
+
+// skipDocument returns the offset just past the Document value starting at off.
+func skipDocument(data []byte, off int) int {
+	off += 4
+	if data[off] == 0 {
+		off++
+	} else {
+		off += 1
+		off = skipTagList(data, off)
+	}
+	return off
+}
+
+// TagListView is a read-only, zero-copy view of an encoded TagList.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type TagListView struct {
+	data []byte
+}
+
+// NewTagListView validates data with ValidateTagList and returns a view over it.
+func NewTagListView(data []byte) (TagListView, error) {
+	if _, err := ValidateTagList(data); err != nil {
+		return TagListView{}, err
+	}
+	return TagListView{data: data}, nil
+}
+
+// Decode materializes the full TagList value.
+func (v TagListView) Decode(dest *TagList) error {
+	return DecodeTagList(dest, v.data)
+}
+
+// Items returns the items field.
+func (v TagListView) Items() ArrayView[string] {
+	return newArrayView(v.data, 0, 0, func(data []byte, off int) string { return viewString(data, off) }, viewSkipString)
+}
+
+// skipTagList returns the offset just past the TagList value starting at off.
+func skipTagList(data []byte, off int) int {
+	off = viewSkipArray(data, off, viewSkipString)
+	return off
+}
@@ -0,0 +1,148 @@
+package primitives
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var benchData = flag.String("benchdata", "", "directory of <Struct>.sdpb files to benchmark instead of random values")
+
+// benchRandomOptions shapes the random values of structs without a fixture.
+var benchRandomOptions = &RandomOptions{OptionalProbability: 1, MinArrayLen: 8, MaxArrayLen: 8, MaxStringLen: 32}
+
+// benchValueAllPrimitives returns the AllPrimitives the benchmarks run on.
+func benchValueAllPrimitives(b *testing.B) *AllPrimitives {
+	if *benchData != "" {
+		data, err := os.ReadFile(filepath.Join(*benchData, "AllPrimitives.sdpb"))
+		if err == nil {
+			var x AllPrimitives
+			if err := DecodeAllPrimitives(&x, data); err != nil {
+				b.Fatalf("AllPrimitives.sdpb: %v", err)
+			}
+			return &x
+		}
+		if !os.IsNotExist(err) {
+			b.Fatal(err)
+		}
+	}
+	return RandomAllPrimitives(rand.New(rand.NewPCG(1, 0)), benchRandomOptions)
+}
+
+func BenchmarkEncodeAllPrimitives(b *testing.B) {
+	x := benchValueAllPrimitives(b)
+	data, err := EncodeAllPrimitives(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeAllPrimitives(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeAllPrimitives(b *testing.B) {
+	x := benchValueAllPrimitives(b)
+	data, err := EncodeAllPrimitives(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result AllPrimitives
+		if err := DecodeAllPrimitives(&result, data); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripAllPrimitives(b *testing.B) {
+	x := benchValueAllPrimitives(b)
+	data, err := EncodeAllPrimitives(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := EncodeAllPrimitives(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result AllPrimitives
+		if err := DecodeAllPrimitives(&result, encoded); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncodeAllPrimitivesJSON(b *testing.B) {
+	x := benchValueAllPrimitives(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := json.Marshal(x); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeAllPrimitivesJSON(b *testing.B) {
+	x := benchValueAllPrimitives(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var result AllPrimitives
+		if err := json.Unmarshal(data, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRoundtripAllPrimitivesJSON(b *testing.B) {
+	x := benchValueAllPrimitives(b)
+	data, err := json.Marshal(x)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		encoded, err := json.Marshal(x)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var result AllPrimitives
+		if err := json.Unmarshal(encoded, &result); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
@@ -2,8 +2,9 @@ package primitives
 
 import (
 	"encoding/binary"
-	"math"
 	"io"
+	"math"
+	"unicode/utf8"
 )
 
 // Size limit constants for decode validation
@@ -16,8 +17,14 @@ const (
 // DecodeContext tracks state during decoding to enforce size limits.
 // It maintains a count of total elements across all arrays to prevent
 // excessive memory allocation from malicious or corrupted data.
+// In strict mode it also rejects non-canonical encodings; in reuse mode
+// the decoder recycles the slices, structs and strings already in dest;
+// with an arena, strings and slices are carved from preallocated memory.
 type DecodeContext struct {
 	totalElements int
+	strict        bool
+	reuse         bool
+	arena         *decodeArena
 }
 
 // checkArraySize validates an array count against per-array and total limits.
@@ -36,6 +43,22 @@ func (ctx *DecodeContext) checkArraySize(count uint32) error {
 	return nil
 }
 
+// Canonical NaN bit patterns accepted by strict decoding
+const (
+	CanonicalNaN32 uint32 = 0x7FC00000
+	CanonicalNaN64 uint64 = 0x7FF8000000000000
+)
+
+// isCanonicalF32 reports whether bits is the canonical encoding of its float32 value.
+func isCanonicalF32(bits uint32) bool {
+	return bits&0x7F800000 != 0x7F800000 || bits&0x007FFFFF == 0 || bits == CanonicalNaN32
+}
+
+// isCanonicalF64 reports whether bits is the canonical encoding of its float64 value.
+func isCanonicalF64(bits uint64) bool {
+	return bits&0x7FF0000000000000 != 0x7FF0000000000000 || bits&0x000FFFFFFFFFFFFF == 0 || bits == CanonicalNaN64
+}
+
 
 // DecodeAllPrimitives decodes a AllPrimitives from wire format.
 // It validates the data size and delegates to the decoder implementation.
@@ -48,6 +71,42 @@ func DecodeAllPrimitives(dest *AllPrimitives, data []byte) error {
 	return decodeAllPrimitives(dest, data, &offset, ctx)
 }
 
+// DecodeAllPrimitivesStrict decodes a AllPrimitives like DecodeAllPrimitives, but only accepts the canonical encoding.
+// It returns ErrTrailingBytes if data continues after the value, and
+// ErrNonCanonical for bool bytes other than 0/1 or NaNs other than
+// CanonicalNaN32/CanonicalNaN64.
+func DecodeAllPrimitivesStrict(dest *AllPrimitives, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{strict: true}
+	offset := 0
+	if err := decodeAllPrimitives(dest, data, &offset, ctx); err != nil {
+		return err
+	}
+	if offset != len(data) {
+		return ErrTrailingBytes
+	}
+	return nil
+}
+
+// DecodeAllPrimitivesReuse decodes a AllPrimitives into dest, reusing the memory dest already holds:
+// slices are truncated and refilled when their capacity suffices, present
+// optional structs are decoded in place, and unchanged strings are kept.
+//
+// Everything reachable from dest is overwritten, so slices or pointers
+// obtained from a previous decode observe the new values; copy anything
+// that must outlive the next call. The result never aliases data, and on
+// error dest holds a partially decoded value.
+func DecodeAllPrimitivesReuse(dest *AllPrimitives, data []byte) error {
+	if len(data) > MaxSerializedSize {
+		return ErrDataTooLarge
+	}
+	ctx := &DecodeContext{reuse: true}
+	offset := 0
+	return decodeAllPrimitives(dest, data, &offset, ctx)
+}
+
 
 // decodeAllPrimitives is the helper function that decodes AllPrimitives fields.
 func decodeAllPrimitives(dest *AllPrimitives, data []byte, offset *int, ctx *DecodeContext) error {
@@ -62,92 +121,108 @@ func decodeAllPrimitives(dest *AllPrimitives, data []byte, offset *int, ctx *Dec
 
 	// Field: U8Field (u8)
 	if *offset + 1 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "u8_field", *offset)
 	}
 	dest.U8Field = uint8(data[*offset])
 	*offset += 1
 
 	// Field: U16Field (u16)
 	if *offset + 2 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "u16_field", *offset)
 	}
 	dest.U16Field = binary.LittleEndian.Uint16(data[*offset:])
 	*offset += 2
 
 	// Field: U32Field (u32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "u32_field", *offset)
 	}
 	dest.U32Field = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	// Field: U64Field (u64)
 	if *offset + 8 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "u64_field", *offset)
 	}
 	dest.U64Field = binary.LittleEndian.Uint64(data[*offset:])
 	*offset += 8
 
 	// Field: I8Field (i8)
 	if *offset + 1 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "i8_field", *offset)
 	}
 	dest.I8Field = int8(data[*offset])
 	*offset += 1
 
 	// Field: I16Field (i16)
 	if *offset + 2 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "i16_field", *offset)
 	}
 	dest.I16Field = int16(binary.LittleEndian.Uint16(data[*offset:]))
 	*offset += 2
 
 	// Field: I32Field (i32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "i32_field", *offset)
 	}
 	dest.I32Field = int32(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: I64Field (i64)
 	if *offset + 8 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "i64_field", *offset)
 	}
 	dest.I64Field = int64(binary.LittleEndian.Uint64(data[*offset:]))
 	*offset += 8
 
 	// Field: F32Field (f32)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "f32_field", *offset)
+	}
+	if ctx.strict && !isCanonicalF32(binary.LittleEndian.Uint32(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "f32_field", *offset)
 	}
 	dest.F32Field = math.Float32frombits(binary.LittleEndian.Uint32(data[*offset:]))
 	*offset += 4
 
 	// Field: F64Field (f64)
 	if *offset + 8 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "f64_field", *offset)
+	}
+	if ctx.strict && !isCanonicalF64(binary.LittleEndian.Uint64(data[*offset:])) {
+		return decodeError(ErrNonCanonical, "f64_field", *offset)
 	}
 	dest.F64Field = math.Float64frombits(binary.LittleEndian.Uint64(data[*offset:]))
 	*offset += 8
 
 	// Field: BoolField (bool)
 	if *offset + 1 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "bool_field", *offset)
+	}
+	if ctx.strict && data[*offset] > 1 {
+		return decodeError(ErrNonCanonical, "bool_field", *offset)
 	}
 	dest.BoolField = data[*offset] != 0
 	*offset += 1
 
 	// Field: StrField (str)
 	if *offset + 4 > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "str_field", *offset)
 	}
 	strLen = binary.LittleEndian.Uint32(data[*offset:])
 	*offset += 4
 
 	if *offset + int(strLen) > len(data) {
-		return ErrUnexpectedEOF
+		return decodeError(ErrUnexpectedEOF, "str_field", *offset)
+	}
+	if ctx.strict && !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "str_field", *offset)
+	}
+	if ctx.arena != nil {
+		dest.StrField = ctx.arena.string(data[*offset:*offset+int(strLen)])
+	} else if !ctx.reuse || dest.StrField != string(data[*offset:*offset+int(strLen)]) {
+		dest.StrField = string(data[*offset:*offset+int(strLen)])
 	}
-	dest.StrField = string(data[*offset:*offset+int(strLen)])
 	*offset += int(strLen)
 
 	return nil
@@ -156,7 +231,8 @@ func decodeAllPrimitives(dest *AllPrimitives, data []byte, offset *int, ctx *Dec
 
 // DecodeAllPrimitivesMessage decodes a AllPrimitives from self-describing message format.
 // The message must include a valid 10-byte header: [SDP:3][version:1][type_id:2][length:4][payload:N]
-// Returns an error if the header is invalid or the payload cannot be decoded.
+// Returns an error if the header is invalid or the payload cannot be decoded,
+// and ErrTrailingBytes if the payload extends past the value.
 func DecodeAllPrimitivesMessage(data []byte) (*AllPrimitives, error) {
 	// Check minimum message size
 	if len(data) < MessageHeaderSize {
@@ -181,6 +257,9 @@ func DecodeAllPrimitivesMessage(data []byte) (*AllPrimitives, error) {
 
 	// Extract payload length
 	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return nil, ErrDataTooLarge
+	}
 
 	// Validate total message size
 	expectedSize := MessageHeaderSize + int(payloadLength)
@@ -191,11 +270,16 @@ func DecodeAllPrimitivesMessage(data []byte) (*AllPrimitives, error) {
 	// Extract payload
 	payload := data[MessageHeaderSize:expectedSize]
 
-	// Decode payload
+	// Decode payload; it must end with the value
 	var result AllPrimitives
-	if err := DecodeAllPrimitives(&result, payload); err != nil {
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := decodeAllPrimitives(&result, payload, &offset, ctx); err != nil {
 		return nil, err
 	}
+	if offset != len(payload) {
+		return nil, ErrTrailingBytes
+	}
 
 	return &result, nil
 }
@@ -2,8 +2,8 @@ package primitives
 
 import (
 	"encoding/binary"
-	"math"
 	"io"
+	"math"
 )
 
 // calculateAllPrimitivesSize calculates the wire format size for AllPrimitives.
@@ -48,6 +48,40 @@ func EncodeAllPrimitives(src *AllPrimitives) ([]byte, error) {
 	return buf, nil
 }
 
+// EncodedSize returns the number of bytes EncodeAllPrimitives produces for src.
+func (src *AllPrimitives) EncodedSize() int {
+	return calculateAllPrimitivesSize(src)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler using EncodeAllPrimitives.
+func (src *AllPrimitives) MarshalBinary() ([]byte, error) {
+	return EncodeAllPrimitives(src)
+}
+
+// AppendBinary implements encoding.BinaryAppender. It appends the encoding
+// to b, growing it at most once, and returns the extended slice.
+func (src *AllPrimitives) AppendBinary(b []byte) ([]byte, error) {
+	size := calculateAllPrimitivesSize(src)
+	start := len(b)
+	if cap(b)-start < size {
+		grown := make([]byte, start, start+size)
+		copy(grown, b)
+		b = grown
+	}
+	b = b[:start+size]
+	offset := start
+	if err := encodeAllPrimitives(src, b, &offset); err != nil {
+		return b[:start], err
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeAllPrimitives.
+// The decoded value does not retain data.
+func (dest *AllPrimitives) UnmarshalBinary(data []byte) error {
+	return DecodeAllPrimitives(dest, data)
+}
+
 
 // encodeAllPrimitives is the helper function that encodes AllPrimitives fields.
 func encodeAllPrimitives(src *AllPrimitives, buf []byte, offset *int) error {
@@ -0,0 +1,144 @@
+package primitives
+
+import (
+	"math"
+)
+
+// FieldChange is one field-level difference reported by Diff.
+type FieldChange struct {
+	// Path is the schema path of the field, e.g. "plugins[2].name".
+	Path string
+	// Kind is "value" if a primitive field differs, "length" if an array
+	// has a different number of elements and "presence" if an optional
+	// is set on one side only.
+	Kind string
+	// Old and New are the field values for "value", the element counts
+	// (int) for "length" and the presence flags (bool) for "presence".
+	Old any
+	New any
+}
+
+// diffPath joins a field name to the path of its struct.
+func diffPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// Equal reports whether x and other encode to the same bytes. Nil and empty
+// arrays are equal and floats are compared by bit pattern.
+func (x *AllPrimitives) Equal(other *AllPrimitives) bool {
+	if x == nil || other == nil {
+		return x == other
+	}
+	if x.U8Field != other.U8Field {
+		return false
+	}
+	if x.U16Field != other.U16Field {
+		return false
+	}
+	if x.U32Field != other.U32Field {
+		return false
+	}
+	if x.U64Field != other.U64Field {
+		return false
+	}
+	if x.I8Field != other.I8Field {
+		return false
+	}
+	if x.I16Field != other.I16Field {
+		return false
+	}
+	if x.I32Field != other.I32Field {
+		return false
+	}
+	if x.I64Field != other.I64Field {
+		return false
+	}
+	if math.Float32bits(x.F32Field) != math.Float32bits(other.F32Field) {
+		return false
+	}
+	if math.Float64bits(x.F64Field) != math.Float64bits(other.F64Field) {
+		return false
+	}
+	if x.BoolField != other.BoolField {
+		return false
+	}
+	if x.StrField != other.StrField {
+		return false
+	}
+	return true
+}
+
+// Clone returns a deep copy of x. Nil arrays stay nil.
+func (x *AllPrimitives) Clone() *AllPrimitives {
+	if x == nil {
+		return nil
+	}
+	c := new(AllPrimitives)
+	x.cloneTo(c)
+	return c
+}
+
+// cloneTo deep-copies x into c.
+func (x *AllPrimitives) cloneTo(c *AllPrimitives) {
+	*c = *x
+}
+
+// Diff returns the fields in which other differs from x, in schema order,
+// using the same comparison as Equal. Arrays of different lengths are
+// reported once and compared up to the shorter length. Diff returns nil
+// if x.Equal(other).
+func (x *AllPrimitives) Diff(other *AllPrimitives) []FieldChange {
+	if x == nil || other == nil {
+		if x == other {
+			return nil
+		}
+		return []FieldChange{{Kind: "presence", Old: x != nil, New: other != nil}}
+	}
+	var changes []FieldChange
+	x.diff("", other, &changes)
+	return changes
+}
+
+// diff appends the differences between x and other to changes.
+func (x *AllPrimitives) diff(prefix string, other *AllPrimitives, changes *[]FieldChange) {
+	if x.U8Field != other.U8Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "u8_field"), Kind: "value", Old: x.U8Field, New: other.U8Field})
+	}
+	if x.U16Field != other.U16Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "u16_field"), Kind: "value", Old: x.U16Field, New: other.U16Field})
+	}
+	if x.U32Field != other.U32Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "u32_field"), Kind: "value", Old: x.U32Field, New: other.U32Field})
+	}
+	if x.U64Field != other.U64Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "u64_field"), Kind: "value", Old: x.U64Field, New: other.U64Field})
+	}
+	if x.I8Field != other.I8Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "i8_field"), Kind: "value", Old: x.I8Field, New: other.I8Field})
+	}
+	if x.I16Field != other.I16Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "i16_field"), Kind: "value", Old: x.I16Field, New: other.I16Field})
+	}
+	if x.I32Field != other.I32Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "i32_field"), Kind: "value", Old: x.I32Field, New: other.I32Field})
+	}
+	if x.I64Field != other.I64Field {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "i64_field"), Kind: "value", Old: x.I64Field, New: other.I64Field})
+	}
+	if math.Float32bits(x.F32Field) != math.Float32bits(other.F32Field) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "f32_field"), Kind: "value", Old: x.F32Field, New: other.F32Field})
+	}
+	if math.Float64bits(x.F64Field) != math.Float64bits(other.F64Field) {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "f64_field"), Kind: "value", Old: x.F64Field, New: other.F64Field})
+	}
+	if x.BoolField != other.BoolField {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "bool_field"), Kind: "value", Old: x.BoolField, New: other.BoolField})
+	}
+	if x.StrField != other.StrField {
+		*changes = append(*changes, FieldChange{Path: diffPath(prefix, "str_field"), Kind: "value", Old: x.StrField, New: other.StrField})
+	}
+}
+
@@ -0,0 +1,43 @@
+package primitives
+
+import (
+	"bytes"
+	"testing"
+)
+
+// FuzzDecodeAllPrimitives checks that DecodeAllPrimitives never panics and
+// that canonical encodings round-trip byte-for-byte.
+func FuzzDecodeAllPrimitives(f *testing.F) {
+	for _, seed := range []*AllPrimitives{
+		{},
+		{U8Field: 1, U16Field: 1, U32Field: 1, U64Field: 1, I8Field: 1, I16Field: 1, I32Field: 1, I64Field: 1, F32Field: 1.5, F64Field: 1.5, BoolField: true, StrField: "sample"},
+	} {
+		data, err := EncodeAllPrimitives(seed)
+		if err != nil {
+			f.Fatalf("EncodeAllPrimitives(seed): %v", err)
+		}
+		f.Add(data)
+		f.Add(data[:len(data)/2])
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var x AllPrimitives
+		if err := DecodeAllPrimitives(&x, data); err != nil {
+			return
+		}
+		encoded, err := EncodeAllPrimitives(&x)
+		if err != nil {
+			t.Fatalf("EncodeAllPrimitives of a decoded value: %v", err)
+		}
+
+		// Only canonical input has a unique encoding to compare against
+		var strict AllPrimitives
+		if err := DecodeAllPrimitivesStrict(&strict, data); err != nil {
+			return
+		}
+		if !bytes.Equal(encoded, data) {
+			t.Fatalf("round trip changed the encoding:\n got %x\nwant %x", encoded, data)
+		}
+	})
+}
+
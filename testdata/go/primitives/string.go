@@ -0,0 +1,108 @@
+package primitives
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"strconv"
+)
+
+// stringMaxElems is the number of array elements String and LogValue show.
+const stringMaxElems = 16
+
+// stringMaxBytes is the number of []u8 bytes String and LogValue show.
+const stringMaxBytes = 32
+
+// stringRedacted replaces the value of fields marked @redact.
+const stringRedacted = "[REDACTED]"
+
+// appendStringMore appends the number of elements left out of a truncated array.
+func appendStringMore(b []byte, n int) []byte {
+	b = append(b, "... (+"...)
+	b = strconv.AppendInt(b, int64(n), 10)
+	return append(b, " more)"...)
+}
+
+// appendStringBytes appends a []u8 in hex.
+func appendStringBytes(b, v []byte) []byte {
+	b = append(b, "0x"...)
+	if len(v) <= stringMaxBytes {
+		return hex.AppendEncode(b, v)
+	}
+	b = hex.AppendEncode(b, v[:stringMaxBytes])
+	return appendStringMore(b, len(v)-stringMaxBytes)
+}
+
+// logValueElems returns the first stringMaxElems elements of an array as a
+// group keyed by index.
+func logValueElems[T any](elems []T, value func(*T) slog.Value) slog.Value {
+	attrs := make([]slog.Attr, 0, min(len(elems), stringMaxElems+1))
+	for i := range elems {
+		if i == stringMaxElems {
+			attrs = append(attrs, slog.Int("more", len(elems)-i))
+			break
+		}
+		attrs = append(attrs, slog.Attr{Key: strconv.Itoa(i), Value: value(&elems[i])})
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String implements fmt.Stringer with a readable form of x for logs and
+// debugging: arrays are truncated and redacted fields hidden.
+func (x *AllPrimitives) String() string {
+	if x == nil {
+		return "<nil>"
+	}
+	return string(x.appendString(nil))
+}
+
+// appendString appends the String form of x to b.
+func (x *AllPrimitives) appendString(b []byte) []byte {
+	b = append(b, "AllPrimitives{"...)
+	b = append(b, "u8_field: "...)
+	b = strconv.AppendUint(b, uint64(x.U8Field), 10)
+	b = append(b, ", u16_field: "...)
+	b = strconv.AppendUint(b, uint64(x.U16Field), 10)
+	b = append(b, ", u32_field: "...)
+	b = strconv.AppendUint(b, uint64(x.U32Field), 10)
+	b = append(b, ", u64_field: "...)
+	b = strconv.AppendUint(b, x.U64Field, 10)
+	b = append(b, ", i8_field: "...)
+	b = strconv.AppendInt(b, int64(x.I8Field), 10)
+	b = append(b, ", i16_field: "...)
+	b = strconv.AppendInt(b, int64(x.I16Field), 10)
+	b = append(b, ", i32_field: "...)
+	b = strconv.AppendInt(b, int64(x.I32Field), 10)
+	b = append(b, ", i64_field: "...)
+	b = strconv.AppendInt(b, x.I64Field, 10)
+	b = append(b, ", f32_field: "...)
+	b = strconv.AppendFloat(b, float64(x.F32Field), 'g', -1, 32)
+	b = append(b, ", f64_field: "...)
+	b = strconv.AppendFloat(b, x.F64Field, 'g', -1, 64)
+	b = append(b, ", bool_field: "...)
+	b = strconv.AppendBool(b, x.BoolField)
+	b = append(b, ", str_field: "...)
+	b = strconv.AppendQuote(b, x.StrField)
+	return append(b, '}')
+}
+
+// LogValue implements slog.LogValuer, logging x as a group of its fields.
+func (x *AllPrimitives) LogValue() slog.Value {
+	if x == nil {
+		return slog.AnyValue(nil)
+	}
+	attrs := make([]slog.Attr, 0, 12)
+	attrs = append(attrs, slog.Uint64("u8_field", uint64(x.U8Field)))
+	attrs = append(attrs, slog.Uint64("u16_field", uint64(x.U16Field)))
+	attrs = append(attrs, slog.Uint64("u32_field", uint64(x.U32Field)))
+	attrs = append(attrs, slog.Uint64("u64_field", x.U64Field))
+	attrs = append(attrs, slog.Int64("i8_field", int64(x.I8Field)))
+	attrs = append(attrs, slog.Int64("i16_field", int64(x.I16Field)))
+	attrs = append(attrs, slog.Int64("i32_field", int64(x.I32Field)))
+	attrs = append(attrs, slog.Int64("i64_field", x.I64Field))
+	attrs = append(attrs, slog.Float64("f32_field", float64(x.F32Field)))
+	attrs = append(attrs, slog.Float64("f64_field", x.F64Field))
+	attrs = append(attrs, slog.Bool("bool_field", x.BoolField))
+	attrs = append(attrs, slog.String("str_field", x.StrField))
+	return slog.GroupValue(attrs...)
+}
+
@@ -1,16 +1,112 @@
 package primitives
 
 type AllPrimitives struct {
-	U8Field uint8
-	U16Field uint16
-	U32Field uint32
-	U64Field uint64
-	I8Field int8
-	I16Field int16
-	I32Field int32
-	I64Field int64
-	F32Field float32
-	F64Field float64
-	BoolField bool
-	StrField string
+	U8Field uint8 `json:"u8_field"`
+	U16Field uint16 `json:"u16_field"`
+	U32Field uint32 `json:"u32_field"`
+	U64Field uint64 `json:"u64_field,string"`
+	I8Field int8 `json:"i8_field"`
+	I16Field int16 `json:"i16_field"`
+	I32Field int32 `json:"i32_field"`
+	I64Field int64 `json:"i64_field,string"`
+	F32Field float32 `json:"f32_field"`
+	F64Field float64 `json:"f64_field"`
+	BoolField bool `json:"bool_field"`
+	StrField string `json:"str_field"`
+}
+
+// GetU8Field returns u8_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetU8Field() uint8 {
+	if x != nil {
+		return x.U8Field
+	}
+	return 0
+}
+
+// GetU16Field returns u16_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetU16Field() uint16 {
+	if x != nil {
+		return x.U16Field
+	}
+	return 0
+}
+
+// GetU32Field returns u32_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetU32Field() uint32 {
+	if x != nil {
+		return x.U32Field
+	}
+	return 0
+}
+
+// GetU64Field returns u64_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetU64Field() uint64 {
+	if x != nil {
+		return x.U64Field
+	}
+	return 0
+}
+
+// GetI8Field returns i8_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetI8Field() int8 {
+	if x != nil {
+		return x.I8Field
+	}
+	return 0
+}
+
+// GetI16Field returns i16_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetI16Field() int16 {
+	if x != nil {
+		return x.I16Field
+	}
+	return 0
+}
+
+// GetI32Field returns i32_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetI32Field() int32 {
+	if x != nil {
+		return x.I32Field
+	}
+	return 0
+}
+
+// GetI64Field returns i64_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetI64Field() int64 {
+	if x != nil {
+		return x.I64Field
+	}
+	return 0
+}
+
+// GetF32Field returns f32_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetF32Field() float32 {
+	if x != nil {
+		return x.F32Field
+	}
+	return 0
+}
+
+// GetF64Field returns f64_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetF64Field() float64 {
+	if x != nil {
+		return x.F64Field
+	}
+	return 0
+}
+
+// GetBoolField returns bool_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetBoolField() bool {
+	if x != nil {
+		return x.BoolField
+	}
+	return false
+}
+
+// GetStrField returns str_field, or the zero value if x is nil.
+func (x *AllPrimitives) GetStrField() string {
+	if x != nil {
+		return x.StrField
+	}
+	return ""
 }
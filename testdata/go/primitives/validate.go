@@ -0,0 +1,162 @@
+package primitives
+
+import (
+	"encoding/binary"
+	"unicode/utf8"
+)
+
+// ValidateAllPrimitives checks that data starts with a well-formed AllPrimitives without decoding it.
+// It accepts the input DecodeAllPrimitives accepts, except strings that are not valid
+// UTF-8, and returns the number of bytes the value occupies. Nothing is
+// allocated unless validation fails.
+func ValidateAllPrimitives(data []byte) (int, error) {
+	if len(data) > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	ctx := &DecodeContext{}
+	offset := 0
+	if err := validateAllPrimitives(data, &offset, ctx); err != nil {
+		return 0, err
+	}
+	return offset, nil
+}
+
+// validateAllPrimitives advances offset over one AllPrimitives value.
+func validateAllPrimitives(data []byte, offset *int, ctx *DecodeContext) error {
+	var (
+		strLen uint32  // For string length prefix
+		arrCount uint32  // For array count
+		err error  // For error handling
+	)
+	_ = strLen  // Avoid unused variable error
+	_ = arrCount  // Avoid unused variable error
+	_ = err  // Avoid unused variable error
+
+	// Field: u8_field
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u8_field", *offset)
+	}
+	*offset += 1
+
+	// Field: u16_field
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u16_field", *offset)
+	}
+	*offset += 2
+
+	// Field: u32_field
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u32_field", *offset)
+	}
+	*offset += 4
+
+	// Field: u64_field
+	if *offset + 8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "u64_field", *offset)
+	}
+	*offset += 8
+
+	// Field: i8_field
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "i8_field", *offset)
+	}
+	*offset += 1
+
+	// Field: i16_field
+	if *offset + 2 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "i16_field", *offset)
+	}
+	*offset += 2
+
+	// Field: i32_field
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "i32_field", *offset)
+	}
+	*offset += 4
+
+	// Field: i64_field
+	if *offset + 8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "i64_field", *offset)
+	}
+	*offset += 8
+
+	// Field: f32_field
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "f32_field", *offset)
+	}
+	*offset += 4
+
+	// Field: f64_field
+	if *offset + 8 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "f64_field", *offset)
+	}
+	*offset += 8
+
+	// Field: bool_field
+	if *offset + 1 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "bool_field", *offset)
+	}
+	*offset += 1
+
+	// Field: str_field
+	if *offset + 4 > len(data) {
+		return decodeError(ErrUnexpectedEOF, "str_field", *offset)
+	}
+	strLen = binary.LittleEndian.Uint32(data[*offset:])
+	*offset += 4
+	if *offset + int(strLen) > len(data) {
+		return decodeError(ErrUnexpectedEOF, "str_field", *offset)
+	}
+	if !utf8.Valid(data[*offset:*offset+int(strLen)]) {
+		return decodeError(ErrInvalidUTF8, "str_field", *offset)
+	}
+	*offset += int(strLen)
+
+	return nil
+}
+
+// ValidateMessage checks that data starts with a well-formed self-describing
+// message of any type in this schema, without decoding it. The payload must
+// hold exactly one value of the type; ErrTrailingBytes is returned if it
+// extends past the value. It returns the total message length
+// (header + payload).
+func ValidateMessage(data []byte) (int, error) {
+	if len(data) < MessageHeaderSize {
+		return 0, ErrUnexpectedEOF
+	}
+	if string(data[0:3]) != MessageMagic {
+		return 0, ErrInvalidMagic
+	}
+	if data[3] != MessageVersion {
+		return 0, ErrInvalidVersion
+	}
+
+	typeID := binary.LittleEndian.Uint16(data[4:6])
+	payloadLength := binary.LittleEndian.Uint32(data[6:10])
+	if payloadLength > MaxSerializedSize {
+		return 0, ErrDataTooLarge
+	}
+	end := MessageHeaderSize + int(payloadLength)
+	if len(data) < end {
+		return 0, ErrUnexpectedEOF
+	}
+	payload := data[MessageHeaderSize:end]
+
+	var (
+		n   int
+		err error
+	)
+	switch typeID {
+	case 1:
+		n, err = ValidateAllPrimitives(payload)
+	default:
+		return 0, ErrUnknownMessageType
+	}
+	if err != nil {
+		return 0, err
+	}
+	if n != len(payload) {
+		return 0, ErrTrailingBytes
+	}
+	return end, nil
+}
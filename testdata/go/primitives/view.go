@@ -0,0 +1,184 @@
+package primitives
+
+import (
+	"encoding/binary"
+	"iter"
+	"math"
+	"unsafe"
+)
+
+// ArrayView is a read-only view of an encoded array. Elements are read
+// from the underlying buffer on access; nothing is copied up front.
+type ArrayView[T any] struct {
+	data []byte
+	off  int  // Offset of the first element
+	n    int  // Element count
+	size int  // Element size, or 0 for variable-size elements
+	read func(data []byte, off int) T
+	skip func(data []byte, off int) int
+}
+
+// newArrayView creates an ArrayView over the array whose count prefix is at off.
+func newArrayView[T any](data []byte, off int, size int, read func([]byte, int) T, skip func([]byte, int) int) ArrayView[T] {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	return ArrayView[T]{data: data, off: off + 4, n: n, size: size, read: read, skip: skip}
+}
+
+// Len returns the number of elements.
+func (a ArrayView[T]) Len() int {
+	return a.n
+}
+
+// At returns element i. Fixed-size elements are located directly;
+// variable-size elements (strings, structs) are found by skipping the
+// preceding ones, so use All to visit every element. At panics if i is
+// out of range.
+func (a ArrayView[T]) At(i int) T {
+	if i < 0 || i >= a.n {
+		panic("ArrayView: index out of range")
+	}
+	if a.size > 0 {
+		return a.read(a.data, a.off+i*a.size)
+	}
+	off := a.off
+	for ; i > 0; i-- {
+		off = a.skip(a.data, off)
+	}
+	return a.read(a.data, off)
+}
+
+// All returns an iterator over the index and value of every element.
+func (a ArrayView[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		off := a.off
+		for i := 0; i < a.n; i++ {
+			if !yield(i, a.read(a.data, off)) {
+				return
+			}
+			if a.size > 0 {
+				off += a.size
+			} else {
+				off = a.skip(a.data, off)
+			}
+		}
+	}
+}
+
+// viewString returns the length-prefixed string at off without copying.
+func viewString(data []byte, off int) string {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	if n == 0 {
+		return ""
+	}
+	return unsafe.String(&data[off+4], n)
+}
+
+// viewSkipString returns the offset just past the string at off.
+func viewSkipString(data []byte, off int) int {
+	return off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+}
+
+// viewBytes returns the []u8 array at off as a sub-slice of data.
+// Its capacity is clipped so appending to it cannot overwrite data.
+func viewBytes(data []byte, off int) []byte {
+	end := off + 4 + int(binary.LittleEndian.Uint32(data[off:]))
+	return data[off+4 : end : end]
+}
+
+// viewSkipArray returns the offset just past the array of variable-size
+// elements at off, using skip to step over each element.
+func viewSkipArray(data []byte, off int, skip func([]byte, int) int) int {
+	n := int(binary.LittleEndian.Uint32(data[off:]))
+	off += 4
+	for i := 0; i < n; i++ {
+		off = skip(data, off)
+	}
+	return off
+}
+
+// AllPrimitivesView is a read-only, zero-copy view of an encoded AllPrimitives.
+// Accessors locate fields on demand; the underlying buffer must not be
+// modified while the view or any string or slice obtained from it is in use.
+type AllPrimitivesView struct {
+	data []byte
+}
+
+// NewAllPrimitivesView validates data with ValidateAllPrimitives and returns a view over it.
+func NewAllPrimitivesView(data []byte) (AllPrimitivesView, error) {
+	if _, err := ValidateAllPrimitives(data); err != nil {
+		return AllPrimitivesView{}, err
+	}
+	return AllPrimitivesView{data: data}, nil
+}
+
+// Decode materializes the full AllPrimitives value.
+func (v AllPrimitivesView) Decode(dest *AllPrimitives) error {
+	return DecodeAllPrimitives(dest, v.data)
+}
+
+// U8Field returns the u8_field field.
+func (v AllPrimitivesView) U8Field() uint8 {
+	return v.data[0]
+}
+
+// U16Field returns the u16_field field.
+func (v AllPrimitivesView) U16Field() uint16 {
+	return binary.LittleEndian.Uint16(v.data[1:])
+}
+
+// U32Field returns the u32_field field.
+func (v AllPrimitivesView) U32Field() uint32 {
+	return binary.LittleEndian.Uint32(v.data[3:])
+}
+
+// U64Field returns the u64_field field.
+func (v AllPrimitivesView) U64Field() uint64 {
+	return binary.LittleEndian.Uint64(v.data[7:])
+}
+
+// I8Field returns the i8_field field.
+func (v AllPrimitivesView) I8Field() int8 {
+	return int8(v.data[15])
+}
+
+// I16Field returns the i16_field field.
+func (v AllPrimitivesView) I16Field() int16 {
+	return int16(binary.LittleEndian.Uint16(v.data[16:]))
+}
+
+// I32Field returns the i32_field field.
+func (v AllPrimitivesView) I32Field() int32 {
+	return int32(binary.LittleEndian.Uint32(v.data[18:]))
+}
+
+// I64Field returns the i64_field field.
+func (v AllPrimitivesView) I64Field() int64 {
+	return int64(binary.LittleEndian.Uint64(v.data[22:]))
+}
+
+// F32Field returns the f32_field field.
+func (v AllPrimitivesView) F32Field() float32 {
+	return math.Float32frombits(binary.LittleEndian.Uint32(v.data[30:]))
+}
+
+// F64Field returns the f64_field field.
+func (v AllPrimitivesView) F64Field() float64 {
+	return math.Float64frombits(binary.LittleEndian.Uint64(v.data[34:]))
+}
+
+// BoolField returns the bool_field field.
+func (v AllPrimitivesView) BoolField() bool {
+	return v.data[42] != 0
+}
+
+// StrField returns the str_field field.
+func (v AllPrimitivesView) StrField() string {
+	return viewString(v.data, 43)
+}
+
+// skipAllPrimitives returns the offset just past the AllPrimitives value starting at off.
+func skipAllPrimitives(data []byte, off int) int {
+	off += 43
+	off = viewSkipString(data, off)
+	return off
+}
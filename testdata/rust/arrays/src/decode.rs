@@ -8,50 +8,68 @@ impl ArraysOfPrimitives {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "u8_array", offset))? as usize;
         offset += 4;
         // Bulk decode optimization for primitive arrays
         let u8_array = if array_len > 0 {
-            wire_slice::check_bounds(buf, offset, array_len)?;
+            wire_slice::check_bounds(buf, offset, array_len).map_err(|e| wire_slice::field_error(e, "u8_array", offset))?;
             let slice = &buf[offset..offset + array_len];
             offset += array_len;
             slice.to_vec()
         } else {
             Vec::new()
         };
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "u32_array", offset))? as usize;
         offset += 4;
         // Bulk decode optimization for primitive arrays
         let u32_array = if array_len > 0 {
             let byte_len = array_len * 4;
-            wire_slice::check_bounds(buf, offset, byte_len)?;
+            wire_slice::check_bounds(buf, offset, byte_len).map_err(|e| wire_slice::field_error(e, "u32_array", offset))?;
             let bytes = &buf[offset..offset + byte_len];
             offset += byte_len;
-            bytemuck::cast_slice(bytes).to_vec()
+            if let Ok(slice) = bytemuck::try_cast_slice(bytes) {
+                slice.to_vec()
+            } else {
+                // Misaligned data, read element-by-element
+                bytes.chunks_exact(4)
+                    .map(|chunk| u32::from_le_bytes(chunk.try_into().unwrap()))
+                    .collect()
+            }
         } else {
             Vec::new()
         };
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "f64_array", offset))? as usize;
         offset += 4;
-        let mut f64_array = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let item = wire_slice::decode_f64(buf, offset)?;
-            offset += 8;
-            f64_array.push(item);
-        }
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        // Bulk decode optimization for primitive arrays
+        let f64_array = if array_len > 0 {
+            let byte_len = array_len * 8;
+            wire_slice::check_bounds(buf, offset, byte_len).map_err(|e| wire_slice::field_error(e, "f64_array", offset))?;
+            let bytes = &buf[offset..offset + byte_len];
+            offset += byte_len;
+            if let Ok(slice) = bytemuck::try_cast_slice(bytes) {
+                slice.to_vec()
+            } else {
+                // Misaligned data, read element-by-element
+                bytes.chunks_exact(8)
+                    .map(|chunk| f64::from_le_bytes(chunk.try_into().unwrap()))
+                    .collect()
+            }
+        } else {
+            Vec::new()
+        };
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "str_array", offset))? as usize;
         offset += 4;
         let mut str_array = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let (item, consumed) = wire_slice::decode_string(buf, offset)?;
+        for i in 0..array_len {
+            let (item, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, &format!("str_array[{}]", i), offset))?;
             offset += consumed;
             str_array.push(item);
         }
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "bool_array", offset))? as usize;
         offset += 4;
         let mut bool_array = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let item = wire_slice::decode_bool(buf, offset)?;
+        for i in 0..array_len {
+            let item = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, &format!("bool_array[{}]", i), offset))?;
             offset += 1;
             bool_array.push(item);
         }
@@ -71,9 +89,9 @@ impl Item {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let id = wire_slice::decode_u32(buf, offset)?;
+        let id = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "id", offset))?;
         offset += 4;
-        let (name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "name", offset))?;
         offset += consumed;
 
         Ok(Self {
@@ -88,15 +106,16 @@ impl ArraysOfStructs {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "items", offset))? as usize;
         offset += 4;
         let mut items = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let item = Item::decode_from_slice(&buf[offset..])?;
+        for i in 0..array_len {
+            let item = Item::decode_from_slice(&buf[offset..])
+                .map_err(|e| wire_slice::field_error(e, &format!("items[{}]", i), offset))?;
             offset += item.encoded_size();
             items.push(item);
         }
-        let count = wire_slice::decode_u32(buf, offset)?;
+        let count = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "count", offset))?;
         offset += 4;
 
         Ok(Self {
@@ -31,9 +31,12 @@ impl ArraysOfPrimitives {
         }
         wire_slice::encode_u32(buf, offset, self.f64_array.len() as u32)?;
         offset += 4;
-        for item in &self.f64_array {
-            wire_slice::encode_f64(buf, offset, *item)?;
-            offset += 8;
+        // Bulk encode optimization for primitive arrays
+        if !self.f64_array.is_empty() {
+            let bytes = bytemuck::cast_slice(&self.f64_array);
+            wire_slice::check_bounds(buf, offset, bytes.len())?;
+            buf[offset..offset + bytes.len()].copy_from_slice(bytes);
+            offset += bytes.len();
         }
         wire_slice::encode_u32(buf, offset, self.str_array.len() as u32)?;
         offset += 4;
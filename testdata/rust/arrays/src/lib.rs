@@ -18,6 +18,11 @@ pub use decode::*;
 pub use message_encode::*;
 pub use message_decode::*;
 
+// Canonical JSON mapping adapters (enable with --features serde)
+#[cfg(feature = "serde")]
+#[doc(hidden)]
+pub mod json;
+
 // Re-export common wire format types
 pub use wire::{Error, Result, Encoder, Decoder};
 pub use wire_slice::{SliceError, SliceResult};
@@ -1,22 +1,42 @@
 // Code generated by sdp-gen. DO NOT EDIT.
 
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct ArraysOfPrimitives {
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty", with = "crate::json::bytes_base64"))]
     pub u8_array: Vec<u8>,
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub u32_array: Vec<u32>,
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub f64_array: Vec<f64>,
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub str_array: Vec<String>,
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub bool_array: Vec<bool>,
 }
 
-#[derive(Debug, Clone, PartialEq)]
+impl PartialEq for ArraysOfPrimitives {
+    fn eq(&self, other: &Self) -> bool {
+        self.u8_array == other.u8_array
+        && self.u32_array == other.u32_array
+        && self.f64_array.len() == other.f64_array.len()
+            && self.f64_array.iter().zip(&other.f64_array).all(|(a, b)| a.to_bits() == b.to_bits())
+        && self.str_array == other.str_array
+        && self.bool_array == other.bool_array
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Item {
     pub id: u32,
     pub name: String,
 }
 
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct ArraysOfStructs {
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub items: Vec<Item>,
     pub count: u32,
 }
@@ -8,27 +8,27 @@ impl Parameter {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let address = wire_slice::decode_u64(buf, offset)?;
+        let address = wire_slice::decode_u64(buf, offset).map_err(|e| wire_slice::field_error(e, "address", offset))?;
         offset += 8;
-        let (display_name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (display_name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "display_name", offset))?;
         offset += consumed;
-        let (identifier, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (identifier, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "identifier", offset))?;
         offset += consumed;
-        let (unit, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (unit, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "unit", offset))?;
         offset += consumed;
-        let min_value = wire_slice::decode_f32(buf, offset)?;
+        let min_value = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "min_value", offset))?;
         offset += 4;
-        let max_value = wire_slice::decode_f32(buf, offset)?;
+        let max_value = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "max_value", offset))?;
         offset += 4;
-        let default_value = wire_slice::decode_f32(buf, offset)?;
+        let default_value = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "default_value", offset))?;
         offset += 4;
-        let current_value = wire_slice::decode_f32(buf, offset)?;
+        let current_value = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "current_value", offset))?;
         offset += 4;
-        let raw_flags = wire_slice::decode_u32(buf, offset)?;
+        let raw_flags = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "raw_flags", offset))?;
         offset += 4;
-        let is_writable = wire_slice::decode_bool(buf, offset)?;
+        let is_writable = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "is_writable", offset))?;
         offset += 1;
-        let can_ramp = wire_slice::decode_bool(buf, offset)?;
+        let can_ramp = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "can_ramp", offset))?;
         offset += 1;
 
         Ok(Self {
@@ -52,19 +52,20 @@ impl Plugin {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let (name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "name", offset))?;
         offset += consumed;
-        let (manufacturer_id, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (manufacturer_id, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "manufacturer_id", offset))?;
         offset += consumed;
-        let (component_type, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (component_type, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "component_type", offset))?;
         offset += consumed;
-        let (component_subtype, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (component_subtype, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "component_subtype", offset))?;
         offset += consumed;
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "parameters", offset))? as usize;
         offset += 4;
         let mut parameters = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let item = Parameter::decode_from_slice(&buf[offset..])?;
+        for i in 0..array_len {
+            let item = Parameter::decode_from_slice(&buf[offset..])
+                .map_err(|e| wire_slice::field_error(e, &format!("parameters[{}]", i), offset))?;
             offset += item.encoded_size();
             parameters.push(item);
         }
@@ -84,17 +85,18 @@ impl PluginRegistry {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "plugins", offset))? as usize;
         offset += 4;
         let mut plugins = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let item = Plugin::decode_from_slice(&buf[offset..])?;
+        for i in 0..array_len {
+            let item = Plugin::decode_from_slice(&buf[offset..])
+                .map_err(|e| wire_slice::field_error(e, &format!("plugins[{}]", i), offset))?;
             offset += item.encoded_size();
             plugins.push(item);
         }
-        let total_plugin_count = wire_slice::decode_u32(buf, offset)?;
+        let total_plugin_count = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "total_plugin_count", offset))?;
         offset += 4;
-        let total_parameter_count = wire_slice::decode_u32(buf, offset)?;
+        let total_parameter_count = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "total_parameter_count", offset))?;
         offset += 4;
 
         Ok(Self {
@@ -1,7 +1,9 @@
 // Code generated by sdp-gen. DO NOT EDIT.
 
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Parameter {
+    #[cfg_attr(feature = "serde", serde(with = "crate::json::u64_string"))]
     pub address: u64,
     pub display_name: String,
     pub identifier: String,
@@ -15,17 +17,37 @@ pub struct Parameter {
     pub can_ramp: bool,
 }
 
-#[derive(Debug, Clone, PartialEq)]
+impl PartialEq for Parameter {
+    fn eq(&self, other: &Self) -> bool {
+        self.address == other.address
+        && self.display_name == other.display_name
+        && self.identifier == other.identifier
+        && self.unit == other.unit
+        && self.min_value.to_bits() == other.min_value.to_bits()
+        && self.max_value.to_bits() == other.max_value.to_bits()
+        && self.default_value.to_bits() == other.default_value.to_bits()
+        && self.current_value.to_bits() == other.current_value.to_bits()
+        && self.raw_flags == other.raw_flags
+        && self.is_writable == other.is_writable
+        && self.can_ramp == other.can_ramp
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Plugin {
     pub name: String,
     pub manufacturer_id: String,
     pub component_type: String,
     pub component_subtype: String,
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub parameters: Vec<Parameter>,
 }
 
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct PluginRegistry {
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub plugins: Vec<Plugin>,
     pub total_plugin_count: u32,
     pub total_parameter_count: u32,
@@ -8,15 +8,15 @@ impl Parameter {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let id = wire_slice::decode_u32(buf, offset)?;
+        let id = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "id", offset))?;
         offset += 4;
-        let (name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "name", offset))?;
         offset += consumed;
-        let value = wire_slice::decode_f32(buf, offset)?;
+        let value = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "value", offset))?;
         offset += 4;
-        let min = wire_slice::decode_f32(buf, offset)?;
+        let min = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "min", offset))?;
         offset += 4;
-        let max = wire_slice::decode_f32(buf, offset)?;
+        let max = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "max", offset))?;
         offset += 4;
 
         Ok(Self {
@@ -34,21 +34,22 @@ impl Plugin {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let id = wire_slice::decode_u32(buf, offset)?;
+        let id = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "id", offset))?;
         offset += 4;
-        let (name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "name", offset))?;
         offset += consumed;
-        let (manufacturer, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (manufacturer, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "manufacturer", offset))?;
         offset += consumed;
-        let version = wire_slice::decode_u32(buf, offset)?;
+        let version = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "version", offset))?;
         offset += 4;
-        let enabled = wire_slice::decode_bool(buf, offset)?;
+        let enabled = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "enabled", offset))?;
         offset += 1;
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "parameters", offset))? as usize;
         offset += 4;
         let mut parameters = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let item = Parameter::decode_from_slice(&buf[offset..])?;
+        for i in 0..array_len {
+            let item = Parameter::decode_from_slice(&buf[offset..])
+                .map_err(|e| wire_slice::field_error(e, &format!("parameters[{}]", i), offset))?;
             offset += item.encoded_size();
             parameters.push(item);
         }
@@ -69,25 +70,26 @@ impl AudioDevice {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let device_id = wire_slice::decode_u32(buf, offset)?;
+        let device_id = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "device_id", offset))?;
         offset += 4;
-        let (device_name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (device_name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "device_name", offset))?;
         offset += consumed;
-        let sample_rate = wire_slice::decode_u32(buf, offset)?;
+        let sample_rate = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "sample_rate", offset))?;
         offset += 4;
-        let buffer_size = wire_slice::decode_u32(buf, offset)?;
+        let buffer_size = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "buffer_size", offset))?;
         offset += 4;
-        let input_channels = wire_slice::decode_u16(buf, offset)?;
+        let input_channels = wire_slice::decode_u16(buf, offset).map_err(|e| wire_slice::field_error(e, "input_channels", offset))?;
         offset += 2;
-        let output_channels = wire_slice::decode_u16(buf, offset)?;
+        let output_channels = wire_slice::decode_u16(buf, offset).map_err(|e| wire_slice::field_error(e, "output_channels", offset))?;
         offset += 2;
-        let is_default = wire_slice::decode_bool(buf, offset)?;
+        let is_default = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "is_default", offset))?;
         offset += 1;
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "active_plugins", offset))? as usize;
         offset += 4;
         let mut active_plugins = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let item = Plugin::decode_from_slice(&buf[offset..])?;
+        for i in 0..array_len {
+            let item = Plugin::decode_from_slice(&buf[offset..])
+                .map_err(|e| wire_slice::field_error(e, &format!("active_plugins[{}]", i), offset))?;
             offset += item.encoded_size();
             active_plugins.push(item);
         }
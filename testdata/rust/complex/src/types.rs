@@ -1,6 +1,7 @@
 // Code generated by sdp-gen. DO NOT EDIT.
 
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Parameter {
     pub id: u32,
     pub name: String,
@@ -9,17 +10,30 @@ pub struct Parameter {
     pub max: f32,
 }
 
-#[derive(Debug, Clone, PartialEq)]
+impl PartialEq for Parameter {
+    fn eq(&self, other: &Self) -> bool {
+        self.id == other.id
+        && self.name == other.name
+        && self.value.to_bits() == other.value.to_bits()
+        && self.min.to_bits() == other.min.to_bits()
+        && self.max.to_bits() == other.max.to_bits()
+    }
+}
+
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Plugin {
     pub id: u32,
     pub name: String,
     pub manufacturer: String,
     pub version: u32,
     pub enabled: bool,
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub parameters: Vec<Parameter>,
 }
 
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct AudioDevice {
     pub device_id: u32,
     pub device_name: String,
@@ -28,5 +42,6 @@ pub struct AudioDevice {
     pub input_channels: u16,
     pub output_channels: u16,
     pub is_default: bool,
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub active_plugins: Vec<Plugin>,
 }
@@ -8,9 +8,9 @@ impl Point {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let x = wire_slice::decode_f32(buf, offset)?;
+        let x = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "x", offset))?;
         offset += 4;
-        let y = wire_slice::decode_f32(buf, offset)?;
+        let y = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "y", offset))?;
         offset += 4;
 
         Ok(Self {
@@ -25,11 +25,11 @@ impl Rectangle {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let top_left = Point::decode_from_slice(&buf[offset..])?;
+        let top_left = Point::decode_from_slice(&buf[offset..]).map_err(|e| wire_slice::field_error(e, "top_left", offset))?;
         offset += top_left.encoded_size();
-        let bottom_right = Point::decode_from_slice(&buf[offset..])?;
+        let bottom_right = Point::decode_from_slice(&buf[offset..]).map_err(|e| wire_slice::field_error(e, "bottom_right", offset))?;
         offset += bottom_right.encoded_size();
-        let color = wire_slice::decode_u32(buf, offset)?;
+        let color = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "color", offset))?;
         offset += 4;
 
         Ok(Self {
@@ -45,11 +45,11 @@ impl Scene {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let (name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "name", offset))?;
         offset += consumed;
-        let main_rect = Rectangle::decode_from_slice(&buf[offset..])?;
+        let main_rect = Rectangle::decode_from_slice(&buf[offset..]).map_err(|e| wire_slice::field_error(e, "main_rect", offset))?;
         offset += main_rect.encoded_size();
-        let count = wire_slice::decode_u32(buf, offset)?;
+        let count = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "count", offset))?;
         offset += 4;
 
         Ok(Self {
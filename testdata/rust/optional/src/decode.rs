@@ -8,12 +8,12 @@ impl Request {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let id = wire_slice::decode_u32(buf, offset)?;
+        let id = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "id", offset))?;
         offset += 4;
-        let present = wire_slice::decode_bool(buf, offset)?;
+        let present = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "metadata", offset))?;
         offset += 1;
         let metadata = if present {
-            let value = Metadata::decode_from_slice(&buf[offset..])?;
+            let value = Metadata::decode_from_slice(&buf[offset..]).map_err(|e| wire_slice::field_error(e, "metadata", offset))?;
             offset += value.encoded_size();
             Some(value)
         } else {
@@ -32,9 +32,9 @@ impl Metadata {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let user_id = wire_slice::decode_u64(buf, offset)?;
+        let user_id = wire_slice::decode_u64(buf, offset).map_err(|e| wire_slice::field_error(e, "user_id", offset))?;
         offset += 8;
-        let (username, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (username, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "username", offset))?;
         offset += consumed;
 
         Ok(Self {
@@ -49,21 +49,21 @@ impl Config {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let (name, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (name, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "name", offset))?;
         offset += consumed;
-        let present = wire_slice::decode_bool(buf, offset)?;
+        let present = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "database", offset))?;
         offset += 1;
         let database = if present {
-            let value = DatabaseConfig::decode_from_slice(&buf[offset..])?;
+            let value = DatabaseConfig::decode_from_slice(&buf[offset..]).map_err(|e| wire_slice::field_error(e, "database", offset))?;
             offset += value.encoded_size();
             Some(value)
         } else {
             None
         };
-        let present = wire_slice::decode_bool(buf, offset)?;
+        let present = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "cache", offset))?;
         offset += 1;
         let cache = if present {
-            let value = CacheConfig::decode_from_slice(&buf[offset..])?;
+            let value = CacheConfig::decode_from_slice(&buf[offset..]).map_err(|e| wire_slice::field_error(e, "cache", offset))?;
             offset += value.encoded_size();
             Some(value)
         } else {
@@ -83,9 +83,9 @@ impl DatabaseConfig {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let (host, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (host, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "host", offset))?;
         offset += consumed;
-        let port = wire_slice::decode_u16(buf, offset)?;
+        let port = wire_slice::decode_u16(buf, offset).map_err(|e| wire_slice::field_error(e, "port", offset))?;
         offset += 2;
 
         Ok(Self {
@@ -100,9 +100,9 @@ impl CacheConfig {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let size_mb = wire_slice::decode_u32(buf, offset)?;
+        let size_mb = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "size_mb", offset))?;
         offset += 4;
-        let ttl_seconds = wire_slice::decode_u32(buf, offset)?;
+        let ttl_seconds = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "ttl_seconds", offset))?;
         offset += 4;
 
         Ok(Self {
@@ -117,12 +117,12 @@ impl Document {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let id = wire_slice::decode_u32(buf, offset)?;
+        let id = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "id", offset))?;
         offset += 4;
-        let present = wire_slice::decode_bool(buf, offset)?;
+        let present = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "tags", offset))?;
         offset += 1;
         let tags = if present {
-            let value = TagList::decode_from_slice(&buf[offset..])?;
+            let value = TagList::decode_from_slice(&buf[offset..]).map_err(|e| wire_slice::field_error(e, "tags", offset))?;
             offset += value.encoded_size();
             Some(value)
         } else {
@@ -141,11 +141,11 @@ impl TagList {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let array_len = wire_slice::decode_u32(buf, offset)? as usize;
+        let array_len = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "items", offset))? as usize;
         offset += 4;
         let mut items = Vec::with_capacity(array_len);
-        for _ in 0..array_len {
-            let (item, consumed) = wire_slice::decode_string(buf, offset)?;
+        for i in 0..array_len {
+            let (item, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, &format!("items[{}]", i), offset))?;
             offset += consumed;
             items.push(item);
         }
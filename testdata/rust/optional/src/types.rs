@@ -1,7 +1,8 @@
 // Code generated by sdp-gen. DO NOT EDIT.
 
 /// Request Basic struct with optional metadata (RC Feature 1 test)
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Request {
     /// id Request ID (always present)
     pub id: u32,
@@ -9,17 +10,43 @@ pub struct Request {
     pub metadata: Option<Metadata>,
 }
 
+impl Request {
+    /// Returns metadata, or the default value if it is absent.
+    pub fn metadata(&self) -> &Metadata {
+        static DEFAULT: std::sync::OnceLock<Metadata> = std::sync::OnceLock::new();
+        self.metadata.as_ref().unwrap_or_else(|| DEFAULT.get_or_init(Metadata::default))
+    }
+
+    /// Reports whether metadata is present.
+    pub fn has_metadata(&self) -> bool {
+        self.metadata.is_some()
+    }
+
+    /// Sets metadata.
+    pub fn set_metadata(&mut self, value: Metadata) {
+        self.metadata = Some(value);
+    }
+
+    /// Marks metadata absent.
+    pub fn clear_metadata(&mut self) {
+        self.metadata = None;
+    }
+}
+
 /// Metadata User metadata
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Metadata {
     /// user_id User ID
+    #[cfg_attr(feature = "serde", serde(with = "crate::json::u64_string"))]
     pub user_id: u64,
-    /// username Username
+    /// username Username, kept out of logs
     pub username: String,
 }
 
 /// Config Complex example with nested optional fields
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Config {
     /// name Configuration name
     pub name: String,
@@ -29,8 +56,53 @@ pub struct Config {
     pub cache: Option<CacheConfig>,
 }
 
+impl Config {
+    /// Returns database, or the default value if it is absent.
+    pub fn database(&self) -> &DatabaseConfig {
+        static DEFAULT: std::sync::OnceLock<DatabaseConfig> = std::sync::OnceLock::new();
+        self.database.as_ref().unwrap_or_else(|| DEFAULT.get_or_init(DatabaseConfig::default))
+    }
+
+    /// Reports whether database is present.
+    pub fn has_database(&self) -> bool {
+        self.database.is_some()
+    }
+
+    /// Sets database.
+    pub fn set_database(&mut self, value: DatabaseConfig) {
+        self.database = Some(value);
+    }
+
+    /// Marks database absent.
+    pub fn clear_database(&mut self) {
+        self.database = None;
+    }
+
+    /// Returns cache, or the default value if it is absent.
+    pub fn cache(&self) -> &CacheConfig {
+        static DEFAULT: std::sync::OnceLock<CacheConfig> = std::sync::OnceLock::new();
+        self.cache.as_ref().unwrap_or_else(|| DEFAULT.get_or_init(CacheConfig::default))
+    }
+
+    /// Reports whether cache is present.
+    pub fn has_cache(&self) -> bool {
+        self.cache.is_some()
+    }
+
+    /// Sets cache.
+    pub fn set_cache(&mut self, value: CacheConfig) {
+        self.cache = Some(value);
+    }
+
+    /// Marks cache absent.
+    pub fn clear_cache(&mut self) {
+        self.cache = None;
+    }
+}
+
 /// DatabaseConfig Database configuration
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct DatabaseConfig {
     /// host Database host
     pub host: String,
@@ -39,7 +111,8 @@ pub struct DatabaseConfig {
 }
 
 /// CacheConfig Cache configuration
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct CacheConfig {
     /// size_mb Cache size in MB
     pub size_mb: u32,
@@ -48,7 +121,8 @@ pub struct CacheConfig {
 }
 
 /// Document Example with optional array (wrapped struct containing array)
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct Document {
     /// id Document ID
     pub id: u32,
@@ -56,9 +130,34 @@ pub struct Document {
     pub tags: Option<TagList>,
 }
 
+impl Document {
+    /// Returns tags, or the default value if it is absent.
+    pub fn tags(&self) -> &TagList {
+        static DEFAULT: std::sync::OnceLock<TagList> = std::sync::OnceLock::new();
+        self.tags.as_ref().unwrap_or_else(|| DEFAULT.get_or_init(TagList::default))
+    }
+
+    /// Reports whether tags is present.
+    pub fn has_tags(&self) -> bool {
+        self.tags.is_some()
+    }
+
+    /// Sets tags.
+    pub fn set_tags(&mut self, value: TagList) {
+        self.tags = Some(value);
+    }
+
+    /// Marks tags absent.
+    pub fn clear_tags(&mut self) {
+        self.tags = None;
+    }
+}
+
 /// TagList Wrapper for tag array
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, PartialEq, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct TagList {
     /// items Array of tags
+    #[cfg_attr(feature = "serde", serde(default, skip_serializing_if = "Vec::is_empty"))]
     pub items: Vec<String>,
 }
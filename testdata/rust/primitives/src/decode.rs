@@ -8,29 +8,29 @@ impl AllPrimitives {
     pub fn decode_from_slice(buf: &[u8]) -> Result<Self> {
         let mut offset = 0;
 
-        let u8_field = wire_slice::decode_u8(buf, offset)?;
+        let u8_field = wire_slice::decode_u8(buf, offset).map_err(|e| wire_slice::field_error(e, "u8_field", offset))?;
         offset += 1;
-        let u16_field = wire_slice::decode_u16(buf, offset)?;
+        let u16_field = wire_slice::decode_u16(buf, offset).map_err(|e| wire_slice::field_error(e, "u16_field", offset))?;
         offset += 2;
-        let u32_field = wire_slice::decode_u32(buf, offset)?;
+        let u32_field = wire_slice::decode_u32(buf, offset).map_err(|e| wire_slice::field_error(e, "u32_field", offset))?;
         offset += 4;
-        let u64_field = wire_slice::decode_u64(buf, offset)?;
+        let u64_field = wire_slice::decode_u64(buf, offset).map_err(|e| wire_slice::field_error(e, "u64_field", offset))?;
         offset += 8;
-        let i8_field = wire_slice::decode_i8(buf, offset)?;
+        let i8_field = wire_slice::decode_i8(buf, offset).map_err(|e| wire_slice::field_error(e, "i8_field", offset))?;
         offset += 1;
-        let i16_field = wire_slice::decode_i16(buf, offset)?;
+        let i16_field = wire_slice::decode_i16(buf, offset).map_err(|e| wire_slice::field_error(e, "i16_field", offset))?;
         offset += 2;
-        let i32_field = wire_slice::decode_i32(buf, offset)?;
+        let i32_field = wire_slice::decode_i32(buf, offset).map_err(|e| wire_slice::field_error(e, "i32_field", offset))?;
         offset += 4;
-        let i64_field = wire_slice::decode_i64(buf, offset)?;
+        let i64_field = wire_slice::decode_i64(buf, offset).map_err(|e| wire_slice::field_error(e, "i64_field", offset))?;
         offset += 8;
-        let f32_field = wire_slice::decode_f32(buf, offset)?;
+        let f32_field = wire_slice::decode_f32(buf, offset).map_err(|e| wire_slice::field_error(e, "f32_field", offset))?;
         offset += 4;
-        let f64_field = wire_slice::decode_f64(buf, offset)?;
+        let f64_field = wire_slice::decode_f64(buf, offset).map_err(|e| wire_slice::field_error(e, "f64_field", offset))?;
         offset += 8;
-        let bool_field = wire_slice::decode_bool(buf, offset)?;
+        let bool_field = wire_slice::decode_bool(buf, offset).map_err(|e| wire_slice::field_error(e, "bool_field", offset))?;
         offset += 1;
-        let (str_field, consumed) = wire_slice::decode_string(buf, offset)?;
+        let (str_field, consumed) = wire_slice::decode_string(buf, offset).map_err(|e| wire_slice::field_error(e, "str_field", offset))?;
         offset += consumed;
 
         Ok(Self {
@@ -0,0 +1,166 @@
+//! Canonical JSON helpers for the optional "serde" feature.
+//!
+//! 64-bit integers are written as decimal strings so JSON readers that use
+//! doubles keep full precision; byte arrays are written as standard base64.
+//! Readers accept plain numbers and number arrays as well.
+
+macro_rules! quoted_integer {
+    ($t:ident, $one:ident, $many:ident) => {
+        pub mod $one {
+            use serde::{de, Deserializer, Serializer};
+            use std::fmt;
+
+            pub(super) struct Quoted(pub $t);
+
+            struct QuotedVisitor;
+
+            impl<'de> de::Visitor<'de> for QuotedVisitor {
+                type Value = $t;
+
+                fn expecting(&self, f: &mut fmt::Formatter) -> fmt::Result {
+                    write!(f, "{} as a decimal string or number", stringify!($t))
+                }
+
+                fn visit_u64<E: de::Error>(self, v: u64) -> Result<$t, E> {
+                    <$t>::try_from(v).map_err(|_| E::invalid_value(de::Unexpected::Unsigned(v), &self))
+                }
+
+                fn visit_i64<E: de::Error>(self, v: i64) -> Result<$t, E> {
+                    <$t>::try_from(v).map_err(|_| E::invalid_value(de::Unexpected::Signed(v), &self))
+                }
+
+                fn visit_str<E: de::Error>(self, v: &str) -> Result<$t, E> {
+                    v.parse().map_err(|_| E::invalid_value(de::Unexpected::Str(v), &self))
+                }
+            }
+
+            impl<'de> serde::Deserialize<'de> for Quoted {
+                fn deserialize<D: Deserializer<'de>>(d: D) -> Result<Self, D::Error> {
+                    d.deserialize_any(QuotedVisitor).map(Quoted)
+                }
+            }
+
+            pub fn serialize<S: Serializer>(v: &$t, s: S) -> Result<S::Ok, S::Error> {
+                s.collect_str(v)
+            }
+
+            pub fn deserialize<'de, D: Deserializer<'de>>(d: D) -> Result<$t, D::Error> {
+                d.deserialize_any(QuotedVisitor)
+            }
+        }
+
+        pub mod $many {
+            use serde::ser::SerializeSeq;
+            use serde::{Deserialize, Deserializer, Serializer};
+
+            pub fn serialize<S: Serializer>(v: &[$t], s: S) -> Result<S::Ok, S::Error> {
+                let mut seq = s.serialize_seq(Some(v.len()))?;
+                for x in v {
+                    seq.serialize_element(&x.to_string())?;
+                }
+                seq.end()
+            }
+
+            pub fn deserialize<'de, D: Deserializer<'de>>(d: D) -> Result<Vec<$t>, D::Error> {
+                let v: Vec<super::$one::Quoted> = Vec::deserialize(d)?;
+                Ok(v.into_iter().map(|q| q.0).collect())
+            }
+        }
+    };
+}
+
+quoted_integer!(u64, u64_string, u64_strings);
+quoted_integer!(i64, i64_string, i64_strings);
+
+pub mod bytes_base64 {
+    use serde::{de, Deserializer, Serializer};
+    use std::fmt;
+
+    const ALPHABET: &[u8; 64] = b"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/";
+
+    pub fn serialize<S: Serializer>(v: &[u8], s: S) -> Result<S::Ok, S::Error> {
+        s.serialize_str(&encode(v))
+    }
+
+    pub fn deserialize<'de, D: Deserializer<'de>>(d: D) -> Result<Vec<u8>, D::Error> {
+        d.deserialize_any(BytesVisitor)
+    }
+
+    struct BytesVisitor;
+
+    impl<'de> de::Visitor<'de> for BytesVisitor {
+        type Value = Vec<u8>;
+
+        fn expecting(&self, f: &mut fmt::Formatter) -> fmt::Result {
+            f.write_str("a base64 string or an array of bytes")
+        }
+
+        fn visit_str<E: de::Error>(self, v: &str) -> Result<Vec<u8>, E> {
+            decode(v).ok_or_else(|| E::invalid_value(de::Unexpected::Str(v), &self))
+        }
+
+        fn visit_seq<A: de::SeqAccess<'de>>(self, mut seq: A) -> Result<Vec<u8>, A::Error> {
+            let mut out = Vec::with_capacity(seq.size_hint().unwrap_or(0));
+            while let Some(b) = seq.next_element::<u8>()? {
+                out.push(b);
+            }
+            Ok(out)
+        }
+    }
+
+    fn encode(data: &[u8]) -> String {
+        let mut out = String::with_capacity((data.len() + 2) / 3 * 4);
+        for chunk in data.chunks(3) {
+            let n = (chunk[0] as u32) << 16
+                | (*chunk.get(1).unwrap_or(&0) as u32) << 8
+                | *chunk.get(2).unwrap_or(&0) as u32;
+            for i in 0..4 {
+                if i <= chunk.len() {
+                    out.push(ALPHABET[(n >> (18 - 6 * i)) as usize & 63] as char);
+                } else {
+                    out.push('=');
+                }
+            }
+        }
+        out
+    }
+
+    fn decode(s: &str) -> Option<Vec<u8>> {
+        let s = s.as_bytes();
+        if s.len() % 4 != 0 {
+            return None;
+        }
+        let mut out = Vec::with_capacity(s.len() / 4 * 3);
+        let chunks = s.len() / 4;
+        for (ci, chunk) in s.chunks(4).enumerate() {
+            let mut n = 0u32;
+            let mut pad = 0;
+            for (i, &c) in chunk.iter().enumerate() {
+                let v = match c {
+                    b'A'..=b'Z' => c - b'A',
+                    b'a'..=b'z' => c - b'a' + 26,
+                    b'0'..=b'9' => c - b'0' + 52,
+                    b'+' => 62,
+                    b'/' => 63,
+                    b'=' if ci == chunks - 1 && i >= 2 => {
+                        pad += 1;
+                        0
+                    }
+                    _ => return None,
+                };
+                if pad > 0 && c != b'=' {
+                    return None;
+                }
+                n = n << 6 | v as u32;
+            }
+            out.push((n >> 16) as u8);
+            if pad < 2 {
+                out.push((n >> 8) as u8);
+            }
+            if pad < 1 {
+                out.push(n as u8);
+            }
+        }
+        Some(out)
+    }
+}
@@ -1,17 +1,37 @@
 // Code generated by sdp-gen. DO NOT EDIT.
 
-#[derive(Debug, Clone, PartialEq)]
+#[derive(Debug, Clone, Default)]
+#[cfg_attr(feature = "serde", derive(serde::Serialize, serde::Deserialize))]
 pub struct AllPrimitives {
     pub u8_field: u8,
     pub u16_field: u16,
     pub u32_field: u32,
+    #[cfg_attr(feature = "serde", serde(with = "crate::json::u64_string"))]
     pub u64_field: u64,
     pub i8_field: i8,
     pub i16_field: i16,
     pub i32_field: i32,
+    #[cfg_attr(feature = "serde", serde(with = "crate::json::i64_string"))]
     pub i64_field: i64,
     pub f32_field: f32,
     pub f64_field: f64,
     pub bool_field: bool,
     pub str_field: String,
 }
+
+impl PartialEq for AllPrimitives {
+    fn eq(&self, other: &Self) -> bool {
+        self.u8_field == other.u8_field
+        && self.u16_field == other.u16_field
+        && self.u32_field == other.u32_field
+        && self.u64_field == other.u64_field
+        && self.i8_field == other.i8_field
+        && self.i16_field == other.i16_field
+        && self.i32_field == other.i32_field
+        && self.i64_field == other.i64_field
+        && self.f32_field.to_bits() == other.f32_field.to_bits()
+        && self.f64_field.to_bits() == other.f64_field.to_bits()
+        && self.bool_field == other.bool_field
+        && self.str_field == other.str_field
+    }
+}
@@ -22,26 +22,26 @@ static ArraysOfPrimitives arrays_of_primitives_decode_impl(const uint8_t* buf, s
     uint32_t total_elements = 0;
 
     /* u8_array */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "u8_array", offset);
     uint32_t u8_array_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (u8_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (u8_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "u8_array", offset - 4);
     total_elements += u8_array_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "u8_array", offset - 4);
     result.u8_array.reserve(u8_array_count);
-    if (offset + u8_array_count > buf_len) throw DecodeError("Buffer too small");
+    if (offset + u8_array_count > buf_len) throw DecodeError("Buffer too small", "u8_array", offset);
     result.u8_array.assign(buf + offset, buf + offset + u8_array_count);
     offset += u8_array_count;
 
     /* u32_array */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "u32_array", offset);
     uint32_t u32_array_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (u32_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (u32_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "u32_array", offset - 4);
     total_elements += u32_array_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "u32_array", offset - 4);
     result.u32_array.reserve(u32_array_count);
-    if (offset + u32_array_count * 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + u32_array_count * 4 > buf_len) throw DecodeError("Buffer too small", "u32_array", offset);
     for (uint32_t i = 0; i < u32_array_count; i++) {
         uint32_t elem;
         elem = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
@@ -50,14 +50,14 @@ static ArraysOfPrimitives arrays_of_primitives_decode_impl(const uint8_t* buf, s
     }
 
     /* f64_array */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "f64_array", offset);
     uint32_t f64_array_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (f64_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (f64_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "f64_array", offset - 4);
     total_elements += f64_array_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "f64_array", offset - 4);
     result.f64_array.reserve(f64_array_count);
-    if (offset + f64_array_count * 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + f64_array_count * 8 > buf_len) throw DecodeError("Buffer too small", "f64_array", offset);
     for (uint32_t i = 0; i < f64_array_count; i++) {
         double elem;
         elem = sdp_le_to_f64(*(const uint64_t*)(buf + offset));
@@ -66,31 +66,31 @@ static ArraysOfPrimitives arrays_of_primitives_decode_impl(const uint8_t* buf, s
     }
 
     /* str_array */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "str_array", offset);
     uint32_t str_array_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (str_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (str_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "str_array", offset - 4);
     total_elements += str_array_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "str_array", offset - 4);
     result.str_array.reserve(str_array_count);
     for (uint32_t i = 0; i < str_array_count; i++) {
-        if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+        if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "str_array[" + std::to_string(i) + "]", offset);
         uint32_t len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
         offset += 4;
-        if (offset + len > buf_len) throw DecodeError("Buffer too small");
+        if (offset + len > buf_len) throw DecodeError("Buffer too small", "str_array[" + std::to_string(i) + "]", offset);
         result.str_array.emplace_back(reinterpret_cast<const char*>(buf + offset), len);
         offset += len;
     }
 
     /* bool_array */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "bool_array", offset);
     uint32_t bool_array_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (bool_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (bool_array_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "bool_array", offset - 4);
     total_elements += bool_array_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "bool_array", offset - 4);
     result.bool_array.reserve(bool_array_count);
-    if (offset + bool_array_count * 1 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + bool_array_count * 1 > buf_len) throw DecodeError("Buffer too small", "bool_array", offset);
     for (uint32_t i = 0; i < bool_array_count; i++) {
         bool elem;
         elem = buf[offset++] != 0;
@@ -109,15 +109,15 @@ static Item item_decode_impl(const uint8_t* buf, size_t buf_len, size_t& offset)
     Item result;
 
     /* id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "id", offset);
     result.id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
@@ -134,19 +134,23 @@ static ArraysOfStructs arrays_of_structs_decode_impl(const uint8_t* buf, size_t
     uint32_t total_elements = 0;
 
     /* items */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "items", offset);
     uint32_t items_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (items_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (items_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "items", offset - 4);
     total_elements += items_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "items", offset - 4);
     result.items.reserve(items_count);
     for (uint32_t i = 0; i < items_count; i++) {
-        result.items.push_back(item_decode_impl(buf, buf_len, offset));
+        try {
+            result.items.push_back(item_decode_impl(buf, buf_len, offset));
+        } catch (const DecodeError& e) {
+            throw e.within("items[" + std::to_string(i) + "]");
+        }
     }
 
     /* count */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "count", offset);
     result.count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -16,9 +16,24 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* ArraysOfPrimitives */
 struct ArraysOfPrimitives {
     // u8_array
@@ -31,6 +46,15 @@ struct ArraysOfPrimitives {
     std::vector<std::string> str_array;
     // bool_array
     std::vector<bool> bool_array;
+
+    bool operator==(const ArraysOfPrimitives& other) const {
+        return u8_array == other.u8_array &&
+               u32_array == other.u32_array &&
+               bits_equal(f64_array, other.f64_array) &&
+               str_array == other.str_array &&
+               bool_array == other.bool_array;
+    }
+    bool operator!=(const ArraysOfPrimitives& other) const { return !(*this == other); }
 };
 
 /* Item */
@@ -39,6 +63,12 @@ struct Item {
     uint32_t id;
     // name
     std::string name;
+
+    bool operator==(const Item& other) const {
+        return id == other.id &&
+               name == other.name;
+    }
+    bool operator!=(const Item& other) const { return !(*this == other); }
 };
 
 /* ArraysOfStructs */
@@ -47,6 +77,12 @@ struct ArraysOfStructs {
     std::vector<Item> items;
     // count
     uint32_t count;
+
+    bool operator==(const ArraysOfStructs& other) const {
+        return items == other.items &&
+               count == other.count;
+    }
+    bool operator!=(const ArraysOfStructs& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
@@ -25,15 +25,15 @@ static Metadata metadata_decode_impl(const uint8_t* buf, size_t buf_len, size_t&
     Metadata result;
 
     /* user_id */
-    if (offset + 8 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 8 > buf_len) throw DecodeError("Buffer too small", "user_id", offset);
     result.user_id = SDP_LE64TOH(*(const uint64_t*)(buf + offset));
     offset += 8;
 
     /* username */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "username", offset);
     uint32_t username_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + username_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + username_len > buf_len) throw DecodeError("Buffer too small", "username", offset);
     result.username = std::string(reinterpret_cast<const char*>(buf + offset), username_len);
     offset += username_len;
 
@@ -49,15 +49,15 @@ static DatabaseConfig database_config_decode_impl(const uint8_t* buf, size_t buf
     DatabaseConfig result;
 
     /* host */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "host", offset);
     uint32_t host_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + host_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + host_len > buf_len) throw DecodeError("Buffer too small", "host", offset);
     result.host = std::string(reinterpret_cast<const char*>(buf + offset), host_len);
     offset += host_len;
 
     /* port */
-    if (offset + 2 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 2 > buf_len) throw DecodeError("Buffer too small", "port", offset);
     result.port = SDP_LE16TOH(*(const uint16_t*)(buf + offset));
     offset += 2;
 
@@ -73,12 +73,12 @@ static CacheConfig cache_config_decode_impl(const uint8_t* buf, size_t buf_len,
     CacheConfig result;
 
     /* size_mb */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "size_mb", offset);
     result.size_mb = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* ttl_seconds */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "ttl_seconds", offset);
     result.ttl_seconds = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
@@ -95,18 +95,18 @@ static TagList tag_list_decode_impl(const uint8_t* buf, size_t buf_len, size_t&
     uint32_t total_elements = 0;
 
     /* items */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "items", offset);
     uint32_t items_count = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (items_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large");
+    if (items_count > MAX_ARRAY_ELEMENTS) throw DecodeError("Array too large", "items", offset - 4);
     total_elements += items_count;
-    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large");
+    if (total_elements > MAX_TOTAL_ELEMENTS) throw DecodeError("Total elements too large", "items", offset - 4);
     result.items.reserve(items_count);
     for (uint32_t i = 0; i < items_count; i++) {
-        if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+        if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "items[" + std::to_string(i) + "]", offset);
         uint32_t len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
         offset += 4;
-        if (offset + len > buf_len) throw DecodeError("Buffer too small");
+        if (offset + len > buf_len) throw DecodeError("Buffer too small", "items[" + std::to_string(i) + "]", offset);
         result.items.emplace_back(reinterpret_cast<const char*>(buf + offset), len);
         offset += len;
     }
@@ -123,15 +123,19 @@ static Request request_decode_impl(const uint8_t* buf, size_t buf_len, size_t& o
     Request result;
 
     /* id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "id", offset);
     result.id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* metadata */
-    if (offset >= buf_len) throw DecodeError("Buffer too small");
+    if (offset >= buf_len) throw DecodeError("Buffer too small", "metadata", offset);
     uint8_t metadata_present = buf[offset++];
     if (metadata_present) {
-        result.metadata = metadata_decode_impl(buf, buf_len, offset);
+        try {
+            result.metadata = metadata_decode_impl(buf, buf_len, offset);
+        } catch (const DecodeError& e) {
+            throw e.within("metadata");
+        }
     }
 
     return result;
@@ -146,25 +150,33 @@ static Config config_decode_impl(const uint8_t* buf, size_t buf_len, size_t& off
     Config result;
 
     /* name */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "name", offset);
     uint32_t name_len = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
-    if (offset + name_len > buf_len) throw DecodeError("Buffer too small");
+    if (offset + name_len > buf_len) throw DecodeError("Buffer too small", "name", offset);
     result.name = std::string(reinterpret_cast<const char*>(buf + offset), name_len);
     offset += name_len;
 
     /* database */
-    if (offset >= buf_len) throw DecodeError("Buffer too small");
+    if (offset >= buf_len) throw DecodeError("Buffer too small", "database", offset);
     uint8_t database_present = buf[offset++];
     if (database_present) {
-        result.database = database_config_decode_impl(buf, buf_len, offset);
+        try {
+            result.database = database_config_decode_impl(buf, buf_len, offset);
+        } catch (const DecodeError& e) {
+            throw e.within("database");
+        }
     }
 
     /* cache */
-    if (offset >= buf_len) throw DecodeError("Buffer too small");
+    if (offset >= buf_len) throw DecodeError("Buffer too small", "cache", offset);
     uint8_t cache_present = buf[offset++];
     if (cache_present) {
-        result.cache = cache_config_decode_impl(buf, buf_len, offset);
+        try {
+            result.cache = cache_config_decode_impl(buf, buf_len, offset);
+        } catch (const DecodeError& e) {
+            throw e.within("cache");
+        }
     }
 
     return result;
@@ -179,15 +191,19 @@ static Document document_decode_impl(const uint8_t* buf, size_t buf_len, size_t&
     Document result;
 
     /* id */
-    if (offset + 4 > buf_len) throw DecodeError("Buffer too small");
+    if (offset + 4 > buf_len) throw DecodeError("Buffer too small", "id", offset);
     result.id = SDP_LE32TOH(*(const uint32_t*)(buf + offset));
     offset += 4;
 
     /* tags */
-    if (offset >= buf_len) throw DecodeError("Buffer too small");
+    if (offset >= buf_len) throw DecodeError("Buffer too small", "tags", offset);
     uint8_t tags_present = buf[offset++];
     if (tags_present) {
-        result.tags = tag_list_decode_impl(buf, buf_len, offset);
+        try {
+            result.tags = tag_list_decode_impl(buf, buf_len, offset);
+        } catch (const DecodeError& e) {
+            throw e.within("tags");
+        }
     }
 
     return result;
@@ -16,15 +16,36 @@
 #include <string>
 #include <vector>
 #include <optional>
+#include <utility>
+#include <cstring>
 
 namespace sdp {
 
+#ifndef SDP_BITS_EQUAL
+#define SDP_BITS_EQUAL
+/* Floats compare by bit pattern, as in every SDP language: two values are
+ * equal exactly when they encode to the same bytes, so a NaN equals an
+ * identical NaN and 0.0 differs from -0.0. */
+inline bool bits_equal(float a, float b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+inline bool bits_equal(double a, double b) { return std::memcmp(&a, &b, sizeof(a)) == 0; }
+template <typename T>
+inline bool bits_equal(const std::vector<T>& a, const std::vector<T>& b) {
+    return a.size() == b.size() && (a.empty() || std::memcmp(a.data(), b.data(), a.size() * sizeof(T)) == 0);
+}
+#endif  // SDP_BITS_EQUAL
+
 /* Metadata */
 struct Metadata {
     // user_id
     uint64_t user_id;
     // username
     std::string username;
+
+    bool operator==(const Metadata& other) const {
+        return user_id == other.user_id &&
+               username == other.username;
+    }
+    bool operator!=(const Metadata& other) const { return !(*this == other); }
 };
 
 /* DatabaseConfig */
@@ -33,6 +54,12 @@ struct DatabaseConfig {
     std::string host;
     // port
     uint16_t port;
+
+    bool operator==(const DatabaseConfig& other) const {
+        return host == other.host &&
+               port == other.port;
+    }
+    bool operator!=(const DatabaseConfig& other) const { return !(*this == other); }
 };
 
 /* CacheConfig */
@@ -41,12 +68,23 @@ struct CacheConfig {
     uint32_t size_mb;
     // ttl_seconds
     uint32_t ttl_seconds;
+
+    bool operator==(const CacheConfig& other) const {
+        return size_mb == other.size_mb &&
+               ttl_seconds == other.ttl_seconds;
+    }
+    bool operator!=(const CacheConfig& other) const { return !(*this == other); }
 };
 
 /* TagList */
 struct TagList {
     // items
     std::vector<std::string> items;
+
+    bool operator==(const TagList& other) const {
+        return items == other.items;
+    }
+    bool operator!=(const TagList& other) const { return !(*this == other); }
 };
 
 /* Request */
@@ -55,6 +93,20 @@ struct Request {
     uint32_t id;
     // metadata (optional)
     std::optional<Metadata> metadata;
+
+    const Metadata& get_metadata() const {
+        static const Metadata empty{};
+        return metadata ? *metadata : empty;
+    }
+    bool has_metadata() const { return metadata.has_value(); }
+    void set_metadata(Metadata value) { metadata = std::move(value); }
+    void clear_metadata() { metadata.reset(); }
+
+    bool operator==(const Request& other) const {
+        return id == other.id &&
+               metadata == other.metadata;
+    }
+    bool operator!=(const Request& other) const { return !(*this == other); }
 };
 
 /* Config */
@@ -65,6 +117,29 @@ struct Config {
     std::optional<DatabaseConfig> database;
     // cache (optional)
     std::optional<CacheConfig> cache;
+
+    const DatabaseConfig& get_database() const {
+        static const DatabaseConfig empty{};
+        return database ? *database : empty;
+    }
+    bool has_database() const { return database.has_value(); }
+    void set_database(DatabaseConfig value) { database = std::move(value); }
+    void clear_database() { database.reset(); }
+
+    const CacheConfig& get_cache() const {
+        static const CacheConfig empty{};
+        return cache ? *cache : empty;
+    }
+    bool has_cache() const { return cache.has_value(); }
+    void set_cache(CacheConfig value) { cache = std::move(value); }
+    void clear_cache() { cache.reset(); }
+
+    bool operator==(const Config& other) const {
+        return name == other.name &&
+               database == other.database &&
+               cache == other.cache;
+    }
+    bool operator!=(const Config& other) const { return !(*this == other); }
 };
 
 /* Document */
@@ -73,6 +148,20 @@ struct Document {
     uint32_t id;
     // tags (optional)
     std::optional<TagList> tags;
+
+    const TagList& get_tags() const {
+        static const TagList empty{};
+        return tags ? *tags : empty;
+    }
+    bool has_tags() const { return tags.has_value(); }
+    void set_tags(TagList value) { tags = std::move(value); }
+    void clear_tags() { tags.reset(); }
+
+    bool operator==(const Document& other) const {
+        return id == other.id &&
+               tags == other.tags;
+    }
+    bool operator!=(const Document& other) const { return !(*this == other); }
 };
 
 }  // namespace sdp
@@ -55,14 +55,26 @@ for schema in "${SCHEMAS[@]}"; do
         continue
     fi
     
-    # This would require implementing sdp-encode functionality
-    # For now, we'll just verify the reference files exist
-    echo "  ℹ️  $schema: Reference binary exists (${ref_file})"
-    GO_WIRE_PASSED=$((GO_WIRE_PASSED + 1))
+    case "$schema" in
+        primitives) type_name="AllPrimitives" ;;
+        nested)     type_name="Scene" ;;
+        optional)   type_name="Config" ;;
+    esac
+
+    if go run ./cmd/sdp-encode -schema "testdata/schemas/${schema}.sdp" -type "$type_name" \
+        -json "$data_file" | cmp -s - "$ref_file"; then
+        echo "  ✓ $schema: sdp-encode output matches ${ref_file}"
+        GO_WIRE_PASSED=$((GO_WIRE_PASSED + 1))
+    else
+        echo "  ❌ $schema: sdp-encode output differs from ${ref_file}"
+        echo "     Inspect with: go run ./cmd/sdp-inspect -schema testdata/schemas/${schema}.sdp -type $type_name -all $ref_file"
+        GO_WIRE_FAILED=$((GO_WIRE_FAILED + 1))
+        FAILED=$((FAILED + 1))
+    fi
 done
 
 echo ""
-echo "  Reference binaries: $GO_WIRE_PASSED verified"
+echo "  Reference binaries: $GO_WIRE_PASSED verified, $GO_WIRE_FAILED failed"
 echo ""
 
 # Test 2: C++ wire format compatibility